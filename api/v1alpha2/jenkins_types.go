@@ -3,6 +3,7 @@ package v1alpha2
 import (
 	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -18,6 +19,11 @@ type JenkinsSpec struct {
 	// +optional
 	SeedJobs []SeedJob `json:"seedJobs,omitempty"`
 
+	// Credentials defines the Jenkins credentials created by the operator from the Kubernetes Secrets, unlike
+	// the credentials of the kubernetes-credentials-provider plugin they can be scoped to a credentials domain
+	// +optional
+	Credentials []Credential `json:"credentials,omitempty"`
+
 	// SeedJobAgentImage defines the image that will be used by the seed job agent. If not defined jenkins/inbound-agent:4.9-1 will be used.
 	// +optional
 	SeedJobAgentImage string `json:"seedJobAgentImage,omitempty"`
@@ -27,10 +33,20 @@ type JenkinsSpec struct {
 	ValidateSecurityWarnings bool `json:"validateSecurityWarnings,omitempty"`
 
 	// Notifications defines list of a services which are used to inform about Jenkins status
-	// Can be used to integrate chat services like Slack, Microsoft Teams or Mailgun
+	// Can be used to integrate chat services like Slack, Microsoft Teams, Mailgun or a generic webhook
 	// +optional
 	Notifications []Notification `json:"notifications,omitempty"`
 
+	// NotificationThrottleWindow suppresses the notifications identical to the one sent within the window, e.g. 10m,
+	// to avoid a storm of notifications during a crash loop of the Jenkins master pod. Disabled by default
+	// +optional
+	NotificationThrottleWindow *metav1.Duration `json:"notificationThrottleWindow,omitempty"`
+
+	// AuditLog enables the rolling audit log of the operator actions kept in the jenkins-operator-audit-<cr_name>
+	// ConfigMap, the records of the notification events are appended to it. Disabled by default
+	// +optional
+	AuditLog *AuditLog `json:"auditLog,omitempty"`
+
 	// Service is Kubernetes service of Jenkins master HTTP pod
 	// Defaults to :
 	// port: 8080
@@ -59,10 +75,21 @@ type JenkinsSpec struct {
 	// +optional
 	GroovyScripts GroovyScripts `json:"groovyScripts,omitempty"`
 
+	// SystemConfigGroovy is the Groovy script with the baseline system settings, it's placed in the init.groovy.d
+	// directory of Jenkins before the other init scripts and it runs on every Jenkins start before the groovy scripts
+	// and the Configuration as Code are applied
+	// +optional
+	SystemConfigGroovy string `json:"systemConfigGroovy,omitempty"`
+
 	// ConfigurationAsCode defines configuration of Jenkins customization via Configuration as Code Jenkins plugin
 	// +optional
 	ConfigurationAsCode ConfigurationAsCode `json:"configurationAsCode,omitempty"`
 
+	// ConfigurationAsCodeExport enables the periodic export of the Jenkins configuration with the Configuration as Code
+	// plugin to the jenkins-<name>-casc-export Secret, e.g. to migrate the configuration made in the UI
+	// +optional
+	ConfigurationAsCodeExport *ConfigurationAsCodeExport `json:"configurationAsCodeExport,omitempty"`
+
 	// Roles defines list of extra RBAC roles for the Jenkins Master pod service account
 	// +optional
 	Roles []rbacv1.RoleRef `json:"roles,omitempty"`
@@ -73,8 +100,211 @@ type JenkinsSpec struct {
 
 	// JenkinsAPISettings defines configuration used by the operator to gain admin access to the Jenkins API
 	JenkinsAPISettings JenkinsAPISettings `json:"jenkinsAPISettings"`
+
+	// Paused stops the reconciliation of the Jenkins CR, resources managed by the operator are left untouched.
+	// Reconciliation can be paused also with the jenkins.io/paused: "true" annotation.
+	// +optional
+	Paused bool `json:"paused,omitempty"`
+
+	// ToolConfig defines the Jenkins global tool configuration managed by the operator,
+	// it is applied with the Configuration as Code plugin
+	// +optional
+	ToolConfig *ToolConfig `json:"toolConfig,omitempty"`
+
+	// Views defines the list views managed by the operator, the views removed from the list are deleted from Jenkins
+	// +optional
+	Views []View `json:"views,omitempty"`
+
+	// GlobalPipelineLibraries defines the global shared pipeline libraries retrieved from Git repositories,
+	// they are applied with the Configuration as Code plugin
+	// +optional
+	GlobalPipelineLibraries []Library `json:"globalPipelineLibraries,omitempty"`
+
+	// PermanentAgents defines the static Jenkins agents launched over SSH, they are applied with the Configuration
+	// as Code plugin and the ssh-slaves plugin is added to the base plugins
+	// +optional
+	PermanentAgents []PermanentAgent `json:"permanentAgents,omitempty"`
+
+	// DependsOn are the Services in the Jenkins namespace Jenkins needs at start, e.g. the external database. The Jenkins
+	// master pod is created only when all the Services have a ready endpoint.
+	// +optional
+	DependsOn []ServiceRef `json:"dependsOn,omitempty"`
+}
+
+// ServiceRef is the reference to the Kubernetes Service in the Jenkins namespace.
+type ServiceRef struct {
+	// Name of the Service
+	Name string `json:"name"`
+}
+
+// PermanentAgent defines the static Jenkins agent launched over SSH.
+type PermanentAgent struct {
+	// Name is the name of the Jenkins node
+	Name string `json:"name"`
+
+	// Host is the host name or the IP address of the agent machine
+	Host string `json:"host"`
+
+	// Port is the SSH port of the agent machine, defaults to 22
+	// +optional
+	Port *int32 `json:"port,omitempty"`
+
+	// CredentialID is the ID of the Jenkins SSH credentials used to connect to the agent machine
+	CredentialID string `json:"credentialID"`
+
+	// Labels are the labels of the agent used to select it in the jobs
+	// +optional
+	Labels []string `json:"labels,omitempty"`
+
+	// RemoteFS is the root directory of the agent on the agent machine, defaults to /home/jenkins
+	// +optional
+	RemoteFS string `json:"remoteFS,omitempty"`
+
+	// NumExecutors is the number of the executors of the agent, defaults to 1
+	// +optional
+	NumExecutors *int32 `json:"numExecutors,omitempty"`
+
+	// HostKeyVerificationStrategy defines how the host key of the agent machine is verified: manuallyProvided checks
+	// it against hostKey, knownHosts against the known_hosts file of the Jenkins master and manuallyTrusted requires
+	// the key to be approved in the Jenkins UI on the first connection. Defaults to manuallyProvided
+	// +kubebuilder:validation:Enum=manuallyProvided;knownHosts;manuallyTrusted
+	// +optional
+	HostKeyVerificationStrategy SSHHostKeyVerificationStrategy `json:"hostKeyVerificationStrategy,omitempty"`
+
+	// HostKey is the public host key of the agent machine in the OpenSSH format, e.g. "ssh-ed25519 AAAAC3Nza...",
+	// it is required by the manuallyProvided host key verification strategy
+	// +optional
+	HostKey string `json:"hostKey,omitempty"`
 }
 
+// SSHHostKeyVerificationStrategy defines how the host key of the SSH agent machine is verified
+type SSHHostKeyVerificationStrategy string
+
+const (
+	// SSHHostKeyVerificationStrategyManuallyProvided verifies the host key against the key set in the Jenkins CR
+	SSHHostKeyVerificationStrategyManuallyProvided SSHHostKeyVerificationStrategy = "manuallyProvided"
+	// SSHHostKeyVerificationStrategyKnownHosts verifies the host key against the known_hosts file of the Jenkins master
+	SSHHostKeyVerificationStrategyKnownHosts SSHHostKeyVerificationStrategy = "knownHosts"
+	// SSHHostKeyVerificationStrategyManuallyTrusted requires the host key to be approved in the Jenkins UI on the first connection
+	SSHHostKeyVerificationStrategyManuallyTrusted SSHHostKeyVerificationStrategy = "manuallyTrusted"
+)
+
+// Library defines the global shared pipeline library.
+type Library struct {
+	// Name is the name of the library used in the @Library annotation
+	Name string `json:"name"`
+
+	// RepositoryURL is the Git repository URL of the library. Can be SSH or HTTPS.
+	RepositoryURL string `json:"repositoryUrl"`
+
+	// DefaultVersion is the branch, tag or commit used when the pipeline doesn't select the version,
+	// required when the library is loaded implicitly
+	// +optional
+	DefaultVersion string `json:"defaultVersion,omitempty"`
+
+	// CredentialID is the ID of the Jenkins credentials used to access the repository
+	// +optional
+	CredentialID string `json:"credentialID,omitempty"`
+
+	// Implicit loads the library in all pipelines without the @Library annotation
+	// +optional
+	Implicit bool `json:"implicit,omitempty"`
+}
+
+// View defines the Jenkins list view.
+type View struct {
+	// Name is the name of the view
+	Name string `json:"name"`
+
+	// JobNames are the names of the jobs listed in the view
+	// +optional
+	JobNames []string `json:"jobNames,omitempty"`
+
+	// Regex includes the jobs with the name matching the regular expression in the view
+	// +optional
+	Regex string `json:"regex,omitempty"`
+}
+
+// CSRF defines the CSRF protection settings of Jenkins.
+type CSRF struct {
+	// Enabled enables the CSRF protection with the default crumb issuer, defaults to true
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// ProxyCompatibility excludes the client IP address from the crumb, required when Jenkins is behind a proxy
+	// which changes the client IP address, defaults to true
+	// +optional
+	ProxyCompatibility *bool `json:"proxyCompatibility,omitempty"`
+}
+
+// ToolConfig defines the Jenkins global tool installations.
+type ToolConfig struct {
+	// JDKs defines the JDK installations
+	// +optional
+	JDKs []ToolInstallation `json:"jdks,omitempty"`
+
+	// Maven defines the Maven installations
+	// +optional
+	Maven []ToolInstallation `json:"maven,omitempty"`
+
+	// Gradle defines the Gradle installations
+	// +optional
+	Gradle []ToolInstallation `json:"gradle,omitempty"`
+}
+
+// ToolInstallation defines the single tool installation, either already present in Home or downloaded by Installer.
+type ToolInstallation struct {
+	// Name is the name of the tool installation used in jobs
+	Name string `json:"name"`
+
+	// Home is the path of the tool installation on the agent
+	// +optional
+	Home string `json:"home,omitempty"`
+
+	// Installer downloads and extracts the tool archive when the tool is not present on the agent
+	// +optional
+	Installer *ToolInstaller `json:"installer,omitempty"`
+}
+
+// ToolInstaller defines the automatic installation of the tool from the ZIP or TAR.GZ archive.
+type ToolInstaller struct {
+	// URL is the http or https URL of the tool archive
+	URL string `json:"url"`
+
+	// Subdir is the name of the top level directory in the archive which contains the tool
+	// +optional
+	Subdir string `json:"subdir,omitempty"`
+
+	// Label restricts the installer to the agents with the matching label expression
+	// +optional
+	Label string `json:"label,omitempty"`
+}
+
+const (
+	// PausedAnnotation stops the reconciliation of the Jenkins CR when set to "true"
+	PausedAnnotation = "jenkins.io/paused"
+	// ReinstallPluginsAnnotation reinstalls the plugins and restarts Jenkins once when set to "true", the operator
+	// removes the annotation afterwards
+	ReinstallPluginsAnnotation = "jenkins.io/reinstall-plugins"
+	// PluginsReinstallIDAnnotation is set by the operator when it consumes the ReinstallPluginsAnnotation, the Jenkins
+	// master removes the installed plugins on the first start with a new ID
+	PluginsReinstallIDAnnotation = "jenkins.io/plugins-reinstall-id"
+)
+
+const (
+	// ConditionPaused informs that the reconciliation of the Jenkins CR is paused
+	ConditionPaused = "Paused"
+	// ConditionResourceQuotaExceeded informs that the Jenkins master resources exceed the remaining namespace resource quota
+	ConditionResourceQuotaExceeded = "ResourceQuotaExceeded"
+	// ConditionPluginsActive informs that all plugins from spec.master.basePlugins and spec.master.plugins are active in Jenkins
+	ConditionPluginsActive = "PluginsActive"
+	// ConditionReferencesMissing informs that some of the Secrets or ConfigMaps referenced by the Jenkins CR don't exist
+	ConditionReferencesMissing = "ReferencesMissing"
+	// ConditionWaitingForDependencies informs that some of the Services from spec.dependsOn don't have a ready endpoint
+	// and the Jenkins master pod isn't created yet
+	ConditionWaitingForDependencies = "WaitingForDependencies"
+)
+
 // AuthorizationStrategy defines authorization strategy of the operator for the Jenkins API
 type AuthorizationStrategy string
 
@@ -120,12 +350,17 @@ type Notification struct {
 	Teams        *MicrosoftTeams   `json:"teams,omitempty"`
 	Mailgun      *Mailgun          `json:"mailgun,omitempty"`
 	SMTP         *SMTP             `json:"smtp,omitempty"`
+	Webhook      *Webhook          `json:"webhook,omitempty"`
 }
 
 // Slack is handler for Slack notification channel.
 type Slack struct {
 	// The web hook URL to Slack App
 	WebHookURLSecretKeySelector SecretKeySelector `json:"webHookURLSecretKeySelector"`
+
+	// Channel overrides the default channel of the web hook, e.g. #jenkins
+	// +optional
+	Channel string `json:"channel,omitempty"`
 }
 
 // SMTP is handler for sending emails via this protocol.
@@ -137,8 +372,72 @@ type SMTP struct {
 	TLSInsecureSkipVerify     bool              `json:"tlsInsecureSkipVerify,omitempty"`
 	From                      string            `json:"from"`
 	To                        string            `json:"to"`
+
+	// TLS defines how the connection to the SMTP server is secured, starttls requires the STARTTLS extension and tls
+	// connects with implicit TLS. By default STARTTLS is used when the server supports it and implicit TLS on port 465
+	// +kubebuilder:validation:Enum=starttls;tls
+	// +optional
+	TLS SMTPTLSMode `json:"tls,omitempty"`
 }
 
+// PluginManagerSite defines the update site of the Jenkins plugin manager
+type PluginManagerSite struct {
+	// ID is the ID of the update site
+	ID string `json:"id"`
+
+	// URL is the URL of the update-center.json of the update site
+	URL string `json:"url"`
+}
+
+// AuditLog defines the rolling audit log of the operator actions
+type AuditLog struct {
+	// MaxEntries is the maximum number of the records kept in the audit ConfigMap, the oldest records are removed
+	// when it's exceeded, defaults to 100
+	// +optional
+	MaxEntries int32 `json:"maxEntries,omitempty"`
+}
+
+// UpdateCenterChannel defines the update center channel the plugins are installed from
+type UpdateCenterChannel string
+
+const (
+	// UpdateCenterChannelStable installs the plugins from the stable update center
+	UpdateCenterChannelStable UpdateCenterChannel = "stable"
+	// UpdateCenterChannelExperimental installs the latest plugin versions from the experimental update center
+	UpdateCenterChannelExperimental UpdateCenterChannel = "experimental"
+)
+
+// YamlMergeStrategy defines how the raw yaml of the agent pod template is merged with the pod templates it inherits
+// from
+type YamlMergeStrategy string
+
+const (
+	// YamlMergeStrategyOverride uses the raw yaml of the pod template and ignores the yaml of the parent pod templates
+	YamlMergeStrategyOverride YamlMergeStrategy = "override"
+	// YamlMergeStrategyMerge merges the raw yaml of the pod template into the yaml of the parent pod templates
+	YamlMergeStrategyMerge YamlMergeStrategy = "merge"
+)
+
+// PluginInstallMode defines where the plugins are installed
+type PluginInstallMode string
+
+const (
+	// PluginInstallModeContainer installs the plugins in the Jenkins master container before Jenkins starts
+	PluginInstallModeContainer PluginInstallMode = "container"
+	// PluginInstallModeJob installs the plugins by a Kubernetes Job populating the Jenkins home volume
+	PluginInstallModeJob PluginInstallMode = "job"
+)
+
+// SMTPTLSMode defines how the connection to the SMTP server is secured
+type SMTPTLSMode string
+
+const (
+	// SMTPTLSModeStartTLS upgrades the connection with the STARTTLS extension and fails when the server doesn't support it
+	SMTPTLSModeStartTLS SMTPTLSMode = "starttls"
+	// SMTPTLSModeTLS connects to the SMTP server with implicit TLS
+	SMTPTLSModeTLS SMTPTLSMode = "tls"
+)
+
 // MicrosoftTeams is handler for Microsoft MicrosoftTeams notification channel.
 type MicrosoftTeams struct {
 	// The web hook URL to MicrosoftTeams App
@@ -153,6 +452,48 @@ type Mailgun struct {
 	From                    string            `json:"from"`
 }
 
+// Webhook is handler for the generic webhook notification channel, the events are POSTed to the URL as JSON.
+type Webhook struct {
+	// URL of the webhook
+	URL string `json:"url"`
+
+	// AuthorizationHeaderSecretKeySelector selects the key of the Secret with the value of the Authorization header
+	// sent with the events, e.g. 'Bearer <token>'
+	// +optional
+	AuthorizationHeaderSecretKeySelector *SecretKeySelector `json:"authorizationHeaderSecretKeySelector,omitempty"`
+}
+
+// PluginProxy defines the HTTP proxy used to download the plugins.
+type PluginProxy struct {
+	// URL of the proxy without the credentials, e.g. http://proxy.example.com:3128
+	URL string `json:"url"`
+
+	// NoProxy are the hosts or the domains starting with a dot downloaded without the proxy
+	// +optional
+	NoProxy []string `json:"noProxy,omitempty"`
+
+	// CredentialsSecretRef is the Secret with the username and password keys of the proxy user
+	// +optional
+	CredentialsSecretRef *corev1.LocalObjectReference `json:"credentialsSecretRef,omitempty"`
+}
+
+// JenkinsProxy defines the HTTP proxy used by Jenkins.
+type JenkinsProxy struct {
+	// Host of the proxy
+	Host string `json:"host"`
+
+	// Port of the proxy
+	Port int32 `json:"port"`
+
+	// NoProxy are the host name patterns connected without the proxy, e.g. '*.cluster.local'
+	// +optional
+	NoProxy []string `json:"noProxy,omitempty"`
+
+	// CredentialsSecretRef is the Secret with the username and password keys of the proxy user
+	// +optional
+	CredentialsSecretRef *corev1.LocalObjectReference `json:"credentialsSecretRef,omitempty"`
+}
+
 // SecretKeySelector selects a key of a Secret.
 type SecretKeySelector struct {
 	// The name of the secret in the pod's namespace to select from.
@@ -260,6 +601,16 @@ type Plugin struct {
 	Version string `json:"version"`
 	// DownloadURL is the custom url from where plugin has to be downloaded.
 	DownloadURL string `json:"downloadURL,omitempty"`
+	// OCIRef is the reference of the OCI artifact containing the plugin HPI file, e.g. ghcr.io/org/plugins/my-plugin:1.0.0,
+	// it must follow the OCI reference grammar. The plugin is pulled with oras, which must be available in the Jenkins master image. Requires the operator
+	// to be started with --enable-oci-plugins.
+	// +optional
+	OCIRef string `json:"ociRef,omitempty"`
+	// Priority greater than zero installs the plugin in a separate pass before the rest of the user plugins,
+	// regardless of the plugin dependency metadata. All the priority plugins are installed together in the single pass,
+	// the value only sorts the lines of the plugins files. Applies only to user plugins.
+	// +optional
+	Priority int `json:"priority,omitempty"`
 }
 
 // JenkinsMaster defines the Jenkins master pod attributes and plugins,
@@ -272,6 +623,11 @@ type JenkinsMaster struct {
 	// +optional
 	Annotations map[string]string `json:"annotations,omitempty"`
 
+	// DeploymentAnnotations are added to the metadata of the Jenkins master Deployment, e.g. for the ArgoCD sync hooks,
+	// unlike the annotations they aren't added to the Jenkins master pod. Used only with the jenkins.io/use-deployment annotation
+	// +optional
+	DeploymentAnnotations map[string]string `json:"deploymentAnnotations,omitempty"`
+
 	// Map of string keys and values that can be used to organize and categorize
 	// (scope and select) objects. May match selectors of replication controllers
 	// and services.
@@ -373,6 +729,32 @@ type JenkinsMaster struct {
 	// DisableCSRFProtection allows you to toggle CSRF Protection on Jenkins
 	DisableCSRFProtection bool `json:"disableCSRFProtection"`
 
+	// CSRF configures the CSRF protection and the crumb issuer of Jenkins, takes precedence over DisableCSRFProtection
+	// +optional
+	CSRF *CSRF `json:"csrf,omitempty"`
+
+	// DeepReadiness replaces the readiness probe handler of the Jenkins master container with a query of the Jenkins API
+	// authenticated with the operator credentials, so the pod is ready only when Jenkins is fully started. The timing
+	// settings of the readiness probe are kept. It requires the createUser authorization strategy of JenkinsAPISettings.
+	// +optional
+	DeepReadiness bool `json:"deepReadiness,omitempty"`
+
+	// Monitoring defines the scraping of the Jenkins metrics
+	// +optional
+	Monitoring *Monitoring `json:"monitoring,omitempty"`
+
+	// DisableRemotingCLI disables the remoting based Jenkins CLI and the CLI access of the /cli URL, defaults to true
+	// +optional
+	DisableRemotingCLI *bool `json:"disableRemotingCLI,omitempty"`
+
+	// JenkinsURL is the root URL of Jenkins used in links sent by emails, webhooks and the build status
+	// +optional
+	JenkinsURL string `json:"jenkinsURL,omitempty"`
+
+	// AdminEmail is the email address used as the sender of the notifications sent by Jenkins
+	// +optional
+	AdminEmail string `json:"adminEmail,omitempty"`
+
 	// PriorityClassName for Jenkins master pod
 	// +optional
 	PriorityClassName string `json:"priorityClassName,omitempty"`
@@ -380,6 +762,726 @@ type JenkinsMaster struct {
 	// HostAliases for Jenkins master pod and SeedJob agent
 	// +optional
 	HostAliases []corev1.HostAlias `json:"hostAliases,omitempty"`
+
+	// Agent defines the settings of the agents connected to the Jenkins master
+	// +optional
+	Agent *JenkinsAgent `json:"agent,omitempty"`
+
+	// JenkinsHomeStorage defines the persistent volume claim used as the Jenkins home volume.
+	// Jenkins home is an emptyDir volume when not set. The claim isn't deleted together with the Jenkins CR.
+	// +optional
+	JenkinsHomeStorage *JenkinsHomeStorage `json:"jenkinsHomeStorage,omitempty"`
+
+	// Replicas is the number of Jenkins master replicas of the Deployment, 0 stops Jenkins. Jenkins doesn't support
+	// more than one active master, even on ReadWriteMany storage, so it can be only 0 or 1, and it's used only with the
+	// jenkins.io/use-deployment annotation.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=1
+	// +optional
+	Replicas *int32 `json:"replicas,omitempty"`
+
+	// ExportResolvedPlugins enables writing the plugins installed in Jenkins, including the transitive dependencies,
+	// to the jenkins-<cr_name>-resolved-plugins ConfigMap
+	// +optional
+	ExportResolvedPlugins bool `json:"exportResolvedPlugins,omitempty"`
+
+	// ExtraInitContainers are run before the Jenkins master containers, the Jenkins home volume is mounted
+	// in every init container which doesn't mount it explicitly
+	// +optional
+	ExtraInitContainers []corev1.Container `json:"extraInitContainers,omitempty"`
+
+	// InitContainerResources are the resource requirements of the spec.master.extraInitContainers which don't define
+	// their own and of the plugin installation Job container in the job spec.master.pluginInstallMode, the plugin
+	// installation often needs more memory than the steady-state Jenkins master. They don't apply to the plugin
+	// installation in the container spec.master.pluginInstallMode, it runs in the Jenkins master container.
+	// Defaults to requests cpu 250m, memory 256Mi and limits cpu 1, memory 1Gi when extra init containers are defined.
+	// +optional
+	InitContainerResources *corev1.ResourceRequirements `json:"initContainerResources,omitempty"`
+
+	// PluginCacheVolume is the persistent volume claim the plugins are downloaded to, it's mounted separately from
+	// the plugins reference directory and the plugins downloaded to the cache are reused across the Jenkins master restarts
+	// +optional
+	PluginCacheVolume *corev1.PersistentVolumeClaimVolumeSource `json:"pluginCacheVolume,omitempty"`
+
+	// PluginProxy is the HTTP proxy used to download the plugins
+	// +optional
+	PluginProxy *PluginProxy `json:"pluginProxy,omitempty"`
+
+	// PluginInstallBatchSize is the maximum number of the user plugins installed by a single jenkins-plugin-cli
+	// call, the batches are installed sequentially to limit the memory usage. All plugins are installed at once
+	// when not set.
+	// +optional
+	PluginInstallBatchSize int32 `json:"pluginInstallBatchSize,omitempty"`
+
+	// PluginInstallRetries is the number of times the whole plugin installation is retried after it fails, e.g. on
+	// a transient update center outage, before the init script fails. The plugin installation isn't retried when
+	// not set.
+	// +optional
+	PluginInstallRetries int32 `json:"pluginInstallRetries,omitempty"`
+
+	// PluginInstallRetryDelay is the time waited before retrying the failed plugin installation, defaults to 30 seconds
+	// +optional
+	PluginInstallRetryDelay *metav1.Duration `json:"pluginInstallRetryDelay,omitempty"`
+
+	// UpdateCenterChannel is the update center channel the plugins are installed from, one of stable or experimental.
+	// The experimental channel sets the JENKINS_UC_EXPERIMENTAL env and installs the latest experimental release of
+	// the plugins with the latest version, defaults to stable.
+	// +kubebuilder:validation:Enum=stable;experimental
+	// +optional
+	UpdateCenterChannel UpdateCenterChannel `json:"updateCenterChannel,omitempty"`
+
+	// PluginManagerSites are the update sites added to the plugin manager of the running Jenkins, e.g. a private or
+	// offline update center. The site with the default ID replaces the Jenkins update center.
+	// +optional
+	PluginManagerSites []PluginManagerSite `json:"pluginManagerSites,omitempty"`
+
+	// InitLockTimeout is the maximum time the init script waits for the lock in the Jenkins home before installing the
+	// plugins, the lock prevents the concurrent plugin installation of the old and the new Jenkins master pod sharing
+	// the Jenkins home during a rolling update of the Deployment.
+	// Setting it enables the lock, it's enabled with the 10 minutes timeout for the ReadWriteMany Jenkins home storage.
+	// +optional
+	InitLockTimeout *metav1.Duration `json:"initLockTimeout,omitempty"`
+
+	// PluginInstallMode defines where the plugins are installed, container installs them in the Jenkins master
+	// container before Jenkins starts and job installs them by a Kubernetes Job into the Jenkins home volume before
+	// the Jenkins master is started. The job mode requires spec.master.jenkinsHomeStorage, defaults to container.
+	// +kubebuilder:validation:Enum=container;job
+	// +optional
+	PluginInstallMode PluginInstallMode `json:"pluginInstallMode,omitempty"`
+
+	// ReinstallPluginsOnImageChange reinstalls the plugins when the image of the Jenkins master container changes, since
+	// the plugins bundled in the Jenkins WAR may have changed. The jenkins.io/init-script-checksum annotation of the
+	// Jenkins master pod changes with the image and restarts Jenkins. In the job mode the plugin installation Job is
+	// recreated, in the container mode the init script removes the plugins kept in spec.master.pluginCacheVolume and
+	// spec.master.jenkinsHomeStorage on the first start with the new image.
+	// +optional
+	ReinstallPluginsOnImageChange bool `json:"reinstallPluginsOnImageChange,omitempty"`
+
+	// JenkinsProxy is the HTTP proxy used by Jenkins for the update center and the outbound connections,
+	// configured in Manage Jenkins > Plugins > Advanced
+	// +optional
+	JenkinsProxy *JenkinsProxy `json:"jenkinsProxy,omitempty"`
+
+	// AgentProtocols is the list of the enabled agent protocols, the protocols not listed are disabled,
+	// e.g. JNLP4-connect and Ping
+	// +optional
+	AgentProtocols []string `json:"agentProtocols,omitempty"`
+
+	// ContextPath is the path Jenkins is served under, e.g. /jenkins, the operator uses it to build the Jenkins URLs.
+	// Jenkins must be started with the matching --prefix option in JENKINS_OPTS, it's validated by the operator
+	// +optional
+	ContextPath string `json:"contextPath,omitempty"`
+
+	// ForwardedHeaders enables the handling of the X-Forwarded-* headers by the Jenkins web server, so Jenkins builds
+	// its URLs from the host, the scheme and the port of the reverse proxy or the ingress. The --forwardedHeaders option
+	// is added to JENKINS_OPTS when it isn't set there already.
+	// +optional
+	ForwardedHeaders bool `json:"forwardedHeaders,omitempty"`
+
+	// Executors is the number of executors on the Jenkins master, defaults to 0. Running builds on the master
+	// is discouraged, use agents instead.
+	// +optional
+	Executors *int32 `json:"executors,omitempty"`
+
+	// NodeLabels are the Jenkins labels of the built-in node used by the jobs to run on the master executors,
+	// not to be confused with the Kubernetes labels of the Jenkins master pod
+	// +optional
+	NodeLabels []string `json:"nodeLabels,omitempty"`
+
+	// CACertsSecretRef is the Secret with the PEM encoded certificates imported to the JVM truststore of Jenkins,
+	// every key of the Secret holds a single certificate or a bundle of certificates
+	// +optional
+	CACertsSecretRef *corev1.LocalObjectReference `json:"caCertsSecretRef,omitempty"`
+
+	// MavenSettingsSecretRef selects the key of the Secret with the Maven settings.xml, it's mounted to the Jenkins
+	// master container and added as the maven-global-settings global Maven settings file of the config-file-provider
+	// plugin, so the builds pick up the repositories and their credentials
+	// +optional
+	MavenSettingsSecretRef *SecretKeySelector `json:"mavenSettingsSecretRef,omitempty"`
+
+	// WebhookTokenSecretRef selects the key of the Secret with the shared secret of the webhooks, it's mounted to the
+	// Jenkins master container, added as the jenkins-operator-webhook-token secret text credentials and set as the
+	// GitHub plugin hook secret, so Jenkins verifies the signature of the GitHub webhooks
+	// +optional
+	WebhookTokenSecretRef *SecretKeySelector `json:"webhookTokenSecretRef,omitempty"`
+
+	// GlobalEnvVars are the global environment variables of Jenkins available in every build,
+	// they are not set in the Jenkins master container
+	// +optional
+	GlobalEnvVars []KeyValue `json:"globalEnvVars,omitempty"`
+
+	// DisableSetupWizard disables the Jenkins setup wizard, -Djenkins.install.runSetupWizard=false is added to JAVA_OPTS
+	// when it isn't set there already, defaults to true
+	// +optional
+	DisableSetupWizard *bool `json:"disableSetupWizard,omitempty"`
+
+	// SystemMessage is the message displayed on the top of the Jenkins main page, e.g. an environment banner
+	// +optional
+	SystemMessage string `json:"systemMessage,omitempty"`
+
+	// ThemeCSS is the http or https URL of the CSS file applied to the Jenkins UI, requires the simple-theme-plugin
+	// +optional
+	ThemeCSS string `json:"themeCSS,omitempty"`
+
+	// Branding replaces the Jenkins logo and adds the footer text to the Jenkins UI, requires the simple-theme-plugin
+	// +optional
+	Branding *Branding `json:"branding,omitempty"`
+
+	// MarkupFormatter is the formatter of the descriptions in Jenkins, one of plainText, safeHtml or rawHtml.
+	// Defaults to safeHtml which requires the antisamy-markup-formatter plugin added to the base plugins.
+	// The rawHtml formatter allows any HTML including scripts and requires the anything-goes-formatter plugin in
+	// spec.master.plugins.
+	// +optional
+	// +kubebuilder:validation:Enum=plainText;safeHtml;rawHtml
+	MarkupFormatter MarkupFormatterName `json:"markupFormatter,omitempty"`
+
+	// Authorization defines the authorization strategy of Jenkins, the strategy set by the operator is kept when not set
+	// +optional
+	Authorization *Authorization `json:"authorization,omitempty"`
+
+	// LDAP configures the LDAP security realm of Jenkins, requires the ldap plugin and the createUser
+	// spec.jenkinsAPISettings.authorizationStrategy, the operator user authenticates with its fixed API token
+	// +optional
+	LDAP *LDAP `json:"ldap,omitempty"`
+
+	// OIDC configures the OpenID Connect security realm of Jenkins, the oic-auth plugin is added to the base plugins.
+	// Requires the createUser spec.jenkinsAPISettings.authorizationStrategy, the operator user authenticates with its
+	// fixed API token
+	// +optional
+	OIDC *OIDC `json:"oidc,omitempty"`
+
+	// BuildDiscarder defines the global build discarder applied to the jobs in addition to their own build discarders
+	// +optional
+	BuildDiscarder *BuildDiscarder `json:"buildDiscarder,omitempty"`
+
+	// QuietPeriod is the default number of seconds the new builds wait in the queue before they start,
+	// the Jenkins default of 5 seconds is kept when not set
+	// +optional
+	QuietPeriod *int32 `json:"quietPeriod,omitempty"`
+
+	// ScmCheckoutRetryCount is the default number of times a failed SCM checkout is retried,
+	// the Jenkins default of 0 is kept when not set
+	// +optional
+	ScmCheckoutRetryCount *int32 `json:"scmCheckoutRetryCount,omitempty"`
+
+	// APIClientTimeout is the timeout of a single call of the operator to the Jenkins API including its retries,
+	// defaults to 20s
+	// +optional
+	APIClientTimeout *metav1.Duration `json:"apiClientTimeout,omitempty"`
+
+	// APIClientRetries is the number of times the idempotent calls of the operator to the Jenkins API are retried
+	// when Jenkins responds with a server error or the connection fails, defaults to 0
+	// +optional
+	APIClientRetries int32 `json:"apiClientRetries,omitempty"`
+
+	// SystemProperties are the Java system properties of Jenkins appended to JAVA_OPTS as -Dkey=value,
+	// the properties already set in JAVA_OPTS aren't overridden
+	// +optional
+	SystemProperties []KeyValue `json:"systemProperties,omitempty"`
+
+	// TimeZone is the IANA time zone of Jenkins, e.g. 'Europe/Warsaw', it sets the TZ env and the time zone of
+	// the timestamps shown in the UI
+	// +optional
+	TimeZone string `json:"timeZone,omitempty"`
+
+	// ContentSecurityPolicy is the Content-Security-Policy header of the files served by Jenkins, e.g. the HTML
+	// publisher reports, set as the hudson.model.DirectoryBrowserSupport.CSP system property. The empty string disables
+	// the header, the restrictive Jenkins default is kept when not set.
+	// +optional
+	ContentSecurityPolicy *string `json:"contentSecurityPolicy,omitempty"`
+
+	// LogRecorders defines the Jenkins log recorders which collect the records of the selected loggers,
+	// e.g. for debugging of a plugin
+	// +optional
+	LogRecorders []LogRecorder `json:"logRecorders,omitempty"`
+}
+
+// LogRecorder defines the Jenkins log recorder.
+type LogRecorder struct {
+	// Name is the name of the log recorder
+	Name string `json:"name"`
+
+	// Loggers are the loggers recorded by the log recorder
+	Loggers []Logger `json:"loggers"`
+}
+
+// Logger defines the logger recorded by the Jenkins log recorder.
+type Logger struct {
+	// Name is the name of the logger, usually the Java package or class name, e.g. hudson.plugins.git
+	Name string `json:"name"`
+
+	// Level is the minimal level of the recorded records, defaults to ALL
+	// +optional
+	// +kubebuilder:validation:Enum=SEVERE;WARNING;INFO;CONFIG;FINE;FINER;FINEST;ALL
+	Level string `json:"level,omitempty"`
+}
+
+// Branding defines the custom branding of the Jenkins UI.
+type Branding struct {
+	// LogoURL is the http or https URL of the image displayed instead of the Jenkins logo in the header
+	// +optional
+	LogoURL string `json:"logoURL,omitempty"`
+
+	// FooterText is the text displayed in the footer of every Jenkins page
+	// +optional
+	FooterText string `json:"footerText,omitempty"`
+}
+
+// Monitoring defines the scraping of the Jenkins metrics.
+type Monitoring struct {
+	// Service enables the jenkins-operator-metrics-<cr_name> Service exposing only the metrics port of the Jenkins master,
+	// e.g. for the Prometheus ServiceMonitor. The metrics are served by the prometheus plugin on the Jenkins HTTP port.
+	// +optional
+	Service bool `json:"service,omitempty"`
+
+	// Port is the port of the metrics Service, defaults to 8080
+	// +optional
+	Port int32 `json:"port,omitempty"`
+
+	// Labels are added to the metrics Service, e.g. for the ServiceMonitor selector
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+// BuildDiscarder defines the Jenkins global build discarder.
+type BuildDiscarder struct {
+	// DaysToKeep is the number of days the builds are kept for, unlimited when not set
+	// +optional
+	DaysToKeep *int32 `json:"daysToKeep,omitempty"`
+
+	// NumToKeep is the maximum number of the kept builds, unlimited when not set
+	// +optional
+	NumToKeep *int32 `json:"numToKeep,omitempty"`
+}
+
+// OIDC defines the OpenID Connect security realm of Jenkins.
+type OIDC struct {
+	// Issuer is the URL of the OpenID Connect provider, the configuration is discovered
+	// from <issuer>/.well-known/openid-configuration
+	Issuer string `json:"issuer"`
+
+	// ClientID is the client ID of Jenkins registered in the OpenID Connect provider
+	ClientID string `json:"clientId"`
+
+	// ClientSecretRef selects the key of the Secret which contains the client secret,
+	// the secret is passed to Jenkins by the environment variable and it's never stored in the configuration
+	ClientSecretRef SecretKeySelector `json:"clientSecretRef"`
+
+	// Scopes are the requested scopes, the plugin defaults are used when not set
+	// +optional
+	Scopes []string `json:"scopes,omitempty"`
+}
+
+// LDAP defines the LDAP security realm of Jenkins.
+type LDAP struct {
+	// Server is the URL of the LDAP server, e.g. ldaps://ldap.example.com:636
+	Server string `json:"server"`
+
+	// RootDN is the root distinguished name of the LDAP directory, e.g. dc=example,dc=com, inferred by Jenkins when not set
+	// +optional
+	RootDN string `json:"rootDN,omitempty"`
+
+	// UserSearchBase is the base of the user search relative to the RootDN, e.g. ou=people
+	// +optional
+	UserSearchBase string `json:"userSearchBase,omitempty"`
+
+	// ManagerDN is the distinguished name used to bind to the LDAP server, the anonymous bind is used when not set
+	// +optional
+	ManagerDN string `json:"managerDN,omitempty"`
+
+	// ManagerDNSecretRef selects the key of the Secret which contains the password of the ManagerDN,
+	// the password is passed to Jenkins by the environment variable and it's never stored in the configuration
+	// +optional
+	ManagerDNSecretRef *SecretKeySelector `json:"managerDNSecretRef,omitempty"`
+}
+
+// MarkupFormatterName defines the name of the Jenkins markup formatter
+type MarkupFormatterName string
+
+const (
+	// PlainTextMarkupFormatterName escapes HTML in the descriptions
+	PlainTextMarkupFormatterName MarkupFormatterName = "plainText"
+	// SafeHTMLMarkupFormatterName allows the safe subset of HTML in the descriptions
+	SafeHTMLMarkupFormatterName MarkupFormatterName = "safeHtml"
+	// RawHTMLMarkupFormatterName allows any HTML in the descriptions
+	RawHTMLMarkupFormatterName MarkupFormatterName = "rawHtml"
+)
+
+// AuthorizationStrategyName defines the name of the Jenkins authorization strategy
+type AuthorizationStrategyName string
+
+const (
+	// LoggedInUsersCanDoAnythingAuthorizationStrategyName grants the full control to the logged in users
+	LoggedInUsersCanDoAnythingAuthorizationStrategyName AuthorizationStrategyName = "loggedInUsersCanDoAnything"
+	// GlobalMatrixAuthorizationStrategyName grants the permissions to the users and groups, requires the matrix-auth plugin
+	GlobalMatrixAuthorizationStrategyName AuthorizationStrategyName = "globalMatrix"
+	// ProjectMatrixAuthorizationStrategyName grants the permissions to the users and groups, the permissions can be extended
+	// in the jobs and folders, requires the matrix-auth plugin
+	ProjectMatrixAuthorizationStrategyName AuthorizationStrategyName = "projectMatrix"
+)
+
+// Authorization defines the Jenkins authorization strategy.
+type Authorization struct {
+	// Strategy is the name of the authorization strategy, one of loggedInUsersCanDoAnything, globalMatrix or projectMatrix
+	Strategy AuthorizationStrategyName `json:"strategy"`
+
+	// AllowAnonymousRead grants the read access to the anonymous users, used by the loggedInUsersCanDoAnything strategy
+	// +optional
+	AllowAnonymousRead bool `json:"allowAnonymousRead,omitempty"`
+
+	// Grants are the permissions granted to the users and groups, used by the globalMatrix and projectMatrix strategies
+	// +optional
+	Grants []AuthorizationGrant `json:"grants,omitempty"`
+}
+
+// AuthorizationGrant defines the permissions granted to the user or the group.
+type AuthorizationGrant struct {
+	// User is the name of the user, mutually exclusive with Group
+	// +optional
+	User string `json:"user,omitempty"`
+
+	// Group is the name of the group, mutually exclusive with User
+	// +optional
+	Group string `json:"group,omitempty"`
+
+	// Permissions are the granted permissions, e.g. Overall/Read or Job/Build
+	Permissions []string `json:"permissions"`
+}
+
+// KeyValue defines the key and the value pair.
+type KeyValue struct {
+	// Key is the name of the entry
+	Key string `json:"key"`
+
+	// Value is the value of the entry
+	// +optional
+	Value string `json:"value,omitempty"`
+}
+
+// JenkinsHomeStorage defines the persistent volume claim created by the operator for the Jenkins home.
+type JenkinsHomeStorage struct {
+	// Size is the requested size of the Jenkins home volume, e.g. 10Gi
+	Size resource.Quantity `json:"size"`
+
+	// StorageClassName is the name of the StorageClass of the volume, the default StorageClass is used when not set
+	// +optional
+	StorageClassName *string `json:"storageClassName,omitempty"`
+
+	// AccessModes contains the desired access modes of the volume
+	// +optional
+	// Defaults to:
+	// - ReadWriteOnce
+	AccessModes []corev1.PersistentVolumeAccessMode `json:"accessModes,omitempty"`
+
+	// Annotations are added to the persistent volume claim, e.g. for backup tooling like Velero.
+	// They are merged with the annotations managed by the operator, which take precedence.
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// JenkinsAgent defines the settings of the agents connected to the Jenkins master.
+type JenkinsAgent struct {
+	// JNLPSecretRef selects the key of the Secret which contains the JNLP secret of the seed job agent.
+	// The secret is mounted to the agent pod instead of being passed in the agent env, the Secret is only read
+	// by the operator and it has to contain the secret computed by Jenkins for the seed-job-agent node.
+	// +optional
+	JNLPSecretRef *SecretKeySelector `json:"jnlpSecretRef,omitempty"`
+
+	// PodTemplates defines the agent pod templates of the Kubernetes plugin cloud
+	// +optional
+	PodTemplates []AgentPodTemplate `json:"podTemplates,omitempty"`
+
+	// IdleMinutes is the number of minutes the idle agent pods are kept before being terminated, used by the pod templates
+	// which don't set it, the agent pods are terminated right after the build when not set
+	// +optional
+	IdleMinutes *int32 `json:"idleMinutes,omitempty"`
+
+	// Image is the Docker image of the jnlp container of the agent pod templates, the Kubernetes plugin default
+	// is used when not set
+	// +optional
+	Image string `json:"image,omitempty"`
+
+	// ImagePullPolicy of the jnlp container image of the agent pod templates, only Always forces the image to be pulled
+	// on every agent pod start
+	// +optional
+	// +kubebuilder:validation:Enum=Always;IfNotPresent;Never
+	ImagePullPolicy corev1.PullPolicy `json:"imagePullPolicy,omitempty"`
+
+	// AllowHostPathVolumes allows the pod templates to mount hostPath volumes, e.g. the Docker or BuildKit socket
+	// of the node. A hostPath volume gives the builds access to the node so it must be allowed explicitly.
+	// +optional
+	AllowHostPathVolumes bool `json:"allowHostPathVolumes,omitempty"`
+
+	// ConnectTimeout is the number of seconds the agent pod has to connect to Jenkins before it's considered failed
+	// and a new agent pod is started, the Kubernetes plugin default of 1000 seconds is used when not set
+	// +optional
+	ConnectTimeout *int32 `json:"connectTimeout,omitempty"`
+
+	// DefaultPodTolerations are the tolerations of the agent pods of the pod templates which don't set their own
+	// tolerations, e.g. for the dedicated agent nodes
+	// +optional
+	DefaultPodTolerations []corev1.Toleration `json:"defaultPodTolerations,omitempty"`
+
+	// RemoveOfflineNodes enables the Jenkins periodic task which removes the Kubernetes agent nodes left offline by
+	// the cloud, e.g. after their agent pods have been deleted outside of Jenkins
+	// +optional
+	RemoveOfflineNodes bool `json:"removeOfflineNodes,omitempty"`
+
+	// RemoveOfflineNodesInterval is the interval of the task removing the offline Kubernetes agent nodes, a node is
+	// removed when it's offline in two subsequent runs. Defaults to 10 minutes.
+	// +optional
+	RemoveOfflineNodesInterval *metav1.Duration `json:"removeOfflineNodesInterval,omitempty"`
+
+	// JenkinsURL is the URL of Jenkins used by the agents, e.g. when the agents run behind NAT,
+	// defaults to the URL of the Jenkins HTTP service
+	// +optional
+	JenkinsURL string `json:"jenkinsUrl,omitempty"`
+
+	// JenkinsTunnel is the host:port of the Jenkins agent listener used by the agents,
+	// defaults to the host and port of the Jenkins slave service
+	// +optional
+	JenkinsTunnel string `json:"jenkinsTunnel,omitempty"`
+
+	// ContainerCap is the maximum number of the agent pods running at the same time in the kubernetes cloud,
+	// unlimited when not set
+	// +optional
+	ContainerCap *int32 `json:"containerCap,omitempty"`
+
+	// WebSocket makes the agents connect to Jenkins over WebSocket through the Jenkins HTTP service instead of the
+	// agent listener, the Kubernetes plugin default is used when not set
+	// +optional
+	WebSocket *bool `json:"webSocket,omitempty"`
+
+	// Clouds are the additional Kubernetes plugin clouds, e.g. other clusters the agent pods are scheduled to besides
+	// the kubernetes cloud of the Jenkins namespace
+	// +optional
+	Clouds []KubernetesCloud `json:"clouds,omitempty"`
+}
+
+// KubernetesCloud defines the additional Kubernetes plugin cloud of the agents
+type KubernetesCloud struct {
+	// Name is the name of the cloud, it can't be kubernetes which is the cloud of the Jenkins namespace
+	Name string `json:"name"`
+
+	// ServerURL is the URL of the Kubernetes API server of the cluster
+	ServerURL string `json:"serverUrl"`
+
+	// CredentialsID is the ID of the Jenkins credentials used to connect to the Kubernetes API server, e.g. the secret
+	// text credentials with a service account token
+	// +optional
+	CredentialsID string `json:"credentialsId,omitempty"`
+
+	// ServerCertificate is the PEM encoded CA certificate of the Kubernetes API server
+	// +optional
+	ServerCertificate string `json:"serverCertificate,omitempty"`
+
+	// Namespace is the namespace of the agent pods in the cluster
+	Namespace string `json:"namespace"`
+
+	// JenkinsURL is the URL of Jenkins reachable by the agents running in the cluster of the cloud
+	JenkinsURL string `json:"jenkinsUrl"`
+
+	// JenkinsTunnel is the host:port of the Jenkins agent listener reachable by the agents running in the cluster
+	// of the cloud
+	JenkinsTunnel string `json:"jenkinsTunnel"`
+
+	// PodTemplates are the names of the pod templates from spec.master.agent.podTemplates provisioned by the cloud,
+	// all pod templates when not set
+	// +optional
+	PodTemplates []string `json:"podTemplates,omitempty"`
+}
+
+// AgentVolume defines the volume of the agent pod mounted to the jnlp container.
+type AgentVolume struct {
+	corev1.Volume `json:",inline"`
+
+	// MountPath is the path within the jnlp container at which the volume is mounted
+	MountPath string `json:"mountPath"`
+
+	// ReadOnly mounts the volume read-only
+	// +optional
+	ReadOnly bool `json:"readOnly,omitempty"`
+}
+
+// AgentNodeProperties defines the node properties of the agents created from the pod template.
+type AgentNodeProperties struct {
+	// EnvVars are the environment variables of the builds running on the agent
+	// +optional
+	EnvVars []KeyValue `json:"envVars,omitempty"`
+
+	// ToolLocations override the home directories of the tool installations on the agent
+	// +optional
+	ToolLocations []ToolLocation `json:"toolLocations,omitempty"`
+}
+
+// ToolType defines the type of the tool installation
+type ToolType string
+
+const (
+	// JDKToolType is the JDK installation type
+	JDKToolType ToolType = "jdk"
+	// MavenToolType is the Maven installation type
+	MavenToolType ToolType = "maven"
+	// GradleToolType is the Gradle installation type
+	GradleToolType ToolType = "gradle"
+)
+
+// ToolLocation defines the home directory of the tool installation on the agent.
+type ToolLocation struct {
+	// Type is the type of the tool installation, one of jdk, maven or gradle
+	// +kubebuilder:validation:Enum=jdk;maven;gradle
+	Type ToolType `json:"type"`
+
+	// Name is the name of the tool installation
+	Name string `json:"name"`
+
+	// Home is the path of the tool installation on the agent
+	Home string `json:"home"`
+}
+
+// AgentWorkspaceVolume defines the workspace volume of the agent pod, exactly one of the volume types must be set.
+type AgentWorkspaceVolume struct {
+	// EmptyDir is the emptyDir workspace volume, the Memory medium uses the tmpfs for the fast scratch space
+	// +optional
+	EmptyDir *corev1.EmptyDirVolumeSource `json:"emptyDir,omitempty"`
+
+	// PersistentVolumeClaim is the existing PersistentVolumeClaim used as the workspace volume
+	// +optional
+	PersistentVolumeClaim *corev1.PersistentVolumeClaimVolumeSource `json:"persistentVolumeClaim,omitempty"`
+
+	// Ephemeral is the generic ephemeral volume, the PersistentVolumeClaim is created with the agent pod and
+	// deleted together with it
+	// +optional
+	Ephemeral *AgentEphemeralWorkspaceVolume `json:"ephemeral,omitempty"`
+}
+
+// AgentEphemeralWorkspaceVolume defines the generic ephemeral workspace volume of the agent pod.
+type AgentEphemeralWorkspaceVolume struct {
+	// StorageClassName is the storage class of the volume, the default storage class is used when not set
+	// +optional
+	StorageClassName string `json:"storageClassName,omitempty"`
+
+	// Size is the requested size of the volume
+	Size resource.Quantity `json:"size"`
+
+	// AccessMode of the volume, defaults to ReadWriteOnce
+	// +optional
+	AccessMode corev1.PersistentVolumeAccessMode `json:"accessMode,omitempty"`
+}
+
+// AgentPodTemplate defines the Kubernetes plugin pod template used to schedule agent pods.
+type AgentPodTemplate struct {
+	// Name is the name of the pod template
+	Name string `json:"name"`
+
+	// Label is the label expression used by jobs to select the pod template, defaults to the name
+	// +optional
+	Label string `json:"label,omitempty"`
+
+	// NodeSelector must match the node labels for the agent pod to be scheduled on that node
+	// +optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// Tolerations of the agent pod, override spec.master.agent.defaultPodTolerations
+	// +optional
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+
+	// InheritDefaultTolerations set to false removes spec.master.agent.defaultPodTolerations from the agent pod of
+	// the pod template without its own tolerations. Defaults to true.
+	// +optional
+	InheritDefaultTolerations *bool `json:"inheritDefaultTolerations,omitempty"`
+
+	// IdleMinutes is the number of minutes the idle agent pods are kept before being terminated,
+	// defaults to spec.master.agent.idleMinutes
+	// +optional
+	IdleMinutes *int32 `json:"idleMinutes,omitempty"`
+
+	// InstanceCap is the maximum number of the agent pods of the pod template running at the same time,
+	// unlimited when not set
+	// +optional
+	InstanceCap *int32 `json:"instanceCap,omitempty"`
+
+	// ActiveDeadlineSeconds is the number of seconds the agent pod may run before it's terminated, so the stuck agent
+	// pods are cleaned up, unlimited when not set
+	// +optional
+	ActiveDeadlineSeconds *int32 `json:"activeDeadlineSeconds,omitempty"`
+
+	// ConnectTimeout is the number of seconds the agent pod has to connect to Jenkins before it's considered failed,
+	// defaults to spec.master.agent.connectTimeout
+	// +optional
+	ConnectTimeout *int32 `json:"connectTimeout,omitempty"`
+
+	// Volumes of the agent pod mounted to the jnlp container, e.g. a hostPath or projected volume with the Docker
+	// or BuildKit socket for the image builds. The hostPath volumes require spec.master.agent.allowHostPathVolumes.
+	// +optional
+	Volumes []AgentVolume `json:"volumes,omitempty"`
+
+	// WorkspaceVolume is the volume of the agent workspace, the Kubernetes plugin default emptyDir volume
+	// is used when not set
+	// +optional
+	WorkspaceVolume *AgentWorkspaceVolume `json:"workspaceVolume,omitempty"`
+
+	// NodeProperties defines the environment variables and the tool locations of the agents
+	// +optional
+	NodeProperties *AgentNodeProperties `json:"nodeProperties,omitempty"`
+
+	// RemoteFSRoot is the remote root directory of the agent set as the working directory of the jnlp container, the
+	// workspaces are created in it. The Kubernetes plugin default /home/jenkins/agent is used when not set.
+	// +optional
+	RemoteFSRoot string `json:"remoteFSRoot,omitempty"`
+
+	// Command overrides the entrypoint of the jnlp container, e.g. for the custom agent images
+	// +optional
+	Command []string `json:"command,omitempty"`
+
+	// Args overrides the arguments of the jnlp container, the Kubernetes plugin passes the agent secret and name
+	// when they are not set
+	// +optional
+	Args []string `json:"args,omitempty"`
+
+	// ResourceRequestCPU is the CPU request of the jnlp container
+	// +optional
+	ResourceRequestCPU *resource.Quantity `json:"resourceRequestCpu,omitempty"`
+
+	// ResourceRequestMemory is the memory request of the jnlp container
+	// +optional
+	ResourceRequestMemory *resource.Quantity `json:"resourceRequestMemory,omitempty"`
+
+	// ResourceLimitCPU is the CPU limit of the jnlp container
+	// +optional
+	ResourceLimitCPU *resource.Quantity `json:"resourceLimitCpu,omitempty"`
+
+	// ResourceLimitMemory is the memory limit of the jnlp container
+	// +optional
+	ResourceLimitMemory *resource.Quantity `json:"resourceLimitMemory,omitempty"`
+
+	// ImagePullSecrets are the Secrets used to pull the images of the agent pod from the private registries
+	// +optional
+	ImagePullSecrets []corev1.LocalObjectReference `json:"imagePullSecrets,omitempty"`
+
+	// InheritFrom lists the names of the pod templates from spec.master.agent.podTemplates which this pod template
+	// inherits from, the later ones override the earlier ones and this pod template overrides all of them
+	// +optional
+	InheritFrom []string `json:"inheritFrom,omitempty"`
+
+	// Annotations are added to the agent pods
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+
+	// Labels are added to the agent pods, e.g. for the cost tracking
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// Yaml is the raw yaml of the agent pod, it's merged by the Kubernetes plugin into the pod generated from the other
+	// settings of the pod template
+	// +optional
+	Yaml string `json:"yaml,omitempty"`
+
+	// YamlMergeStrategy defines how the yaml of the pod template is merged with the pod templates from inheritFrom,
+	// one of override or merge. The Kubernetes plugin default override is used when not set.
+	// +kubebuilder:validation:Enum=override;merge
+	// +optional
+	YamlMergeStrategy YamlMergeStrategy `json:"yamlMergeStrategy,omitempty"`
 }
 
 // Service defines Kubernetes service attributes
@@ -443,6 +1545,11 @@ type Service struct {
 	// This field will be ignored if the cloud-provider does not support the feature.
 	// +optional
 	LoadBalancerIP string `json:"loadBalancerIP,omitempty"`
+
+	// AppProtocol is the application protocol of the service port, e.g. 'http', 'https' or 'kubernetes.io/h2c',
+	// used by the ingress controllers and service meshes to choose the protocol of the upstream connections
+	// +optional
+	AppProtocol *string `json:"appProtocol,omitempty"`
 }
 
 // JenkinsStatus defines the observed state of Jenkins
@@ -491,6 +1598,14 @@ type JenkinsStatus struct {
 	// AppliedGroovyScripts is a list with all applied groovy scripts in Jenkins by the operator
 	// +optional
 	AppliedGroovyScripts []AppliedGroovyScript `json:"appliedGroovyScripts,omitempty"`
+
+	// Conditions represent the latest available observations of the Jenkins state
+	// +optional
+	// +patchMergeKey=type
+	// +patchStrategy=merge
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
 }
 
 // +kubebuilder:object:root=true
@@ -511,6 +1626,11 @@ type Jenkins struct {
 	Status JenkinsStatus `json:"status,omitempty"`
 }
 
+// IsPaused returns true if the reconciliation of the Jenkins CR is paused
+func (in *Jenkins) IsPaused() bool {
+	return in.Spec.Paused || in.Annotations[PausedAnnotation] == "true"
+}
+
 // +kubebuilder:object:root=true
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
@@ -532,6 +1652,8 @@ const (
 	// UsernamePasswordCredentialType define username & password Jenkins credential type
 	UsernamePasswordCredentialType JenkinsCredentialType = "usernamePassword"
 	GithubAppCredentialType        JenkinsCredentialType = "githubApp"
+	// SecretTextCredentialType define secret text Jenkins credential type, it's supported only by spec.credentials
+	SecretTextCredentialType JenkinsCredentialType = "secretText"
 	// ExternalCredentialType defines other credential type
 	ExternalCredentialType JenkinsCredentialType = "external"
 )
@@ -604,6 +1726,51 @@ type SeedJob struct {
 	UnstableOnDeprecation bool `json:"unstableOnDeprecation"`
 }
 
+// Credential defines a Jenkins credential created by the operator from the keys of a Kubernetes Secret.
+type Credential struct {
+	// ID is the unique ID of the Jenkins credential
+	ID string `json:"id"`
+
+	// Description is the description of the Jenkins credential
+	// +optional
+	Description string `json:"description,omitempty"`
+
+	// Type is the type of the Jenkins credential, usernamePassword uses the username and password keys,
+	// basicSSHUserPrivateKey uses the username and privateKey keys and secretText uses the text key of the Secret
+	// +kubebuilder:validation:Enum=usernamePassword;basicSSHUserPrivateKey;secretText
+	Type JenkinsCredentialType `json:"type"`
+
+	// SecretRef is the Secret with the values of the Jenkins credential
+	SecretRef corev1.LocalObjectReference `json:"secretRef"`
+
+	// Domain is the credentials domain the Jenkins credential is scoped to, the global domain is used when not set
+	// +optional
+	Domain *CredentialsDomain `json:"domain,omitempty"`
+}
+
+// CredentialsDomain defines a Jenkins credentials domain, the credentials sharing a domain name must define
+// the same domain.
+type CredentialsDomain struct {
+	// Name is the name of the credentials domain
+	Name string `json:"name"`
+
+	// Description is the description of the credentials domain
+	// +optional
+	Description string `json:"description,omitempty"`
+
+	// Hostnames are the hostname patterns the credentials domain applies to, e.g. *.example.com
+	// +optional
+	Hostnames []string `json:"hostnames,omitempty"`
+
+	// ExcludedHostnames are the hostname patterns the credentials domain doesn't apply to
+	// +optional
+	ExcludedHostnames []string `json:"excludedHostnames,omitempty"`
+
+	// Schemes are the URI schemes the credentials domain applies to, e.g. https
+	// +optional
+	Schemes []string `json:"schemes,omitempty"`
+}
+
 // Handler defines a specific action that should be taken.
 type Handler struct {
 	// Exec specifies the action to take.
@@ -624,8 +1791,26 @@ type Backup struct {
 
 	// MakeBackupBeforePodDeletion tells operator to make backup before Jenkins master pod deletion
 	MakeBackupBeforePodDeletion bool `json:"makeBackupBeforePodDeletion"`
+
+	// Compression is the compression of the backup archives, one of gzip, zstd or none. It's passed to the backup
+	// and restore actions in the BACKUP_COMPRESSION env, the backup scripts default to gzip when not set.
+	// +kubebuilder:validation:Enum=gzip;zstd;none
+	// +optional
+	Compression BackupCompression `json:"compression,omitempty"`
 }
 
+// BackupCompression defines the compression of the backup archives
+type BackupCompression string
+
+const (
+	// BackupCompressionGzip compresses the backup archives with gzip
+	BackupCompressionGzip BackupCompression = "gzip"
+	// BackupCompressionZstd compresses the backup archives with zstd
+	BackupCompressionZstd BackupCompression = "zstd"
+	// BackupCompressionNone doesn't compress the backup archives
+	BackupCompressionNone BackupCompression = "none"
+)
+
 // Restore defines configuration of Jenkins backup restore operation.
 type Restore struct {
 	// ContainerName is the container name responsible for restore backup operation
@@ -681,3 +1866,10 @@ type GroovyScripts struct {
 type ConfigurationAsCode struct {
 	Customization `json:",inline"`
 }
+
+// ConfigurationAsCodeExport defines the periodic export of the Jenkins configuration.
+type ConfigurationAsCodeExport struct {
+	// Interval is the minimal interval between the exports, defaults to 1h
+	// +optional
+	Interval *metav1.Duration `json:"interval,omitempty"`
+}
@@ -24,9 +24,222 @@ package v1alpha2
 import (
 	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AgentEphemeralWorkspaceVolume) DeepCopyInto(out *AgentEphemeralWorkspaceVolume) {
+	*out = *in
+	out.Size = in.Size.DeepCopy()
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentEphemeralWorkspaceVolume.
+func (in *AgentEphemeralWorkspaceVolume) DeepCopy() *AgentEphemeralWorkspaceVolume {
+	if in == nil {
+		return nil
+	}
+	out := new(AgentEphemeralWorkspaceVolume)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AgentNodeProperties) DeepCopyInto(out *AgentNodeProperties) {
+	*out = *in
+	if in.EnvVars != nil {
+		in, out := &in.EnvVars, &out.EnvVars
+		*out = make([]KeyValue, len(*in))
+		copy(*out, *in)
+	}
+	if in.ToolLocations != nil {
+		in, out := &in.ToolLocations, &out.ToolLocations
+		*out = make([]ToolLocation, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentNodeProperties.
+func (in *AgentNodeProperties) DeepCopy() *AgentNodeProperties {
+	if in == nil {
+		return nil
+	}
+	out := new(AgentNodeProperties)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AgentPodTemplate) DeepCopyInto(out *AgentPodTemplate) {
+	*out = *in
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]corev1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.InheritDefaultTolerations != nil {
+		in, out := &in.InheritDefaultTolerations, &out.InheritDefaultTolerations
+		*out = new(bool)
+		**out = **in
+	}
+	if in.IdleMinutes != nil {
+		in, out := &in.IdleMinutes, &out.IdleMinutes
+		*out = new(int32)
+		**out = **in
+	}
+	if in.InstanceCap != nil {
+		in, out := &in.InstanceCap, &out.InstanceCap
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ActiveDeadlineSeconds != nil {
+		in, out := &in.ActiveDeadlineSeconds, &out.ActiveDeadlineSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ConnectTimeout != nil {
+		in, out := &in.ConnectTimeout, &out.ConnectTimeout
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Volumes != nil {
+		in, out := &in.Volumes, &out.Volumes
+		*out = make([]AgentVolume, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.WorkspaceVolume != nil {
+		in, out := &in.WorkspaceVolume, &out.WorkspaceVolume
+		*out = new(AgentWorkspaceVolume)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NodeProperties != nil {
+		in, out := &in.NodeProperties, &out.NodeProperties
+		*out = new(AgentNodeProperties)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Command != nil {
+		in, out := &in.Command, &out.Command
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Args != nil {
+		in, out := &in.Args, &out.Args
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ResourceRequestCPU != nil {
+		in, out := &in.ResourceRequestCPU, &out.ResourceRequestCPU
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.ResourceRequestMemory != nil {
+		in, out := &in.ResourceRequestMemory, &out.ResourceRequestMemory
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.ResourceLimitCPU != nil {
+		in, out := &in.ResourceLimitCPU, &out.ResourceLimitCPU
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.ResourceLimitMemory != nil {
+		in, out := &in.ResourceLimitMemory, &out.ResourceLimitMemory
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.ImagePullSecrets != nil {
+		in, out := &in.ImagePullSecrets, &out.ImagePullSecrets
+		*out = make([]corev1.LocalObjectReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.InheritFrom != nil {
+		in, out := &in.InheritFrom, &out.InheritFrom
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentPodTemplate.
+func (in *AgentPodTemplate) DeepCopy() *AgentPodTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(AgentPodTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AgentVolume) DeepCopyInto(out *AgentVolume) {
+	*out = *in
+	in.Volume.DeepCopyInto(&out.Volume)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentVolume.
+func (in *AgentVolume) DeepCopy() *AgentVolume {
+	if in == nil {
+		return nil
+	}
+	out := new(AgentVolume)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AgentWorkspaceVolume) DeepCopyInto(out *AgentWorkspaceVolume) {
+	*out = *in
+	if in.EmptyDir != nil {
+		in, out := &in.EmptyDir, &out.EmptyDir
+		*out = new(corev1.EmptyDirVolumeSource)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PersistentVolumeClaim != nil {
+		in, out := &in.PersistentVolumeClaim, &out.PersistentVolumeClaim
+		*out = new(corev1.PersistentVolumeClaimVolumeSource)
+		**out = **in
+	}
+	if in.Ephemeral != nil {
+		in, out := &in.Ephemeral, &out.Ephemeral
+		*out = new(AgentEphemeralWorkspaceVolume)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentWorkspaceVolume.
+func (in *AgentWorkspaceVolume) DeepCopy() *AgentWorkspaceVolume {
+	if in == nil {
+		return nil
+	}
+	out := new(AgentWorkspaceVolume)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AppliedGroovyScript) DeepCopyInto(out *AppliedGroovyScript) {
 	*out = *in
@@ -42,6 +255,63 @@ func (in *AppliedGroovyScript) DeepCopy() *AppliedGroovyScript {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AuditLog) DeepCopyInto(out *AuditLog) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuditLog.
+func (in *AuditLog) DeepCopy() *AuditLog {
+	if in == nil {
+		return nil
+	}
+	out := new(AuditLog)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Authorization) DeepCopyInto(out *Authorization) {
+	*out = *in
+	if in.Grants != nil {
+		in, out := &in.Grants, &out.Grants
+		*out = make([]AuthorizationGrant, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Authorization.
+func (in *Authorization) DeepCopy() *Authorization {
+	if in == nil {
+		return nil
+	}
+	out := new(Authorization)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AuthorizationGrant) DeepCopyInto(out *AuthorizationGrant) {
+	*out = *in
+	if in.Permissions != nil {
+		in, out := &in.Permissions, &out.Permissions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuthorizationGrant.
+func (in *AuthorizationGrant) DeepCopy() *AuthorizationGrant {
+	if in == nil {
+		return nil
+	}
+	out := new(AuthorizationGrant)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Backup) DeepCopyInto(out *Backup) {
 	*out = *in
@@ -58,6 +328,71 @@ func (in *Backup) DeepCopy() *Backup {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Branding) DeepCopyInto(out *Branding) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Branding.
+func (in *Branding) DeepCopy() *Branding {
+	if in == nil {
+		return nil
+	}
+	out := new(Branding)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BuildDiscarder) DeepCopyInto(out *BuildDiscarder) {
+	*out = *in
+	if in.DaysToKeep != nil {
+		in, out := &in.DaysToKeep, &out.DaysToKeep
+		*out = new(int32)
+		**out = **in
+	}
+	if in.NumToKeep != nil {
+		in, out := &in.NumToKeep, &out.NumToKeep
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BuildDiscarder.
+func (in *BuildDiscarder) DeepCopy() *BuildDiscarder {
+	if in == nil {
+		return nil
+	}
+	out := new(BuildDiscarder)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CSRF) DeepCopyInto(out *CSRF) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ProxyCompatibility != nil {
+		in, out := &in.ProxyCompatibility, &out.ProxyCompatibility
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CSRF.
+func (in *CSRF) DeepCopy() *CSRF {
+	if in == nil {
+		return nil
+	}
+	out := new(CSRF)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ConfigMapRef) DeepCopyInto(out *ConfigMapRef) {
 	*out = *in
@@ -89,6 +424,26 @@ func (in *ConfigurationAsCode) DeepCopy() *ConfigurationAsCode {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConfigurationAsCodeExport) DeepCopyInto(out *ConfigurationAsCodeExport) {
+	*out = *in
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigurationAsCodeExport.
+func (in *ConfigurationAsCodeExport) DeepCopy() *ConfigurationAsCodeExport {
+	if in == nil {
+		return nil
+	}
+	out := new(ConfigurationAsCodeExport)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Container) DeepCopyInto(out *Container) {
 	*out = *in
@@ -161,6 +516,57 @@ func (in *Container) DeepCopy() *Container {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Credential) DeepCopyInto(out *Credential) {
+	*out = *in
+	out.SecretRef = in.SecretRef
+	if in.Domain != nil {
+		in, out := &in.Domain, &out.Domain
+		*out = new(CredentialsDomain)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Credential.
+func (in *Credential) DeepCopy() *Credential {
+	if in == nil {
+		return nil
+	}
+	out := new(Credential)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CredentialsDomain) DeepCopyInto(out *CredentialsDomain) {
+	*out = *in
+	if in.Hostnames != nil {
+		in, out := &in.Hostnames, &out.Hostnames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExcludedHostnames != nil {
+		in, out := &in.ExcludedHostnames, &out.ExcludedHostnames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Schemes != nil {
+		in, out := &in.Schemes, &out.Schemes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CredentialsDomain.
+func (in *CredentialsDomain) DeepCopy() *CredentialsDomain {
+	if in == nil {
+		return nil
+	}
+	out := new(CredentialsDomain)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Customization) DeepCopyInto(out *Customization) {
 	*out = *in
@@ -260,6 +666,105 @@ func (in *JenkinsAPISettings) DeepCopy() *JenkinsAPISettings {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JenkinsAgent) DeepCopyInto(out *JenkinsAgent) {
+	*out = *in
+	if in.JNLPSecretRef != nil {
+		in, out := &in.JNLPSecretRef, &out.JNLPSecretRef
+		*out = new(SecretKeySelector)
+		**out = **in
+	}
+	if in.PodTemplates != nil {
+		in, out := &in.PodTemplates, &out.PodTemplates
+		*out = make([]AgentPodTemplate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.IdleMinutes != nil {
+		in, out := &in.IdleMinutes, &out.IdleMinutes
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ConnectTimeout != nil {
+		in, out := &in.ConnectTimeout, &out.ConnectTimeout
+		*out = new(int32)
+		**out = **in
+	}
+	if in.DefaultPodTolerations != nil {
+		in, out := &in.DefaultPodTolerations, &out.DefaultPodTolerations
+		*out = make([]corev1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.RemoveOfflineNodesInterval != nil {
+		in, out := &in.RemoveOfflineNodesInterval, &out.RemoveOfflineNodesInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.ContainerCap != nil {
+		in, out := &in.ContainerCap, &out.ContainerCap
+		*out = new(int32)
+		**out = **in
+	}
+	if in.WebSocket != nil {
+		in, out := &in.WebSocket, &out.WebSocket
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Clouds != nil {
+		in, out := &in.Clouds, &out.Clouds
+		*out = make([]KubernetesCloud, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JenkinsAgent.
+func (in *JenkinsAgent) DeepCopy() *JenkinsAgent {
+	if in == nil {
+		return nil
+	}
+	out := new(JenkinsAgent)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JenkinsHomeStorage) DeepCopyInto(out *JenkinsHomeStorage) {
+	*out = *in
+	out.Size = in.Size.DeepCopy()
+	if in.StorageClassName != nil {
+		in, out := &in.StorageClassName, &out.StorageClassName
+		*out = new(string)
+		**out = **in
+	}
+	if in.AccessModes != nil {
+		in, out := &in.AccessModes, &out.AccessModes
+		*out = make([]corev1.PersistentVolumeAccessMode, len(*in))
+		copy(*out, *in)
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JenkinsHomeStorage.
+func (in *JenkinsHomeStorage) DeepCopy() *JenkinsHomeStorage {
+	if in == nil {
+		return nil
+	}
+	out := new(JenkinsHomeStorage)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *JenkinsList) DeepCopyInto(out *JenkinsList) {
 	*out = *in
@@ -302,6 +807,13 @@ func (in *JenkinsMaster) DeepCopyInto(out *JenkinsMaster) {
 			(*out)[key] = val
 		}
 	}
+	if in.DeploymentAnnotations != nil {
+		in, out := &in.DeploymentAnnotations, &out.DeploymentAnnotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	if in.Labels != nil {
 		in, out := &in.Labels, &out.Labels
 		*out = make(map[string]string, len(*in))
@@ -357,6 +869,21 @@ func (in *JenkinsMaster) DeepCopyInto(out *JenkinsMaster) {
 		*out = make([]Plugin, len(*in))
 		copy(*out, *in)
 	}
+	if in.CSRF != nil {
+		in, out := &in.CSRF, &out.CSRF
+		*out = new(CSRF)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Monitoring != nil {
+		in, out := &in.Monitoring, &out.Monitoring
+		*out = new(Monitoring)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DisableRemotingCLI != nil {
+		in, out := &in.DisableRemotingCLI, &out.DisableRemotingCLI
+		*out = new(bool)
+		**out = **in
+	}
 	if in.HostAliases != nil {
 		in, out := &in.HostAliases, &out.HostAliases
 		*out = make([]corev1.HostAlias, len(*in))
@@ -364,6 +891,160 @@ func (in *JenkinsMaster) DeepCopyInto(out *JenkinsMaster) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Agent != nil {
+		in, out := &in.Agent, &out.Agent
+		*out = new(JenkinsAgent)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.JenkinsHomeStorage != nil {
+		in, out := &in.JenkinsHomeStorage, &out.JenkinsHomeStorage
+		*out = new(JenkinsHomeStorage)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ExtraInitContainers != nil {
+		in, out := &in.ExtraInitContainers, &out.ExtraInitContainers
+		*out = make([]corev1.Container, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.InitContainerResources != nil {
+		in, out := &in.InitContainerResources, &out.InitContainerResources
+		*out = new(corev1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PluginCacheVolume != nil {
+		in, out := &in.PluginCacheVolume, &out.PluginCacheVolume
+		*out = new(corev1.PersistentVolumeClaimVolumeSource)
+		**out = **in
+	}
+	if in.PluginProxy != nil {
+		in, out := &in.PluginProxy, &out.PluginProxy
+		*out = new(PluginProxy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PluginInstallRetryDelay != nil {
+		in, out := &in.PluginInstallRetryDelay, &out.PluginInstallRetryDelay
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.PluginManagerSites != nil {
+		in, out := &in.PluginManagerSites, &out.PluginManagerSites
+		*out = make([]PluginManagerSite, len(*in))
+		copy(*out, *in)
+	}
+	if in.InitLockTimeout != nil {
+		in, out := &in.InitLockTimeout, &out.InitLockTimeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.JenkinsProxy != nil {
+		in, out := &in.JenkinsProxy, &out.JenkinsProxy
+		*out = new(JenkinsProxy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AgentProtocols != nil {
+		in, out := &in.AgentProtocols, &out.AgentProtocols
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Executors != nil {
+		in, out := &in.Executors, &out.Executors
+		*out = new(int32)
+		**out = **in
+	}
+	if in.NodeLabels != nil {
+		in, out := &in.NodeLabels, &out.NodeLabels
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.CACertsSecretRef != nil {
+		in, out := &in.CACertsSecretRef, &out.CACertsSecretRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	if in.MavenSettingsSecretRef != nil {
+		in, out := &in.MavenSettingsSecretRef, &out.MavenSettingsSecretRef
+		*out = new(SecretKeySelector)
+		**out = **in
+	}
+	if in.WebhookTokenSecretRef != nil {
+		in, out := &in.WebhookTokenSecretRef, &out.WebhookTokenSecretRef
+		*out = new(SecretKeySelector)
+		**out = **in
+	}
+	if in.GlobalEnvVars != nil {
+		in, out := &in.GlobalEnvVars, &out.GlobalEnvVars
+		*out = make([]KeyValue, len(*in))
+		copy(*out, *in)
+	}
+	if in.DisableSetupWizard != nil {
+		in, out := &in.DisableSetupWizard, &out.DisableSetupWizard
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Branding != nil {
+		in, out := &in.Branding, &out.Branding
+		*out = new(Branding)
+		**out = **in
+	}
+	if in.Authorization != nil {
+		in, out := &in.Authorization, &out.Authorization
+		*out = new(Authorization)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LDAP != nil {
+		in, out := &in.LDAP, &out.LDAP
+		*out = new(LDAP)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OIDC != nil {
+		in, out := &in.OIDC, &out.OIDC
+		*out = new(OIDC)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.BuildDiscarder != nil {
+		in, out := &in.BuildDiscarder, &out.BuildDiscarder
+		*out = new(BuildDiscarder)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.QuietPeriod != nil {
+		in, out := &in.QuietPeriod, &out.QuietPeriod
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ScmCheckoutRetryCount != nil {
+		in, out := &in.ScmCheckoutRetryCount, &out.ScmCheckoutRetryCount
+		*out = new(int32)
+		**out = **in
+	}
+	if in.APIClientTimeout != nil {
+		in, out := &in.APIClientTimeout, &out.APIClientTimeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.SystemProperties != nil {
+		in, out := &in.SystemProperties, &out.SystemProperties
+		*out = make([]KeyValue, len(*in))
+		copy(*out, *in)
+	}
+	if in.ContentSecurityPolicy != nil {
+		in, out := &in.ContentSecurityPolicy, &out.ContentSecurityPolicy
+		*out = new(string)
+		**out = **in
+	}
+	if in.LogRecorders != nil {
+		in, out := &in.LogRecorders, &out.LogRecorders
+		*out = make([]LogRecorder, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JenkinsMaster.
@@ -376,6 +1057,31 @@ func (in *JenkinsMaster) DeepCopy() *JenkinsMaster {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JenkinsProxy) DeepCopyInto(out *JenkinsProxy) {
+	*out = *in
+	if in.NoProxy != nil {
+		in, out := &in.NoProxy, &out.NoProxy
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.CredentialsSecretRef != nil {
+		in, out := &in.CredentialsSecretRef, &out.CredentialsSecretRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JenkinsProxy.
+func (in *JenkinsProxy) DeepCopy() *JenkinsProxy {
+	if in == nil {
+		return nil
+	}
+	out := new(JenkinsProxy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *JenkinsSpec) DeepCopyInto(out *JenkinsSpec) {
 	*out = *in
@@ -385,6 +1091,13 @@ func (in *JenkinsSpec) DeepCopyInto(out *JenkinsSpec) {
 		*out = make([]SeedJob, len(*in))
 		copy(*out, *in)
 	}
+	if in.Credentials != nil {
+		in, out := &in.Credentials, &out.Credentials
+		*out = make([]Credential, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.Notifications != nil {
 		in, out := &in.Notifications, &out.Notifications
 		*out = make([]Notification, len(*in))
@@ -392,12 +1105,27 @@ func (in *JenkinsSpec) DeepCopyInto(out *JenkinsSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.NotificationThrottleWindow != nil {
+		in, out := &in.NotificationThrottleWindow, &out.NotificationThrottleWindow
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.AuditLog != nil {
+		in, out := &in.AuditLog, &out.AuditLog
+		*out = new(AuditLog)
+		**out = **in
+	}
 	in.Service.DeepCopyInto(&out.Service)
 	in.SlaveService.DeepCopyInto(&out.SlaveService)
 	in.Backup.DeepCopyInto(&out.Backup)
 	in.Restore.DeepCopyInto(&out.Restore)
 	in.GroovyScripts.DeepCopyInto(&out.GroovyScripts)
 	in.ConfigurationAsCode.DeepCopyInto(&out.ConfigurationAsCode)
+	if in.ConfigurationAsCodeExport != nil {
+		in, out := &in.ConfigurationAsCodeExport, &out.ConfigurationAsCodeExport
+		*out = new(ConfigurationAsCodeExport)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Roles != nil {
 		in, out := &in.Roles, &out.Roles
 		*out = make([]rbacv1.RoleRef, len(*in))
@@ -405,6 +1133,35 @@ func (in *JenkinsSpec) DeepCopyInto(out *JenkinsSpec) {
 	}
 	in.ServiceAccount.DeepCopyInto(&out.ServiceAccount)
 	out.JenkinsAPISettings = in.JenkinsAPISettings
+	if in.ToolConfig != nil {
+		in, out := &in.ToolConfig, &out.ToolConfig
+		*out = new(ToolConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Views != nil {
+		in, out := &in.Views, &out.Views
+		*out = make([]View, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.GlobalPipelineLibraries != nil {
+		in, out := &in.GlobalPipelineLibraries, &out.GlobalPipelineLibraries
+		*out = make([]Library, len(*in))
+		copy(*out, *in)
+	}
+	if in.PermanentAgents != nil {
+		in, out := &in.PermanentAgents, &out.PermanentAgents
+		*out = make([]PermanentAgent, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.DependsOn != nil {
+		in, out := &in.DependsOn, &out.DependsOn
+		*out = make([]ServiceRef, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JenkinsSpec.
@@ -442,6 +1199,13 @@ func (in *JenkinsStatus) DeepCopyInto(out *JenkinsStatus) {
 		*out = make([]AppliedGroovyScript, len(*in))
 		copy(*out, *in)
 	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JenkinsStatus.
@@ -454,6 +1218,111 @@ func (in *JenkinsStatus) DeepCopy() *JenkinsStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeyValue) DeepCopyInto(out *KeyValue) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeyValue.
+func (in *KeyValue) DeepCopy() *KeyValue {
+	if in == nil {
+		return nil
+	}
+	out := new(KeyValue)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubernetesCloud) DeepCopyInto(out *KubernetesCloud) {
+	*out = *in
+	if in.PodTemplates != nil {
+		in, out := &in.PodTemplates, &out.PodTemplates
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubernetesCloud.
+func (in *KubernetesCloud) DeepCopy() *KubernetesCloud {
+	if in == nil {
+		return nil
+	}
+	out := new(KubernetesCloud)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LDAP) DeepCopyInto(out *LDAP) {
+	*out = *in
+	if in.ManagerDNSecretRef != nil {
+		in, out := &in.ManagerDNSecretRef, &out.ManagerDNSecretRef
+		*out = new(SecretKeySelector)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LDAP.
+func (in *LDAP) DeepCopy() *LDAP {
+	if in == nil {
+		return nil
+	}
+	out := new(LDAP)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Library) DeepCopyInto(out *Library) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Library.
+func (in *Library) DeepCopy() *Library {
+	if in == nil {
+		return nil
+	}
+	out := new(Library)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LogRecorder) DeepCopyInto(out *LogRecorder) {
+	*out = *in
+	if in.Loggers != nil {
+		in, out := &in.Loggers, &out.Loggers
+		*out = make([]Logger, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogRecorder.
+func (in *LogRecorder) DeepCopy() *LogRecorder {
+	if in == nil {
+		return nil
+	}
+	out := new(LogRecorder)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Logger) DeepCopyInto(out *Logger) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Logger.
+func (in *Logger) DeepCopy() *Logger {
+	if in == nil {
+		return nil
+	}
+	out := new(Logger)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Mailgun) DeepCopyInto(out *Mailgun) {
 	*out = *in
@@ -486,6 +1355,28 @@ func (in *MicrosoftTeams) DeepCopy() *MicrosoftTeams {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Monitoring) DeepCopyInto(out *Monitoring) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Monitoring.
+func (in *Monitoring) DeepCopy() *Monitoring {
+	if in == nil {
+		return nil
+	}
+	out := new(Monitoring)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Notification) DeepCopyInto(out *Notification) {
 	*out = *in
@@ -509,6 +1400,11 @@ func (in *Notification) DeepCopyInto(out *Notification) {
 		*out = new(SMTP)
 		**out = **in
 	}
+	if in.Webhook != nil {
+		in, out := &in.Webhook, &out.Webhook
+		*out = new(Webhook)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Notification.
@@ -521,6 +1417,57 @@ func (in *Notification) DeepCopy() *Notification {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OIDC) DeepCopyInto(out *OIDC) {
+	*out = *in
+	out.ClientSecretRef = in.ClientSecretRef
+	if in.Scopes != nil {
+		in, out := &in.Scopes, &out.Scopes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OIDC.
+func (in *OIDC) DeepCopy() *OIDC {
+	if in == nil {
+		return nil
+	}
+	out := new(OIDC)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PermanentAgent) DeepCopyInto(out *PermanentAgent) {
+	*out = *in
+	if in.Port != nil {
+		in, out := &in.Port, &out.Port
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.NumExecutors != nil {
+		in, out := &in.NumExecutors, &out.NumExecutors
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PermanentAgent.
+func (in *PermanentAgent) DeepCopy() *PermanentAgent {
+	if in == nil {
+		return nil
+	}
+	out := new(PermanentAgent)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Plugin) DeepCopyInto(out *Plugin) {
 	*out = *in
@@ -573,6 +1520,46 @@ func (in *PluginInfo) DeepCopy() *PluginInfo {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PluginManagerSite) DeepCopyInto(out *PluginManagerSite) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PluginManagerSite.
+func (in *PluginManagerSite) DeepCopy() *PluginManagerSite {
+	if in == nil {
+		return nil
+	}
+	out := new(PluginManagerSite)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PluginProxy) DeepCopyInto(out *PluginProxy) {
+	*out = *in
+	if in.NoProxy != nil {
+		in, out := &in.NoProxy, &out.NoProxy
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.CredentialsSecretRef != nil {
+		in, out := &in.CredentialsSecretRef, &out.CredentialsSecretRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PluginProxy.
+func (in *PluginProxy) DeepCopy() *PluginProxy {
+	if in == nil {
+		return nil
+	}
+	out := new(PluginProxy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PluginsInfo) DeepCopyInto(out *PluginsInfo) {
 	*out = *in
@@ -713,6 +1700,11 @@ func (in *Service) DeepCopyInto(out *Service) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.AppProtocol != nil {
+		in, out := &in.AppProtocol, &out.AppProtocol
+		*out = new(string)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Service.
@@ -747,6 +1739,21 @@ func (in *ServiceAccount) DeepCopy() *ServiceAccount {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceRef) DeepCopyInto(out *ServiceRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceRef.
+func (in *ServiceRef) DeepCopy() *ServiceRef {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Slack) DeepCopyInto(out *Slack) {
 	*out = *in
@@ -763,6 +1770,92 @@ func (in *Slack) DeepCopy() *Slack {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ToolConfig) DeepCopyInto(out *ToolConfig) {
+	*out = *in
+	if in.JDKs != nil {
+		in, out := &in.JDKs, &out.JDKs
+		*out = make([]ToolInstallation, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Maven != nil {
+		in, out := &in.Maven, &out.Maven
+		*out = make([]ToolInstallation, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Gradle != nil {
+		in, out := &in.Gradle, &out.Gradle
+		*out = make([]ToolInstallation, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ToolConfig.
+func (in *ToolConfig) DeepCopy() *ToolConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ToolConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ToolInstallation) DeepCopyInto(out *ToolInstallation) {
+	*out = *in
+	if in.Installer != nil {
+		in, out := &in.Installer, &out.Installer
+		*out = new(ToolInstaller)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ToolInstallation.
+func (in *ToolInstallation) DeepCopy() *ToolInstallation {
+	if in == nil {
+		return nil
+	}
+	out := new(ToolInstallation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ToolInstaller) DeepCopyInto(out *ToolInstaller) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ToolInstaller.
+func (in *ToolInstaller) DeepCopy() *ToolInstaller {
+	if in == nil {
+		return nil
+	}
+	out := new(ToolInstaller)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ToolLocation) DeepCopyInto(out *ToolLocation) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ToolLocation.
+func (in *ToolLocation) DeepCopy() *ToolLocation {
+	if in == nil {
+		return nil
+	}
+	out := new(ToolLocation)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Version) DeepCopyInto(out *Version) {
 	*out = *in
@@ -778,6 +1871,26 @@ func (in *Version) DeepCopy() *Version {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *View) DeepCopyInto(out *View) {
+	*out = *in
+	if in.JobNames != nil {
+		in, out := &in.JobNames, &out.JobNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new View.
+func (in *View) DeepCopy() *View {
+	if in == nil {
+		return nil
+	}
+	out := new(View)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Warning) DeepCopyInto(out *Warning) {
 	*out = *in
@@ -797,3 +1910,23 @@ func (in *Warning) DeepCopy() *Warning {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Webhook) DeepCopyInto(out *Webhook) {
+	*out = *in
+	if in.AuthorizationHeaderSecretKeySelector != nil {
+		in, out := &in.AuthorizationHeaderSecretKeySelector, &out.AuthorizationHeaderSecretKeySelector
+		*out = new(SecretKeySelector)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Webhook.
+func (in *Webhook) DeepCopy() *Webhook {
+	if in == nil {
+		return nil
+	}
+	out := new(Webhook)
+	in.DeepCopyInto(out)
+	return out
+}
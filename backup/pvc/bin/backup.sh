@@ -11,16 +11,23 @@ trap "test -d "${BACKUP_TMP_DIR}" && rm -fr "${BACKUP_TMP_DIR}"" EXIT ERR SIGINT
 backup_number=$1
 echo "Running backup"
 
+case "${BACKUP_COMPRESSION:-gzip}" in
+  gzip) compress_option="--gzip"; extension="tar.gz" ;;
+  zstd) compress_option="--use-compress-program=zstd"; extension="tar.zst" ;;
+  none) compress_option=""; extension="tar" ;;
+  *) echo "Unsupported 'BACKUP_COMPRESSION' env '${BACKUP_COMPRESSION}'" && exit 1 ;;
+esac
+
 # config.xml in a job directory is a config file that shouldnt be backed up
 # config.xml in child directores is state that should. For example-
 # branches/myorg/branches/myrepo/branches/master/config.xml should be retained while
 # branches/myorg/config.xml should not
-tar -C "${JENKINS_HOME}" -czf "${BACKUP_TMP_DIR}/${backup_number}.tar.gz" --exclude jobs/*/workspace* --no-wildcards-match-slash --anchored --exclude jobs/*/config.xml -c jobs && \
-mv "${BACKUP_TMP_DIR}/${backup_number}.tar.gz" "${BACKUP_DIR}/${backup_number}.tar.gz"
+tar -C "${JENKINS_HOME}" ${compress_option} -cf "${BACKUP_TMP_DIR}/${backup_number}.${extension}" --exclude jobs/*/workspace* --no-wildcards-match-slash --anchored --exclude jobs/*/config.xml -c jobs && \
+mv "${BACKUP_TMP_DIR}/${backup_number}.${extension}" "${BACKUP_DIR}/${backup_number}.${extension}"
 
 rm -rf "${BACKUP_TMP_DIR}"
 
-[[ ! -s ${BACKUP_DIR}/${backup_number}.tar.gz ]] && echo "backup file '${BACKUP_DIR}/${backup_number}.tar.gz' is empty" && exit 1;
+[[ ! -s ${BACKUP_DIR}/${backup_number}.${extension} ]] && echo "backup file '${BACKUP_DIR}/${backup_number}.${extension}' is empty" && exit 1;
 
 echo Done
 exit 0
@@ -4,7 +4,7 @@ set -eo pipefail
 
 [[ -z "${BACKUP_DIR}" ]] && echo "Required 'BACKUP_DIR' env not set" && exit 1
 
-latest=$(find ${BACKUP_DIR} -name '*.tar.gz' -exec basename {} \; | sort -g | tail -n 1)
+latest=$(find ${BACKUP_DIR} \( -name '*.tar.gz' -o -name '*.tar.zst' -o -name '*.tar' \) -exec basename {} \; | sort -g | tail -n 1)
 
 if [[ "${latest}" == "" ]]; then
   echo "-1"
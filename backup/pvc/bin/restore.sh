@@ -9,7 +9,16 @@ set -eo pipefail
 backup_number=$1
 echo "Running restore backup"
 
-tar -C ${JENKINS_HOME} -zxf "${BACKUP_DIR}/${backup_number}.tar.gz"
+# the backup is restored by its extension, the compression could have changed since it was made
+if [[ -f "${BACKUP_DIR}/${backup_number}.tar.gz" ]]; then
+  tar -C ${JENKINS_HOME} --gzip -xf "${BACKUP_DIR}/${backup_number}.tar.gz"
+elif [[ -f "${BACKUP_DIR}/${backup_number}.tar.zst" ]]; then
+  tar -C ${JENKINS_HOME} --use-compress-program=zstd -xf "${BACKUP_DIR}/${backup_number}.tar.zst"
+elif [[ -f "${BACKUP_DIR}/${backup_number}.tar" ]]; then
+  tar -C ${JENKINS_HOME} -xf "${BACKUP_DIR}/${backup_number}.tar"
+else
+  echo "backup '${backup_number}' not found in '${BACKUP_DIR}'" && exit 1
+fi
 
 echo Done
 exit 0
\ No newline at end of file
@@ -6,8 +6,8 @@ import (
 
 	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
 	"github.com/jenkinsci/kubernetes-operator/pkg/constants"
-	"github.com/jenkinsci/kubernetes-operator/pkg/log"
 
+	"github.com/go-logr/logr"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/util/workqueue"
@@ -17,7 +17,9 @@ import (
 )
 
 // enqueueRequestForJenkins enqueues a Request for Secrets and ConfigMaps created by jenkins-operator.
-type enqueueRequestForJenkins struct{}
+type enqueueRequestForJenkins struct {
+	logger logr.Logger
+}
 
 func (e *enqueueRequestForJenkins) Create(evt event.CreateEvent, q workqueue.RateLimitingInterface) {
 	if req := e.getOwnerReconcileRequests(evt.Object); req != nil {
@@ -38,7 +40,7 @@ func (e *enqueueRequestForJenkins) Update(evt event.UpdateEvent, q workqueue.Rat
 			jenkinsName = req2.Name
 		}
 
-		log.Log.WithValues("cr", jenkinsName).Info(
+		e.logger.WithValues("cr", jenkinsName).Info(
 			fmt.Sprintf("%T/%s has been updated", evt.ObjectNew, evt.ObjectNew.GetName()))
 	}
 
@@ -77,23 +79,24 @@ func (e *enqueueRequestForJenkins) getOwnerReconcileRequests(object metav1.Objec
 
 type jenkinsDecorator struct {
 	handler handler.EventHandler
+	logger  logr.Logger
 }
 
 func (e *jenkinsDecorator) Create(evt event.CreateEvent, q workqueue.RateLimitingInterface) {
-	log.Log.WithValues("cr", evt.Object.GetName()).Info(fmt.Sprintf("%T/%s was created", evt.Object, evt.Object.GetName()))
+	e.logger.WithValues("cr", evt.Object.GetName()).Info(fmt.Sprintf("%T/%s was created", evt.Object, evt.Object.GetName()))
 	e.handler.Create(evt, q)
 }
 
 func (e *jenkinsDecorator) Update(evt event.UpdateEvent, q workqueue.RateLimitingInterface) {
 	if !reflect.DeepEqual(evt.ObjectOld.(*v1alpha2.Jenkins).Spec, evt.ObjectNew.(*v1alpha2.Jenkins).Spec) {
-		log.Log.WithValues("cr", evt.ObjectNew.GetName()).Info(
+		e.logger.WithValues("cr", evt.ObjectNew.GetName()).Info(
 			fmt.Sprintf("%T/%s has been updated", evt.ObjectNew, evt.ObjectNew.GetName()))
 	}
 	e.handler.Update(evt, q)
 }
 
 func (e *jenkinsDecorator) Delete(evt event.DeleteEvent, q workqueue.RateLimitingInterface) {
-	log.Log.WithValues("cr", evt.Object.GetName()).Info(fmt.Sprintf("%T/%s was deleted", evt.Object, evt.Object.GetName()))
+	e.logger.WithValues("cr", evt.Object.GetName()).Info(fmt.Sprintf("%T/%s was deleted", evt.Object, evt.Object.GetName()))
 	e.handler.Delete(evt, q)
 }
 
@@ -0,0 +1,21 @@
+package controllers
+
+import (
+	"github.com/jenkinsci/kubernetes-operator/pkg/constants"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+// isManagedByOperatorInstance returns true when the object is labeled with the given operator instance ID, the objects
+// without the label are managed by the operator instance without ID.
+func isManagedByOperatorInstance(object client.Object, instanceID string) bool {
+	return object.GetLabels()[constants.LabelOperatorInstanceKey] == instanceID
+}
+
+// operatorInstancePredicate filters out the events of the objects managed by the other operator instances.
+func operatorInstancePredicate(instanceID string) predicate.Predicate {
+	return predicate.NewPredicateFuncs(func(object client.Object) bool {
+		return isManagedByOperatorInstance(object, instanceID)
+	})
+}
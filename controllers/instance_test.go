@@ -0,0 +1,39 @@
+package controllers
+
+import (
+	"testing"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/pkg/constants"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+)
+
+func TestOperatorInstancePredicate(t *testing.T) {
+	newJenkins := func(instanceID string) *v1alpha2.Jenkins {
+		jenkins := &v1alpha2.Jenkins{ObjectMeta: metav1.ObjectMeta{Name: "jenkins", Namespace: "default"}}
+		if len(instanceID) > 0 {
+			jenkins.Labels = map[string]string{constants.LabelOperatorInstanceKey: instanceID}
+		}
+		return jenkins
+	}
+
+	t.Run("operator without instance ID", func(t *testing.T) {
+		filter := operatorInstancePredicate("")
+
+		assert.True(t, filter.Create(event.CreateEvent{Object: newJenkins("")}))
+		assert.False(t, filter.Create(event.CreateEvent{Object: newJenkins("team-a")}))
+	})
+	t.Run("operator with instance ID", func(t *testing.T) {
+		filter := operatorInstancePredicate("team-a")
+
+		assert.True(t, filter.Create(event.CreateEvent{Object: newJenkins("team-a")}))
+		assert.True(t, filter.Update(event.UpdateEvent{ObjectOld: newJenkins("team-a"), ObjectNew: newJenkins("team-a")}))
+		assert.True(t, filter.Delete(event.DeleteEvent{Object: newJenkins("team-a")}))
+		assert.False(t, filter.Create(event.CreateEvent{Object: newJenkins("")}))
+		assert.False(t, filter.Update(event.UpdateEvent{ObjectOld: newJenkins("team-b"), ObjectNew: newJenkins("team-b")}))
+		assert.False(t, filter.Delete(event.DeleteEvent{Object: newJenkins("team-b")}))
+	})
+}
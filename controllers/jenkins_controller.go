@@ -34,10 +34,14 @@ import (
 	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/event"
 	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/reason"
 	"github.com/jenkinsci/kubernetes-operator/pkg/plugins"
+	"github.com/jenkinsci/kubernetes-operator/pkg/tracing"
 
+	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/kubernetes"
@@ -63,7 +67,6 @@ const (
 )
 
 var reconcileErrors = map[string]reconcileError{}
-var logx = log.Log
 
 // JenkinsReconciler reconciles a Jenkins object
 type JenkinsReconciler struct {
@@ -74,22 +77,39 @@ type JenkinsReconciler struct {
 	Config                       rest.Config
 	NotificationEvents           *chan event.Event
 	KubernetesClusterDomain      string
+	OCIPluginsEnabled            bool
+	ServerSideApply              bool
+	StartupQuietPeriod           time.Duration
+	OperatorInstanceID           string
+	Logger                       logr.Logger
+	startedAt                    time.Time
+}
+
+// getLogger returns the logger of the Jenkins controller, log.Log when the Logger isn't set
+func (r *JenkinsReconciler) getLogger() logr.Logger {
+	if r.Logger == nil {
+		return log.Log
+	}
+	return r.Logger
 }
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *JenkinsReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	jenkinsHandler := &enqueueRequestForJenkins{}
+	r.startedAt = time.Now()
+	jenkinsHandler := &enqueueRequestForJenkins{logger: r.getLogger()}
 	configMapResource := &source.Kind{Type: &corev1.ConfigMap{TypeMeta: metav1.TypeMeta{APIVersion: APIVersion, Kind: ConfigMapKind}}}
 	secretResource := &source.Kind{Type: &corev1.Secret{TypeMeta: metav1.TypeMeta{APIVersion: APIVersion, Kind: SecretKind}}}
-	decorator := jenkinsDecorator{handler: &handler.EnqueueRequestForObject{}}
+	decorator := jenkinsDecorator{handler: &handler.EnqueueRequestForObject{}, logger: r.getLogger()}
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&v1alpha2.Jenkins{}).
 		Owns(&corev1.Pod{}).
 		Owns(&corev1.Secret{}).
 		Owns(&corev1.ConfigMap{}).
+		Owns(&batchv1.Job{}).
 		Watches(secretResource, jenkinsHandler).
 		Watches(configMapResource, jenkinsHandler).
 		Watches(&source.Kind{Type: &v1alpha2.Jenkins{}}, &decorator).
+		WithEventFilter(operatorInstancePredicate(r.OperatorInstanceID)).
 		Complete(r)
 }
 
@@ -103,6 +123,9 @@ func (r *JenkinsReconciler) newJenkinsReconcilier(jenkins *v1alpha2.Jenkins) con
 		Config:                       &r.Config,
 		JenkinsAPIConnectionSettings: r.JenkinsAPIConnectionSettings,
 		KubernetesClusterDomain:      r.KubernetesClusterDomain,
+		OCIPluginsEnabled:            r.OCIPluginsEnabled,
+		ServerSideApply:              r.ServerSideApply,
+		Logger:                       r.getLogger(),
 	}
 	return config
 }
@@ -111,29 +134,42 @@ func (r *JenkinsReconciler) newJenkinsReconcilier(jenkins *v1alpha2.Jenkins) con
 // +kubebuilder:rbac:groups=jenkins.io,resources=jenkins/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=jenkins.io,resources=jenkins/finalizers,verbs=update
 // +kubebuilder:rbac:groups=core,resources=pods,verbs=get;list;watch;create;update;patch;delete
-// +kubebuilder:rbac:groups=core,resources=services;configmaps;secrets,verbs=get;list;watch;create;update
+// +kubebuilder:rbac:groups=core,resources=services;configmaps;secrets,verbs=get;list;watch;create;update;patch
+// +kubebuilder:rbac:groups=core,resources=services,verbs=delete
+// +kubebuilder:rbac:groups=core,resources=endpoints,verbs=get;list;watch
 // +kubebuilder:rbac:groups=apps,resources=deployments;daemonsets;replicasets;statefulsets,verbs=*
+// +kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch;create;delete
 // +kubebuilder:rbac:groups=core,resources=serviceaccounts,verbs=get;list;watch;create;update
-// +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=roles;rolebindings,verbs=get;list;watch;create;update
+// +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=roles;rolebindings,verbs=get;list;watch;create;update;patch
 // +kubebuilder:rbac:groups=core,resources=pods/portforward,verbs=create
 // +kubebuilder:rbac:groups=core,resources=pods/log,verbs=get;list;watch
 // +kubebuilder:rbac:groups=core,resources=pods;pods/exec,verbs=*
 // +kubebuilder:rbac:groups=core,resources=events,verbs=get;watch;list;create;patch
 // +kubebuilder:rbac:groups=apps;jenkins-operator,resources=deployments/finalizers,verbs=update
 // +kubebuilder:rbac:groups=jenkins.io,resources=*,verbs=*
-// +kubebuilder:rbac:groups=core,resources=persistentvolumeclaims,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core,resources=persistentvolumeclaims,verbs=get;list;watch;create;update
+// +kubebuilder:rbac:groups=core,resources=resourcequotas,verbs=get;list;watch
 // +kubebuilder:rbac:groups=route.openshift.io,resources=routes,verbs=get;list;watch;create;update
 // +kubebuilder:rbac:groups=image.openshift.io,resources=imagestreams,verbs=get;list;watch
 // +kubebuilder:rbac:groups=build.openshift.io,resources=builds;buildconfigs,verbs=get;list;watch
 
 // For more details, check Reconcile and its Result here:
 // - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.7.0/pkg/reconcile
-func (r *JenkinsReconciler) Reconcile(_ context.Context, request ctrl.Request) (ctrl.Result, error) {
+func (r *JenkinsReconciler) Reconcile(ctx context.Context, request ctrl.Request) (ctrl.Result, error) {
 	reconcileFailLimit := uint64(10)
-	logger := logx.WithValues("cr", request.Name)
+	logger := r.getLogger().WithValues("cr", request.Name)
 	logger.V(log.VDebug).Info("Reconciling Jenkins")
 
+	if delay := startupRemainingDelay(request.String(), r.startedAt, time.Now(), r.StartupQuietPeriod); delay > 0 {
+		logger.V(log.VDebug).Info(fmt.Sprintf("Startup quiet period, postponing reconcile by %s", delay))
+		return reconcile.Result{RequeueAfter: delay}, nil
+	}
+
+	_, span := tracing.StartReconcileSpan(ctx, request)
+	defer span.End()
+
 	result, jenkins, err := r.reconcile(request)
+	tracing.RecordOutcome(span, result, err)
 	if err != nil && apierrors.IsConflict(err) {
 		return reconcile.Result{Requeue: true}, nil
 	} else if err != nil {
@@ -153,7 +189,7 @@ func (r *JenkinsReconciler) Reconcile(_ context.Context, request ctrl.Request) (
 		}
 		reconcileErrors[request.Name] = lastErrors
 		if lastErrors.counter >= reconcileFailLimit {
-			if log.Debug {
+			if logger.V(log.VDebug).Enabled() {
 				logger.V(log.VWarn).Info(fmt.Sprintf("Reconcile loop failed %d times with the same errors, giving up: %+v", reconcileFailLimit, err))
 			} else {
 				logger.V(log.VWarn).Info(fmt.Sprintf("Reconcile loop failed %d times with the same errors, giving up: %s", reconcileFailLimit, err))
@@ -171,7 +207,7 @@ func (r *JenkinsReconciler) Reconcile(_ context.Context, request ctrl.Request) (
 			return reconcile.Result{Requeue: false}, nil
 		}
 
-		if log.Debug {
+		if logger.V(log.VDebug).Enabled() {
 			logger.V(log.VWarn).Info(fmt.Sprintf("Reconcile loop failed: %+v", err))
 		} else if err.Error() != fmt.Sprintf("Operation cannot be fulfilled on jenkins.jenkins.io \"%s\": the object has been modified; please apply your changes to the latest version and try again", request.Name) {
 			logger.V(log.VWarn).Info(fmt.Sprintf("Reconcile loop failed: %s", err))
@@ -199,7 +235,7 @@ func (r *JenkinsReconciler) Reconcile(_ context.Context, request ctrl.Request) (
 }
 
 func (r *JenkinsReconciler) reconcile(request reconcile.Request) (reconcile.Result, *v1alpha2.Jenkins, error) {
-	logger := logx.WithValues("cr", request.Name)
+	logger := r.getLogger().WithValues("cr", request.Name)
 	// Fetch the Jenkins instance
 	jenkins := &v1alpha2.Jenkins{}
 	var err error
@@ -209,11 +245,26 @@ func (r *JenkinsReconciler) reconcile(request reconcile.Request) (reconcile.Resu
 			// Request object not found, could have been deleted after reconcile request.
 			// Owned objects are automatically garbage collected. For additional cleanup logic use finalizers.
 			// Return and don't requeue
+			configuration.ForgetJenkinsResources(request.NamespacedName)
 			return reconcile.Result{}, nil, nil
 		}
 		// Error reading the object - requeue the request.
 		return reconcile.Result{}, nil, errors.WithStack(err)
 	}
+	if !isManagedByOperatorInstance(jenkins, r.OperatorInstanceID) {
+		logger.V(log.VDebug).Info("Jenkins is managed by another operator instance, skipping")
+		return reconcile.Result{}, nil, nil
+	}
+
+	paused, err := r.reconcilePausedCondition(jenkins)
+	if err != nil {
+		return reconcile.Result{}, jenkins, err
+	}
+	if paused {
+		logger.V(log.VDebug).Info("Reconciliation is paused")
+		return reconcile.Result{}, jenkins, nil
+	}
+
 	var requeue bool
 	requeue, err = r.setDefaults(jenkins)
 	if err != nil {
@@ -338,12 +389,38 @@ func (r *JenkinsReconciler) reconcile(request reconcile.Request) (reconcile.Resu
 		}
 		logger.Info(message)
 	}
-	return reconcile.Result{}, jenkins, nil
+	// requeued after the time remaining to the next periodic task, e.g. the configuration as code export
+	return reconcile.Result{RequeueAfter: result.RequeueAfter}, jenkins, nil
+}
+
+// reconcilePausedCondition keeps the Paused condition in sync with the Jenkins CR, returns true when the reconciliation is paused
+func (r *JenkinsReconciler) reconcilePausedCondition(jenkins *v1alpha2.Jenkins) (bool, error) {
+	paused := jenkins.IsPaused()
+	condition := metav1.Condition{
+		Type:               v1alpha2.ConditionPaused,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: jenkins.Generation,
+		Reason:             "ReconciliationActive",
+		Message:            "Jenkins is reconciled by the operator",
+	}
+	if paused {
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = "ReconciliationPaused"
+		condition.Message = fmt.Sprintf("Reconciliation is paused by spec.paused or the %s annotation", v1alpha2.PausedAnnotation)
+	} else if meta.FindStatusCondition(jenkins.Status.Conditions, v1alpha2.ConditionPaused) == nil {
+		return false, nil // Jenkins has never been paused
+	}
+
+	if meta.IsStatusConditionPresentAndEqual(jenkins.Status.Conditions, condition.Type, condition.Status) {
+		return paused, nil
+	}
+	meta.SetStatusCondition(&jenkins.Status.Conditions, condition)
+	return paused, errors.WithStack(r.Client.Status().Update(context.TODO(), jenkins))
 }
 
 func (r *JenkinsReconciler) setDefaults(jenkins *v1alpha2.Jenkins) (requeue bool, err error) {
 	changed := false
-	logger := logx.WithValues("cr", jenkins.Name)
+	logger := r.getLogger().WithValues("cr", jenkins.Name)
 
 	var jenkinsContainer v1alpha2.Container
 	if len(jenkins.Spec.Master.Containers) == 0 {
@@ -396,6 +473,18 @@ func (r *JenkinsReconciler) setDefaults(jenkins *v1alpha2.Jenkins) (requeue bool
 		changed = true
 		jenkins.Spec.Master.BasePlugins = basePlugins()
 	}
+	if len(jenkins.Spec.Master.MarkupFormatter) == 0 {
+		logger.Info("Setting default markup formatter")
+		changed = true
+		jenkins.Spec.Master.MarkupFormatter = v1alpha2.SafeHTMLMarkupFormatterName
+	}
+	for _, plugin := range getRequiredPlugins(jenkins) {
+		if !hasPlugin(jenkins.Spec.Master.BasePlugins, plugin.Name) && !hasPlugin(jenkins.Spec.Master.Plugins, plugin.Name) {
+			logger.Info(fmt.Sprintf("Adding %s plugin to operator plugins", plugin.Name))
+			changed = true
+			jenkins.Spec.Master.BasePlugins = append(jenkins.Spec.Master.BasePlugins, v1alpha2.Plugin{Name: plugin.Name, Version: plugin.Version})
+		}
+	}
 	if isResourceRequirementsNotSet(jenkinsContainer.Resources) {
 		logger.Info("Setting default Jenkins master container resource requirements")
 		changed = true
@@ -428,6 +517,19 @@ func (r *JenkinsReconciler) setDefaults(jenkins *v1alpha2.Jenkins) (requeue bool
 			}
 		}
 	}
+	for i, initContainer := range jenkins.Spec.Master.ExtraInitContainers {
+		if len(initContainer.ImagePullPolicy) == 0 {
+			logger.Info(fmt.Sprintf("Setting default image pull policy of the init container '%s': %s", initContainer.Name, corev1.PullAlways))
+			changed = true
+			jenkins.Spec.Master.ExtraInitContainers[i].ImagePullPolicy = corev1.PullAlways
+		}
+	}
+	if len(jenkins.Spec.Master.ExtraInitContainers) > 0 && jenkins.Spec.Master.InitContainerResources == nil {
+		logger.Info("Setting default init container resource requirements")
+		changed = true
+		initContainerResources := resources.NewResourceRequirements("250m", "256Mi", "1", "1Gi")
+		jenkins.Spec.Master.InitContainerResources = &initContainerResources
+	}
 	if len(jenkins.Spec.Backup.ContainerName) > 0 && jenkins.Spec.Backup.Interval == 0 {
 		logger.Info("Setting default backup interval")
 		changed = true
@@ -472,7 +574,7 @@ func isJavaOpsVariableNotSet(container v1alpha2.Container) bool {
 
 func (r *JenkinsReconciler) setDefaultsForContainer(jenkins *v1alpha2.Jenkins, containerName string, containerIndex int) bool {
 	changed := false
-	logger := logx.WithValues("cr", jenkins.Name, "container", containerName)
+	logger := r.getLogger().WithValues("cr", jenkins.Name, "container", containerName)
 
 	if len(jenkins.Spec.Master.Containers[containerIndex].ImagePullPolicy) == 0 {
 		logger.Info(fmt.Sprintf("Setting default container image pull policy: %s", corev1.PullAlways))
@@ -491,6 +593,41 @@ func isResourceRequirementsNotSet(requirements corev1.ResourceRequirements) bool
 	return reflect.DeepEqual(requirements, corev1.ResourceRequirements{})
 }
 
+// getRequiredPlugins returns the plugins required by the features configured in the Jenkins CR, they are added to the
+// operator plugins unless the user already set them
+func getRequiredPlugins(jenkins *v1alpha2.Jenkins) []plugins.Plugin {
+	var required []plugins.Plugin
+	if jenkins.Spec.Master.OIDC != nil {
+		required = append(required, plugins.OICAuthPlugin)
+	}
+	if jenkins.Spec.Master.LDAP != nil {
+		required = append(required, plugins.LDAPPlugin)
+	}
+	if authorization := jenkins.Spec.Master.Authorization; authorization != nil &&
+		(authorization.Strategy == v1alpha2.GlobalMatrixAuthorizationStrategyName || authorization.Strategy == v1alpha2.ProjectMatrixAuthorizationStrategyName) {
+		required = append(required, plugins.MatrixAuthPlugin)
+	}
+	if toolConfig := jenkins.Spec.ToolConfig; toolConfig != nil && len(toolConfig.Gradle) > 0 {
+		required = append(required, plugins.GradlePlugin)
+	}
+	if len(jenkins.Spec.PermanentAgents) > 0 {
+		required = append(required, plugins.SSHSlavesPlugin)
+	}
+	if jenkins.Spec.Master.MarkupFormatter == v1alpha2.SafeHTMLMarkupFormatterName {
+		required = append(required, plugins.AntisamyMarkupFormatterPlugin)
+	}
+	return required
+}
+
+func hasPlugin(jenkinsPlugins []v1alpha2.Plugin, name string) bool {
+	for _, plugin := range jenkinsPlugins {
+		if plugin.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
 func basePlugins() (result []v1alpha2.Plugin) {
 	for _, value := range plugins.BasePlugins() {
 		result = append(result, v1alpha2.Plugin{Name: value.Name, Version: value.Version})
@@ -0,0 +1,218 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/pkg/log"
+	"github.com/jenkinsci/kubernetes-operator/pkg/plugins"
+	"github.com/jenkinsci/kubernetes-operator/pkg/tracing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.uber.org/zap/zapcore"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestReconcilePaused(t *testing.T) {
+	namespace := "default"
+	jenkinsName := "example"
+	request := reconcile.Request{NamespacedName: types.NamespacedName{Name: jenkinsName, Namespace: namespace}}
+	require.NoError(t, v1alpha2.SchemeBuilder.AddToScheme(scheme.Scheme))
+
+	newReconciler := func(jenkins *v1alpha2.Jenkins) *JenkinsReconciler {
+		return &JenkinsReconciler{
+			Client: fake.NewClientBuilder().WithObjects(jenkins).Build(),
+			Scheme: scheme.Scheme,
+		}
+	}
+	getJenkins := func(t *testing.T, k8sClient client.Client) *v1alpha2.Jenkins {
+		jenkins := &v1alpha2.Jenkins{}
+		require.NoError(t, k8sClient.Get(context.TODO(), request.NamespacedName, jenkins))
+		return jenkins
+	}
+
+	t.Run("paused by annotation", func(t *testing.T) {
+		// given
+		jenkins := &v1alpha2.Jenkins{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        jenkinsName,
+				Namespace:   namespace,
+				Annotations: map[string]string{v1alpha2.PausedAnnotation: "true"},
+			},
+		}
+		reconciler := newReconciler(jenkins)
+		before := getJenkins(t, reconciler.Client)
+
+		// when
+		result, _, err := reconciler.reconcile(request)
+
+		// then
+		require.NoError(t, err)
+		assert.False(t, result.Requeue)
+		after := getJenkins(t, reconciler.Client)
+		assert.Equal(t, before.Spec, after.Spec)
+		assert.True(t, meta.IsStatusConditionTrue(after.Status.Conditions, v1alpha2.ConditionPaused))
+		assert.Nil(t, after.Status.ProvisionStartTime)
+
+		configMaps := &corev1.ConfigMapList{}
+		require.NoError(t, reconciler.Client.List(context.TODO(), configMaps, client.InNamespace(namespace)))
+		assert.Empty(t, configMaps.Items)
+		services := &corev1.ServiceList{}
+		require.NoError(t, reconciler.Client.List(context.TODO(), services, client.InNamespace(namespace)))
+		assert.Empty(t, services.Items)
+		pods := &corev1.PodList{}
+		require.NoError(t, reconciler.Client.List(context.TODO(), pods, client.InNamespace(namespace)))
+		assert.Empty(t, pods.Items)
+	})
+	t.Run("paused by spec", func(t *testing.T) {
+		// given
+		jenkins := &v1alpha2.Jenkins{
+			ObjectMeta: metav1.ObjectMeta{Name: jenkinsName, Namespace: namespace},
+			Spec:       v1alpha2.JenkinsSpec{Paused: true},
+		}
+		reconciler := newReconciler(jenkins)
+
+		// when
+		_, _, err := reconciler.reconcile(request)
+
+		// then
+		require.NoError(t, err)
+		after := getJenkins(t, reconciler.Client)
+		assert.Empty(t, after.Spec.Master.Containers)
+		assert.True(t, meta.IsStatusConditionTrue(after.Status.Conditions, v1alpha2.ConditionPaused))
+	})
+	t.Run("resumed", func(t *testing.T) {
+		// given
+		jenkins := &v1alpha2.Jenkins{
+			ObjectMeta: metav1.ObjectMeta{Name: jenkinsName, Namespace: namespace},
+			Status: v1alpha2.JenkinsStatus{
+				Conditions: []metav1.Condition{{Type: v1alpha2.ConditionPaused, Status: metav1.ConditionTrue}},
+			},
+		}
+		reconciler := newReconciler(jenkins)
+
+		// when
+		paused, err := reconciler.reconcilePausedCondition(getJenkins(t, reconciler.Client))
+
+		// then
+		require.NoError(t, err)
+		assert.False(t, paused)
+		after := getJenkins(t, reconciler.Client)
+		assert.True(t, meta.IsStatusConditionFalse(after.Status.Conditions, v1alpha2.ConditionPaused))
+	})
+	t.Run("never paused", func(t *testing.T) {
+		// given
+		jenkins := &v1alpha2.Jenkins{ObjectMeta: metav1.ObjectMeta{Name: jenkinsName, Namespace: namespace}}
+		reconciler := newReconciler(jenkins)
+
+		// when
+		paused, err := reconciler.reconcilePausedCondition(getJenkins(t, reconciler.Client))
+
+		// then
+		require.NoError(t, err)
+		assert.False(t, paused)
+		assert.Empty(t, getJenkins(t, reconciler.Client).Status.Conditions)
+	})
+}
+
+func TestReconcileTracing(t *testing.T) {
+	// given
+	namespace := "default"
+	jenkinsName := "example"
+	request := reconcile.Request{NamespacedName: types.NamespacedName{Name: jenkinsName, Namespace: namespace}}
+	require.NoError(t, v1alpha2.SchemeBuilder.AddToScheme(scheme.Scheme))
+	jenkins := &v1alpha2.Jenkins{
+		ObjectMeta: metav1.ObjectMeta{Name: jenkinsName, Namespace: namespace},
+		Spec:       v1alpha2.JenkinsSpec{Paused: true},
+	}
+	reconciler := &JenkinsReconciler{
+		Client: fake.NewClientBuilder().WithObjects(jenkins).Build(),
+		Scheme: scheme.Scheme,
+	}
+
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	previousProvider := otel.GetTracerProvider()
+	otel.SetTracerProvider(provider)
+	defer otel.SetTracerProvider(previousProvider)
+
+	// when
+	_, err := reconciler.Reconcile(context.TODO(), request)
+
+	// then
+	require.NoError(t, err)
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 1)
+	assert.Equal(t, tracing.ReconcileSpanName, spans[0].Name)
+	assert.Contains(t, spans[0].Attributes, tracing.JenkinsNameKey.String(jenkinsName))
+	assert.Contains(t, spans[0].Attributes, tracing.JenkinsNamespaceKey.String(namespace))
+	assert.Contains(t, spans[0].Attributes, tracing.OutcomeKey.String(tracing.OutcomeSuccess))
+}
+
+func TestJenkinsReconcilerLogger(t *testing.T) {
+	jenkins := &v1alpha2.Jenkins{ObjectMeta: metav1.ObjectMeta{Name: "example", Namespace: "default"}}
+
+	t.Run("global logger when not set", func(t *testing.T) {
+		reconciler := &JenkinsReconciler{}
+
+		config := reconciler.newJenkinsReconcilier(jenkins)
+
+		assert.Equal(t, log.Log, config.GetLogger())
+	})
+	t.Run("controller logger is passed to the configuration", func(t *testing.T) {
+		logger := zap.New(zap.Level(zapcore.DebugLevel)).WithName("controller-jenkins")
+		reconciler := &JenkinsReconciler{Logger: logger}
+
+		config := reconciler.newJenkinsReconcilier(jenkins)
+
+		assert.Equal(t, logger, config.GetLogger())
+		assert.True(t, config.GetLogger().V(log.VDebug).Enabled())
+		assert.NotEqual(t, log.Log, config.GetLogger())
+	})
+}
+
+func TestGetRequiredPlugins(t *testing.T) {
+	t.Run("without features", func(t *testing.T) {
+		assert.Empty(t, getRequiredPlugins(&v1alpha2.Jenkins{}))
+	})
+	t.Run("with features", func(t *testing.T) {
+		jenkins := &v1alpha2.Jenkins{
+			Spec: v1alpha2.JenkinsSpec{
+				Master: v1alpha2.JenkinsMaster{
+					LDAP:            &v1alpha2.LDAP{},
+					Authorization:   &v1alpha2.Authorization{Strategy: v1alpha2.ProjectMatrixAuthorizationStrategyName},
+					MarkupFormatter: v1alpha2.SafeHTMLMarkupFormatterName,
+				},
+				ToolConfig:      &v1alpha2.ToolConfig{Gradle: []v1alpha2.ToolInstallation{{Name: "gradle7"}}},
+				PermanentAgents: []v1alpha2.PermanentAgent{{Name: "build-1"}},
+			},
+		}
+
+		assert.Equal(t, []plugins.Plugin{
+			plugins.LDAPPlugin,
+			plugins.MatrixAuthPlugin,
+			plugins.GradlePlugin,
+			plugins.SSHSlavesPlugin,
+			plugins.AntisamyMarkupFormatterPlugin,
+		}, getRequiredPlugins(jenkins))
+	})
+	t.Run("strategy without plugin", func(t *testing.T) {
+		jenkins := &v1alpha2.Jenkins{}
+		jenkins.Spec.Master.Authorization = &v1alpha2.Authorization{Strategy: v1alpha2.LoggedInUsersCanDoAnythingAuthorizationStrategyName}
+
+		assert.Empty(t, getRequiredPlugins(jenkins))
+	})
+}
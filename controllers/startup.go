@@ -0,0 +1,28 @@
+package controllers
+
+import (
+	"hash/fnv"
+	"math"
+	"time"
+)
+
+// startupStaggerDelay returns the delay of the first reconcile of the Jenkins identified by key after the operator
+// startup. Delays are spread evenly across the quiet period, the same key always gets the same delay.
+func startupStaggerDelay(key string, quietPeriod time.Duration) time.Duration {
+	if quietPeriod <= 0 {
+		return 0
+	}
+	hash := fnv.New32a()
+	_, _ = hash.Write([]byte(key))
+	return time.Duration(float64(quietPeriod) * float64(hash.Sum32()) / float64(math.MaxUint32+1))
+}
+
+// startupRemainingDelay returns how long the reconcile of the Jenkins identified by key has to be postponed,
+// zero means that Jenkins can be reconciled now.
+func startupRemainingDelay(key string, startedAt, now time.Time, quietPeriod time.Duration) time.Duration {
+	reconcileAt := startedAt.Add(startupStaggerDelay(key, quietPeriod))
+	if now.Before(reconcileAt) {
+		return reconcileAt.Sub(now)
+	}
+	return 0
+}
@@ -0,0 +1,61 @@
+package controllers
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStartupStaggerDelay(t *testing.T) {
+	t.Run("disabled", func(t *testing.T) {
+		assert.Equal(t, time.Duration(0), startupStaggerDelay("default/jenkins", 0))
+	})
+	t.Run("delay is stable and within quiet period", func(t *testing.T) {
+		quietPeriod := 5 * time.Minute
+
+		for i := 0; i < 100; i++ {
+			key := fmt.Sprintf("default/jenkins-%d", i)
+			delay := startupStaggerDelay(key, quietPeriod)
+
+			assert.True(t, delay >= 0 && delay < quietPeriod, "delay %s is out of range", delay)
+			assert.Equal(t, delay, startupStaggerDelay(key, quietPeriod))
+		}
+	})
+	t.Run("delays are spread across quiet period", func(t *testing.T) {
+		quietPeriod := 10 * time.Minute
+		buckets := make([]int, 5)
+
+		for i := 0; i < 1000; i++ {
+			delay := startupStaggerDelay(fmt.Sprintf("namespace-%d/jenkins", i), quietPeriod)
+			buckets[int(delay*time.Duration(len(buckets))/quietPeriod)]++
+		}
+
+		for _, count := range buckets {
+			assert.InDelta(t, 200, count, 60)
+		}
+	})
+}
+
+func TestStartupRemainingDelay(t *testing.T) {
+	key := "default/jenkins"
+	quietPeriod := time.Hour
+	startedAt := time.Date(2021, 1, 1, 12, 0, 0, 0, time.UTC)
+	delay := startupStaggerDelay(key, quietPeriod)
+
+	t.Run("right after startup", func(t *testing.T) {
+		assert.Equal(t, delay, startupRemainingDelay(key, startedAt, startedAt, quietPeriod))
+	})
+	t.Run("during quiet period", func(t *testing.T) {
+		now := startedAt.Add(delay / 2)
+
+		assert.Equal(t, delay-delay/2, startupRemainingDelay(key, startedAt, now, quietPeriod))
+	})
+	t.Run("after quiet period", func(t *testing.T) {
+		assert.Equal(t, time.Duration(0), startupRemainingDelay(key, startedAt, startedAt.Add(quietPeriod), quietPeriod))
+	})
+	t.Run("disabled", func(t *testing.T) {
+		assert.Equal(t, time.Duration(0), startupRemainingDelay(key, startedAt, startedAt, 0))
+	})
+}
@@ -17,29 +17,38 @@ limitations under the License.
 package main
 
 import (
+	"context"
 	"flag"
 	"fmt"
 	"os"
 	r "runtime"
+	"strings"
+	"time"
 
 	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
 	"github.com/jenkinsci/kubernetes-operator/controllers"
 	"github.com/jenkinsci/kubernetes-operator/pkg/client"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration"
 	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/base/resources"
 	"github.com/jenkinsci/kubernetes-operator/pkg/constants"
 	"github.com/jenkinsci/kubernetes-operator/pkg/event"
 	"github.com/jenkinsci/kubernetes-operator/pkg/log"
+	"github.com/jenkinsci/kubernetes-operator/pkg/metrics"
 	"github.com/jenkinsci/kubernetes-operator/pkg/notifications"
 	e "github.com/jenkinsci/kubernetes-operator/pkg/notifications/event"
+	"github.com/jenkinsci/kubernetes-operator/pkg/tracing"
 	"github.com/jenkinsci/kubernetes-operator/version"
 
 	routev1 "github.com/openshift/api/route/v1"
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/client-go/kubernetes"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
 	"sigs.k8s.io/controller-runtime/pkg/client/config"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
@@ -94,6 +103,24 @@ func main() {
 	port := flag.Int("jenkins-api-port", 0, "The port on which Jenkins API is running. Note: If you want to use nodePort don't set this setting and --jenkins-api-use-nodeport must be true.")
 	useNodePort := flag.Bool("jenkins-api-use-nodeport", false, "Connect to Jenkins API using the service nodePort instead of service port. If you want to set this as true - don't set --jenkins-api-port.")
 	kubernetesClusterDomain := flag.String("cluster-domain", "cluster.local", "Use custom domain name instead of 'cluster.local'.")
+	ociPluginsEnabled := flag.Bool("enable-oci-plugins", false, "Enable pulling plugins from OCI artifacts referenced by spec.master.plugins[].ociRef. Requires oras in the Jenkins master image.")
+	serverSideApply := flag.Bool("server-side-apply", false, "Use server-side apply with the '"+configuration.FieldManager+"' field manager instead of update for the resources managed by the operator, "+
+		"so the fields managed by other controllers are not overwritten.")
+	startupQuietPeriod := flag.Duration("startup-quiet-period", 0, "The period after the operator startup across which the first reconciles of Jenkins custom resources are staggered "+
+		"to avoid restarting all Jenkins instances at once, e.g. '5m'. Disabled when zero.")
+	resyncPeriod := flag.Duration("resync-period", 0, "The period after which every Jenkins custom resource is reconciled again even without any change, "+
+		"e.g. '10m'. The controller-runtime default is used when zero.")
+	operatorInstanceID := flag.String("operator-instance-id", "", "ID of the operator instance, the operator manages only the Jenkins custom resources labeled with "+
+		"'"+constants.LabelOperatorInstanceKey+"=<ID>' and stamps their resources with that label. When empty, only the Jenkins custom resources without the label are managed.")
+	otlpEndpoint := flag.String("otlp-endpoint", "", "The host:port of the OTLP gRPC collector to which reconcile trace spans are exported. Tracing is disabled when empty.")
+	controllerLogLevels := flag.String("controller-log-level", "", "Comma separated list of controller=level pairs which overrides the log level of the given controller, e.g. 'jenkins=debug'. "+
+		"Level is one of debug, info, warn, error or the verbosity number. Supported controllers: jenkins.")
+	metricsRequireAuth := flag.Bool("metrics-require-auth", false, "Require a bearer token to access the operator metrics endpoint, "+
+		"the token is read from the '"+metrics.TokenSecretKey+"' key of the Secret set by --metrics-auth-secret.")
+	metricsAuthSecret := flag.String("metrics-auth-secret", "", "The <namespace>/<name> of the Secret holding the bearer token of the operator metrics endpoint. "+
+		"Required when --metrics-require-auth is set.")
+	kubeAPIQPS := flag.Float64("kube-api-qps", 0, "The maximum queries per second of the operator to the Kubernetes API server, the client-go default is used when not set.")
+	kubeAPIBurst := flag.Int("kube-api-burst", 0, "The maximum burst of queries of the operator to the Kubernetes API server, the client-go default is used when not set.")
 	opts := zap.Options{
 		Development: true,
 	}
@@ -105,6 +132,30 @@ func main() {
 	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&opts)))
 	printInfo()
 
+	logLevels, err := log.ParseControllerLogLevels(*controllerLogLevels)
+	if err != nil {
+		fatal(errors.Wrap(err, "invalid command line parameters"), *debug)
+	}
+	jenkinsControllerLogger := log.Log
+	for controller, level := range logLevels {
+		switch controller {
+		case log.JenkinsControllerName:
+			jenkinsControllerLogger = zap.New(zap.UseFlagOptions(&opts), zap.Level(level)).WithName("controller-jenkins")
+		default:
+			logger.Info(fmt.Sprintf("Unknown controller '%s' in controller log level, ignoring", controller))
+		}
+	}
+
+	shutdownTracing, err := tracing.Setup(context.Background(), *otlpEndpoint)
+	if err != nil {
+		fatal(errors.Wrap(err, "failed to setup tracing"), *debug)
+	}
+	defer func() {
+		if err := shutdownTracing(context.Background()); err != nil {
+			logger.Error(err, "failed to shutdown tracing")
+		}
+	}()
+
 	namespace, found := os.LookupEnv("WATCH_NAMESPACE")
 	if !found {
 		fatal(errors.New("failed to get watch namespace, please set up WATCH_NAMESPACE environment variable"), *debug)
@@ -125,20 +176,47 @@ func main() {
 	if err != nil {
 		fatal(errors.Wrap(err, "failed to get config"), *debug)
 	}
+	if err := setKubeAPIRateLimits(cfg, *kubeAPIQPS, *kubeAPIBurst); err != nil {
+		fatal(errors.Wrap(err, "invalid command line parameters"), *debug)
+	}
 
-	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
-		Scheme:                 scheme,
-		MetricsBindAddress:     fmt.Sprintf("%s:%d", metricsHost, metricsPort),
-		Port:                   9443,
-		HealthProbeBindAddress: probeAddr,
-		LeaderElection:         enableLeaderElection,
-		LeaderElectionID:       "c674355f.jenkins.io",
-		Namespace:              namespace,
-	})
+	if msgs := validation.IsValidLabelValue(*operatorInstanceID); len(msgs) > 0 {
+		fatal(errors.Errorf("invalid command line parameters: operator instance ID: %s", strings.Join(msgs, ", ")), *debug)
+	}
+
+	if *resyncPeriod < 0 {
+		fatal(errors.New("invalid command line parameters: resync period can't be negative"), *debug)
+	}
+
+	var metricsAuthSecretName types.NamespacedName
+	if *metricsRequireAuth {
+		metricsAuthSecretName, err = parseNamespacedName(*metricsAuthSecret)
+		if err != nil {
+			fatal(errors.Wrap(err, "invalid command line parameters: metrics auth secret"), *debug)
+		}
+	}
+
+	managerOptions := newManagerOptions(namespace, probeAddr, enableLeaderElection, *resyncPeriod, *operatorInstanceID)
+	if *metricsRequireAuth {
+		// the built-in metrics endpoint can't be protected, it's replaced by the metrics.Server
+		managerOptions.MetricsBindAddress = "0"
+	}
+	mgr, err := ctrl.NewManager(cfg, managerOptions)
 	if err != nil {
 		fatal(errors.Wrap(err, "unable to start manager"), *debug)
 	}
 
+	if *metricsRequireAuth {
+		metricsServer := &metrics.Server{
+			Address:       fmt.Sprintf("%s:%d", metricsHost, metricsPort),
+			TokenProvider: metrics.SecretTokenProvider(mgr.GetAPIReader(), metricsAuthSecretName),
+		}
+		if err := mgr.Add(metricsServer); err != nil {
+			fatal(errors.Wrap(err, "unable to set up metrics server"), *debug)
+		}
+		logger.Info(fmt.Sprintf("Metrics endpoint requires a bearer token from the '%s' secret", metricsAuthSecretName))
+	}
+
 	// setup events
 	events, err := event.New(cfg, constants.OperatorName)
 	if err != nil {
@@ -176,6 +254,11 @@ func main() {
 		Config:                       *cfg,
 		NotificationEvents:           &notificationEvents,
 		KubernetesClusterDomain:      *kubernetesClusterDomain,
+		OCIPluginsEnabled:            *ociPluginsEnabled,
+		ServerSideApply:              *serverSideApply,
+		StartupQuietPeriod:           *startupQuietPeriod,
+		OperatorInstanceID:           *operatorInstanceID,
+		Logger:                       jenkinsControllerLogger,
 	}).SetupWithManager(mgr); err != nil {
 		fatal(errors.Wrap(err, "unable to create Jenkins controller"), *debug)
 	}
@@ -200,6 +283,55 @@ func main() {
 	}
 }
 
+// newManagerOptions builds the controller manager options, the informers are resynced every resyncPeriod
+// which triggers the reconciliation of all Jenkins custom resources. Every operator instance elects its own leader.
+func newManagerOptions(namespace, probeAddr string, enableLeaderElection bool, resyncPeriod time.Duration, operatorInstanceID string) ctrl.Options {
+	leaderElectionID := "c674355f.jenkins.io"
+	if len(operatorInstanceID) > 0 {
+		leaderElectionID = fmt.Sprintf("%s-%s", operatorInstanceID, leaderElectionID)
+	}
+	options := ctrl.Options{
+		Scheme:                 scheme,
+		MetricsBindAddress:     fmt.Sprintf("%s:%d", metricsHost, metricsPort),
+		Port:                   9443,
+		HealthProbeBindAddress: probeAddr,
+		LeaderElection:         enableLeaderElection,
+		LeaderElectionID:       leaderElectionID,
+		Namespace:              namespace,
+	}
+	if resyncPeriod > 0 {
+		options.SyncPeriod = &resyncPeriod
+	}
+	return options
+}
+
+// setKubeAPIRateLimits sets the client-side rate limits of the queries to the Kubernetes API server, the limits
+// which aren't set keep the client-go defaults
+func setKubeAPIRateLimits(cfg *rest.Config, qps float64, burst int) error {
+	if qps < 0 {
+		return errors.Errorf("kube API QPS '%g' must be positive", qps)
+	}
+	if burst < 0 {
+		return errors.Errorf("kube API burst '%d' must be positive", burst)
+	}
+	if qps > 0 {
+		cfg.QPS = float32(qps)
+	}
+	if burst > 0 {
+		cfg.Burst = burst
+	}
+	return nil
+}
+
+// parseNamespacedName parses the <namespace>/<name> reference of a Kubernetes resource
+func parseNamespacedName(value string) (types.NamespacedName, error) {
+	parts := strings.Split(value, "/")
+	if len(parts) != 2 || len(parts[0]) == 0 || len(parts[1]) == 0 {
+		return types.NamespacedName{}, errors.Errorf("'%s' must be in the <namespace>/<name> format", value)
+	}
+	return types.NamespacedName{Namespace: parts[0], Name: parts[1]}, nil
+}
+
 func fatal(err error, debug bool) {
 	if debug {
 		logger.Error(nil, fmt.Sprintf("%+v", err))
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/rest"
+)
+
+func TestNewManagerOptions(t *testing.T) {
+	t.Run("resync period not set", func(t *testing.T) {
+		options := newManagerOptions("default", ":8081", true, 0, "")
+
+		assert.Nil(t, options.SyncPeriod)
+		assert.Equal(t, "c674355f.jenkins.io", options.LeaderElectionID)
+		assert.Equal(t, "default", options.Namespace)
+		assert.Equal(t, ":8081", options.HealthProbeBindAddress)
+		assert.True(t, options.LeaderElection)
+	})
+	t.Run("resync period set", func(t *testing.T) {
+		options := newManagerOptions("default", ":8081", false, 10*time.Minute, "")
+
+		if assert.NotNil(t, options.SyncPeriod) {
+			assert.Equal(t, 10*time.Minute, *options.SyncPeriod)
+		}
+	})
+	t.Run("operator instance ID", func(t *testing.T) {
+		options := newManagerOptions("default", ":8081", true, 0, "team-a")
+
+		assert.Equal(t, "team-a-c674355f.jenkins.io", options.LeaderElectionID)
+	})
+}
+
+func TestSetKubeAPIRateLimits(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		cfg := &rest.Config{QPS: 20, Burst: 30}
+
+		err := setKubeAPIRateLimits(cfg, 50.5, 100)
+
+		require.NoError(t, err)
+		assert.Equal(t, float32(50.5), cfg.QPS)
+		assert.Equal(t, 100, cfg.Burst)
+	})
+	t.Run("not set", func(t *testing.T) {
+		cfg := &rest.Config{}
+
+		err := setKubeAPIRateLimits(cfg, 0, 0)
+
+		require.NoError(t, err)
+		assert.Zero(t, cfg.QPS)
+		assert.Zero(t, cfg.Burst)
+	})
+	t.Run("invalid", func(t *testing.T) {
+		cfg := &rest.Config{QPS: 20, Burst: 30}
+
+		assert.EqualError(t, setKubeAPIRateLimits(cfg, -1, 100), "kube API QPS '-1' must be positive")
+		assert.EqualError(t, setKubeAPIRateLimits(cfg, 50, -1), "kube API burst '-1' must be positive")
+		assert.Equal(t, float32(20), cfg.QPS)
+		assert.Equal(t, 30, cfg.Burst)
+	})
+}
+
+func TestParseNamespacedName(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		got, err := parseNamespacedName("jenkins-operator/metrics-token")
+
+		require.NoError(t, err)
+		assert.Equal(t, types.NamespacedName{Namespace: "jenkins-operator", Name: "metrics-token"}, got)
+	})
+	t.Run("invalid", func(t *testing.T) {
+		for _, value := range []string{"", "metrics-token", "/metrics-token", "jenkins-operator/", "a/b/c"} {
+			_, err := parseNamespacedName(value)
+
+			assert.Error(t, err, value)
+		}
+	})
+}
@@ -0,0 +1,29 @@
+package client
+
+import (
+	"net/http"
+
+	"github.com/bndr/gojenkins"
+	"github.com/pkg/errors"
+)
+
+// ExportConfigurationAsCode exports the current Jenkins configuration as the Configuration as Code YAML
+func (jenkins *jenkins) ExportConfigurationAsCode() (string, error) {
+	output := ""
+	ar := gojenkins.NewAPIRequest("POST", "/configuration-as-code/export", nil)
+	if err := jenkins.Requester.SetCrumb(ar); err != nil {
+		return output, err
+	}
+	ar.Suffix = ""
+
+	r, err := jenkins.Requester.Do(ar, &output, nil)
+	if err != nil {
+		return "", errors.Wrap(err, "couldn't export configuration as code")
+	}
+	defer r.Body.Close()
+
+	if r.StatusCode != http.StatusOK {
+		return "", errors.Errorf("couldn't export configuration as code, invalid status code '%d'", r.StatusCode)
+	}
+	return output, nil
+}
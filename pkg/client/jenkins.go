@@ -12,6 +12,12 @@ import (
 	"github.com/pkg/errors"
 )
 
+const (
+	// DefaultTimeout is the default timeout of the Jenkins API calls
+	DefaultTimeout = 20 * time.Second
+	retryBackoff   = 500 * time.Millisecond
+)
+
 var (
 	errorNotFound = errors.New("404")
 	regex         = regexp.MustCompile("(<application-desc><argument>)(?P<secret>[a-z0-9]*)")
@@ -57,12 +63,21 @@ type Jenkins interface {
 	Poll() (int, error)
 	ExecuteScript(groovyScript string) (logs string, err error)
 	GetNodeSecret(name string) (string, error)
+	ExportConfigurationAsCode() (string, error)
 }
 
 type jenkins struct {
 	gojenkins.Jenkins
 }
 
+// ClientOptions defines the timeout and the retries of the Jenkins API client.
+type ClientOptions struct {
+	// Timeout of a single Jenkins API call including its retries, DefaultTimeout is used when not set
+	Timeout time.Duration
+	// Retries is the number of retries of the idempotent Jenkins API calls failed with a connection or server error
+	Retries int
+}
+
 // JenkinsAPIConnectionSettings is struct that handle information about Jenkins API connection.
 type JenkinsAPIConnectionSettings struct {
 	Hostname    string
@@ -87,6 +102,53 @@ func (t *setBearerToken) RoundTrip(r *http.Request) (*http.Response, error) {
 	return t.transport().RoundTrip(r)
 }
 
+type retryTransport struct {
+	rt      http.RoundTripper
+	retries int
+	backoff time.Duration
+}
+
+func (t *retryTransport) transport() http.RoundTripper {
+	if t.rt != nil {
+		return t.rt
+	}
+	return http.DefaultTransport
+}
+
+func (t *retryTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		return t.transport().RoundTrip(r)
+	}
+
+	var response *http.Response
+	var err error
+	for attempt := 0; ; attempt++ {
+		response, err = t.transport().RoundTrip(r)
+		if attempt >= t.retries || !isRetryable(response, err) {
+			return response, err
+		}
+		if response != nil {
+			_ = response.Body.Close()
+		}
+		select {
+		case <-r.Context().Done():
+			return nil, r.Context().Err()
+		case <-time.After(t.backoff * time.Duration(attempt+1)):
+		}
+	}
+}
+
+func isRetryable(response *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+	switch response.StatusCode {
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
 // CreateOrUpdateJob creates or updates a job from config.
 func (jenkins *jenkins) CreateOrUpdateJob(config, jobName string) (job *gojenkins.Job, created bool, err error) {
 	// create or update
@@ -134,16 +196,36 @@ func (j JenkinsAPIConnectionSettings) Validate() error {
 }
 
 // NewUserAndPasswordAuthorization creates Jenkins API client with user and password authorization.
-func NewUserAndPasswordAuthorization(url, userName, passwordOrToken string) (Jenkins, error) {
-	return newClient(url, userName, passwordOrToken)
+func NewUserAndPasswordAuthorization(url, userName, passwordOrToken string, options ClientOptions) (Jenkins, error) {
+	return newClient(url, userName, passwordOrToken, options)
 }
 
 // NewBearerTokenAuthorization creates Jenkins API client with bearer token authorization.
-func NewBearerTokenAuthorization(url, token string) (Jenkins, error) {
-	return newClient(url, "", token)
+func NewBearerTokenAuthorization(url, token string, options ClientOptions) (Jenkins, error) {
+	return newClient(url, "", token, options)
+}
+
+func newHTTPClient(options ClientOptions) (*http.Client, error) {
+	jar, err := cookiejar.New(nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "couldn't create a cookie jar")
+	}
+
+	timeout := options.Timeout
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	httpClient := &http.Client{
+		Jar:     jar,
+		Timeout: timeout,
+	}
+	if options.Retries > 0 {
+		httpClient.Transport = &retryTransport{retries: options.Retries, backoff: retryBackoff}
+	}
+	return httpClient, nil
 }
 
-func newClient(url, userName, passwordOrToken string) (Jenkins, error) {
+func newClient(url, userName, passwordOrToken string, options ClientOptions) (Jenkins, error) {
 	if strings.HasSuffix(url, "/") {
 		url = url[:len(url)-1]
 	}
@@ -152,14 +234,9 @@ func newClient(url, userName, passwordOrToken string) (Jenkins, error) {
 	jenkinsClient.Server = url
 
 	var basicAuth *gojenkins.BasicAuth
-	jar, err := cookiejar.New(nil)
+	httpClient, err := newHTTPClient(options)
 	if err != nil {
-		return nil, errors.Wrap(err, "couldn't create a cookie jar")
-	}
-
-	httpClient := &http.Client{
-		Jar:     jar,
-		Timeout: 20 * time.Second,
+		return nil, err
 	}
 
 	if len(userName) > 0 && len(passwordOrToken) > 0 {
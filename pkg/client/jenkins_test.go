@@ -0,0 +1,72 @@
+package client
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewHTTPClient(t *testing.T) {
+	t.Run("default timeout", func(t *testing.T) {
+		httpClient, err := newHTTPClient(ClientOptions{})
+
+		require.NoError(t, err)
+		assert.Equal(t, DefaultTimeout, httpClient.Timeout)
+		assert.Nil(t, httpClient.Transport)
+	})
+	t.Run("timeout and retries", func(t *testing.T) {
+		httpClient, err := newHTTPClient(ClientOptions{Timeout: time.Minute, Retries: 3})
+
+		require.NoError(t, err)
+		assert.Equal(t, time.Minute, httpClient.Timeout)
+		assert.Equal(t, 3, httpClient.Transport.(*retryTransport).retries)
+	})
+	t.Run("slow Jenkins", func(t *testing.T) {
+		ts := httptest.NewServer(http.HandlerFunc(func(responseWriter http.ResponseWriter, request *http.Request) {
+			time.Sleep(time.Second)
+		}))
+		defer ts.Close()
+
+		_, err := NewBearerTokenAuthorization(ts.URL, "token", ClientOptions{Timeout: 100 * time.Millisecond})
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "Client.Timeout exceeded")
+	})
+}
+
+func TestRetryTransport(t *testing.T) {
+	var requests int32
+	ts := httptest.NewServer(http.HandlerFunc(func(responseWriter http.ResponseWriter, request *http.Request) {
+		if atomic.AddInt32(&requests, 1) < 3 {
+			responseWriter.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer ts.Close()
+	httpClient := &http.Client{Transport: &retryTransport{retries: 2}}
+
+	t.Run("retries GET requests", func(t *testing.T) {
+		atomic.StoreInt32(&requests, 0)
+
+		response, err := httpClient.Get(ts.URL)
+
+		require.NoError(t, err)
+		_ = response.Body.Close()
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+		assert.Equal(t, int32(3), atomic.LoadInt32(&requests))
+	})
+	t.Run("doesn't retry POST requests", func(t *testing.T) {
+		atomic.StoreInt32(&requests, 0)
+
+		response, err := httpClient.Post(ts.URL, "text/plain", nil)
+
+		require.NoError(t, err)
+		_ = response.Body.Close()
+		assert.Equal(t, http.StatusServiceUnavailable, response.StatusCode)
+		assert.Equal(t, int32(1), atomic.LoadInt32(&requests))
+	})
+}
@@ -632,3 +632,18 @@ func (mr *MockJenkinsMockRecorder) ExecuteScript(groovyScript interface{}) *gomo
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExecuteScript", reflect.TypeOf((*MockJenkins)(nil).ExecuteScript), groovyScript)
 }
+
+// ExportConfigurationAsCode mocks base method
+func (m *MockJenkins) ExportConfigurationAsCode() (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportConfigurationAsCode")
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExportConfigurationAsCode indicates an expected call of ExportConfigurationAsCode
+func (mr *MockJenkinsMockRecorder) ExportConfigurationAsCode() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportConfigurationAsCode", reflect.TypeOf((*MockJenkins)(nil).ExportConfigurationAsCode))
+}
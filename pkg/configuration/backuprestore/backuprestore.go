@@ -102,6 +102,12 @@ func (bar *BackupAndRestore) Validate() []string {
 			messages = append(messages, "spec.backup.interval is not configured")
 		}
 	}
+	switch backup.Compression {
+	case "", v1alpha2.BackupCompressionGzip, v1alpha2.BackupCompressionZstd, v1alpha2.BackupCompressionNone:
+	default:
+		messages = append(messages, fmt.Sprintf("spec.backup.compression '%s' is invalid, it must be one of: %s, %s, %s",
+			backup.Compression, v1alpha2.BackupCompressionGzip, v1alpha2.BackupCompressionZstd, v1alpha2.BackupCompressionNone))
+	}
 
 	if len(restore.ContainerName) > 0 && len(backup.ContainerName) == 0 {
 		messages = append(messages, "spec.backup.containerName is not configured")
@@ -116,6 +122,39 @@ func (bar *BackupAndRestore) Validate() []string {
 // helper value indicating no saved backup
 const noBackup = "-1"
 
+// CompressionEnvName is the env with spec.backup.compression passed to the backup and restore actions
+const CompressionEnvName = "BACKUP_COMPRESSION"
+
+// compressors maps the backup compressions to the programs required in the backup and restore containers
+var compressors = map[v1alpha2.BackupCompression]string{
+	v1alpha2.BackupCompressionGzip: "gzip",
+	v1alpha2.BackupCompressionZstd: "zstd",
+}
+
+// buildActionCommand returns the command of the backup or restore action with the backup number, spec.backup.compression
+// is passed in the CompressionEnvName env when it's set
+func buildActionCommand(command []string, backupNumber uint64, compression v1alpha2.BackupCompression) []string {
+	var actionCommand []string
+	if len(compression) > 0 {
+		actionCommand = append(actionCommand, "env", fmt.Sprintf("%s=%s", CompressionEnvName, compression))
+	}
+	actionCommand = append(actionCommand, command...)
+	return append(actionCommand, fmt.Sprintf("%d", backupNumber))
+}
+
+// ensureCompressorAvailable checks that the program of spec.backup.compression is available in the backup container
+func (bar *BackupAndRestore) ensureCompressorAvailable(podName, containerName string) error {
+	compressor, found := compressors[bar.Configuration.Jenkins.Spec.Backup.Compression]
+	if !found {
+		return nil
+	}
+	_, _, err := bar.Exec(podName, containerName, []string{"sh", "-c", fmt.Sprintf("command -v %s", compressor)})
+	if err != nil {
+		return errors.Wrapf(err, "backup compressor '%s' is not available in the container '%s'", compressor, containerName)
+	}
+	return nil
+}
+
 // Restore performs Jenkins restore backup operation
 func (bar *BackupAndRestore) Restore(jenkinsClient jenkinsclient.Jenkins) error {
 	jenkins := bar.Configuration.Jenkins
@@ -171,8 +210,9 @@ func (bar *BackupAndRestore) Restore(jenkinsClient jenkinsclient.Jenkins) error
 		backupNumber = jenkins.Spec.Restore.RecoveryOnce
 	}
 	bar.logger.Info(fmt.Sprintf("Restoring backup '%d'", backupNumber))
-	command := jenkins.Spec.Restore.Action.Exec.Command
-	command = append(command, fmt.Sprintf("%d", backupNumber))
+	// the restore action picks the decompressor by the archive extension, not by spec.backup.compression, so the
+	// compressor isn't checked before the restore
+	command := buildActionCommand(jenkins.Spec.Restore.Action.Exec.Command, backupNumber, jenkins.Spec.Backup.Compression)
 	_, _, err := bar.Exec(podName, jenkins.Spec.Restore.ContainerName, command)
 
 	if err == nil {
@@ -218,8 +258,10 @@ func (bar *BackupAndRestore) Backup(setBackupDoneBeforePodDeletion bool) error {
 	backupNumber := jenkins.Status.PendingBackup
 	bar.logger.Info(fmt.Sprintf("Performing backup '%d'", backupNumber))
 	podName := resources.GetJenkinsMasterPodName(jenkins)
-	command := jenkins.Spec.Backup.Action.Exec.Command
-	command = append(command, fmt.Sprintf("%d", backupNumber))
+	if err := bar.ensureCompressorAvailable(podName, jenkins.Spec.Backup.ContainerName); err != nil {
+		return err
+	}
+	command := buildActionCommand(jenkins.Spec.Backup.Action.Exec.Command, backupNumber, jenkins.Spec.Backup.Compression)
 	_, _, err := bar.Exec(podName, jenkins.Spec.Backup.ContainerName, command)
 
 	if err == nil {
@@ -0,0 +1,53 @@
+package backuprestore
+
+import (
+	"testing"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildActionCommand(t *testing.T) {
+	command := []string{"/home/user/bin/backup.sh"}
+
+	t.Run("without compression", func(t *testing.T) {
+		assert.Equal(t, []string{"/home/user/bin/backup.sh", "3"}, buildActionCommand(command, 3, ""))
+	})
+	t.Run("zstd", func(t *testing.T) {
+		assert.Equal(t, []string{"env", "BACKUP_COMPRESSION=zstd", "/home/user/bin/backup.sh", "3"},
+			buildActionCommand(command, 3, v1alpha2.BackupCompressionZstd))
+	})
+	t.Run("none", func(t *testing.T) {
+		assert.Equal(t, []string{"env", "BACKUP_COMPRESSION=none", "/home/user/bin/backup.sh", "12"},
+			buildActionCommand(command, 12, v1alpha2.BackupCompressionNone))
+	})
+	t.Run("doesn't modify the action command", func(t *testing.T) {
+		actionCommand := make([]string, 1, 2)
+		actionCommand[0] = "/home/user/bin/backup.sh"
+
+		buildActionCommand(actionCommand, 1, v1alpha2.BackupCompressionGzip)
+
+		assert.Equal(t, []string{"/home/user/bin/backup.sh"}, actionCommand)
+		assert.Empty(t, actionCommand[:2][1])
+	})
+}
+
+func TestValidateCompression(t *testing.T) {
+	newBackupAndRestore := func(compression v1alpha2.BackupCompression) *BackupAndRestore {
+		return New(configuration.Configuration{
+			Jenkins: &v1alpha2.Jenkins{Spec: v1alpha2.JenkinsSpec{Backup: v1alpha2.Backup{Compression: compression}}},
+		}, nil)
+	}
+
+	t.Run("happy", func(t *testing.T) {
+		for _, compression := range []v1alpha2.BackupCompression{"", v1alpha2.BackupCompressionGzip, v1alpha2.BackupCompressionZstd, v1alpha2.BackupCompressionNone} {
+			assert.Empty(t, newBackupAndRestore(compression).Validate())
+		}
+	})
+	t.Run("invalid", func(t *testing.T) {
+		assert.Equal(t, []string{"spec.backup.compression 'xz' is invalid, it must be one of: gzip, zstd, none"},
+			newBackupAndRestore("xz").Validate())
+	})
+}
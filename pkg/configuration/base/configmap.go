@@ -1,18 +1,23 @@
 package base
 
 import (
+	"context"
+
 	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/base/resources"
 
 	stackerr "github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 )
 
 func (r *JenkinsBaseConfigurationReconciler) createScriptsConfigMap(meta metav1.ObjectMeta) error {
-	configMap, err := resources.NewScriptsConfigMap(meta, r.Configuration.Jenkins)
+	configMap, err := resources.NewScriptsConfigMap(meta, r.Configuration.Jenkins, r.OCIPluginsEnabled)
 	if err != nil {
 		return err
 	}
-	return stackerr.WithStack(r.CreateOrUpdateResource(configMap))
+	return r.createOrUpdateConfigMap(configMap)
 }
 
 func (r *JenkinsBaseConfigurationReconciler) createInitConfigurationConfigMap(meta metav1.ObjectMeta) error {
@@ -20,7 +25,7 @@ func (r *JenkinsBaseConfigurationReconciler) createInitConfigurationConfigMap(me
 	if err != nil {
 		return err
 	}
-	return stackerr.WithStack(r.CreateOrUpdateResource(configMap))
+	return r.createOrUpdateConfigMap(configMap)
 }
 
 func (r *JenkinsBaseConfigurationReconciler) createBaseConfigurationConfigMap(meta metav1.ObjectMeta) error {
@@ -28,5 +33,18 @@ func (r *JenkinsBaseConfigurationReconciler) createBaseConfigurationConfigMap(me
 	if err != nil {
 		return err
 	}
+	return r.createOrUpdateConfigMap(configMap)
+}
+
+// createOrUpdateConfigMap creates or updates the ConfigMap managed by the operator and notifies when it has been removed
+func (r *JenkinsBaseConfigurationReconciler) createOrUpdateConfigMap(configMap *corev1.ConfigMap) error {
+	err := r.Client.Get(context.TODO(), types.NamespacedName{Name: configMap.Name, Namespace: configMap.Namespace}, &corev1.ConfigMap{})
+	if err != nil && apierrors.IsNotFound(err) {
+		r.notifyResourceRecreated("ConfigMap", configMap.Name)
+	} else if err != nil {
+		return stackerr.WithStack(err)
+	} else {
+		r.ObserveResource("ConfigMap", configMap.Name)
+	}
 	return stackerr.WithStack(r.CreateOrUpdateResource(configMap))
 }
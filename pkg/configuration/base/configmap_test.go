@@ -0,0 +1,71 @@
+package base
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/pkg/client"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/base/resources"
+	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/event"
+	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/reason"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestCreateOrUpdateConfigMap(t *testing.T) {
+	require.NoError(t, v1alpha2.SchemeBuilder.AddToScheme(scheme.Scheme))
+	newReconciler := func(jenkins *v1alpha2.Jenkins, notifications chan event.Event) *JenkinsBaseConfigurationReconciler {
+		return New(configuration.Configuration{
+			Client:        fake.NewClientBuilder().Build(),
+			Scheme:        scheme.Scheme,
+			Jenkins:       jenkins,
+			Notifications: &notifications,
+		}, client.JenkinsAPIConnectionSettings{})
+	}
+
+	t.Run("creates ConfigMap without notification", func(t *testing.T) {
+		// given
+		jenkins := &v1alpha2.Jenkins{ObjectMeta: metav1.ObjectMeta{Name: "configmap-created", Namespace: defaultNamespace}}
+		notifications := make(chan event.Event, 1)
+		reconciler := newReconciler(jenkins, notifications)
+
+		// when
+		err := reconciler.createBaseConfigurationConfigMap(resources.NewResourceObjectMeta(jenkins))
+
+		// then
+		require.NoError(t, err)
+		assert.Empty(t, notifications)
+	})
+	t.Run("recreates deleted ConfigMap", func(t *testing.T) {
+		// given
+		jenkins := &v1alpha2.Jenkins{ObjectMeta: metav1.ObjectMeta{Name: "configmap-deleted", Namespace: defaultNamespace}}
+		notifications := make(chan event.Event, 1)
+		reconciler := newReconciler(jenkins, notifications)
+		metaObject := resources.NewResourceObjectMeta(jenkins)
+		require.NoError(t, reconciler.createBaseConfigurationConfigMap(metaObject))
+		require.NoError(t, reconciler.createBaseConfigurationConfigMap(metaObject))
+		name := resources.GetBaseConfigurationConfigMapName(jenkins)
+		configMap := &corev1.ConfigMap{}
+		require.NoError(t, reconciler.Client.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: defaultNamespace}, configMap))
+		require.NoError(t, reconciler.Client.Delete(context.TODO(), configMap))
+
+		// when
+		err := reconciler.createBaseConfigurationConfigMap(metaObject)
+
+		// then
+		require.NoError(t, err)
+		assert.NoError(t, reconciler.Client.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: defaultNamespace}, &corev1.ConfigMap{}))
+		require.Len(t, notifications, 1)
+		notification := <-notifications
+		assert.IsType(t, &reason.ResourceRecreated{}, notification.Reason)
+		assert.Contains(t, notification.Reason.Short()[0], name)
+	})
+}
@@ -0,0 +1,117 @@
+package base
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/pkg/log"
+
+	stackerr "github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// findUnreadyDependencies returns a message for every Service which doesn't exist or doesn't have a ready endpoint.
+// Only the endpoints of the Services with a selector are checked, the ExternalName Services and the Services without
+// a selector, e.g. with the manually managed endpoints, are ready once they exist
+func findUnreadyDependencies(k8sClient client.Client, namespace string, dependencies []v1alpha2.ServiceRef) ([]string, error) {
+	var messages []string
+	for _, dependency := range dependencies {
+		name := types.NamespacedName{Name: dependency.Name, Namespace: namespace}
+		service := &corev1.Service{}
+		err := k8sClient.Get(context.TODO(), name, service)
+		if apierrors.IsNotFound(err) {
+			messages = append(messages, fmt.Sprintf("Service '%s' not found", dependency.Name))
+			continue
+		} else if err != nil {
+			return nil, stackerr.WithStack(err)
+		}
+		if service.Spec.Type == corev1.ServiceTypeExternalName || len(service.Spec.Selector) == 0 {
+			continue
+		}
+
+		endpoints := &corev1.Endpoints{}
+		err = k8sClient.Get(context.TODO(), name, endpoints)
+		if err != nil && !apierrors.IsNotFound(err) {
+			return nil, stackerr.WithStack(err)
+		}
+		if apierrors.IsNotFound(err) || !hasReadyAddress(endpoints) {
+			messages = append(messages, fmt.Sprintf("Service '%s' doesn't have a ready endpoint", dependency.Name))
+		}
+	}
+	return messages, nil
+}
+
+func hasReadyAddress(endpoints *corev1.Endpoints) bool {
+	for _, subset := range endpoints.Subsets {
+		if len(subset.Addresses) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// ensureDependencies waits until all Services from spec.dependsOn have a ready endpoint before the Jenkins master is
+// created, the Services Jenkins is waiting for are reported with the WaitingForDependencies condition. The running
+// Jenkins master isn't gated, so a dependency losing its endpoints doesn't stop its reconciliation
+func (r *JenkinsBaseConfigurationReconciler) ensureDependencies() (reconcile.Result, error) {
+	jenkins := r.Configuration.Jenkins
+	exists, err := r.jenkinsMasterExists()
+	if err != nil || exists {
+		return reconcile.Result{}, err
+	}
+
+	messages, err := findUnreadyDependencies(r.Client, jenkins.Namespace, jenkins.Spec.DependsOn)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	condition := metav1.Condition{
+		Type:               v1alpha2.ConditionWaitingForDependencies,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: jenkins.Generation,
+		Reason:             "DependenciesReady",
+		Message:            "All Services from spec.dependsOn have a ready endpoint",
+	}
+	result := reconcile.Result{}
+	if len(messages) > 0 {
+		r.logger.V(log.VDebug).Info(fmt.Sprintf("Waiting for the dependencies: %s", strings.Join(messages, "; ")))
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = "WaitingForDependencies"
+		condition.Message = strings.Join(messages, "; ")
+		result = reconcile.Result{Requeue: true, RequeueAfter: time.Second * 10}
+	} else if meta.FindStatusCondition(jenkins.Status.Conditions, condition.Type) == nil {
+		return result, nil // dependencies have never been waited for
+	}
+
+	current := meta.FindStatusCondition(jenkins.Status.Conditions, condition.Type)
+	if current != nil && current.Status == condition.Status && current.Message == condition.Message {
+		return result, nil
+	}
+	meta.SetStatusCondition(&jenkins.Status.Conditions, condition)
+	return result, stackerr.WithStack(r.Client.Status().Update(context.TODO(), jenkins))
+}
+
+// jenkinsMasterExists returns true when the Jenkins master pod, or the Jenkins Deployment, has already been created
+func (r *JenkinsBaseConfigurationReconciler) jenkinsMasterExists() (bool, error) {
+	var err error
+	if useDeploymentForJenkinsMaster(r.Configuration.Jenkins) {
+		_, err = r.GetJenkinsDeployment()
+	} else {
+		_, err = r.Configuration.GetJenkinsMasterPod()
+	}
+	if apierrors.IsNotFound(err) {
+		return false, nil
+	} else if err != nil {
+		return false, stackerr.WithStack(err)
+	}
+	return true, nil
+}
@@ -0,0 +1,145 @@
+package base
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/pkg/client"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func newEndpoints(name string, ready bool) *corev1.Endpoints {
+	address := corev1.EndpointAddress{IP: "10.0.0.1"}
+	subset := corev1.EndpointSubset{NotReadyAddresses: []corev1.EndpointAddress{address}}
+	if ready {
+		subset = corev1.EndpointSubset{Addresses: []corev1.EndpointAddress{address}}
+	}
+	return &corev1.Endpoints{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: defaultNamespace},
+		Subsets:    []corev1.EndpointSubset{subset},
+	}
+}
+
+func newDependencyService(name string) *corev1.Service {
+	return &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: defaultNamespace},
+		Spec:       corev1.ServiceSpec{Selector: map[string]string{"app": name}},
+	}
+}
+
+func TestEnsureDependencies(t *testing.T) {
+	require.NoError(t, v1alpha2.SchemeBuilder.AddToScheme(scheme.Scheme))
+	newJenkins := func(dependencies ...v1alpha2.ServiceRef) *v1alpha2.Jenkins {
+		return &v1alpha2.Jenkins{
+			ObjectMeta: metav1.ObjectMeta{Name: "example", Namespace: defaultNamespace},
+			Spec:       v1alpha2.JenkinsSpec{DependsOn: dependencies},
+		}
+	}
+	newReconciler := func(jenkins *v1alpha2.Jenkins, objects ...k8sclient.Object) *JenkinsBaseConfigurationReconciler {
+		builder := fake.NewClientBuilder().WithObjects(jenkins)
+		for _, object := range objects {
+			builder = builder.WithObjects(object)
+		}
+		return New(configuration.Configuration{
+			Client:  builder.Build(),
+			Jenkins: jenkins,
+		}, client.JenkinsAPIConnectionSettings{})
+	}
+	getCondition := func(t *testing.T, reconciler *JenkinsBaseConfigurationReconciler) *metav1.Condition {
+		jenkins := &v1alpha2.Jenkins{}
+		err := reconciler.Client.Get(context.TODO(), types.NamespacedName{Name: "example", Namespace: defaultNamespace}, jenkins)
+		require.NoError(t, err)
+		return meta.FindStatusCondition(jenkins.Status.Conditions, v1alpha2.ConditionWaitingForDependencies)
+	}
+
+	t.Run("without dependencies", func(t *testing.T) {
+		// given
+		reconciler := newReconciler(newJenkins())
+
+		// when
+		result, err := reconciler.ensureDependencies()
+
+		// then
+		require.NoError(t, err)
+		assert.False(t, result.Requeue)
+		assert.Nil(t, getCondition(t, reconciler))
+	})
+	t.Run("waits for the dependencies", func(t *testing.T) {
+		// given
+		reconciler := newReconciler(
+			newJenkins(v1alpha2.ServiceRef{Name: "postgres"}, v1alpha2.ServiceRef{Name: "redis"}, v1alpha2.ServiceRef{Name: "vault"}, v1alpha2.ServiceRef{Name: "mongo"}),
+			newDependencyService("postgres"), newEndpoints("postgres", false),
+			newDependencyService("redis"), newEndpoints("redis", true),
+			newDependencyService("mongo"),
+		)
+
+		// when
+		result, err := reconciler.ensureDependencies()
+
+		// then
+		require.NoError(t, err)
+		assert.True(t, result.Requeue)
+		condition := getCondition(t, reconciler)
+		require.NotNil(t, condition)
+		assert.Equal(t, metav1.ConditionTrue, condition.Status)
+		assert.Equal(t, "Service 'postgres' doesn't have a ready endpoint; Service 'vault' not found; Service 'mongo' doesn't have a ready endpoint", condition.Message)
+	})
+	t.Run("dependencies are ready", func(t *testing.T) {
+		// given
+		jenkins := newJenkins(v1alpha2.ServiceRef{Name: "postgres"})
+		jenkins.Status.Conditions = []metav1.Condition{{Type: v1alpha2.ConditionWaitingForDependencies, Status: metav1.ConditionTrue}}
+		reconciler := newReconciler(jenkins, newDependencyService("postgres"), newEndpoints("postgres", true))
+
+		// when
+		result, err := reconciler.ensureDependencies()
+
+		// then
+		require.NoError(t, err)
+		assert.False(t, result.Requeue)
+		condition := getCondition(t, reconciler)
+		require.NotNil(t, condition)
+		assert.Equal(t, metav1.ConditionFalse, condition.Status)
+	})
+	t.Run("doesn't wait for the dependencies of the running Jenkins master", func(t *testing.T) {
+		// given
+		jenkins := newJenkins(v1alpha2.ServiceRef{Name: "postgres"})
+		pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "jenkins-example", Namespace: defaultNamespace}}
+		reconciler := newReconciler(jenkins, newDependencyService("postgres"), newEndpoints("postgres", false), pod)
+
+		// when
+		result, err := reconciler.ensureDependencies()
+
+		// then
+		require.NoError(t, err)
+		assert.False(t, result.Requeue)
+		assert.Nil(t, getCondition(t, reconciler))
+	})
+	t.Run("Services without endpoints managed by selector", func(t *testing.T) {
+		// given
+		externalName := &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: "postgres", Namespace: defaultNamespace},
+			Spec:       corev1.ServiceSpec{Type: corev1.ServiceTypeExternalName, ExternalName: "postgres.example.com"},
+		}
+		withoutSelector := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "redis", Namespace: defaultNamespace}}
+		reconciler := newReconciler(newJenkins(v1alpha2.ServiceRef{Name: "postgres"}, v1alpha2.ServiceRef{Name: "redis"}), externalName, withoutSelector)
+
+		// when
+		result, err := reconciler.ensureDependencies()
+
+		// then
+		require.NoError(t, err)
+		assert.False(t, result.Requeue)
+		assert.Nil(t, getCondition(t, reconciler))
+	})
+}
@@ -22,9 +22,13 @@ func (r *JenkinsBaseConfigurationReconciler) ensureJenkinsDeployment(meta metav1
 		return reconcile.Result{}, err
 	}
 
-	_, err = r.GetJenkinsDeployment()
+	deployment, err := r.GetJenkinsDeployment()
 	if apierrors.IsNotFound(err) {
+		if err := r.reconcileResourceQuotaCondition(); err != nil {
+			return reconcile.Result{}, err
+		}
 		jenkinsDeployment := resources.NewJenkinsDeployment(meta, r.Configuration.Jenkins)
+		r.notifyResourceRecreated("Deployment", jenkinsDeployment.Name)
 		*r.Notifications <- event.Event{
 			Jenkins: *r.Configuration.Jenkins,
 			Phase:   event.PhaseBase,
@@ -45,11 +49,39 @@ func (r *JenkinsBaseConfigurationReconciler) ensureJenkinsDeployment(meta metav1
 			LastBackup:          r.Configuration.Jenkins.Status.LastBackup,
 			PendingBackup:       r.Configuration.Jenkins.Status.LastBackup,
 			UserAndPasswordHash: userAndPasswordHash,
+			Conditions:          r.Configuration.Jenkins.Status.Conditions,
 		}
 		return reconcile.Result{Requeue: true}, r.Client.Update(context.TODO(), r.Configuration.Jenkins)
 	} else if err != nil && !apierrors.IsNotFound(err) {
 		return reconcile.Result{}, stackerr.WithStack(err)
 	}
+	r.ObserveResource("Deployment", deployment.Name)
+
+	if deploymentAnnotations := r.Configuration.Jenkins.Spec.Master.DeploymentAnnotations; !compareMap(deploymentAnnotations, deployment.Annotations) {
+		r.logger.Info(fmt.Sprintf("Updating annotations of Jenkins Deployment %s/%s", deployment.Namespace, deployment.Name))
+		if deployment.Annotations == nil {
+			deployment.Annotations = map[string]string{}
+		}
+		for key, value := range deploymentAnnotations {
+			deployment.Annotations[key] = value
+		}
+		return reconcile.Result{}, stackerr.WithStack(r.UpdateResource(deployment))
+	}
+
+	// the pod template is rebuilt when the plugins or the image change with spec.master.reinstallPluginsOnImageChange
+	checksum := resources.GetJenkinsMasterPodAnnotations(r.Configuration.Jenkins)[resources.InitScriptChecksumAnnotation]
+	if deployment.Spec.Template.Annotations[resources.InitScriptChecksumAnnotation] != checksum {
+		r.logger.Info(fmt.Sprintf("Rolling out Jenkins Deployment %s/%s, the init script checksum has changed", deployment.Namespace, deployment.Name))
+		deployment.Spec.Template = resources.NewJenkinsDeployment(meta, r.Configuration.Jenkins).Spec.Template
+		return reconcile.Result{}, stackerr.WithStack(r.UpdateResource(deployment))
+	}
+
+	replicas := resources.GetJenkinsDeploymentReplicas(r.Configuration.Jenkins)
+	if deployment.Spec.Replicas == nil || *deployment.Spec.Replicas != replicas {
+		r.logger.Info(fmt.Sprintf("Scaling Jenkins Deployment %s/%s to %d replicas", deployment.Namespace, deployment.Name, replicas))
+		deployment.Spec.Replicas = &replicas
+		return reconcile.Result{}, stackerr.WithStack(r.UpdateResource(deployment))
+	}
 
 	return reconcile.Result{}, nil
 }
@@ -0,0 +1,63 @@
+package base
+
+import (
+	"context"
+
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/base/resources"
+
+	stackerr "github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// ensureJenkinsHomePersistentVolumeClaim creates the Jenkins home claim without the owner reference, like the watched
+// resources, so the Jenkins home data isn't garbage collected after the Jenkins CR deletion
+func (r *JenkinsBaseConfigurationReconciler) ensureJenkinsHomePersistentVolumeClaim(meta metav1.ObjectMeta) error {
+	if r.Configuration.Jenkins.Spec.Master.JenkinsHomeStorage == nil {
+		return nil
+	}
+
+	name := resources.GetJenkinsHomePersistentVolumeClaimName(r.Configuration.Jenkins)
+	claim := &corev1.PersistentVolumeClaim{}
+	err := r.Client.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: meta.Namespace}, claim)
+	if err != nil && apierrors.IsNotFound(err) {
+		r.notifyResourceRecreated("PersistentVolumeClaim", name)
+		return stackerr.WithStack(r.Client.Create(context.TODO(), resources.NewJenkinsHomePersistentVolumeClaim(meta, r.Configuration.Jenkins)))
+	} else if err != nil {
+		return stackerr.WithStack(err)
+	}
+	r.ObserveResource("PersistentVolumeClaim", name)
+
+	// the claim spec is immutable, only the annotations are kept in sync
+	changed := r.removeJenkinsOwnerReference(claim)
+	if claim.Annotations == nil {
+		claim.Annotations = map[string]string{}
+	}
+	for key, value := range resources.BuildJenkinsHomePersistentVolumeClaimAnnotations(r.Configuration.Jenkins) {
+		if claim.Annotations[key] != value {
+			claim.Annotations[key] = value
+			changed = true
+		}
+	}
+	if !changed {
+		return nil
+	}
+	return stackerr.WithStack(r.Client.Update(context.TODO(), claim))
+}
+
+// removeJenkinsOwnerReference removes the owner reference of the Jenkins CR set by the previous operator versions
+func (r *JenkinsBaseConfigurationReconciler) removeJenkinsOwnerReference(claim *corev1.PersistentVolumeClaim) bool {
+	var ownerReferences []metav1.OwnerReference
+	for _, ownerReference := range claim.OwnerReferences {
+		if ownerReference.UID != r.Configuration.Jenkins.UID {
+			ownerReferences = append(ownerReferences, ownerReference)
+		}
+	}
+	if len(ownerReferences) == len(claim.OwnerReferences) {
+		return false
+	}
+	claim.OwnerReferences = ownerReferences
+	return true
+}
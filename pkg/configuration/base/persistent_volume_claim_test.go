@@ -0,0 +1,80 @@
+package base
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/pkg/client"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/base/resources"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestEnsureJenkinsHomePersistentVolumeClaim(t *testing.T) {
+	jenkins := &v1alpha2.Jenkins{
+		ObjectMeta: metav1.ObjectMeta{Name: "home", Namespace: defaultNamespace, UID: "jenkins-uid"},
+		Spec: v1alpha2.JenkinsSpec{
+			Master: v1alpha2.JenkinsMaster{
+				JenkinsHomeStorage: &v1alpha2.JenkinsHomeStorage{Size: resource.MustParse("10Gi")},
+			},
+		},
+	}
+	getClaim := func(t *testing.T, reconciler *JenkinsBaseConfigurationReconciler) *corev1.PersistentVolumeClaim {
+		claim := &corev1.PersistentVolumeClaim{}
+		err := reconciler.Client.Get(context.TODO(), types.NamespacedName{Name: "jenkins-operator-home-home", Namespace: defaultNamespace}, claim)
+		require.NoError(t, err)
+		return claim
+	}
+
+	t.Run("claim is created without owner", func(t *testing.T) {
+		// given
+		require.NoError(t, v1alpha2.SchemeBuilder.AddToScheme(scheme.Scheme))
+		reconciler := New(configuration.Configuration{
+			Client:  fake.NewClientBuilder().Build(),
+			Scheme:  scheme.Scheme,
+			Jenkins: jenkins,
+		}, client.JenkinsAPIConnectionSettings{})
+
+		// when
+		err := reconciler.ensureJenkinsHomePersistentVolumeClaim(resources.NewResourceObjectMeta(jenkins))
+
+		// then
+		require.NoError(t, err)
+		claim := getClaim(t, reconciler)
+		assert.Empty(t, claim.OwnerReferences)
+		assert.Equal(t, "home", claim.Annotations[resources.JenkinsHomeAnnotation])
+	})
+	t.Run("owner of existing claim is removed", func(t *testing.T) {
+		// given
+		require.NoError(t, v1alpha2.SchemeBuilder.AddToScheme(scheme.Scheme))
+		controller := true
+		claim := resources.NewJenkinsHomePersistentVolumeClaim(resources.NewResourceObjectMeta(jenkins), jenkins)
+		claim.OwnerReferences = []metav1.OwnerReference{
+			{APIVersion: "jenkins.io/v1alpha2", Kind: "Jenkins", Name: "home", UID: "jenkins-uid", Controller: &controller},
+			{APIVersion: "v1", Kind: "ConfigMap", Name: "other", UID: "other-uid"},
+		}
+		reconciler := New(configuration.Configuration{
+			Client:  fake.NewClientBuilder().WithObjects(claim).Build(),
+			Scheme:  scheme.Scheme,
+			Jenkins: jenkins,
+		}, client.JenkinsAPIConnectionSettings{})
+
+		// when
+		err := reconciler.ensureJenkinsHomePersistentVolumeClaim(resources.NewResourceObjectMeta(jenkins))
+
+		// then
+		require.NoError(t, err)
+		ownerReferences := getClaim(t, reconciler).OwnerReferences
+		require.Len(t, ownerReferences, 1)
+		assert.Equal(t, "other", ownerReferences[0].Name)
+	})
+}
@@ -1,17 +1,27 @@
 package base
 
 import (
+	"context"
 	"fmt"
+	"sort"
+	"strings"
 
 	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
 	jenkinsclient "github.com/jenkinsci/kubernetes-operator/pkg/client"
+	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/event"
+	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/reason"
 
 	"github.com/bndr/gojenkins"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/base/resources"
 	"github.com/jenkinsci/kubernetes-operator/pkg/log"
 	"github.com/jenkinsci/kubernetes-operator/pkg/plugins"
 	stackerr "github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+// verifyPlugins returns false when a required plugin is missing, inactive or has the different version, the required
+// plugins which are missing, disabled or waiting for the restart are reported with the PluginsActive condition
 func (r *JenkinsBaseConfigurationReconciler) verifyPlugins(jenkinsClient jenkinsclient.Jenkins) (bool, error) {
 	allPluginsInJenkins, err := jenkinsClient.GetPlugins(fetchAllPlugins)
 	if err != nil {
@@ -27,24 +37,96 @@ func (r *JenkinsBaseConfigurationReconciler) verifyPlugins(jenkinsClient jenkins
 	r.logger.V(log.VDebug).Info(fmt.Sprintf("Installed plugins '%+v'", installedPlugins))
 
 	status := true
+	var inactivePlugins []string
 	allRequiredPlugins := [][]v1alpha2.Plugin{r.Configuration.Jenkins.Spec.Master.BasePlugins, r.Configuration.Jenkins.Spec.Master.Plugins}
 	for _, requiredPlugins := range allRequiredPlugins {
 		for _, plugin := range requiredPlugins {
 			if _, ok := isPluginInstalled(allPluginsInJenkins, plugin); !ok {
-				r.logger.V(log.VWarn).Info(fmt.Sprintf("Missing plugin '%s'", plugin))
+				r.logger.V(log.VWarn).Info(fmt.Sprintf("Missing plugin '%v'", plugin))
+				inactivePlugins = append(inactivePlugins, plugin.Name)
 				status = false
 				continue
 			}
 			if found, ok := isPluginVersionCompatible(allPluginsInJenkins, plugin); !ok {
-				r.logger.V(log.VWarn).Info(fmt.Sprintf("Incompatible plugin '%s' version, actual '%+v'", plugin, found.Version))
+				r.logger.V(log.VWarn).Info(fmt.Sprintf("Incompatible plugin '%v' version, actual '%+v'", plugin, found.Version))
 				status = false
 			}
 		}
 	}
 
+	if err := r.setPluginsActiveCondition(inactivePlugins); err != nil {
+		return false, err
+	}
 	return status, nil
 }
 
+// setPluginsActiveCondition sets the PluginsActive condition from the required plugins which aren't active, the
+// warning is sent when the condition becomes false
+func (r *JenkinsBaseConfigurationReconciler) setPluginsActiveCondition(inactivePlugins []string) error {
+	jenkins := r.Configuration.Jenkins
+	condition := metav1.Condition{
+		Type:               v1alpha2.ConditionPluginsActive,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: jenkins.Generation,
+		Reason:             "AllPluginsActive",
+		Message:            "All required plugins are active",
+	}
+	if len(inactivePlugins) > 0 {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = "PluginsNotActive"
+		condition.Message = fmt.Sprintf("Plugins not active: %s, %s", strings.Join(inactivePlugins, ", "), getPluginInstallLogsHint(jenkins))
+	}
+
+	current := meta.FindStatusCondition(jenkins.Status.Conditions, condition.Type)
+	if current != nil && current.Status == condition.Status && current.Message == condition.Message && current.ObservedGeneration == condition.ObservedGeneration {
+		return nil
+	}
+	// notify only when the condition becomes false, a changing list of inactive plugins doesn't send it again
+	if condition.Status == metav1.ConditionFalse && (current == nil || current.Status != metav1.ConditionFalse) {
+		*r.Notifications <- event.Event{
+			Jenkins: *jenkins,
+			Phase:   event.PhaseBase,
+			Level:   v1alpha2.NotificationLevelWarning,
+			Reason:  reason.NewPluginsNotActive(reason.OperatorSource, []string{condition.Message}),
+		}
+	}
+	meta.SetStatusCondition(&jenkins.Status.Conditions, condition)
+	return stackerr.WithStack(r.Client.Status().Update(context.TODO(), jenkins))
+}
+
+// getPluginInstallLogsHint returns where the plugin installation logs are, the init script installs the plugins in the
+// Jenkins master container, or in the plugin installation Job in the job mode
+func getPluginInstallLogsHint(jenkins *v1alpha2.Jenkins) string {
+	if resources.IsPluginInstallJobEnabled(jenkins) {
+		return fmt.Sprintf("check the logs of the plugin installation Job '%s'", resources.GetPluginInstallJobName(jenkins))
+	}
+	return fmt.Sprintf("check the plugin installation logs of the '%s' container", resources.JenkinsMasterContainerName)
+}
+
+// ensureResolvedPluginsConfigMap writes the plugins installed in Jenkins to the resolved plugins ConfigMap
+// when spec.master.exportResolvedPlugins is enabled
+func (r *JenkinsBaseConfigurationReconciler) ensureResolvedPluginsConfigMap(meta metav1.ObjectMeta, jenkinsClient jenkinsclient.Jenkins) error {
+	if !r.Configuration.Jenkins.Spec.Master.ExportResolvedPlugins {
+		return nil
+	}
+
+	allPluginsInJenkins, err := jenkinsClient.GetPlugins(fetchAllPlugins)
+	if err != nil {
+		return stackerr.WithStack(err)
+	}
+
+	var resolvedPlugins []string
+	for _, jenkinsPlugin := range allPluginsInJenkins.Raw.Plugins {
+		if isValidPlugin(jenkinsPlugin) {
+			resolvedPlugins = append(resolvedPlugins, plugins.Plugin{Name: jenkinsPlugin.ShortName, Version: jenkinsPlugin.Version}.String())
+		}
+	}
+	sort.Strings(resolvedPlugins)
+
+	configMap := resources.NewResolvedPluginsConfigMap(meta, r.Configuration.Jenkins, resolvedPlugins)
+	return r.createOrUpdateConfigMap(configMap)
+}
+
 func isPluginVersionCompatible(plugins *gojenkins.Plugins, plugin v1alpha2.Plugin) (gojenkins.Plugin, bool) {
 	p := plugins.Contains(plugin.Name)
 	if p == nil {
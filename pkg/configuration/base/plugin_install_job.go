@@ -0,0 +1,109 @@
+package base
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/base/resources"
+	"github.com/jenkinsci/kubernetes-operator/pkg/log"
+	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/reason"
+
+	stackerr "github.com/pkg/errors"
+	batchv1 "k8s.io/api/batch/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// ensurePluginInstallJob creates the plugin installation Job and requeues until it completes, the Job is recreated
+// when the plugins change or when it fails. The Jenkins master is stopped before the Job is created, so the Job
+// doesn't change the plugins of the running Jenkins and can mount the ReadWriteOnce Jenkins home volume.
+func (r *JenkinsBaseConfigurationReconciler) ensurePluginInstallJob(meta metav1.ObjectMeta) (reconcile.Result, error) {
+	name := resources.GetPluginInstallJobName(r.Configuration.Jenkins)
+	job := &batchv1.Job{}
+	err := r.Client.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: meta.Namespace}, job)
+	if err != nil && apierrors.IsNotFound(err) {
+		stopped, err := r.stopJenkinsMasterForPluginInstallJob()
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+		if !stopped {
+			r.logger.V(log.VDebug).Info("Waiting for the Jenkins master to stop before the plugin installation Job")
+			return reconcile.Result{Requeue: true, RequeueAfter: time.Second * 5}, nil
+		}
+
+		r.logger.Info(fmt.Sprintf("Creating the plugin installation Job %s/%s", meta.Namespace, name))
+		if err := r.CreateResource(resources.NewPluginInstallJob(meta, r.Configuration.Jenkins)); err != nil {
+			return reconcile.Result{}, stackerr.WithStack(err)
+		}
+		return reconcile.Result{Requeue: true, RequeueAfter: time.Second * 5}, nil
+	} else if err != nil {
+		return reconcile.Result{}, stackerr.WithStack(err)
+	}
+
+	if job.Annotations[resources.PluginsHashAnnotation] != resources.GetPluginsHash(r.Configuration.Jenkins) {
+		r.logger.Info(fmt.Sprintf("Plugins have changed, deleting the plugin installation Job %s/%s", job.Namespace, job.Name))
+		if err := r.deletePluginInstallJob(job); err != nil {
+			return reconcile.Result{}, err
+		}
+		return reconcile.Result{Requeue: true, RequeueAfter: time.Second * 5}, nil
+	}
+
+	completed, err := resources.GetPluginInstallJobStatus(job)
+	if err != nil {
+		// the next reconciliation creates the Job again, e.g. after the plugin proxy credentials are fixed
+		r.logger.Info(fmt.Sprintf("Deleting the failed plugin installation Job %s/%s", job.Namespace, job.Name))
+		if err := r.deletePluginInstallJob(job); err != nil {
+			return reconcile.Result{}, err
+		}
+		return reconcile.Result{}, err
+	}
+	if !completed {
+		r.logger.V(log.VDebug).Info(fmt.Sprintf("Waiting for the plugin installation Job %s/%s", job.Namespace, job.Name))
+		return reconcile.Result{Requeue: true, RequeueAfter: time.Second * 5}, nil
+	}
+	return reconcile.Result{}, nil
+}
+
+func (r *JenkinsBaseConfigurationReconciler) deletePluginInstallJob(job *batchv1.Job) error {
+	err := r.Client.Delete(context.TODO(), job, client.PropagationPolicy(metav1.DeletePropagationBackground))
+	if err != nil && !apierrors.IsNotFound(err) {
+		return stackerr.WithStack(err)
+	}
+	return nil
+}
+
+// stopJenkinsMasterForPluginInstallJob deletes the Jenkins master pod or scales the Jenkins Deployment down, it returns
+// true when no Jenkins master pod is left. The Deployment is scaled up again by ensureJenkinsDeployment after the Job.
+func (r *JenkinsBaseConfigurationReconciler) stopJenkinsMasterForPluginInstallJob() (bool, error) {
+	if useDeploymentForJenkinsMaster(r.Configuration.Jenkins) {
+		deployment, err := r.GetJenkinsDeployment()
+		if err != nil && apierrors.IsNotFound(err) {
+			return true, nil
+		} else if err != nil {
+			return false, stackerr.WithStack(err)
+		}
+		if deployment.Spec.Replicas == nil || *deployment.Spec.Replicas > 0 {
+			r.logger.Info(fmt.Sprintf("Scaling Jenkins Deployment %s/%s down to install the plugins", deployment.Namespace, deployment.Name))
+			deployment.Spec.Replicas = pointer.Int32Ptr(0)
+			return false, stackerr.WithStack(r.UpdateResource(deployment))
+		}
+		return deployment.Status.Replicas == 0, nil
+	}
+
+	pod, err := r.Configuration.GetJenkinsMasterPod()
+	if err != nil && apierrors.IsNotFound(err) {
+		return true, nil
+	} else if err != nil {
+		return false, stackerr.WithStack(err)
+	}
+	if r.IsJenkinsTerminating(*pod) {
+		return false, nil
+	}
+	restartReason := reason.NewPodRestart(reason.OperatorSource, []string{"Installing the plugins by the plugin installation Job, stopping Jenkins"})
+	return false, r.Configuration.RestartJenkinsMasterPod(restartReason)
+}
@@ -0,0 +1,172 @@
+package base
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/pkg/client"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/base/resources"
+	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/event"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/pointer"
+	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestEnsurePluginInstallJob(t *testing.T) {
+	require.NoError(t, v1alpha2.SchemeBuilder.AddToScheme(scheme.Scheme))
+	newJenkins := func() *v1alpha2.Jenkins {
+		return &v1alpha2.Jenkins{
+			ObjectMeta: metav1.ObjectMeta{Name: "example", Namespace: defaultNamespace},
+			Spec: v1alpha2.JenkinsSpec{
+				Master: v1alpha2.JenkinsMaster{
+					PluginInstallMode:  v1alpha2.PluginInstallModeJob,
+					JenkinsHomeStorage: &v1alpha2.JenkinsHomeStorage{Size: resource.MustParse("10Gi")},
+					Containers:         []v1alpha2.Container{{Name: resources.JenkinsMasterContainerName, Image: "jenkins/jenkins:lts"}},
+					Plugins:            []v1alpha2.Plugin{{Name: "git", Version: "2.0"}},
+				},
+			},
+		}
+	}
+	newReconciler := func(jenkins *v1alpha2.Jenkins, objects ...k8sclient.Object) *JenkinsBaseConfigurationReconciler {
+		notifications := make(chan event.Event, 1)
+		return New(configuration.Configuration{
+			Client:        fake.NewClientBuilder().WithObjects(jenkins).WithObjects(objects...).Build(),
+			Scheme:        scheme.Scheme,
+			Jenkins:       jenkins,
+			Notifications: &notifications,
+		}, client.JenkinsAPIConnectionSettings{})
+	}
+	getJob := func(reconciler *JenkinsBaseConfigurationReconciler) (*batchv1.Job, error) {
+		job := &batchv1.Job{}
+		err := reconciler.Client.Get(context.TODO(), types.NamespacedName{Name: "jenkins-operator-plugins-example", Namespace: defaultNamespace}, job)
+		return job, err
+	}
+
+	t.Run("creates the Job and waits", func(t *testing.T) {
+		// given
+		jenkins := newJenkins()
+		reconciler := newReconciler(jenkins)
+
+		// when
+		result, err := reconciler.ensurePluginInstallJob(resources.NewResourceObjectMeta(jenkins))
+
+		// then
+		require.NoError(t, err)
+		assert.True(t, result.Requeue)
+		job, err := getJob(reconciler)
+		require.NoError(t, err)
+		assert.Equal(t, resources.GetPluginsHash(jenkins), job.Annotations[resources.PluginsHashAnnotation])
+	})
+	t.Run("waits for the running Job", func(t *testing.T) {
+		// given
+		jenkins := newJenkins()
+		job := resources.NewPluginInstallJob(resources.NewResourceObjectMeta(jenkins), jenkins)
+		job.Status.Active = 1
+		reconciler := newReconciler(jenkins, job)
+
+		// when
+		result, err := reconciler.ensurePluginInstallJob(resources.NewResourceObjectMeta(jenkins))
+
+		// then
+		require.NoError(t, err)
+		assert.True(t, result.Requeue)
+	})
+	t.Run("Job has completed", func(t *testing.T) {
+		// given
+		jenkins := newJenkins()
+		job := resources.NewPluginInstallJob(resources.NewResourceObjectMeta(jenkins), jenkins)
+		job.Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobComplete, Status: corev1.ConditionTrue}}
+		reconciler := newReconciler(jenkins, job)
+
+		// when
+		result, err := reconciler.ensurePluginInstallJob(resources.NewResourceObjectMeta(jenkins))
+
+		// then
+		require.NoError(t, err)
+		assert.False(t, result.Requeue)
+	})
+	t.Run("Job has failed", func(t *testing.T) {
+		// given
+		jenkins := newJenkins()
+		job := resources.NewPluginInstallJob(resources.NewResourceObjectMeta(jenkins), jenkins)
+		job.Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobFailed, Status: corev1.ConditionTrue, Message: "BackoffLimitExceeded"}}
+		reconciler := newReconciler(jenkins, job)
+
+		// when
+		_, err := reconciler.ensurePluginInstallJob(resources.NewResourceObjectMeta(jenkins))
+
+		// then
+		assert.EqualError(t, err, "plugin installation Job 'jenkins-operator-plugins-example' failed: BackoffLimitExceeded")
+		_, err = getJob(reconciler)
+		assert.True(t, apierrors.IsNotFound(err))
+	})
+	t.Run("stops the Jenkins master pod before creating the Job", func(t *testing.T) {
+		// given
+		jenkins := newJenkins()
+		pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: resources.GetJenkinsMasterPodName(jenkins), Namespace: defaultNamespace}}
+		reconciler := newReconciler(jenkins, pod)
+
+		// when
+		result, err := reconciler.ensurePluginInstallJob(resources.NewResourceObjectMeta(jenkins))
+
+		// then
+		require.NoError(t, err)
+		assert.True(t, result.Requeue)
+		_, err = getJob(reconciler)
+		assert.True(t, apierrors.IsNotFound(err))
+		err = reconciler.Client.Get(context.TODO(), types.NamespacedName{Name: pod.Name, Namespace: defaultNamespace}, &corev1.Pod{})
+		assert.True(t, apierrors.IsNotFound(err))
+	})
+	t.Run("scales the Jenkins Deployment down before creating the Job", func(t *testing.T) {
+		// given
+		jenkins := newJenkins()
+		jenkins.Annotations = map[string]string{"jenkins.io/use-deployment": "true"}
+		deployment := &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: resources.GetJenkinsDeploymentName(jenkins), Namespace: defaultNamespace},
+			Spec:       appsv1.DeploymentSpec{Replicas: pointer.Int32Ptr(1)},
+			Status:     appsv1.DeploymentStatus{Replicas: 1},
+		}
+		reconciler := newReconciler(jenkins, deployment)
+
+		// when
+		result, err := reconciler.ensurePluginInstallJob(resources.NewResourceObjectMeta(jenkins))
+
+		// then
+		require.NoError(t, err)
+		assert.True(t, result.Requeue)
+		_, err = getJob(reconciler)
+		assert.True(t, apierrors.IsNotFound(err))
+		require.NoError(t, reconciler.Client.Get(context.TODO(), types.NamespacedName{Name: deployment.Name, Namespace: defaultNamespace}, deployment))
+		assert.Equal(t, pointer.Int32Ptr(0), deployment.Spec.Replicas)
+	})
+	t.Run("plugins have changed", func(t *testing.T) {
+		// given
+		jenkins := newJenkins()
+		job := resources.NewPluginInstallJob(resources.NewResourceObjectMeta(jenkins), jenkins)
+		job.Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobComplete, Status: corev1.ConditionTrue}}
+		jenkins.Spec.Master.Plugins[0].Version = "2.1"
+		reconciler := newReconciler(jenkins, job)
+
+		// when
+		result, err := reconciler.ensurePluginInstallJob(resources.NewResourceObjectMeta(jenkins))
+
+		// then
+		require.NoError(t, err)
+		assert.True(t, result.Requeue)
+		_, err = getJob(reconciler)
+		assert.True(t, apierrors.IsNotFound(err))
+	})
+}
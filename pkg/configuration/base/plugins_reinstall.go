@@ -0,0 +1,89 @@
+package base
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/base/resources"
+	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/reason"
+
+	stackerr "github.com/pkg/errors"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// ensurePluginsReinstall consumes the jenkins.io/reinstall-plugins annotation, the plugin installation Job and its
+// plugins hash are deleted and the annotation is replaced with a new jenkins.io/plugins-reinstall-id. The init script
+// removes the plugins kept across the restarts on the first start with the new ID, so the Jenkins master doesn't have
+// to be running. The annotation is replaced before the Jenkins master is restarted, so a failed annotation update can't
+// restart Jenkins twice
+func (r *JenkinsBaseConfigurationReconciler) ensurePluginsReinstall() (reconcile.Result, error) {
+	jenkins := r.Configuration.Jenkins
+	if jenkins.Annotations[v1alpha2.ReinstallPluginsAnnotation] != "true" {
+		return reconcile.Result{}, nil
+	}
+
+	r.logger.Info(fmt.Sprintf("Reinstalling the plugins requested by the %s annotation", v1alpha2.ReinstallPluginsAnnotation))
+	if resources.IsPluginInstallJobEnabled(jenkins) {
+		job := &batchv1.Job{ObjectMeta: metav1.ObjectMeta{Name: resources.GetPluginInstallJobName(jenkins), Namespace: jenkins.Namespace}}
+		err := r.Client.Delete(context.TODO(), job, client.PropagationPolicy(metav1.DeletePropagationBackground))
+		if err != nil && !apierrors.IsNotFound(err) {
+			return reconcile.Result{}, stackerr.WithStack(err)
+		}
+	}
+
+	patch := client.MergeFrom(jenkins.DeepCopy())
+	delete(jenkins.Annotations, v1alpha2.ReinstallPluginsAnnotation)
+	reinstallID := time.Now().UTC().Format(time.RFC3339Nano)
+	jenkins.Annotations[v1alpha2.PluginsReinstallIDAnnotation] = reinstallID
+	if err := r.Client.Patch(context.TODO(), jenkins, patch); err != nil {
+		return reconcile.Result{}, stackerr.WithStack(err)
+	}
+
+	if useDeploymentForJenkinsMaster(jenkins) {
+		deployment, err := r.GetJenkinsDeployment()
+		if err != nil && !apierrors.IsNotFound(err) {
+			return reconcile.Result{}, stackerr.WithStack(err)
+		} else if err == nil {
+			// the new env rolls out the Jenkins master pods
+			setPluginsReinstallIDEnv(deployment.Spec.Template.Spec.Containers, reinstallID)
+			if err := r.UpdateResource(deployment); err != nil {
+				return reconcile.Result{}, stackerr.WithStack(err)
+			}
+		}
+	} else {
+		_, err := r.Configuration.GetJenkinsMasterPod()
+		if err != nil && !apierrors.IsNotFound(err) {
+			return reconcile.Result{}, stackerr.WithStack(err)
+		} else if err == nil {
+			restartReason := reason.NewPodRestart(reason.OperatorSource, []string{"Reinstalling the plugins, restarting Jenkins"})
+			if err := r.Configuration.RestartJenkinsMasterPod(restartReason); err != nil {
+				return reconcile.Result{}, err
+			}
+		}
+	}
+
+	return reconcile.Result{Requeue: true}, nil
+}
+
+// setPluginsReinstallIDEnv sets the plugins reinstall ID env of the Jenkins master container
+func setPluginsReinstallIDEnv(containers []corev1.Container, reinstallID string) {
+	for i := range containers {
+		if containers[i].Name != resources.JenkinsMasterContainerName {
+			continue
+		}
+		for j := range containers[i].Env {
+			if containers[i].Env[j].Name == resources.PluginsReinstallIDEnvName {
+				containers[i].Env[j].Value = reinstallID
+				return
+			}
+		}
+		containers[i].Env = append(containers[i].Env, corev1.EnvVar{Name: resources.PluginsReinstallIDEnvName, Value: reinstallID})
+	}
+}
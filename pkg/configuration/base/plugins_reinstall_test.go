@@ -0,0 +1,148 @@
+package base
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/pkg/client"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/base/resources"
+	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/event"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestEnsurePluginsReinstall(t *testing.T) {
+	require.NoError(t, v1alpha2.SchemeBuilder.AddToScheme(scheme.Scheme))
+	newJenkins := func(annotations map[string]string) *v1alpha2.Jenkins {
+		return &v1alpha2.Jenkins{
+			ObjectMeta: metav1.ObjectMeta{Name: "example", Namespace: defaultNamespace, Annotations: annotations},
+			Spec: v1alpha2.JenkinsSpec{
+				Master: v1alpha2.JenkinsMaster{
+					Containers: []v1alpha2.Container{{Name: resources.JenkinsMasterContainerName, Image: "jenkins/jenkins:lts"}},
+				},
+			},
+		}
+	}
+	newPod := func() *corev1.Pod {
+		return &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "jenkins-example", Namespace: defaultNamespace}}
+	}
+	newReconciler := func(jenkins *v1alpha2.Jenkins, objects ...k8sclient.Object) (*JenkinsBaseConfigurationReconciler, chan event.Event) {
+		notifications := make(chan event.Event, 1)
+		return New(configuration.Configuration{
+			Client:        fake.NewClientBuilder().WithObjects(jenkins).WithObjects(objects...).Build(),
+			Scheme:        scheme.Scheme,
+			Jenkins:       jenkins,
+			Notifications: &notifications,
+		}, client.JenkinsAPIConnectionSettings{}), notifications
+	}
+	getAnnotations := func(t *testing.T, reconciler *JenkinsBaseConfigurationReconciler) map[string]string {
+		jenkins := &v1alpha2.Jenkins{}
+		require.NoError(t, reconciler.Client.Get(context.TODO(), types.NamespacedName{Name: "example", Namespace: defaultNamespace}, jenkins))
+		return jenkins.Annotations
+	}
+
+	t.Run("without annotation", func(t *testing.T) {
+		// given
+		reconciler, _ := newReconciler(newJenkins(nil), newPod())
+
+		// when
+		result, err := reconciler.ensurePluginsReinstall()
+
+		// then
+		require.NoError(t, err)
+		assert.False(t, result.Requeue)
+		_, err = reconciler.Configuration.GetJenkinsMasterPod()
+		assert.NoError(t, err)
+	})
+	t.Run("restarts the Jenkins master pod and removes the annotation", func(t *testing.T) {
+		// given
+		jenkins := newJenkins(map[string]string{v1alpha2.ReinstallPluginsAnnotation: "true", "team": "platform"})
+		reconciler, notifications := newReconciler(jenkins, newPod())
+
+		// when
+		result, err := reconciler.ensurePluginsReinstall()
+
+		// then
+		require.NoError(t, err)
+		assert.True(t, result.Requeue)
+		_, err = reconciler.Configuration.GetJenkinsMasterPod()
+		assert.True(t, apierrors.IsNotFound(err))
+		assert.Len(t, notifications, 1)
+		annotations := getAnnotations(t, reconciler)
+		assert.Equal(t, "platform", annotations["team"])
+		assert.NotContains(t, annotations, v1alpha2.ReinstallPluginsAnnotation)
+		assert.NotEmpty(t, annotations[v1alpha2.PluginsReinstallIDAnnotation])
+
+		result, err = reconciler.ensurePluginsReinstall()
+		require.NoError(t, err)
+		assert.False(t, result.Requeue)
+	})
+	t.Run("sets the plugins reinstall ID when the Jenkins master pod is not running", func(t *testing.T) {
+		// given
+		jenkins := newJenkins(map[string]string{v1alpha2.ReinstallPluginsAnnotation: "true"})
+		jenkins.Spec.Master.PluginCacheVolume = &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "plugin-cache"}
+		reconciler, notifications := newReconciler(jenkins)
+
+		// when
+		result, err := reconciler.ensurePluginsReinstall()
+
+		// then
+		require.NoError(t, err)
+		assert.True(t, result.Requeue)
+		assert.Len(t, notifications, 0)
+		annotations := getAnnotations(t, reconciler)
+		assert.NotContains(t, annotations, v1alpha2.ReinstallPluginsAnnotation)
+		reinstallID := annotations[v1alpha2.PluginsReinstallIDAnnotation]
+		assert.NotEmpty(t, reinstallID)
+		assert.Contains(t, resources.GetJenkinsMasterContainerBaseEnvs(jenkins), corev1.EnvVar{Name: resources.PluginsReinstallIDEnvName, Value: reinstallID})
+	})
+	t.Run("rolls out the Jenkins Deployment with the plugins reinstall ID", func(t *testing.T) {
+		// given
+		jenkins := newJenkins(map[string]string{v1alpha2.ReinstallPluginsAnnotation: "true", "jenkins.io/use-deployment": "true"})
+		jenkins.Spec.Master.Containers[0].ReadinessProbe = &corev1.Probe{}
+		deployment := resources.NewJenkinsDeployment(resources.NewResourceObjectMeta(jenkins), jenkins)
+		reconciler, _ := newReconciler(jenkins, deployment)
+
+		// when
+		result, err := reconciler.ensurePluginsReinstall()
+
+		// then
+		require.NoError(t, err)
+		assert.True(t, result.Requeue)
+		deployment, err = reconciler.GetJenkinsDeployment()
+		require.NoError(t, err)
+		reinstallID := getAnnotations(t, reconciler)[v1alpha2.PluginsReinstallIDAnnotation]
+		assert.Contains(t, deployment.Spec.Template.Spec.Containers[0].Env, corev1.EnvVar{Name: resources.PluginsReinstallIDEnvName, Value: reinstallID})
+	})
+	t.Run("deletes the plugin installation Job", func(t *testing.T) {
+		// given
+		jenkins := newJenkins(map[string]string{v1alpha2.ReinstallPluginsAnnotation: "true"})
+		jenkins.Spec.Master.PluginInstallMode = v1alpha2.PluginInstallModeJob
+		jenkins.Spec.Master.JenkinsHomeStorage = &v1alpha2.JenkinsHomeStorage{Size: resource.MustParse("10Gi")}
+		job := resources.NewPluginInstallJob(resources.NewResourceObjectMeta(jenkins), jenkins)
+		job.Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobComplete, Status: corev1.ConditionTrue}}
+		reconciler, _ := newReconciler(jenkins, job)
+
+		// when
+		result, err := reconciler.ensurePluginsReinstall()
+
+		// then
+		require.NoError(t, err)
+		assert.True(t, result.Requeue)
+		err = reconciler.Client.Get(context.TODO(), types.NamespacedName{Name: job.Name, Namespace: defaultNamespace}, &batchv1.Job{})
+		assert.True(t, apierrors.IsNotFound(err))
+		assert.NotContains(t, getAnnotations(t, reconciler), v1alpha2.ReinstallPluginsAnnotation)
+	})
+}
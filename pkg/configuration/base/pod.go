@@ -82,10 +82,10 @@ func (r *JenkinsBaseConfigurationReconciler) checkForPodRecreation(currentJenkin
 			currentJenkinsMasterPod.Labels, r.Configuration.Jenkins.Spec.Master.Labels))
 	}
 
-	if !compareMap(r.Configuration.Jenkins.Spec.Master.Annotations, currentJenkinsMasterPod.ObjectMeta.Annotations) {
+	if annotations := resources.GetJenkinsMasterPodAnnotations(r.Configuration.Jenkins); !compareMap(annotations, currentJenkinsMasterPod.ObjectMeta.Annotations) {
 		messages = append(messages, "Jenkins pod annotations have changed")
 		verbose = append(verbose, fmt.Sprintf("Jenkins pod annotations have changed, actual '%+v' required '%+v'",
-			currentJenkinsMasterPod.ObjectMeta.Annotations, r.Configuration.Jenkins.Spec.Master.Annotations))
+			currentJenkinsMasterPod.ObjectMeta.Annotations, annotations))
 	}
 
 	if !r.compareVolumes(currentJenkinsMasterPod) {
@@ -100,6 +100,10 @@ func (r *JenkinsBaseConfigurationReconciler) checkForPodRecreation(currentJenkin
 			len(currentJenkinsMasterPod.Spec.Containers), len(r.Configuration.Jenkins.Spec.Master.Containers)))
 	}
 
+	initContainersMessages, initContainersVerbose := r.compareInitContainers(currentJenkinsMasterPod.Spec.InitContainers)
+	messages = append(messages, initContainersMessages...)
+	verbose = append(verbose, initContainersVerbose...)
+
 	if r.Configuration.Jenkins.Spec.Master.PriorityClassName != currentJenkinsMasterPod.Spec.PriorityClassName {
 		messages = append(messages, "Jenkins priorityClassName has changed")
 		verbose = append(verbose, fmt.Sprintf("Jenkins priorityClassName has changed, actual '%+v' required '%+v'",
@@ -146,6 +150,31 @@ func (r *JenkinsBaseConfigurationReconciler) checkForPodRecreation(currentJenkin
 	return reason.NewPodRestart(reason.OperatorSource, messages, verbose...)
 }
 
+// compareInitContainers compares the init containers of the Jenkins master pod with spec.master.extraInitContainers,
+// the init containers run in order so they're compared by the position
+func (r *JenkinsBaseConfigurationReconciler) compareInitContainers(actual []corev1.Container) (messages []string, verbose []string) {
+	expected := resources.NewJenkinsMasterInitContainers(r.Configuration.Jenkins)
+	if len(expected) != len(actual) {
+		messages = append(messages, "Jenkins amount of init containers has changed")
+		verbose = append(verbose, fmt.Sprintf("Jenkins amount of init containers has changed, actual '%+v' required '%+v'",
+			len(actual), len(expected)))
+		return messages, verbose
+	}
+
+	for i, expectedInitContainer := range expected {
+		if expectedInitContainer.Name != actual[i].Name {
+			messages = append(messages, fmt.Sprintf("Init container '%s' not found in pod", expectedInitContainer.Name))
+			verbose = append(verbose, fmt.Sprintf("Init container '%+v' not found in pod", expectedInitContainer))
+			continue
+		}
+
+		containerMessages, verboseMessages := r.compareContainers(expectedInitContainer, actual[i])
+		messages = append(messages, containerMessages...)
+		verbose = append(verbose, verboseMessages...)
+	}
+	return messages, verbose
+}
+
 func (r *JenkinsBaseConfigurationReconciler) ensureJenkinsMasterPod(meta metav1.ObjectMeta) (reconcile.Result, error) {
 	userAndPasswordHash, err := r.calculateUserAndPasswordHash()
 	if err != nil {
@@ -155,7 +184,11 @@ func (r *JenkinsBaseConfigurationReconciler) ensureJenkinsMasterPod(meta metav1.
 	// Check if this Pod already exists
 	currentJenkinsMasterPod, err := r.Configuration.GetJenkinsMasterPod()
 	if err != nil && apierrors.IsNotFound(err) {
+		if err := r.reconcileResourceQuotaCondition(); err != nil {
+			return reconcile.Result{}, err
+		}
 		jenkinsMasterPod := resources.NewJenkinsMasterPod(meta, r.Configuration.Jenkins)
+		r.notifyResourceRecreated("Pod", jenkinsMasterPod.Name)
 		*r.Notifications <- event.Event{
 			Jenkins: *r.Configuration.Jenkins,
 			Phase:   event.PhaseBase,
@@ -175,6 +208,7 @@ func (r *JenkinsBaseConfigurationReconciler) ensureJenkinsMasterPod(meta metav1.
 			LastBackup:          r.Configuration.Jenkins.Status.LastBackup,
 			PendingBackup:       r.Configuration.Jenkins.Status.LastBackup,
 			UserAndPasswordHash: userAndPasswordHash,
+			Conditions:          r.Configuration.Jenkins.Status.Conditions,
 		}
 		return reconcile.Result{Requeue: true}, r.Client.Status().Update(context.TODO(), r.Configuration.Jenkins)
 	} else if err != nil && !apierrors.IsNotFound(err) {
@@ -184,6 +218,9 @@ func (r *JenkinsBaseConfigurationReconciler) ensureJenkinsMasterPod(meta metav1.
 	if currentJenkinsMasterPod == nil {
 		return reconcile.Result{Requeue: true}, nil
 	}
+	if !r.IsJenkinsTerminating(*currentJenkinsMasterPod) {
+		r.ObserveResource("Pod", currentJenkinsMasterPod.Name)
+	}
 
 	if r.IsJenkinsTerminating(*currentJenkinsMasterPod) && r.Configuration.Jenkins.Status.UserConfigurationCompletedTime != nil {
 		backupAndRestore := backuprestore.New(r.Configuration, r.logger)
@@ -9,12 +9,15 @@ import (
 	"github.com/jenkinsci/kubernetes-operator/pkg/configuration"
 	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/base/resources"
 	"github.com/jenkinsci/kubernetes-operator/pkg/log"
+	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/event"
 
 	"github.com/bndr/gojenkins"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes/scheme"
@@ -164,15 +167,17 @@ func TestCompareVolumes(t *testing.T) {
 
 func TestJenkinsBaseConfigurationReconciler_verifyPlugins(t *testing.T) {
 	log.SetupLogger(true)
+	assert.NoError(t, v1alpha2.SchemeBuilder.AddToScheme(scheme.Scheme))
+	newReconciler := func(jenkins *v1alpha2.Jenkins) *JenkinsBaseConfigurationReconciler {
+		jenkins.ObjectMeta = metav1.ObjectMeta{Name: "example", Namespace: defaultNamespace}
+		notifications := make(chan event.Event, 10)
+		k8sClient := fake.NewClientBuilder().WithObjects(jenkins).Build()
+		return New(configuration.Configuration{Client: k8sClient, Jenkins: jenkins, Scheme: scheme.Scheme, Notifications: &notifications}, client.JenkinsAPIConnectionSettings{})
+	}
 
 	t.Run("happy, empty base and user plugins", func(t *testing.T) {
 		jenkins := &v1alpha2.Jenkins{}
-		r := JenkinsBaseConfigurationReconciler{
-			logger: log.Log,
-			Configuration: configuration.Configuration{
-				Jenkins: jenkins,
-			},
-		}
+		r := newReconciler(jenkins)
 		pluginsInJenkins := &gojenkins.Plugins{
 			Raw: &gojenkins.PluginResponse{},
 		}
@@ -195,12 +200,7 @@ func TestJenkinsBaseConfigurationReconciler_verifyPlugins(t *testing.T) {
 				},
 			},
 		}
-		r := JenkinsBaseConfigurationReconciler{
-			logger: log.Log,
-			Configuration: configuration.Configuration{
-				Jenkins: jenkins,
-			},
-		}
+		r := newReconciler(jenkins)
 		pluginsInJenkins := &gojenkins.Plugins{
 			Raw: &gojenkins.PluginResponse{
 				Plugins: []gojenkins.Plugin{
@@ -239,12 +239,7 @@ func TestJenkinsBaseConfigurationReconciler_verifyPlugins(t *testing.T) {
 				},
 			},
 		}
-		r := JenkinsBaseConfigurationReconciler{
-			logger: log.Log,
-			Configuration: configuration.Configuration{
-				Jenkins: jenkins,
-			},
-		}
+		r := newReconciler(jenkins)
 		pluginsInJenkins := &gojenkins.Plugins{
 			Raw: &gojenkins.PluginResponse{
 				Plugins: []gojenkins.Plugin{
@@ -276,12 +271,7 @@ func TestJenkinsBaseConfigurationReconciler_verifyPlugins(t *testing.T) {
 				},
 			},
 		}
-		r := JenkinsBaseConfigurationReconciler{
-			logger: log.Log,
-			Configuration: configuration.Configuration{
-				Jenkins: jenkins,
-			},
-		}
+		r := newReconciler(jenkins)
 		pluginsInJenkins := &gojenkins.Plugins{
 			Raw: &gojenkins.PluginResponse{
 				Plugins: []gojenkins.Plugin{
@@ -313,12 +303,7 @@ func TestJenkinsBaseConfigurationReconciler_verifyPlugins(t *testing.T) {
 				},
 			},
 		}
-		r := JenkinsBaseConfigurationReconciler{
-			logger: log.Log,
-			Configuration: configuration.Configuration{
-				Jenkins: jenkins,
-			},
-		}
+		r := newReconciler(jenkins)
 		pluginsInJenkins := &gojenkins.Plugins{
 			Raw: &gojenkins.PluginResponse{
 				Plugins: []gojenkins.Plugin{
@@ -350,12 +335,7 @@ func TestJenkinsBaseConfigurationReconciler_verifyPlugins(t *testing.T) {
 				},
 			},
 		}
-		r := JenkinsBaseConfigurationReconciler{
-			logger: log.Log,
-			Configuration: configuration.Configuration{
-				Jenkins: jenkins,
-			},
-		}
+		r := newReconciler(jenkins)
 		pluginsInJenkins := &gojenkins.Plugins{
 			Raw: &gojenkins.PluginResponse{
 				Plugins: []gojenkins.Plugin{
@@ -387,12 +367,7 @@ func TestJenkinsBaseConfigurationReconciler_verifyPlugins(t *testing.T) {
 				},
 			},
 		}
-		r := JenkinsBaseConfigurationReconciler{
-			logger: log.Log,
-			Configuration: configuration.Configuration{
-				Jenkins: jenkins,
-			},
-		}
+		r := newReconciler(jenkins)
 		pluginsInJenkins := &gojenkins.Plugins{
 			Raw: &gojenkins.PluginResponse{
 				Plugins: []gojenkins.Plugin{},
@@ -416,12 +391,7 @@ func TestJenkinsBaseConfigurationReconciler_verifyPlugins(t *testing.T) {
 				},
 			},
 		}
-		r := JenkinsBaseConfigurationReconciler{
-			logger: log.Log,
-			Configuration: configuration.Configuration{
-				Jenkins: jenkins,
-			},
-		}
+		r := newReconciler(jenkins)
 		pluginsInJenkins := &gojenkins.Plugins{
 			Raw: &gojenkins.PluginResponse{
 				Plugins: []gojenkins.Plugin{},
@@ -994,3 +964,277 @@ func TestCompareContainerResources(t *testing.T) {
 		assert.False(t, got)
 	})
 }
+
+func TestCompareInitContainers(t *testing.T) {
+	newReconciler := func(initContainers ...corev1.Container) *JenkinsBaseConfigurationReconciler {
+		return New(configuration.Configuration{
+			Jenkins: &v1alpha2.Jenkins{
+				Spec: v1alpha2.JenkinsSpec{Master: v1alpha2.JenkinsMaster{
+					Containers:          []v1alpha2.Container{{Name: resources.JenkinsMasterContainerName}},
+					ExtraInitContainers: initContainers,
+				}},
+			},
+		}, client.JenkinsAPIConnectionSettings{})
+	}
+	vault := corev1.Container{
+		Name:            "vault",
+		Image:           "vault:1.13",
+		ImagePullPolicy: corev1.PullAlways,
+		Command:         []string{"vault", "agent"},
+		Env:             []corev1.EnvVar{{Name: "VAULT_ADDR", Value: "https://vault:8200"}},
+	}
+
+	t.Run("not changed", func(t *testing.T) {
+		r := newReconciler(vault)
+
+		messages, verbose := r.compareInitContainers(resources.NewJenkinsMasterInitContainers(r.Configuration.Jenkins))
+
+		assert.Empty(t, messages)
+		assert.Empty(t, verbose)
+	})
+	t.Run("command, env and resources have changed", func(t *testing.T) {
+		actual := resources.NewJenkinsMasterInitContainers(newReconciler(vault).Configuration.Jenkins)
+		changed := *vault.DeepCopy()
+		changed.Command = []string{"vault", "agent", "-config=/etc/vault/agent.hcl"}
+		changed.Env = []corev1.EnvVar{{Name: "VAULT_ADDR", Value: "https://vault.example.com:8200"}}
+		changed.Resources = resources.NewResourceRequirements("50m", "50Mi", "100m", "100Mi")
+
+		messages, _ := newReconciler(changed).compareInitContainers(actual)
+
+		assert.Equal(t, []string{"Command has changed", "Env has changed", "Resources have changed"}, messages)
+	})
+	t.Run("volume mounts have changed", func(t *testing.T) {
+		actual := resources.NewJenkinsMasterInitContainers(newReconciler(vault).Configuration.Jenkins)
+		changed := *vault.DeepCopy()
+		changed.VolumeMounts = []corev1.VolumeMount{{Name: "vault-config", MountPath: "/etc/vault"}}
+
+		messages, _ := newReconciler(changed).compareInitContainers(actual)
+
+		assert.Equal(t, []string{"Volume mounts have changed"}, messages)
+	})
+	t.Run("init container added", func(t *testing.T) {
+		actual := resources.NewJenkinsMasterInitContainers(newReconciler(vault).Configuration.Jenkins)
+
+		messages, _ := newReconciler(vault, corev1.Container{Name: "permissions", Image: "busybox"}).compareInitContainers(actual)
+
+		assert.Equal(t, []string{"Jenkins amount of init containers has changed"}, messages)
+	})
+	t.Run("init container replaced", func(t *testing.T) {
+		actual := resources.NewJenkinsMasterInitContainers(newReconciler(vault).Configuration.Jenkins)
+		replaced := *vault.DeepCopy()
+		replaced.Name = "secrets"
+
+		messages, _ := newReconciler(replaced).compareInitContainers(actual)
+
+		assert.Equal(t, []string{"Init container 'secrets' not found in pod"}, messages)
+	})
+}
+
+func TestJenkinsBaseConfigurationReconciler_ensureResolvedPluginsConfigMap(t *testing.T) {
+	log.SetupLogger(true)
+	newReconciler := func(k8sClient k8sclient.Client, exportResolvedPlugins bool) *JenkinsBaseConfigurationReconciler {
+		jenkins := &v1alpha2.Jenkins{
+			ObjectMeta: metav1.ObjectMeta{Name: "example", Namespace: defaultNamespace},
+			Spec: v1alpha2.JenkinsSpec{
+				Master: v1alpha2.JenkinsMaster{ExportResolvedPlugins: exportResolvedPlugins},
+			},
+		}
+		return New(configuration.Configuration{Client: k8sClient, Jenkins: jenkins, Scheme: scheme.Scheme}, client.JenkinsAPIConnectionSettings{})
+	}
+
+	t.Run("disabled", func(t *testing.T) {
+		// given
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		jenkinsClient := client.NewMockJenkins(ctrl)
+		k8sClient := fake.NewClientBuilder().Build()
+		r := newReconciler(k8sClient, false)
+
+		// when
+		err := r.ensureResolvedPluginsConfigMap(resources.NewResourceObjectMeta(r.Configuration.Jenkins), jenkinsClient)
+
+		// then
+		assert.NoError(t, err)
+		configMap := &corev1.ConfigMap{}
+		err = k8sClient.Get(context.TODO(), k8sclient.ObjectKey{Name: "jenkins-example-resolved-plugins", Namespace: defaultNamespace}, configMap)
+		assert.True(t, apierrors.IsNotFound(err))
+	})
+	t.Run("writes installed plugins", func(t *testing.T) {
+		// given
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		jenkinsClient := client.NewMockJenkins(ctrl)
+		jenkinsClient.EXPECT().GetPlugins(fetchAllPlugins).Return(&gojenkins.Plugins{
+			Raw: &gojenkins.PluginResponse{
+				Plugins: []gojenkins.Plugin{
+					{ShortName: "workflow-job", Version: "1282.ve6d865025906", Active: true, Enabled: true},
+					{ShortName: "credentials", Version: "1139.veb_9579fca_33b_", Active: true, Enabled: true},
+					{ShortName: "deleted-plugin", Version: "1.0", Active: true, Enabled: true, Deleted: true},
+				},
+			},
+		}, nil)
+		k8sClient := fake.NewClientBuilder().Build()
+		r := newReconciler(k8sClient, true)
+
+		// when
+		err := r.ensureResolvedPluginsConfigMap(resources.NewResourceObjectMeta(r.Configuration.Jenkins), jenkinsClient)
+
+		// then
+		assert.NoError(t, err)
+		configMap := &corev1.ConfigMap{}
+		err = k8sClient.Get(context.TODO(), k8sclient.ObjectKey{Name: "jenkins-example-resolved-plugins", Namespace: defaultNamespace}, configMap)
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]string{
+			resources.ResolvedPluginsConfigMapKey: "credentials:1139.veb_9579fca_33b_\nworkflow-job:1282.ve6d865025906\n",
+		}, configMap.Data)
+	})
+}
+
+func TestJenkinsBaseConfigurationReconciler_pluginsActiveCondition(t *testing.T) {
+	log.SetupLogger(true)
+	assert.NoError(t, v1alpha2.SchemeBuilder.AddToScheme(scheme.Scheme))
+	notifications := make(chan event.Event, 10)
+	newReconciler := func() *JenkinsBaseConfigurationReconciler {
+		jenkins := &v1alpha2.Jenkins{
+			ObjectMeta: metav1.ObjectMeta{Name: "example", Namespace: defaultNamespace},
+			Spec: v1alpha2.JenkinsSpec{
+				Master: v1alpha2.JenkinsMaster{
+					BasePlugins: []v1alpha2.Plugin{{Name: "kubernetes", Version: "3883.v4d70a_a_a_df034"}},
+					Plugins:     []v1alpha2.Plugin{{Name: "git", Version: "5.0.0"}},
+				},
+			},
+		}
+		k8sClient := fake.NewClientBuilder().WithObjects(jenkins).Build()
+		return New(configuration.Configuration{Client: k8sClient, Jenkins: jenkins, Scheme: scheme.Scheme, Notifications: &notifications}, client.JenkinsAPIConnectionSettings{})
+	}
+	getCondition := func(t *testing.T, r *JenkinsBaseConfigurationReconciler) *metav1.Condition {
+		jenkins := &v1alpha2.Jenkins{}
+		assert.NoError(t, r.Client.Get(context.TODO(), k8sclient.ObjectKey{Name: "example", Namespace: defaultNamespace}, jenkins))
+		return meta.FindStatusCondition(jenkins.Status.Conditions, v1alpha2.ConditionPluginsActive)
+	}
+
+	t.Run("all plugins active", func(t *testing.T) {
+		// given
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		jenkinsClient := client.NewMockJenkins(ctrl)
+		jenkinsClient.EXPECT().GetPlugins(fetchAllPlugins).Return(&gojenkins.Plugins{
+			Raw: &gojenkins.PluginResponse{
+				Plugins: []gojenkins.Plugin{
+					{ShortName: "kubernetes", Version: "3883.v4d70a_a_a_df034", Active: true, Enabled: true},
+					{ShortName: "git", Version: "5.0.0", Active: true, Enabled: true},
+				},
+			},
+		}, nil)
+		r := newReconciler()
+
+		// when
+		_, err := r.verifyPlugins(jenkinsClient)
+
+		// then
+		assert.NoError(t, err)
+		condition := getCondition(t, r)
+		if assert.NotNil(t, condition) {
+			assert.Equal(t, metav1.ConditionTrue, condition.Status)
+			assert.Equal(t, "AllPluginsActive", condition.Reason)
+		}
+	})
+	t.Run("plugins waiting for restart or missing", func(t *testing.T) {
+		// given
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		jenkinsClient := client.NewMockJenkins(ctrl)
+		jenkinsClient.EXPECT().GetPlugins(fetchAllPlugins).Return(&gojenkins.Plugins{
+			Raw: &gojenkins.PluginResponse{
+				Plugins: []gojenkins.Plugin{
+					{ShortName: "kubernetes", Version: "3883.v4d70a_a_a_df034", Active: false, Enabled: true},
+				},
+			},
+		}, nil)
+		r := newReconciler()
+
+		// when
+		_, err := r.verifyPlugins(jenkinsClient)
+
+		// then
+		assert.NoError(t, err)
+		condition := getCondition(t, r)
+		if assert.NotNil(t, condition) {
+			assert.Equal(t, metav1.ConditionFalse, condition.Status)
+			assert.Equal(t, "PluginsNotActive", condition.Reason)
+			assert.Equal(t, "Plugins not active: kubernetes, git, check the plugin installation logs of the 'jenkins-master' container", condition.Message)
+		}
+		if assert.Len(t, notifications, 1) {
+			notification := <-notifications
+			assert.Equal(t, v1alpha2.NotificationLevelWarning, notification.Level)
+			assert.Equal(t, []string{"Plugins not active: kubernetes, git, check the plugin installation logs of the 'jenkins-master' container"}, notification.Reason.Short())
+		}
+	})
+	t.Run("plugins still not active", func(t *testing.T) {
+		// given
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		jenkinsClient := client.NewMockJenkins(ctrl)
+		jenkinsClient.EXPECT().GetPlugins(fetchAllPlugins).Return(&gojenkins.Plugins{
+			Raw: &gojenkins.PluginResponse{Plugins: []gojenkins.Plugin{}},
+		}, nil)
+		r := newReconciler()
+		meta.SetStatusCondition(&r.Configuration.Jenkins.Status.Conditions, metav1.Condition{
+			Type:    v1alpha2.ConditionPluginsActive,
+			Status:  metav1.ConditionFalse,
+			Reason:  "PluginsNotActive",
+			Message: "Plugins not active: kubernetes, check the plugin installation logs of the 'jenkins-master' container",
+		})
+
+		// when
+		_, err := r.verifyPlugins(jenkinsClient)
+
+		// then
+		assert.NoError(t, err)
+		assert.Len(t, notifications, 0)
+	})
+}
+
+func TestGetPluginInstallLogsHint(t *testing.T) {
+	t.Run("container mode", func(t *testing.T) {
+		jenkins := &v1alpha2.Jenkins{ObjectMeta: metav1.ObjectMeta{Name: "example"}}
+
+		assert.Equal(t, "check the plugin installation logs of the 'jenkins-master' container", getPluginInstallLogsHint(jenkins))
+	})
+	t.Run("job mode", func(t *testing.T) {
+		jenkins := &v1alpha2.Jenkins{ObjectMeta: metav1.ObjectMeta{Name: "example"}}
+		jenkins.Spec.Master.PluginInstallMode = v1alpha2.PluginInstallModeJob
+
+		assert.Equal(t, "check the logs of the plugin installation Job 'jenkins-operator-plugins-example'", getPluginInstallLogsHint(jenkins))
+	})
+}
+
+func TestCreateOperatorCredentialsSecret(t *testing.T) {
+	t.Run("API token is added to existing secret", func(t *testing.T) {
+		// given
+		assert.NoError(t, v1alpha2.SchemeBuilder.AddToScheme(scheme.Scheme))
+		jenkins := &v1alpha2.Jenkins{ObjectMeta: metav1.ObjectMeta{Name: "credentials", Namespace: defaultNamespace}}
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: resources.GetOperatorCredentialsSecretName(jenkins), Namespace: defaultNamespace},
+			Data: map[string][]byte{
+				resources.OperatorCredentialsSecretUserNameKey: []byte("jenkins-operator"),
+				resources.OperatorCredentialsSecretPasswordKey: []byte("password"),
+			},
+		}
+		reconciler := New(configuration.Configuration{
+			Client:  fake.NewClientBuilder().WithObjects(secret).Build(),
+			Scheme:  scheme.Scheme,
+			Jenkins: jenkins,
+		}, client.JenkinsAPIConnectionSettings{})
+
+		// when
+		err := reconciler.createOperatorCredentialsSecret(resources.NewResourceObjectMeta(jenkins))
+
+		// then
+		assert.NoError(t, err)
+		got := &corev1.Secret{}
+		assert.NoError(t, reconciler.Client.Get(context.TODO(), k8sclient.ObjectKeyFromObject(secret), got))
+		assert.Equal(t, "password", string(got.Data[resources.OperatorCredentialsSecretPasswordKey]))
+		assert.Regexp(t, "^11[0-9a-f]{32}$", string(got.Data[resources.OperatorCredentialsSecretAPITokenKey]))
+	})
+}
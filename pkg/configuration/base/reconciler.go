@@ -16,6 +16,7 @@ import (
 	"github.com/jenkinsci/kubernetes-operator/pkg/constants"
 	"github.com/jenkinsci/kubernetes-operator/pkg/groovy"
 	"github.com/jenkinsci/kubernetes-operator/pkg/log"
+	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/event"
 	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/reason"
 
 	"github.com/go-logr/logr"
@@ -43,7 +44,7 @@ type JenkinsBaseConfigurationReconciler struct {
 func New(config configuration.Configuration, jenkinsAPIConnectionSettings jenkinsclient.JenkinsAPIConnectionSettings) *JenkinsBaseConfigurationReconciler {
 	return &JenkinsBaseConfigurationReconciler{
 		Configuration:                config,
-		logger:                       log.Log.WithValues("cr", config.Jenkins.Name),
+		logger:                       config.GetLogger().WithValues("cr", config.Jenkins.Name),
 		jenkinsAPIConnectionSettings: jenkinsAPIConnectionSettings,
 	}
 }
@@ -59,6 +60,38 @@ func (r *JenkinsBaseConfigurationReconciler) Reconcile() (reconcile.Result, jenk
 	}
 	r.logger.V(log.VDebug).Info("Kubernetes resources are present")
 
+	if err := r.reconcileReferencesCondition(); err != nil {
+		return reconcile.Result{}, nil, err
+	}
+
+	result, err := r.ensureDependencies()
+	if err != nil {
+		return reconcile.Result{}, nil, err
+	}
+	if result.Requeue {
+		return result, nil, nil
+	}
+	r.logger.V(log.VDebug).Info("Dependencies are ready")
+
+	result, err = r.ensurePluginsReinstall()
+	if err != nil {
+		return reconcile.Result{}, nil, err
+	}
+	if result.Requeue {
+		return result, nil, nil
+	}
+
+	if resources.IsPluginInstallJobEnabled(r.Configuration.Jenkins) {
+		result, err := r.ensurePluginInstallJob(metaObject)
+		if err != nil {
+			return reconcile.Result{}, nil, err
+		}
+		if result.Requeue {
+			return result, nil, nil
+		}
+		r.logger.V(log.VDebug).Info("Plugin installation Job has completed")
+	}
+
 	if useDeploymentForJenkinsMaster(r.Configuration.Jenkins) {
 		result, err := r.ensureJenkinsDeployment(metaObject)
 		if err != nil {
@@ -72,7 +105,7 @@ func (r *JenkinsBaseConfigurationReconciler) Reconcile() (reconcile.Result, jenk
 		return result, nil, err
 	}
 
-	result, err := r.ensureJenkinsMasterPod(metaObject)
+	result, err = r.ensureJenkinsMasterPod(metaObject)
 	if err != nil {
 		return reconcile.Result{}, nil, err
 	}
@@ -120,6 +153,10 @@ func (r *JenkinsBaseConfigurationReconciler) Reconcile() (reconcile.Result, jenk
 		return reconcile.Result{Requeue: true}, nil, r.Configuration.RestartJenkinsMasterPod(restartReason)
 	}
 
+	if err := r.ensureResolvedPluginsConfigMap(metaObject, jenkinsClient); err != nil {
+		return reconcile.Result{}, nil, err
+	}
+
 	result, err = r.ensureBaseConfiguration(jenkinsClient)
 
 	return result, jenkinsClient, err
@@ -165,6 +202,11 @@ func (r *JenkinsBaseConfigurationReconciler) ensureResourcesRequiredForJenkinsPo
 	}
 	r.logger.V(log.VDebug).Info("ConfigurationAsCode Secret and ConfigMap added watched labels")
 
+	if err := r.ensureJenkinsHomePersistentVolumeClaim(metaObject); err != nil {
+		return err
+	}
+	r.logger.V(log.VDebug).Info("Jenkins home persistent volume claim is present")
+
 	if err := r.createRBAC(metaObject); err != nil {
 		return err
 	}
@@ -186,6 +228,11 @@ func (r *JenkinsBaseConfigurationReconciler) ensureResourcesRequiredForJenkinsPo
 	}
 	r.logger.V(log.VDebug).Info("Jenkins slave Service is present")
 
+	if err := r.ensureMetricsService(metaObject); err != nil {
+		return err
+	}
+	r.logger.V(log.VDebug).Info("Jenkins metrics Service is reconciled")
+
 	if resources.IsRouteAPIAvailable(&r.ClientSet) {
 		r.logger.V(log.VDebug).Info("Route API is available. Now creating route.")
 		if err := r.createRoute(metaObject, httpServiceName, r.Configuration.Jenkins); err != nil {
@@ -197,19 +244,44 @@ func (r *JenkinsBaseConfigurationReconciler) ensureResourcesRequiredForJenkinsPo
 	return nil
 }
 
+// notifyResourceRecreated informs that the resource required by Jenkins has been removed and the operator created it again,
+// nothing is sent for the resources which haven't been found before, e.g. when they are created for the first time
+func (r *JenkinsBaseConfigurationReconciler) notifyResourceRecreated(kind, name string) {
+	if !r.ForgetResource(kind, name) {
+		return
+	}
+
+	message := fmt.Sprintf("%s '%s' has been removed, recreating it", kind, name)
+	r.logger.Info(message)
+	*r.Notifications <- event.Event{
+		Jenkins: *r.Configuration.Jenkins,
+		Phase:   event.PhaseBase,
+		Level:   v1alpha2.NotificationLevelWarning,
+		Reason:  reason.NewResourceRecreated(reason.KubernetesSource, []string{message}),
+	}
+}
+
 func (r *JenkinsBaseConfigurationReconciler) createOperatorCredentialsSecret(meta metav1.ObjectMeta) error {
 	found := &corev1.Secret{}
-	err := r.Configuration.Client.Get(context.TODO(), types.NamespacedName{Name: resources.GetOperatorCredentialsSecretName(r.Configuration.Jenkins), Namespace: r.Configuration.Jenkins.ObjectMeta.Namespace}, found)
+	name := resources.GetOperatorCredentialsSecretName(r.Configuration.Jenkins)
+	err := r.Configuration.Client.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: r.Configuration.Jenkins.ObjectMeta.Namespace}, found)
 
 	if err != nil && apierrors.IsNotFound(err) {
+		r.notifyResourceRecreated("Secret", name)
 		return stackerr.WithStack(r.CreateResource(resources.NewOperatorCredentialsSecret(meta, r.Configuration.Jenkins)))
 	} else if err != nil && !apierrors.IsNotFound(err) {
 		return stackerr.WithStack(err)
 	}
+	r.ObserveResource("Secret", name)
 
 	if found.Data[resources.OperatorCredentialsSecretUserNameKey] != nil &&
 		found.Data[resources.OperatorCredentialsSecretPasswordKey] != nil {
-		return nil
+		if found.Data[resources.OperatorCredentialsSecretAPITokenKey] != nil {
+			return nil
+		}
+		// the secrets created by the previous operator versions don't have the fixed API token
+		found.Data[resources.OperatorCredentialsSecretAPITokenKey] = []byte(resources.NewOperatorAPIToken())
+		return stackerr.WithStack(r.UpdateResource(found))
 	}
 	return stackerr.WithStack(r.UpdateResource(resources.NewOperatorCredentialsSecret(meta, r.Configuration.Jenkins)))
 }
@@ -410,7 +482,7 @@ func (r *JenkinsBaseConfigurationReconciler) ensureBaseConfiguration(jenkinsClie
 			Configurations: []v1alpha2.ConfigMapRef{{Name: resources.GetBaseConfigurationConfigMapName(r.Configuration.Jenkins)}},
 		},
 	}
-	groovyClient := groovy.New(jenkinsClient, r.Client, r.Configuration.Jenkins, "base-groovy", customization.Customization)
+	groovyClient := groovy.New(jenkinsClient, r.Client, r.Configuration.Jenkins, "base-groovy", customization.Customization, r.logger)
 	requeue, err := groovyClient.Ensure(func(name string) bool {
 		return strings.HasSuffix(name, ".groovy")
 	}, func(groovyScript string) string {
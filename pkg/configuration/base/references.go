@@ -0,0 +1,255 @@
+package base
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/pkg/log"
+	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/event"
+	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/reason"
+
+	stackerr "github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	secretKind    = "Secret"
+	configMapKind = "ConfigMap"
+)
+
+// objectReference is a Secret or ConfigMap referenced by the path of the Jenkins CR field
+type objectReference struct {
+	kind string
+	name string
+	path string
+}
+
+type objectReferences []objectReference
+
+func (o *objectReferences) add(kind, name, path string) {
+	if len(name) > 0 {
+		*o = append(*o, objectReference{kind: kind, name: name, path: path})
+	}
+}
+
+func isOptional(optional *bool) bool {
+	return optional != nil && *optional
+}
+
+func (o *objectReferences) addVolumes(volumes []corev1.Volume, path string) {
+	for i, volume := range volumes {
+		volumePath := fmt.Sprintf("%s[%d]", path, i)
+		if volume.Secret != nil && !isOptional(volume.Secret.Optional) {
+			o.add(secretKind, volume.Secret.SecretName, volumePath+".secret")
+		}
+		if volume.ConfigMap != nil && !isOptional(volume.ConfigMap.Optional) {
+			o.add(configMapKind, volume.ConfigMap.Name, volumePath+".configMap")
+		}
+		if volume.Projected == nil {
+			continue
+		}
+		for j, source := range volume.Projected.Sources {
+			sourcePath := fmt.Sprintf("%s.projected.sources[%d]", volumePath, j)
+			if source.Secret != nil && !isOptional(source.Secret.Optional) {
+				o.add(secretKind, source.Secret.Name, sourcePath+".secret")
+			}
+			if source.ConfigMap != nil && !isOptional(source.ConfigMap.Optional) {
+				o.add(configMapKind, source.ConfigMap.Name, sourcePath+".configMap")
+			}
+		}
+	}
+}
+
+func (o *objectReferences) addContainers(containers []v1alpha2.Container, path string) {
+	for i, container := range containers {
+		containerPath := fmt.Sprintf("%s[%d]", path, i)
+		for j, envFrom := range container.EnvFrom {
+			envFromPath := fmt.Sprintf("%s.envFrom[%d]", containerPath, j)
+			if envFrom.SecretRef != nil && !isOptional(envFrom.SecretRef.Optional) {
+				o.add(secretKind, envFrom.SecretRef.Name, envFromPath+".secretRef")
+			}
+			if envFrom.ConfigMapRef != nil && !isOptional(envFrom.ConfigMapRef.Optional) {
+				o.add(configMapKind, envFrom.ConfigMapRef.Name, envFromPath+".configMapRef")
+			}
+		}
+		for j, env := range container.Env {
+			if env.ValueFrom == nil {
+				continue
+			}
+			envPath := fmt.Sprintf("%s.env[%d].valueFrom", containerPath, j)
+			if ref := env.ValueFrom.SecretKeyRef; ref != nil && !isOptional(ref.Optional) {
+				o.add(secretKind, ref.Name, envPath+".secretKeyRef")
+			}
+			if ref := env.ValueFrom.ConfigMapKeyRef; ref != nil && !isOptional(ref.Optional) {
+				o.add(configMapKind, ref.Name, envPath+".configMapKeyRef")
+			}
+		}
+	}
+}
+
+// getReferencedObjects lists the Secrets and ConfigMaps referenced by the Jenkins CR, the optional references
+// of the volumes and the environment variables are skipped
+func getReferencedObjects(jenkins *v1alpha2.Jenkins) objectReferences {
+	var references objectReferences
+	master := jenkins.Spec.Master
+
+	for i, imagePullSecret := range master.ImagePullSecrets {
+		references.add(secretKind, imagePullSecret.Name, fmt.Sprintf("spec.master.imagePullSecrets[%d]", i))
+	}
+	if master.CACertsSecretRef != nil {
+		references.add(secretKind, master.CACertsSecretRef.Name, "spec.master.caCertsSecretRef")
+	}
+	if master.MavenSettingsSecretRef != nil {
+		references.add(secretKind, master.MavenSettingsSecretRef.Name, "spec.master.mavenSettingsSecretRef")
+	}
+	if master.WebhookTokenSecretRef != nil {
+		references.add(secretKind, master.WebhookTokenSecretRef.Name, "spec.master.webhookTokenSecretRef")
+	}
+	if master.PluginProxy != nil && master.PluginProxy.CredentialsSecretRef != nil {
+		references.add(secretKind, master.PluginProxy.CredentialsSecretRef.Name, "spec.master.pluginProxy.credentialsSecretRef")
+	}
+	if master.JenkinsProxy != nil && master.JenkinsProxy.CredentialsSecretRef != nil {
+		references.add(secretKind, master.JenkinsProxy.CredentialsSecretRef.Name, "spec.master.jenkinsProxy.credentialsSecretRef")
+	}
+	if master.LDAP != nil && master.LDAP.ManagerDNSecretRef != nil {
+		references.add(secretKind, master.LDAP.ManagerDNSecretRef.Name, "spec.master.ldap.managerDNSecretRef")
+	}
+	if master.OIDC != nil {
+		references.add(secretKind, master.OIDC.ClientSecretRef.Name, "spec.master.oidc.clientSecretRef")
+	}
+	if master.Agent != nil {
+		if master.Agent.JNLPSecretRef != nil {
+			references.add(secretKind, master.Agent.JNLPSecretRef.Name, "spec.master.agent.jnlpSecretRef")
+		}
+		for i, podTemplate := range master.Agent.PodTemplates {
+			for j, imagePullSecret := range podTemplate.ImagePullSecrets {
+				references.add(secretKind, imagePullSecret.Name, fmt.Sprintf("spec.master.agent.podTemplates[%d].imagePullSecrets[%d]", i, j))
+			}
+		}
+	}
+	references.addVolumes(master.Volumes, "spec.master.volumes")
+	references.addContainers(master.Containers, "spec.master.containers")
+
+	// the customizations are listed in a slice, the iteration over a map would make the order of the paths random
+	for _, customization := range []struct {
+		path string
+		v1alpha2.Customization
+	}{
+		{path: "spec.groovyScripts", Customization: jenkins.Spec.GroovyScripts.Customization},
+		{path: "spec.configurationAsCode", Customization: jenkins.Spec.ConfigurationAsCode.Customization},
+	} {
+		references.add(secretKind, customization.Secret.Name, customization.path+".secret")
+		for i, configMap := range customization.Configurations {
+			references.add(configMapKind, configMap.Name, fmt.Sprintf("%s.configurations[%d]", customization.path, i))
+		}
+	}
+
+	for i, credential := range jenkins.Spec.Credentials {
+		references.add(secretKind, credential.SecretRef.Name, fmt.Sprintf("spec.credentials[%d].secretRef", i))
+	}
+
+	for i, notification := range jenkins.Spec.Notifications {
+		path := fmt.Sprintf("spec.notifications[%d]", i)
+		switch {
+		case notification.Slack != nil:
+			references.add(secretKind, notification.Slack.WebHookURLSecretKeySelector.Name, path+".slack.webHookURLSecretKeySelector")
+		case notification.Teams != nil:
+			references.add(secretKind, notification.Teams.WebHookURLSecretKeySelector.Name, path+".teams.webHookURLSecretKeySelector")
+		case notification.Mailgun != nil:
+			references.add(secretKind, notification.Mailgun.APIKeySecretKeySelector.Name, path+".mailgun.apiKeySecretKeySelector")
+		case notification.SMTP != nil:
+			references.add(secretKind, notification.SMTP.UsernameSecretKeySelector.Name, path+".smtp.usernameSecretKeySelector")
+			references.add(secretKind, notification.SMTP.PasswordSecretKeySelector.Name, path+".smtp.passwordSecretKeySelector")
+		case notification.Webhook != nil && notification.Webhook.AuthorizationHeaderSecretKeySelector != nil:
+			references.add(secretKind, notification.Webhook.AuthorizationHeaderSecretKeySelector.Name, path+".webhook.authorizationHeaderSecretKeySelector")
+		}
+	}
+
+	return references
+}
+
+// findMissingReferences returns a message for every referenced Secret or ConfigMap which doesn't exist in the namespace,
+// the paths of the fields referencing the same object are listed in one message
+func findMissingReferences(k8sClient client.Client, namespace string, references objectReferences) ([]string, error) {
+	var missing []objectReference
+	checked := map[objectReference]bool{}
+	isMissing := map[objectReference]bool{}
+	paths := map[objectReference][]string{}
+	for _, reference := range references {
+		key := objectReference{kind: reference.kind, name: reference.name}
+		if !checked[key] {
+			checked[key] = true
+			var object client.Object = &corev1.Secret{}
+			if reference.kind == configMapKind {
+				object = &corev1.ConfigMap{}
+			}
+			err := k8sClient.Get(context.TODO(), types.NamespacedName{Name: reference.name, Namespace: namespace}, object)
+			if apierrors.IsNotFound(err) {
+				missing = append(missing, key)
+				isMissing[key] = true
+			} else if err != nil {
+				return nil, stackerr.WithStack(err)
+			}
+		}
+		if isMissing[key] {
+			paths[key] = append(paths[key], reference.path)
+		}
+	}
+
+	var messages []string
+	for _, reference := range missing {
+		messages = append(messages, fmt.Sprintf("%s '%s' referenced by %s not found",
+			reference.kind, reference.name, strings.Join(paths[reference], ", ")))
+	}
+	return messages, nil
+}
+
+// reconcileReferencesCondition reports the referenced Secrets and ConfigMaps which don't exist with
+// the ReferencesMissing condition and a warning notification, instead of the Jenkins master pod failing at start
+func (r *JenkinsBaseConfigurationReconciler) reconcileReferencesCondition() error {
+	jenkins := r.Configuration.Jenkins
+	messages, err := findMissingReferences(r.Client, jenkins.Namespace, getReferencedObjects(jenkins))
+	if err != nil {
+		return err
+	}
+
+	condition := metav1.Condition{
+		Type:               v1alpha2.ConditionReferencesMissing,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: jenkins.Generation,
+		Reason:             "AllReferencesFound",
+		Message:            "All referenced Secrets and ConfigMaps exist",
+	}
+	if len(messages) > 0 {
+		for _, message := range messages {
+			r.logger.V(log.VWarn).Info(message)
+		}
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = "ReferencesMissing"
+		condition.Message = strings.Join(messages, "; ")
+	} else if meta.FindStatusCondition(jenkins.Status.Conditions, condition.Type) == nil {
+		return nil // references have never been missing
+	}
+
+	current := meta.FindStatusCondition(jenkins.Status.Conditions, condition.Type)
+	if current != nil && current.Status == condition.Status && current.Message == condition.Message {
+		return nil
+	}
+	if condition.Status == metav1.ConditionTrue {
+		*r.Notifications <- event.Event{
+			Jenkins: *jenkins,
+			Phase:   event.PhaseBase,
+			Level:   v1alpha2.NotificationLevelWarning,
+			Reason:  reason.NewReferencesMissing(reason.HumanSource, messages),
+		}
+	}
+	meta.SetStatusCondition(&jenkins.Status.Conditions, condition)
+	return stackerr.WithStack(r.Client.Status().Update(context.TODO(), jenkins))
+}
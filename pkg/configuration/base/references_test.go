@@ -0,0 +1,160 @@
+package base
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/pkg/client"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration"
+	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/event"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func newReferencesTestJenkins() *v1alpha2.Jenkins {
+	optional := true
+	return &v1alpha2.Jenkins{
+		ObjectMeta: metav1.ObjectMeta{Name: "example", Namespace: defaultNamespace},
+		Spec: v1alpha2.JenkinsSpec{
+			Master: v1alpha2.JenkinsMaster{
+				ImagePullSecrets: []corev1.LocalObjectReference{{Name: "registry"}},
+				CACertsSecretRef: &corev1.LocalObjectReference{Name: "ca-certs"},
+				Containers: []v1alpha2.Container{{
+					Name: "jenkins-master",
+					Env: []corev1.EnvVar{{
+						Name: "TOKEN",
+						ValueFrom: &corev1.EnvVarSource{SecretKeyRef: &corev1.SecretKeySelector{
+							LocalObjectReference: corev1.LocalObjectReference{Name: "registry"}, Key: "token",
+						}},
+					}},
+					EnvFrom: []corev1.EnvFromSource{{
+						ConfigMapRef: &corev1.ConfigMapEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "optional"}, Optional: &optional},
+					}},
+				}},
+				Volumes: []corev1.Volume{{
+					Name:         "settings",
+					VolumeSource: corev1.VolumeSource{ConfigMap: &corev1.ConfigMapVolumeSource{LocalObjectReference: corev1.LocalObjectReference{Name: "settings"}}},
+				}},
+			},
+			ConfigurationAsCode: v1alpha2.ConfigurationAsCode{Customization: v1alpha2.Customization{
+				Secret:         v1alpha2.SecretRef{Name: "casc-secrets"},
+				Configurations: []v1alpha2.ConfigMapRef{{Name: "casc"}},
+			}},
+		},
+	}
+}
+
+func TestGetReferencedObjects(t *testing.T) {
+	got := getReferencedObjects(newReferencesTestJenkins())
+
+	assert.ElementsMatch(t, objectReferences{
+		{kind: secretKind, name: "registry", path: "spec.master.imagePullSecrets[0]"},
+		{kind: secretKind, name: "ca-certs", path: "spec.master.caCertsSecretRef"},
+		{kind: configMapKind, name: "settings", path: "spec.master.volumes[0].configMap"},
+		{kind: secretKind, name: "registry", path: "spec.master.containers[0].env[0].valueFrom.secretKeyRef"},
+		{kind: secretKind, name: "casc-secrets", path: "spec.configurationAsCode.secret"},
+		{kind: configMapKind, name: "casc", path: "spec.configurationAsCode.configurations[0]"},
+	}, got)
+}
+
+func TestFindMissingReferencesOrder(t *testing.T) {
+	jenkins := newReferencesTestJenkins()
+	jenkins.Spec.GroovyScripts.Customization = v1alpha2.Customization{Secret: v1alpha2.SecretRef{Name: "casc-secrets"}}
+	jenkins.Spec.ConfigurationAsCode.Configurations = nil
+	registry := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "registry", Namespace: defaultNamespace}}
+	caCerts := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "ca-certs", Namespace: defaultNamespace}}
+	settings := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "settings", Namespace: defaultNamespace}}
+	k8sClient := fake.NewClientBuilder().WithObjects(registry, caCerts, settings).Build()
+
+	for i := 0; i < 10; i++ {
+		got, err := findMissingReferences(k8sClient, defaultNamespace, getReferencedObjects(jenkins))
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"Secret 'casc-secrets' referenced by spec.groovyScripts.secret, spec.configurationAsCode.secret not found"}, got)
+	}
+}
+
+func TestJenkinsBaseConfigurationReconciler_reconcileReferencesCondition(t *testing.T) {
+	require.NoError(t, v1alpha2.SchemeBuilder.AddToScheme(scheme.Scheme))
+	getJenkins := func(t *testing.T, reconciler *JenkinsBaseConfigurationReconciler) *v1alpha2.Jenkins {
+		jenkins := &v1alpha2.Jenkins{}
+		err := reconciler.Client.Get(context.TODO(), types.NamespacedName{Name: "example", Namespace: defaultNamespace}, jenkins)
+		require.NoError(t, err)
+		return jenkins
+	}
+	newReconciler := func(jenkins *v1alpha2.Jenkins, notifications *chan event.Event, objects ...k8sclient.Object) *JenkinsBaseConfigurationReconciler {
+		return New(configuration.Configuration{
+			Client:        fake.NewClientBuilder().WithObjects(append(objects, jenkins)...).Build(),
+			Jenkins:       jenkins,
+			Notifications: notifications,
+		}, client.JenkinsAPIConnectionSettings{})
+	}
+	secret := func(name string) *corev1.Secret {
+		return &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: defaultNamespace}}
+	}
+	configMap := func(name string) *corev1.ConfigMap {
+		return &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: defaultNamespace}}
+	}
+
+	t.Run("references missing", func(t *testing.T) {
+		// given
+		notifications := make(chan event.Event, 10)
+		reconciler := newReconciler(newReferencesTestJenkins(), &notifications, secret("ca-certs"), configMap("casc"))
+
+		// when
+		err := reconciler.reconcileReferencesCondition()
+
+		// then
+		require.NoError(t, err)
+		condition := meta.FindStatusCondition(getJenkins(t, reconciler).Status.Conditions, v1alpha2.ConditionReferencesMissing)
+		require.NotNil(t, condition)
+		assert.Equal(t, metav1.ConditionTrue, condition.Status)
+		assert.Equal(t, "Secret 'registry' referenced by spec.master.imagePullSecrets[0], spec.master.containers[0].env[0].valueFrom.secretKeyRef not found; "+
+			"ConfigMap 'settings' referenced by spec.master.volumes[0].configMap not found; "+
+			"Secret 'casc-secrets' referenced by spec.configurationAsCode.secret not found", condition.Message)
+		if assert.Len(t, notifications, 1) {
+			notification := <-notifications
+			assert.Equal(t, v1alpha2.NotificationLevelWarning, notification.Level)
+			assert.Len(t, notification.Reason.Short(), 3)
+		}
+	})
+	t.Run("all references found", func(t *testing.T) {
+		// given
+		notifications := make(chan event.Event, 10)
+		reconciler := newReconciler(newReferencesTestJenkins(), &notifications,
+			secret("registry"), secret("ca-certs"), secret("casc-secrets"), configMap("settings"), configMap("casc"))
+
+		// when
+		err := reconciler.reconcileReferencesCondition()
+
+		// then
+		require.NoError(t, err)
+		assert.Empty(t, getJenkins(t, reconciler).Status.Conditions)
+		assert.Len(t, notifications, 0)
+	})
+	t.Run("references no longer missing", func(t *testing.T) {
+		// given
+		notifications := make(chan event.Event, 10)
+		jenkins := newReferencesTestJenkins()
+		jenkins.Status.Conditions = []metav1.Condition{{Type: v1alpha2.ConditionReferencesMissing, Status: metav1.ConditionTrue}}
+		reconciler := newReconciler(jenkins, &notifications,
+			secret("registry"), secret("ca-certs"), secret("casc-secrets"), configMap("settings"), configMap("casc"))
+
+		// when
+		err := reconciler.reconcileReferencesCondition()
+
+		// then
+		require.NoError(t, err)
+		assert.True(t, meta.IsStatusConditionFalse(getJenkins(t, reconciler).Status.Conditions, v1alpha2.ConditionReferencesMissing))
+		assert.Len(t, notifications, 0)
+	})
+}
@@ -0,0 +1,113 @@
+package base
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/pkg/log"
+
+	stackerr "github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// getJenkinsMasterResources sums up the resources of all Jenkins master containers, the sum is keyed
+// the same way as the resources in the ResourceQuota, e.g. requests.cpu or limits.memory
+func getJenkinsMasterResources(jenkins *v1alpha2.Jenkins) corev1.ResourceList {
+	total := corev1.ResourceList{corev1.ResourcePods: resource.MustParse("1")}
+	add := func(name corev1.ResourceName, quantity resource.Quantity) {
+		sum := total[name]
+		sum.Add(quantity)
+		total[name] = sum
+	}
+	for _, container := range jenkins.Spec.Master.Containers {
+		for name, quantity := range container.Resources.Requests {
+			add(corev1.ResourceName("requests."+string(name)), quantity)
+			if name == corev1.ResourceCPU || name == corev1.ResourceMemory {
+				add(name, quantity)
+			}
+		}
+		for name, quantity := range container.Resources.Limits {
+			add(corev1.ResourceName("limits."+string(name)), quantity)
+		}
+	}
+	return total
+}
+
+// CheckResourceQuota verifies if the requested resources fit into the remaining ResourceQuotas of the namespace,
+// returns messages describing every exceeded quota
+func CheckResourceQuota(k8sClient client.Client, namespace string, requested corev1.ResourceList) ([]string, error) {
+	quotas := &corev1.ResourceQuotaList{}
+	if err := k8sClient.List(context.TODO(), quotas, client.InNamespace(namespace)); err != nil {
+		return nil, stackerr.WithStack(err)
+	}
+
+	var messages []string
+	for _, quota := range quotas.Items {
+		hard := quota.Status.Hard
+		if len(hard) == 0 {
+			hard = quota.Spec.Hard
+		}
+		var names []string
+		for name := range hard {
+			names = append(names, string(name))
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			quantity, found := requested[corev1.ResourceName(name)]
+			if !found {
+				continue
+			}
+			remaining := hard[corev1.ResourceName(name)].DeepCopy()
+			remaining.Sub(quota.Status.Used[corev1.ResourceName(name)])
+			if quantity.Cmp(remaining) > 0 {
+				messages = append(messages, fmt.Sprintf("ResourceQuota '%s' %s: requested %s, remaining %s",
+					quota.Name, name, quantity.String(), remaining.String()))
+			}
+		}
+	}
+
+	return messages, nil
+}
+
+// reconcileResourceQuotaCondition warns with the ResourceQuotaExceeded condition when the Jenkins master resources
+// exceed the remaining quota of the namespace
+func (r *JenkinsBaseConfigurationReconciler) reconcileResourceQuotaCondition() error {
+	jenkins := r.Configuration.Jenkins
+	messages, err := CheckResourceQuota(r.Client, jenkins.Namespace, getJenkinsMasterResources(jenkins))
+	if err != nil {
+		return err
+	}
+
+	condition := metav1.Condition{
+		Type:               v1alpha2.ConditionResourceQuotaExceeded,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: jenkins.Generation,
+		Reason:             "WithinResourceQuota",
+		Message:            "Jenkins master resources fit into the namespace resource quota",
+	}
+	if len(messages) > 0 {
+		for _, message := range messages {
+			r.logger.V(log.VWarn).Info(fmt.Sprintf("Jenkins master resources exceed the namespace resource quota: %s", message))
+		}
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = "ResourceQuotaExceeded"
+		condition.Message = strings.Join(messages, "; ")
+	} else if meta.FindStatusCondition(jenkins.Status.Conditions, condition.Type) == nil {
+		return nil // quota has never been exceeded
+	}
+
+	current := meta.FindStatusCondition(jenkins.Status.Conditions, condition.Type)
+	if current != nil && current.Status == condition.Status && current.Message == condition.Message {
+		return nil
+	}
+	meta.SetStatusCondition(&jenkins.Status.Conditions, condition)
+	return stackerr.WithStack(r.Client.Status().Update(context.TODO(), jenkins))
+}
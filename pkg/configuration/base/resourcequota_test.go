@@ -0,0 +1,174 @@
+package base
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/pkg/client"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func newResourceQuota(hard, used corev1.ResourceList) *corev1.ResourceQuota {
+	return &corev1.ResourceQuota{
+		ObjectMeta: metav1.ObjectMeta{Name: "quota", Namespace: defaultNamespace},
+		Spec:       corev1.ResourceQuotaSpec{Hard: hard},
+		Status:     corev1.ResourceQuotaStatus{Hard: hard, Used: used},
+	}
+}
+
+func newResourceQuotaTestJenkins() *v1alpha2.Jenkins {
+	return &v1alpha2.Jenkins{
+		ObjectMeta: metav1.ObjectMeta{Name: "example", Namespace: defaultNamespace},
+		Spec: v1alpha2.JenkinsSpec{
+			Master: v1alpha2.JenkinsMaster{
+				Containers: []v1alpha2.Container{
+					{
+						Name: "jenkins-master",
+						Resources: corev1.ResourceRequirements{
+							Requests: corev1.ResourceList{
+								corev1.ResourceCPU:    resource.MustParse("1"),
+								corev1.ResourceMemory: resource.MustParse("1Gi"),
+							},
+							Limits: corev1.ResourceList{
+								corev1.ResourceCPU:    resource.MustParse("2"),
+								corev1.ResourceMemory: resource.MustParse("2Gi"),
+							},
+						},
+					},
+					{
+						Name: "sidecar",
+						Resources: corev1.ResourceRequirements{
+							Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("500m")},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestGetJenkinsMasterResources(t *testing.T) {
+	got := getJenkinsMasterResources(newResourceQuotaTestJenkins())
+
+	assert.Equal(t, "1", got.Pods().String())
+	assert.True(t, resource.MustParse("1500m").Equal(got["requests.cpu"]))
+	assert.True(t, resource.MustParse("1500m").Equal(got[corev1.ResourceCPU]))
+	assert.True(t, resource.MustParse("1Gi").Equal(got["requests.memory"]))
+	assert.True(t, resource.MustParse("2").Equal(got["limits.cpu"]))
+	assert.True(t, resource.MustParse("2Gi").Equal(got["limits.memory"]))
+}
+
+func TestCheckResourceQuota(t *testing.T) {
+	requested := getJenkinsMasterResources(newResourceQuotaTestJenkins())
+
+	t.Run("no resource quota", func(t *testing.T) {
+		got, err := CheckResourceQuota(fake.NewClientBuilder().Build(), defaultNamespace, requested)
+
+		require.NoError(t, err)
+		assert.Empty(t, got)
+	})
+	t.Run("fits into remaining quota", func(t *testing.T) {
+		quota := newResourceQuota(
+			corev1.ResourceList{"requests.cpu": resource.MustParse("4"), "limits.memory": resource.MustParse("8Gi")},
+			corev1.ResourceList{"requests.cpu": resource.MustParse("2"), "limits.memory": resource.MustParse("6Gi")},
+		)
+
+		got, err := CheckResourceQuota(fake.NewClientBuilder().WithObjects(quota).Build(), defaultNamespace, requested)
+
+		require.NoError(t, err)
+		assert.Empty(t, got)
+	})
+	t.Run("exceeds remaining quota", func(t *testing.T) {
+		quota := newResourceQuota(
+			corev1.ResourceList{"requests.cpu": resource.MustParse("4"), "limits.memory": resource.MustParse("8Gi"), corev1.ResourcePods: resource.MustParse("10")},
+			corev1.ResourceList{"requests.cpu": resource.MustParse("3"), "limits.memory": resource.MustParse("7Gi"), corev1.ResourcePods: resource.MustParse("10")},
+		)
+
+		got, err := CheckResourceQuota(fake.NewClientBuilder().WithObjects(quota).Build(), defaultNamespace, requested)
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{
+			"ResourceQuota 'quota' limits.memory: requested 2Gi, remaining 1Gi",
+			"ResourceQuota 'quota' pods: requested 1, remaining 0",
+			"ResourceQuota 'quota' requests.cpu: requested 1500m, remaining 1",
+		}, got)
+	})
+	t.Run("quota in other namespace", func(t *testing.T) {
+		quota := newResourceQuota(corev1.ResourceList{"requests.cpu": resource.MustParse("1")}, nil)
+		quota.Namespace = "other"
+
+		got, err := CheckResourceQuota(fake.NewClientBuilder().WithObjects(quota).Build(), defaultNamespace, requested)
+
+		require.NoError(t, err)
+		assert.Empty(t, got)
+	})
+}
+
+func TestReconcileResourceQuotaCondition(t *testing.T) {
+	require.NoError(t, v1alpha2.SchemeBuilder.AddToScheme(scheme.Scheme))
+	getJenkins := func(t *testing.T, reconciler *JenkinsBaseConfigurationReconciler) *v1alpha2.Jenkins {
+		jenkins := &v1alpha2.Jenkins{}
+		err := reconciler.Client.Get(context.TODO(), types.NamespacedName{Name: "example", Namespace: defaultNamespace}, jenkins)
+		require.NoError(t, err)
+		return jenkins
+	}
+	newReconciler := func(jenkins *v1alpha2.Jenkins, quota *corev1.ResourceQuota) *JenkinsBaseConfigurationReconciler {
+		return New(configuration.Configuration{
+			Client:  fake.NewClientBuilder().WithObjects(jenkins, quota).Build(),
+			Jenkins: jenkins,
+		}, client.JenkinsAPIConnectionSettings{})
+	}
+
+	t.Run("quota exceeded", func(t *testing.T) {
+		// given
+		quota := newResourceQuota(corev1.ResourceList{"requests.memory": resource.MustParse("512Mi")}, nil)
+		reconciler := newReconciler(newResourceQuotaTestJenkins(), quota)
+
+		// when
+		err := reconciler.reconcileResourceQuotaCondition()
+
+		// then
+		require.NoError(t, err)
+		condition := meta.FindStatusCondition(getJenkins(t, reconciler).Status.Conditions, v1alpha2.ConditionResourceQuotaExceeded)
+		require.NotNil(t, condition)
+		assert.Equal(t, metav1.ConditionTrue, condition.Status)
+		assert.Equal(t, "ResourceQuota 'quota' requests.memory: requested 1Gi, remaining 512Mi", condition.Message)
+	})
+	t.Run("quota not exceeded", func(t *testing.T) {
+		// given
+		quota := newResourceQuota(corev1.ResourceList{"requests.memory": resource.MustParse("4Gi")}, nil)
+		reconciler := newReconciler(newResourceQuotaTestJenkins(), quota)
+
+		// when
+		err := reconciler.reconcileResourceQuotaCondition()
+
+		// then
+		require.NoError(t, err)
+		assert.Empty(t, getJenkins(t, reconciler).Status.Conditions)
+	})
+	t.Run("quota no longer exceeded", func(t *testing.T) {
+		// given
+		jenkins := newResourceQuotaTestJenkins()
+		jenkins.Status.Conditions = []metav1.Condition{{Type: v1alpha2.ConditionResourceQuotaExceeded, Status: metav1.ConditionTrue}}
+		quota := newResourceQuota(corev1.ResourceList{"requests.memory": resource.MustParse("4Gi")}, nil)
+		reconciler := newReconciler(jenkins, quota)
+
+		// when
+		err := reconciler.reconcileResourceQuotaCondition()
+
+		// then
+		require.NoError(t, err)
+		assert.True(t, meta.IsStatusConditionFalse(getJenkins(t, reconciler).Status.Conditions, v1alpha2.ConditionResourceQuotaExceeded))
+	})
+}
@@ -0,0 +1,22 @@
+package resources
+
+import (
+	"sort"
+)
+
+// KnownAgentProtocols are the names of the agent protocols supported by Jenkins
+var KnownAgentProtocols = []string{"CLI-connect", "CLI2-connect", "JNLP-connect", "JNLP2-connect", "JNLP3-connect", "JNLP4-connect", "Ping"}
+
+// BuildAgentProtocolsConfiguration builds the agentProtocols section of the Configuration as Code which enables
+// only the given agent protocols
+func BuildAgentProtocolsConfiguration(agentProtocols []string) map[string]interface{} {
+	protocols := make([]string, len(agentProtocols))
+	copy(protocols, agentProtocols)
+	sort.Strings(protocols)
+
+	return map[string]interface{}{
+		"jenkins": map[string]interface{}{
+			"agentProtocols": protocols,
+		},
+	}
+}
@@ -0,0 +1,42 @@
+package resources
+
+import (
+	"testing"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestNewBaseConfigurationConfigMapAgentProtocols(t *testing.T) {
+	newJenkins := func(agentProtocols ...string) *v1alpha2.Jenkins {
+		return &v1alpha2.Jenkins{
+			ObjectMeta: metav1.ObjectMeta{Name: "example", Namespace: "default"},
+			Spec: v1alpha2.JenkinsSpec{
+				Master: v1alpha2.JenkinsMaster{
+					Containers:     []v1alpha2.Container{{Name: JenkinsMasterContainerName}},
+					AgentProtocols: agentProtocols,
+				},
+			},
+		}
+	}
+
+	t.Run("agent protocols not set", func(t *testing.T) {
+		configMap, err := NewBaseConfigurationConfigMap(metav1.ObjectMeta{}, newJenkins(), "cluster.local")
+
+		require.NoError(t, err)
+		assert.NotContains(t, configMap.Data, configurationAsCodeGroovyScriptName)
+	})
+	t.Run("only listed protocols are enabled", func(t *testing.T) {
+		configMap, err := NewBaseConfigurationConfigMap(metav1.ObjectMeta{}, newJenkins("Ping", "JNLP4-connect"), "cluster.local")
+
+		require.NoError(t, err)
+		assert.Contains(t, configMap.Data[configurationAsCodeGroovyScriptName], `def config = '''jenkins:
+  agentProtocols:
+  - JNLP4-connect
+  - Ping
+'''`)
+	})
+}
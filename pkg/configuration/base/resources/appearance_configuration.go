@@ -0,0 +1,64 @@
+package resources
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+)
+
+// cssStringReplacer escapes the value of the CSS string literal
+var cssStringReplacer = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\A `, "<", `\3C `)
+
+// buildBrandingCSS builds the CSS of spec.master.branding, the logo replaces the Jenkins header icon and the footer
+// text is prepended to the page footer
+func buildBrandingCSS(branding v1alpha2.Branding) string {
+	var rules []string
+	if len(branding.LogoURL) > 0 {
+		rules = append(rules, fmt.Sprintf(`#jenkins-head-icon { content: url("%s"); }`, cssStringReplacer.Replace(branding.LogoURL)))
+	}
+	if len(branding.FooterText) > 0 {
+		rules = append(rules, fmt.Sprintf(`.page-footer::before { content: "%s"; white-space: pre-wrap; }`, cssStringReplacer.Replace(branding.FooterText)))
+	}
+	return strings.Join(rules, "\n")
+}
+
+// BuildAppearanceConfiguration builds the system message and the theme sections of the Configuration as Code
+// from spec.master.systemMessage, spec.master.themeCSS and spec.master.branding, returns nil when none of them is set
+func BuildAppearanceConfiguration(master v1alpha2.JenkinsMaster) map[string]interface{} {
+	var themeElements []interface{}
+	if len(master.ThemeCSS) > 0 {
+		themeElements = append(themeElements, map[string]interface{}{
+			"cssUrl": map[string]interface{}{
+				"url": master.ThemeCSS,
+			},
+		})
+	}
+	if master.Branding != nil {
+		if css := buildBrandingCSS(*master.Branding); len(css) > 0 {
+			themeElements = append(themeElements, map[string]interface{}{
+				"cssText": map[string]interface{}{
+					"text": css,
+				},
+			})
+		}
+	}
+	if len(master.SystemMessage) == 0 && len(themeElements) == 0 {
+		return nil
+	}
+
+	configuration := map[string]interface{}{}
+	if len(master.SystemMessage) > 0 {
+		configuration["jenkins"] = map[string]interface{}{
+			"systemMessage": master.SystemMessage,
+		}
+	}
+	if len(themeElements) > 0 {
+		configuration["appearance"] = map[string]interface{}{
+			"simpleTheme": map[string]interface{}{
+				"elements": themeElements,
+			},
+		}
+	}
+	return configuration
+}
@@ -0,0 +1,75 @@
+package resources
+
+import (
+	"testing"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestNewBaseConfigurationConfigMapAppearance(t *testing.T) {
+	newJenkins := func(systemMessage, themeCSS string) *v1alpha2.Jenkins {
+		return &v1alpha2.Jenkins{
+			ObjectMeta: metav1.ObjectMeta{Name: "example", Namespace: "default"},
+			Spec: v1alpha2.JenkinsSpec{
+				Master: v1alpha2.JenkinsMaster{
+					Containers:    []v1alpha2.Container{{Name: JenkinsMasterContainerName}},
+					SystemMessage: systemMessage,
+					ThemeCSS:      themeCSS,
+				},
+			},
+		}
+	}
+
+	t.Run("without appearance", func(t *testing.T) {
+		configMap, err := NewBaseConfigurationConfigMap(metav1.ObjectMeta{}, newJenkins("", ""), "cluster.local")
+
+		require.NoError(t, err)
+		assert.NotContains(t, configMap.Data, configurationAsCodeGroovyScriptName)
+	})
+	t.Run("system message and theme", func(t *testing.T) {
+		configMap, err := NewBaseConfigurationConfigMap(metav1.ObjectMeta{}, newJenkins("PROD - be careful, it's live", "https://example.com/theme.css"), "cluster.local")
+
+		require.NoError(t, err)
+		assert.Contains(t, configMap.Data[configurationAsCodeGroovyScriptName], `def config = '''appearance:
+  simpleTheme:
+    elements:
+    - cssUrl:
+        url: https://example.com/theme.css
+jenkins:
+  systemMessage: PROD - be careful, it\'s live
+'''`)
+	})
+	t.Run("branding", func(t *testing.T) {
+		jenkins := newJenkins("", "https://example.com/theme.css")
+		jenkins.Spec.Master.Branding = &v1alpha2.Branding{
+			LogoURL:    "https://example.com/logo.svg",
+			FooterText: `ACME "CI"`,
+		}
+
+		configMap, err := NewBaseConfigurationConfigMap(metav1.ObjectMeta{}, jenkins, "cluster.local")
+
+		require.NoError(t, err)
+		assert.Contains(t, configMap.Data[configurationAsCodeGroovyScriptName], `def config = '''appearance:
+  simpleTheme:
+    elements:
+    - cssUrl:
+        url: https://example.com/theme.css
+    - cssText:
+        text: |-
+          #jenkins-head-icon { content: url("https://example.com/logo.svg"); }
+          .page-footer::before { content: "ACME \\"CI\\""; white-space: pre-wrap; }
+'''`)
+	})
+	t.Run("only system message", func(t *testing.T) {
+		configMap, err := NewBaseConfigurationConfigMap(metav1.ObjectMeta{}, newJenkins("PROD", ""), "cluster.local")
+
+		require.NoError(t, err)
+		assert.Contains(t, configMap.Data[configurationAsCodeGroovyScriptName], `def config = '''jenkins:
+  systemMessage: PROD
+'''`)
+	})
+}
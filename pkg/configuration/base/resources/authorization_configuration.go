@@ -0,0 +1,55 @@
+package resources
+
+import (
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+)
+
+// OperatorUserPermission is the permission granted to the operator user in the matrix authorization strategies
+const OperatorUserPermission = "Overall/Administer"
+
+// BuildAuthorizationConfiguration builds the authorizationStrategy section of the Configuration as Code
+// from spec.master.authorization. The operator user keeps the administer permission in the matrix strategies
+// when the operator creates the user.
+func BuildAuthorizationConfiguration(jenkins *v1alpha2.Jenkins) map[string]interface{} {
+	authorization := jenkins.Spec.Master.Authorization
+
+	var strategy interface{}
+	switch authorization.Strategy {
+	case v1alpha2.LoggedInUsersCanDoAnythingAuthorizationStrategyName:
+		strategy = map[string]interface{}{
+			"allowAnonymousRead": authorization.AllowAnonymousRead,
+		}
+	case v1alpha2.GlobalMatrixAuthorizationStrategyName, v1alpha2.ProjectMatrixAuthorizationStrategyName:
+		var entries []interface{}
+		if jenkins.Spec.JenkinsAPISettings.AuthorizationStrategy == v1alpha2.CreateUserAuthorizationStrategy {
+			entries = append(entries, buildAuthorizationEntry("user", OperatorUserName, []string{OperatorUserPermission}))
+		}
+		for _, grant := range authorization.Grants {
+			if len(grant.User) > 0 {
+				entries = append(entries, buildAuthorizationEntry("user", grant.User, grant.Permissions))
+			} else {
+				entries = append(entries, buildAuthorizationEntry("group", grant.Group, grant.Permissions))
+			}
+		}
+		strategy = map[string]interface{}{
+			"entries": entries,
+		}
+	}
+
+	return map[string]interface{}{
+		"jenkins": map[string]interface{}{
+			"authorizationStrategy": map[string]interface{}{
+				string(authorization.Strategy): strategy,
+			},
+		},
+	}
+}
+
+func buildAuthorizationEntry(kind, name string, permissions []string) map[string]interface{} {
+	return map[string]interface{}{
+		kind: map[string]interface{}{
+			"name":        name,
+			"permissions": permissions,
+		},
+	}
+}
@@ -0,0 +1,90 @@
+package resources
+
+import (
+	"testing"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/yaml"
+)
+
+func TestBuildAuthorizationConfiguration(t *testing.T) {
+	newJenkins := func(authorizationStrategy v1alpha2.AuthorizationStrategy, authorization v1alpha2.Authorization) *v1alpha2.Jenkins {
+		return &v1alpha2.Jenkins{
+			Spec: v1alpha2.JenkinsSpec{
+				Master:             v1alpha2.JenkinsMaster{Authorization: &authorization},
+				JenkinsAPISettings: v1alpha2.JenkinsAPISettings{AuthorizationStrategy: authorizationStrategy},
+			},
+		}
+	}
+	grants := []v1alpha2.AuthorizationGrant{
+		{User: "admin", Permissions: []string{"Overall/Administer"}},
+		{Group: "developers", Permissions: []string{"Overall/Read", "Job/Build"}},
+	}
+
+	t.Run("loggedInUsersCanDoAnything", func(t *testing.T) {
+		jenkins := newJenkins(v1alpha2.CreateUserAuthorizationStrategy, v1alpha2.Authorization{
+			Strategy:           v1alpha2.LoggedInUsersCanDoAnythingAuthorizationStrategyName,
+			AllowAnonymousRead: true,
+		})
+
+		got, err := yaml.Marshal(BuildAuthorizationConfiguration(jenkins))
+
+		require.NoError(t, err)
+		assert.Equal(t, `jenkins:
+  authorizationStrategy:
+    loggedInUsersCanDoAnything:
+      allowAnonymousRead: true
+`, string(got))
+	})
+	t.Run("globalMatrix", func(t *testing.T) {
+		jenkins := newJenkins(v1alpha2.CreateUserAuthorizationStrategy, v1alpha2.Authorization{
+			Strategy: v1alpha2.GlobalMatrixAuthorizationStrategyName,
+			Grants:   grants,
+		})
+
+		got, err := yaml.Marshal(BuildAuthorizationConfiguration(jenkins))
+
+		require.NoError(t, err)
+		assert.Equal(t, `jenkins:
+  authorizationStrategy:
+    globalMatrix:
+      entries:
+      - user:
+          name: jenkins-operator
+          permissions:
+          - Overall/Administer
+      - user:
+          name: admin
+          permissions:
+          - Overall/Administer
+      - group:
+          name: developers
+          permissions:
+          - Overall/Read
+          - Job/Build
+`, string(got))
+	})
+	t.Run("projectMatrix with service account authorization", func(t *testing.T) {
+		jenkins := newJenkins(v1alpha2.ServiceAccountAuthorizationStrategy, v1alpha2.Authorization{
+			Strategy: v1alpha2.ProjectMatrixAuthorizationStrategyName,
+			Grants:   grants[1:],
+		})
+
+		got, err := yaml.Marshal(BuildAuthorizationConfiguration(jenkins))
+
+		require.NoError(t, err)
+		assert.Equal(t, `jenkins:
+  authorizationStrategy:
+    projectMatrix:
+      entries:
+      - group:
+          name: developers
+          permissions:
+          - Overall/Read
+          - Job/Build
+`, string(got))
+	})
+}
@@ -2,6 +2,7 @@ package resources
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
 	"github.com/jenkinsci/kubernetes-operator/pkg/constants"
@@ -10,14 +11,21 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+// the base groovy scripts are run in the lexical order of their names, so the prefixes are zero-padded
 const (
-	basicSettingsGroovyScriptName               = "1-basic-settings.groovy"
-	enableCSRFGroovyScriptName                  = "2-enable-csrf.groovy"
-	disableUsageStatsGroovyScriptName           = "3-disable-usage-stats.groovy"
-	disableInsecureFeaturesGroovyScriptName     = "4-disable-insecure-features.groovy"
-	configureKubernetesPluginGroovyScriptName   = "5-configure-kubernetes-plugin.groovy"
-	configureViewsGroovyScriptName              = "6-configure-views.groovy"
-	disableJobDslScriptApprovalGroovyScriptName = "7-disable-job-dsl-script-approval.groovy"
+	basicSettingsGroovyScriptName               = "01-basic-settings.groovy"
+	enableCSRFGroovyScriptName                  = "02-enable-csrf.groovy"
+	disableUsageStatsGroovyScriptName           = "03-disable-usage-stats.groovy"
+	disableInsecureFeaturesGroovyScriptName     = "04-disable-insecure-features.groovy"
+	configureKubernetesPluginGroovyScriptName   = "05-configure-kubernetes-plugin.groovy"
+	configureViewsGroovyScriptName              = "06-configure-views.groovy"
+	disableJobDslScriptApprovalGroovyScriptName = "07-disable-job-dsl-script-approval.groovy"
+	configurationAsCodeGroovyScriptName         = "08-configuration-as-code.groovy"
+	configureUserViewsGroovyScriptName          = "09-configure-user-views.groovy"
+	configureLogRecordersGroovyScriptName       = "10-configure-log-recorders.groovy"
+	removeOfflineNodesGroovyScriptName          = "11-remove-offline-nodes.groovy"
+	configureWebhookTokenGroovyScriptName       = "12-configure-webhook-token.groovy"
+	configurePluginManagerSitesGroovyScriptName = "13-configure-plugin-manager-sites.groovy"
 )
 
 const basicSettingsFmt = `
@@ -28,6 +36,8 @@ import hudson.model.Node.Mode
 def jenkins = Jenkins.instance
 //Number of jobs that run simultaneously on master.
 jenkins.setNumExecutors(%d)
+//Labels of the master node selected by the jobs
+jenkins.setLabelString(%s)
 //Jobs must specify that they want to run on master
 jenkins.setMode(Mode.EXCLUSIVE)
 jenkins.save()
@@ -48,6 +58,43 @@ if (jenkins.getCrumbIssuer() == null) {
 }
 `
 
+const configureCSRFFmt = `
+import hudson.security.csrf.DefaultCrumbIssuer
+import jenkins.model.Jenkins
+
+def jenkins = Jenkins.instance
+def enabled = %t
+def proxyCompatibility = %t
+
+def crumbIssuer = jenkins.getCrumbIssuer()
+if (!enabled) {
+    if (crumbIssuer != null) {
+        jenkins.setCrumbIssuer(null)
+        jenkins.save()
+        println('CSRF Protection disabled.')
+    }
+} else if (!(crumbIssuer instanceof DefaultCrumbIssuer) || crumbIssuer.isExcludeClientIPFromCrumb() != proxyCompatibility) {
+    jenkins.setCrumbIssuer(new DefaultCrumbIssuer(proxyCompatibility))
+    jenkins.save()
+    println('CSRF Protection enabled.')
+} else {
+    println('CSRF Protection already configured.')
+}
+`
+
+// buildConfigureCSRFGroovyScript renders the CSRF groovy script from spec.master.csrf,
+// the protection and the proxy compatibility are enabled by default
+func buildConfigureCSRFGroovyScript(csrf v1alpha2.CSRF) string {
+	enabled, proxyCompatibility := true, true
+	if csrf.Enabled != nil {
+		enabled = *csrf.Enabled
+	}
+	if csrf.ProxyCompatibility != nil {
+		proxyCompatibility = *csrf.ProxyCompatibility
+	}
+	return fmt.Sprintf(configureCSRFFmt, enabled, proxyCompatibility)
+}
+
 const disableUsageStats = `
 import jenkins.model.Jenkins
 
@@ -62,7 +109,7 @@ if (jenkins.isUsageStatisticsCollected()) {
 }
 `
 
-const disableInsecureFeatures = `
+const disableInsecureFeaturesFmt = `
 import jenkins.*
 import jenkins.model.*
 import hudson.model.*
@@ -78,7 +125,11 @@ HashSet<String> newProtocols = new HashSet<>(jenkins.getAgentProtocols())
 newProtocols.removeAll(Arrays.asList("JNLP3-connect", "JNLP2-connect", "JNLP-connect", "CLI-connect"))
 println("New protocols: [" + newProtocols.join(", ") + "]")
 jenkins.setAgentProtocols(newProtocols)
+%s
+jenkins.save()
+`
 
+const disableRemotingCLI = `
 println("Disabling CLI access of /cli URL...")
 def remove = { list ->
     list.each { item ->
@@ -94,10 +145,30 @@ remove(jenkins.actions)
 if (jenkins.getDescriptor("jenkins.CLI") != null) {
     jenkins.getDescriptor("jenkins.CLI").get().setEnabled(false)
 }
+`
 
-jenkins.save()
+const enableRemotingCLI = `
+println("Enabling the remoting CLI...")
+if (jenkins.getDescriptor("jenkins.CLI") != null) {
+    jenkins.getDescriptor("jenkins.CLI").get().setEnabled(true)
+}
 `
 
+// IsRemotingCLIDisabled returns true if the remoting based Jenkins CLI is disabled by spec.master.disableRemotingCLI,
+// defaults to true
+func IsRemotingCLIDisabled(jenkins *v1alpha2.Jenkins) bool {
+	return jenkins.Spec.Master.DisableRemotingCLI == nil || *jenkins.Spec.Master.DisableRemotingCLI
+}
+
+// buildDisableInsecureFeaturesGroovyScript renders the insecure features groovy script, the remoting CLI is disabled
+// or enabled according to spec.master.disableRemotingCLI
+func buildDisableInsecureFeaturesGroovyScript(jenkins *v1alpha2.Jenkins) string {
+	if IsRemotingCLIDisabled(jenkins) {
+		return fmt.Sprintf(disableInsecureFeaturesFmt, disableRemotingCLI)
+	}
+	return fmt.Sprintf(disableInsecureFeaturesFmt, enableRemotingCLI)
+}
+
 const configureKubernetesPluginFmt = `
 import com.cloudbees.plugins.credentials.CredentialsScope
 import com.cloudbees.plugins.credentials.SystemCredentialsProvider
@@ -113,7 +184,7 @@ if (kubernetes == null) {
     add = true
 	kubernetes = new KubernetesCloud("kubernetes")
 }
-kubernetes.setServerUrl("https://kubernetes.default.svc.%s:443")
+kubernetes.setServerUrl("%s")
 kubernetes.setNamespace("%s")
 kubernetes.setJenkinsUrl("%s")
 kubernetes.setJenkinsTunnel("%s")
@@ -159,6 +230,21 @@ GlobalConfiguration.all().get(GlobalJobDslSecurityConfiguration.class).useScript
 GlobalConfiguration.all().get(GlobalJobDslSecurityConfiguration.class).save()
 `
 
+// GetJenkinsMasterExecutors returns the number of executors on the Jenkins master
+func GetJenkinsMasterExecutors(jenkins *v1alpha2.Jenkins) int32 {
+	if jenkins.Spec.Master.Executors == nil {
+		return constants.DefaultAmountOfExecutors
+	}
+	return *jenkins.Spec.Master.Executors
+}
+
+// buildBasicSettingsGroovyScript renders the basic settings groovy script with the executors and the labels
+// of the master node
+func buildBasicSettingsGroovyScript(jenkins *v1alpha2.Jenkins) string {
+	labels := quoteGroovyString(strings.Join(jenkins.Spec.Master.NodeLabels, " "))
+	return fmt.Sprintf(basicSettingsFmt, GetJenkinsMasterExecutors(jenkins), labels)
+}
+
 // GetBaseConfigurationConfigMapName returns name of Kubernetes config map used to base configuration.
 func GetBaseConfigurationConfigMapName(jenkins *v1alpha2.Jenkins) string {
 	return fmt.Sprintf("%s-base-configuration-%s", constants.OperatorName, jenkins.ObjectMeta.Name)
@@ -179,28 +265,136 @@ func NewBaseConfigurationConfigMap(meta metav1.ObjectMeta, jenkins *v1alpha2.Jen
 	if err != nil {
 		return nil, err
 	}
-	suffix := ""
-	if prefix, ok := GetJenkinsOpts(*jenkins)["prefix"]; ok {
-		suffix = prefix
+	serverURL := fmt.Sprintf("https://kubernetes.default.svc.%s:443", clusterDomain)
+	jenkinsURL := BuildJenkinsURL(jenkins, fmt.Sprintf("http://%s:%d", jenkinsServiceFQDN, jenkins.Spec.Service.Port))
+	jenkinsTunnel := fmt.Sprintf("%s:%d", jenkinsSlavesServiceFQDN, jenkins.Spec.SlaveService.Port)
+	if agent := jenkins.Spec.Master.Agent; agent != nil {
+		if len(agent.JenkinsURL) > 0 {
+			jenkinsURL = agent.JenkinsURL
+		}
+		if len(agent.JenkinsTunnel) > 0 {
+			jenkinsTunnel = agent.JenkinsTunnel
+		}
 	}
 	groovyScriptsMap := map[string]string{
-		basicSettingsGroovyScriptName:           fmt.Sprintf(basicSettingsFmt, constants.DefaultAmountOfExecutors),
+		basicSettingsGroovyScriptName:           buildBasicSettingsGroovyScript(jenkins),
 		enableCSRFGroovyScriptName:              enableCSRF,
 		disableUsageStatsGroovyScriptName:       disableUsageStats,
-		disableInsecureFeaturesGroovyScriptName: disableInsecureFeatures,
+		disableInsecureFeaturesGroovyScriptName: buildDisableInsecureFeaturesGroovyScript(jenkins),
 		configureKubernetesPluginGroovyScriptName: fmt.Sprintf(configureKubernetesPluginFmt,
-			clusterDomain,
+			serverURL,
 			jenkins.ObjectMeta.Namespace,
-			fmt.Sprintf("http://%s:%d%s", jenkinsServiceFQDN, jenkins.Spec.Service.Port, suffix),
-			fmt.Sprintf("%s:%d", jenkinsSlavesServiceFQDN, jenkins.Spec.SlaveService.Port),
+			jenkinsURL,
+			jenkinsTunnel,
 		),
 		configureViewsGroovyScriptName:              configureViews,
 		disableJobDslScriptApprovalGroovyScriptName: disableJobDSLScriptApproval,
 	}
 
-	if jenkins.Spec.Master.DisableCSRFProtection {
+	if jenkins.Spec.Master.CSRF != nil {
+		groovyScriptsMap[enableCSRFGroovyScriptName] = buildConfigureCSRFGroovyScript(*jenkins.Spec.Master.CSRF)
+	} else if jenkins.Spec.Master.DisableCSRFProtection {
 		delete(groovyScriptsMap, enableCSRFGroovyScriptName)
 	}
+
+	groovyScriptsMap[configureUserViewsGroovyScriptName], err = buildConfigureUserViewsGroovyScript(jenkins.Spec.Views)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(jenkins.Spec.Master.LogRecorders) > 0 {
+		groovyScriptsMap[configureLogRecordersGroovyScriptName], err = buildConfigureLogRecordersGroovyScript(jenkins.Spec.Master.LogRecorders)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if jenkins.Spec.Master.WebhookTokenSecretRef != nil {
+		groovyScriptsMap[configureWebhookTokenGroovyScriptName] = buildConfigureWebhookTokenGroovyScript()
+	}
+
+	if len(jenkins.Spec.Master.PluginManagerSites) > 0 {
+		groovyScriptsMap[configurePluginManagerSitesGroovyScriptName], err = buildConfigurePluginManagerSitesGroovyScript(jenkins.Spec.Master.PluginManagerSites)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if jenkins.Spec.Master.Agent != nil {
+		groovyScriptsMap[removeOfflineNodesGroovyScriptName], err = buildRemoveOfflineNodesGroovyScript(*jenkins.Spec.Master.Agent)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	configurationAsCode := map[string]interface{}{}
+	if jenkins.Spec.ToolConfig != nil {
+		mergeConfigurationAsCode(configurationAsCode, BuildToolConfiguration(*jenkins.Spec.ToolConfig))
+	}
+	if jenkins.Spec.Master.MavenSettingsSecretRef != nil {
+		mergeConfigurationAsCode(configurationAsCode, BuildMavenSettingsConfiguration())
+	}
+	if jenkins.Spec.Master.WebhookTokenSecretRef != nil {
+		mergeConfigurationAsCode(configurationAsCode, BuildWebhookTokenConfiguration())
+	}
+	if len(jenkins.Spec.GlobalPipelineLibraries) > 0 {
+		mergeConfigurationAsCode(configurationAsCode, BuildGlobalLibrariesConfiguration(jenkins.Spec.GlobalPipelineLibraries))
+	}
+	if len(jenkins.Spec.PermanentAgents) > 0 {
+		mergeConfigurationAsCode(configurationAsCode, BuildPermanentAgentsConfiguration(jenkins))
+	}
+	if location := BuildLocationConfiguration(jenkins.Spec.Master); location != nil {
+		mergeConfigurationAsCode(configurationAsCode, location)
+	}
+	if appearance := BuildAppearanceConfiguration(jenkins.Spec.Master); appearance != nil {
+		mergeConfigurationAsCode(configurationAsCode, appearance)
+	}
+	if markupFormatter := BuildMarkupFormatterConfiguration(jenkins.Spec.Master.MarkupFormatter); markupFormatter != nil {
+		mergeConfigurationAsCode(configurationAsCode, markupFormatter)
+	}
+	if jenkins.Spec.Master.Authorization != nil {
+		mergeConfigurationAsCode(configurationAsCode, BuildAuthorizationConfiguration(jenkins))
+	}
+	if jenkins.Spec.Master.LDAP != nil {
+		mergeConfigurationAsCode(configurationAsCode, BuildLDAPConfiguration(*jenkins.Spec.Master.LDAP))
+	}
+	if jenkins.Spec.Master.OIDC != nil {
+		mergeConfigurationAsCode(configurationAsCode, BuildOIDCConfiguration(*jenkins.Spec.Master.OIDC))
+	}
+	if jenkins.Spec.Master.BuildDiscarder != nil {
+		mergeConfigurationAsCode(configurationAsCode, BuildBuildDiscarderConfiguration(*jenkins.Spec.Master.BuildDiscarder))
+	}
+	if buildDefaults := BuildBuildDefaultsConfiguration(jenkins.Spec.Master); buildDefaults != nil {
+		mergeConfigurationAsCode(configurationAsCode, buildDefaults)
+	}
+	if jenkins.Spec.Master.JenkinsProxy != nil {
+		mergeConfigurationAsCode(configurationAsCode, BuildProxyConfiguration(*jenkins.Spec.Master.JenkinsProxy))
+	}
+	if len(jenkins.Spec.Master.AgentProtocols) > 0 {
+		mergeConfigurationAsCode(configurationAsCode, BuildAgentProtocolsConfiguration(jenkins.Spec.Master.AgentProtocols))
+	}
+	if len(jenkins.Spec.Master.GlobalEnvVars) > 0 {
+		mergeConfigurationAsCode(configurationAsCode, BuildGlobalEnvVarsConfiguration(jenkins.Spec.Master.GlobalEnvVars))
+	}
+	if HasKubernetesCloudConfiguration(jenkins.Spec.Master.Agent) {
+		cloud, err := BuildKubernetesCloudConfiguration(KubernetesCloud{
+			ServerURL:     serverURL,
+			Namespace:     jenkins.ObjectMeta.Namespace,
+			JenkinsURL:    jenkinsURL,
+			JenkinsTunnel: jenkinsTunnel,
+		}, *jenkins.Spec.Master.Agent)
+		if err != nil {
+			return nil, err
+		}
+		mergeConfigurationAsCode(configurationAsCode, cloud)
+	}
+	if len(configurationAsCode) > 0 {
+		groovyScriptsMap[configurationAsCodeGroovyScriptName], err = buildApplyConfigurationAsCodeGroovyScript(configurationAsCode)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return &corev1.ConfigMap{
 		TypeMeta:   buildConfigMapTypeMeta(),
 		ObjectMeta: meta,
@@ -0,0 +1,238 @@
+package resources
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestBuildConfigureCSRFGroovyScript(t *testing.T) {
+	enabled, disabled := true, false
+
+	t.Run("defaults", func(t *testing.T) {
+		got := buildConfigureCSRFGroovyScript(v1alpha2.CSRF{})
+
+		assert.Contains(t, got, "def enabled = true\n")
+		assert.Contains(t, got, "def proxyCompatibility = true\n")
+		assert.Contains(t, got, "jenkins.setCrumbIssuer(new DefaultCrumbIssuer(proxyCompatibility))")
+	})
+	t.Run("without proxy compatibility", func(t *testing.T) {
+		got := buildConfigureCSRFGroovyScript(v1alpha2.CSRF{Enabled: &enabled, ProxyCompatibility: &disabled})
+
+		assert.Contains(t, got, "def enabled = true\n")
+		assert.Contains(t, got, "def proxyCompatibility = false\n")
+	})
+	t.Run("disabled", func(t *testing.T) {
+		got := buildConfigureCSRFGroovyScript(v1alpha2.CSRF{Enabled: &disabled})
+
+		assert.Contains(t, got, "def enabled = false\n")
+		assert.Contains(t, got, "jenkins.setCrumbIssuer(null)")
+	})
+}
+
+func TestBaseConfigurationGroovyScriptsOrder(t *testing.T) {
+	scripts := []string{
+		basicSettingsGroovyScriptName,
+		enableCSRFGroovyScriptName,
+		disableUsageStatsGroovyScriptName,
+		disableInsecureFeaturesGroovyScriptName,
+		configureKubernetesPluginGroovyScriptName,
+		configureViewsGroovyScriptName,
+		disableJobDslScriptApprovalGroovyScriptName,
+		configurationAsCodeGroovyScriptName,
+		configureUserViewsGroovyScriptName,
+		configureLogRecordersGroovyScriptName,
+		removeOfflineNodesGroovyScriptName,
+		configureWebhookTokenGroovyScriptName,
+		configurePluginManagerSitesGroovyScriptName,
+	}
+
+	assert.True(t, sort.StringsAreSorted(scripts))
+}
+
+func TestNewBaseConfigurationConfigMapCSRF(t *testing.T) {
+	disabled := false
+	newJenkins := func(disableCSRFProtection bool, csrf *v1alpha2.CSRF) *v1alpha2.Jenkins {
+		return &v1alpha2.Jenkins{
+			ObjectMeta: metav1.ObjectMeta{Name: "example", Namespace: "default"},
+			Spec: v1alpha2.JenkinsSpec{
+				Master: v1alpha2.JenkinsMaster{
+					Containers:            []v1alpha2.Container{{Name: JenkinsMasterContainerName}},
+					DisableCSRFProtection: disableCSRFProtection,
+					CSRF:                  csrf,
+				},
+			},
+		}
+	}
+
+	t.Run("enabled by default", func(t *testing.T) {
+		configMap, err := NewBaseConfigurationConfigMap(metav1.ObjectMeta{}, newJenkins(false, nil), "cluster.local")
+
+		require.NoError(t, err)
+		assert.Equal(t, enableCSRF, configMap.Data[enableCSRFGroovyScriptName])
+	})
+	t.Run("disableCSRFProtection", func(t *testing.T) {
+		configMap, err := NewBaseConfigurationConfigMap(metav1.ObjectMeta{}, newJenkins(true, nil), "cluster.local")
+
+		require.NoError(t, err)
+		assert.NotContains(t, configMap.Data, enableCSRFGroovyScriptName)
+	})
+	t.Run("csrf takes precedence over disableCSRFProtection", func(t *testing.T) {
+		csrf := &v1alpha2.CSRF{ProxyCompatibility: &disabled}
+
+		configMap, err := NewBaseConfigurationConfigMap(metav1.ObjectMeta{}, newJenkins(true, csrf), "cluster.local")
+
+		require.NoError(t, err)
+		assert.Equal(t, buildConfigureCSRFGroovyScript(*csrf), configMap.Data[enableCSRFGroovyScriptName])
+	})
+}
+
+func TestNewBaseConfigurationConfigMapRemotingCLI(t *testing.T) {
+	newJenkins := func(disableRemotingCLI *bool) *v1alpha2.Jenkins {
+		return &v1alpha2.Jenkins{
+			ObjectMeta: metav1.ObjectMeta{Name: "example", Namespace: "default"},
+			Spec: v1alpha2.JenkinsSpec{
+				Master: v1alpha2.JenkinsMaster{
+					Containers:         []v1alpha2.Container{{Name: JenkinsMasterContainerName}},
+					DisableRemotingCLI: disableRemotingCLI,
+				},
+			},
+		}
+	}
+
+	t.Run("disabled by default", func(t *testing.T) {
+		configMap, err := NewBaseConfigurationConfigMap(metav1.ObjectMeta{}, newJenkins(nil), "cluster.local")
+
+		require.NoError(t, err)
+		script := configMap.Data[disableInsecureFeaturesGroovyScriptName]
+		assert.Contains(t, script, `jenkins.getDescriptor("jenkins.CLI").get().setEnabled(false)`)
+		assert.Contains(t, script, "remove(jenkins.getExtensionList(RootAction.class))")
+		assert.Contains(t, script, `newProtocols.removeAll(Arrays.asList("JNLP3-connect", "JNLP2-connect", "JNLP-connect", "CLI-connect"))`)
+		assert.Contains(t, script, "jenkins.save()")
+	})
+	t.Run("enabled", func(t *testing.T) {
+		disabled := false
+
+		configMap, err := NewBaseConfigurationConfigMap(metav1.ObjectMeta{}, newJenkins(&disabled), "cluster.local")
+
+		require.NoError(t, err)
+		script := configMap.Data[disableInsecureFeaturesGroovyScriptName]
+		assert.Contains(t, script, `jenkins.getDescriptor("jenkins.CLI").get().setEnabled(true)`)
+		assert.NotContains(t, script, "CLIAction")
+		assert.Contains(t, script, "jenkins.save()")
+	})
+}
+
+func TestNewBaseConfigurationConfigMapExecutors(t *testing.T) {
+	newJenkins := func(executors *int32) *v1alpha2.Jenkins {
+		return &v1alpha2.Jenkins{
+			ObjectMeta: metav1.ObjectMeta{Name: "example", Namespace: "default"},
+			Spec: v1alpha2.JenkinsSpec{
+				Master: v1alpha2.JenkinsMaster{
+					Containers: []v1alpha2.Container{{Name: JenkinsMasterContainerName}},
+					Executors:  executors,
+				},
+			},
+		}
+	}
+
+	t.Run("no executors by default", func(t *testing.T) {
+		configMap, err := NewBaseConfigurationConfigMap(metav1.ObjectMeta{}, newJenkins(nil), "cluster.local")
+
+		require.NoError(t, err)
+		assert.Contains(t, configMap.Data[basicSettingsGroovyScriptName], "jenkins.setNumExecutors(0)\n")
+	})
+	t.Run("custom executors", func(t *testing.T) {
+		executors := int32(2)
+
+		configMap, err := NewBaseConfigurationConfigMap(metav1.ObjectMeta{}, newJenkins(&executors), "cluster.local")
+
+		require.NoError(t, err)
+		assert.Contains(t, configMap.Data[basicSettingsGroovyScriptName], "jenkins.setNumExecutors(2)\n")
+	})
+}
+
+func TestBuildBasicSettingsGroovyScript(t *testing.T) {
+	newJenkins := func(nodeLabels ...string) *v1alpha2.Jenkins {
+		return &v1alpha2.Jenkins{Spec: v1alpha2.JenkinsSpec{Master: v1alpha2.JenkinsMaster{NodeLabels: nodeLabels}}}
+	}
+
+	t.Run("no labels by default", func(t *testing.T) {
+		script := buildBasicSettingsGroovyScript(newJenkins())
+
+		assert.Contains(t, script, "jenkins.setLabelString('')\n")
+	})
+	t.Run("labels", func(t *testing.T) {
+		script := buildBasicSettingsGroovyScript(newJenkins("master", "linux-amd64"))
+
+		assert.Contains(t, script, "jenkins.setNumExecutors(0)\n")
+		assert.Contains(t, script, "jenkins.setLabelString('master linux-amd64')\n")
+	})
+}
+
+func TestNewBaseConfigurationConfigMapAgentConnection(t *testing.T) {
+	newJenkins := func(agent *v1alpha2.JenkinsAgent) *v1alpha2.Jenkins {
+		return &v1alpha2.Jenkins{
+			ObjectMeta: metav1.ObjectMeta{Name: "example", Namespace: "default"},
+			Spec: v1alpha2.JenkinsSpec{
+				Master: v1alpha2.JenkinsMaster{
+					Containers: []v1alpha2.Container{{Name: JenkinsMasterContainerName}},
+					Agent:      agent,
+				},
+				Service:      v1alpha2.Service{Port: 8080},
+				SlaveService: v1alpha2.Service{Port: 50000},
+			},
+		}
+	}
+
+	t.Run("services by default", func(t *testing.T) {
+		configMap, err := NewBaseConfigurationConfigMap(metav1.ObjectMeta{}, newJenkins(nil), "cluster.local")
+
+		require.NoError(t, err)
+		script := configMap.Data[configureKubernetesPluginGroovyScriptName]
+		assert.Contains(t, script, `kubernetes.setJenkinsUrl("http://jenkins-operator-http-example.default.svc.cluster.local:8080")`)
+		assert.Contains(t, script, `kubernetes.setJenkinsTunnel("jenkins-operator-slave-example.default.svc.cluster.local:50000")`)
+	})
+	t.Run("overridden", func(t *testing.T) {
+		agent := &v1alpha2.JenkinsAgent{
+			JenkinsURL:    "https://jenkins.example.com",
+			JenkinsTunnel: "jenkins-agents.example.com:50000",
+			PodTemplates:  []v1alpha2.AgentPodTemplate{{Name: "linux"}},
+		}
+
+		configMap, err := NewBaseConfigurationConfigMap(metav1.ObjectMeta{}, newJenkins(agent), "cluster.local")
+
+		require.NoError(t, err)
+		script := configMap.Data[configureKubernetesPluginGroovyScriptName]
+		assert.Contains(t, script, `kubernetes.setJenkinsUrl("https://jenkins.example.com")`)
+		assert.Contains(t, script, `kubernetes.setJenkinsTunnel("jenkins-agents.example.com:50000")`)
+		casc := configMap.Data[configurationAsCodeGroovyScriptName]
+		assert.Contains(t, casc, "jenkinsTunnel: jenkins-agents.example.com:50000")
+		assert.Contains(t, casc, "jenkinsUrl: https://jenkins.example.com")
+	})
+	t.Run("cloud settings without pod templates", func(t *testing.T) {
+		containerCap, webSocket := int32(10), true
+		agent := &v1alpha2.JenkinsAgent{ContainerCap: &containerCap, WebSocket: &webSocket}
+
+		configMap, err := NewBaseConfigurationConfigMap(metav1.ObjectMeta{}, newJenkins(agent), "cluster.local")
+
+		require.NoError(t, err)
+		casc := configMap.Data[configurationAsCodeGroovyScriptName]
+		assert.Contains(t, casc, "containerCapStr: \"10\"")
+		assert.Contains(t, casc, "webSocket: true")
+		assert.Contains(t, casc, "templates: []")
+	})
+	t.Run("no cloud settings", func(t *testing.T) {
+		agent := &v1alpha2.JenkinsAgent{RemoveOfflineNodes: true}
+
+		configMap, err := NewBaseConfigurationConfigMap(metav1.ObjectMeta{}, newJenkins(agent), "cluster.local")
+
+		require.NoError(t, err)
+		assert.NotContains(t, configMap.Data[configurationAsCodeGroovyScriptName], "clouds:")
+	})
+}
@@ -0,0 +1,23 @@
+package resources
+
+import (
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+)
+
+// BuildBuildDefaultsConfiguration builds the quietPeriod and scmCheckoutRetryCount of the jenkins section of
+// the Configuration as Code, returns nil when none of them is set
+func BuildBuildDefaultsConfiguration(master v1alpha2.JenkinsMaster) map[string]interface{} {
+	if master.QuietPeriod == nil && master.ScmCheckoutRetryCount == nil {
+		return nil
+	}
+
+	configuration := map[string]interface{}{}
+	if master.QuietPeriod != nil {
+		configuration["quietPeriod"] = *master.QuietPeriod
+	}
+	if master.ScmCheckoutRetryCount != nil {
+		configuration["scmCheckoutRetryCount"] = *master.ScmCheckoutRetryCount
+	}
+
+	return map[string]interface{}{"jenkins": configuration}
+}
@@ -0,0 +1,40 @@
+package resources
+
+import (
+	"testing"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestNewBaseConfigurationConfigMapBuildDefaults(t *testing.T) {
+	newJenkins := func(quietPeriod, scmCheckoutRetryCount *int32) *v1alpha2.Jenkins {
+		return &v1alpha2.Jenkins{
+			ObjectMeta: metav1.ObjectMeta{Name: "example", Namespace: "default"},
+			Spec: v1alpha2.JenkinsSpec{
+				Master: v1alpha2.JenkinsMaster{
+					Containers:            []v1alpha2.Container{{Name: JenkinsMasterContainerName}},
+					QuietPeriod:           quietPeriod,
+					ScmCheckoutRetryCount: scmCheckoutRetryCount,
+				},
+			},
+		}
+	}
+	zero, retries := int32(0), int32(3)
+
+	t.Run("quiet period and SCM checkout retry count", func(t *testing.T) {
+		configMap, err := NewBaseConfigurationConfigMap(metav1.ObjectMeta{}, newJenkins(&zero, &retries), "cluster.local")
+
+		require.NoError(t, err)
+		assert.Contains(t, configMap.Data[configurationAsCodeGroovyScriptName], `def config = '''jenkins:
+  quietPeriod: 0
+  scmCheckoutRetryCount: 3
+'''`)
+	})
+	t.Run("not set", func(t *testing.T) {
+		assert.Nil(t, BuildBuildDefaultsConfiguration(newJenkins(nil, nil).Spec.Master))
+	})
+}
@@ -0,0 +1,41 @@
+package resources
+
+import (
+	"strconv"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+)
+
+type cascLogRotator struct {
+	DaysToKeepStr string `json:"daysToKeepStr,omitempty"`
+	NumToKeepStr  string `json:"numToKeepStr,omitempty"`
+}
+
+// BuildBuildDiscarderConfiguration builds the buildDiscarders section of the Configuration as Code from
+// spec.master.buildDiscarder, the build discarders configured in the jobs are applied too
+func BuildBuildDiscarderConfiguration(buildDiscarder v1alpha2.BuildDiscarder) map[string]interface{} {
+	logRotator := cascLogRotator{}
+	if buildDiscarder.DaysToKeep != nil {
+		logRotator.DaysToKeepStr = strconv.Itoa(int(*buildDiscarder.DaysToKeep))
+	}
+	if buildDiscarder.NumToKeep != nil {
+		logRotator.NumToKeepStr = strconv.Itoa(int(*buildDiscarder.NumToKeep))
+	}
+
+	return map[string]interface{}{
+		"unclassified": map[string]interface{}{
+			"buildDiscarders": map[string]interface{}{
+				"configuredBuildDiscarders": []interface{}{
+					"jobBuildDiscarder",
+					map[string]interface{}{
+						"simpleBuildDiscarder": map[string]interface{}{
+							"discarder": map[string]interface{}{
+								"logRotator": logRotator,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
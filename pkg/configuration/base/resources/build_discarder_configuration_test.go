@@ -0,0 +1,50 @@
+package resources
+
+import (
+	"testing"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestNewBaseConfigurationConfigMapBuildDiscarder(t *testing.T) {
+	newJenkins := func(buildDiscarder *v1alpha2.BuildDiscarder) *v1alpha2.Jenkins {
+		return &v1alpha2.Jenkins{
+			ObjectMeta: metav1.ObjectMeta{Name: "example", Namespace: "default"},
+			Spec: v1alpha2.JenkinsSpec{
+				Master: v1alpha2.JenkinsMaster{
+					Containers:     []v1alpha2.Container{{Name: JenkinsMasterContainerName}},
+					BuildDiscarder: buildDiscarder,
+				},
+			},
+		}
+	}
+	daysToKeep, numToKeep := int32(30), int32(100)
+
+	t.Run("days and number of builds", func(t *testing.T) {
+		configMap, err := NewBaseConfigurationConfigMap(metav1.ObjectMeta{}, newJenkins(&v1alpha2.BuildDiscarder{DaysToKeep: &daysToKeep, NumToKeep: &numToKeep}), "cluster.local")
+
+		require.NoError(t, err)
+		assert.Contains(t, configMap.Data[configurationAsCodeGroovyScriptName], `def config = '''unclassified:
+  buildDiscarders:
+    configuredBuildDiscarders:
+    - jobBuildDiscarder
+    - simpleBuildDiscarder:
+        discarder:
+          logRotator:
+            daysToKeepStr: "30"
+            numToKeepStr: "100"
+'''`)
+	})
+	t.Run("only number of builds", func(t *testing.T) {
+		configMap, err := NewBaseConfigurationConfigMap(metav1.ObjectMeta{}, newJenkins(&v1alpha2.BuildDiscarder{NumToKeep: &numToKeep}), "cluster.local")
+
+		require.NoError(t, err)
+		assert.Contains(t, configMap.Data[configurationAsCodeGroovyScriptName], `          logRotator:
+            numToKeepStr: "100"
+'''`)
+	})
+}
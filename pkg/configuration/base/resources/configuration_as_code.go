@@ -0,0 +1,43 @@
+package resources
+
+import (
+	"fmt"
+	"strings"
+
+	stackerr "github.com/pkg/errors"
+	"sigs.k8s.io/yaml"
+)
+
+const applyConfigurationAsCodeFmt = `
+import io.jenkins.plugins.casc.ConfigurationAsCode
+import io.jenkins.plugins.casc.yaml.YamlSource
+
+def config = '''%s'''
+
+ConfigurationAsCode.get().configureWith(YamlSource.of(new ByteArrayInputStream(config.getBytes('UTF-8'))))
+`
+
+// buildApplyConfigurationAsCodeGroovyScript serializes the Configuration as Code fragment to YAML
+// and wraps it with the groovy script which applies it
+func buildApplyConfigurationAsCodeGroovyScript(fragment interface{}) (string, error) {
+	config, err := yaml.Marshal(fragment)
+	if err != nil {
+		return "", stackerr.WithStack(err)
+	}
+
+	escaped := strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(string(config))
+	return fmt.Sprintf(applyConfigurationAsCodeFmt, escaped), nil
+}
+
+// mergeConfigurationAsCode merges the Configuration as Code fragment into dst, nested sections are merged recursively
+func mergeConfigurationAsCode(dst, fragment map[string]interface{}) {
+	for key, value := range fragment {
+		dstSection, dstIsSection := dst[key].(map[string]interface{})
+		section, isSection := value.(map[string]interface{})
+		if dstIsSection && isSection {
+			mergeConfigurationAsCode(dstSection, section)
+			continue
+		}
+		dst[key] = value
+	}
+}
@@ -14,23 +14,25 @@ import (
 // NewJenkinsMasterPod builds Jenkins Master Kubernetes Pod resource.
 func NewJenkinsDeployment(objectMeta metav1.ObjectMeta, jenkins *v1alpha2.Jenkins) *appsv1.Deployment {
 	serviceAccountName := objectMeta.Name
-	objectMeta.Annotations = jenkins.Spec.Master.Annotations
+	objectMeta.Annotations = GetJenkinsMasterPodAnnotations(jenkins)
 	objectMeta.Name = GetJenkinsDeploymentName(jenkins)
 	selector := &metav1.LabelSelector{MatchLabels: objectMeta.Labels}
 	return &appsv1.Deployment{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      objectMeta.Name,
-			Namespace: objectMeta.Namespace,
-			Labels:    objectMeta.Labels,
+			Name:        objectMeta.Name,
+			Namespace:   objectMeta.Namespace,
+			Labels:      objectMeta.Labels,
+			Annotations: jenkins.Spec.Master.DeploymentAnnotations,
 		},
 		Spec: appsv1.DeploymentSpec{
-			Replicas: pointer.Int32Ptr(1),
+			Replicas: pointer.Int32Ptr(GetJenkinsDeploymentReplicas(jenkins)),
 			Strategy: appsv1.DeploymentStrategy{Type: appsv1.RollingUpdateDeploymentStrategyType},
 			Template: corev1.PodTemplateSpec{
 				ObjectMeta: objectMeta,
 				Spec: corev1.PodSpec{
 					ServiceAccountName: serviceAccountName,
 					NodeSelector:       jenkins.Spec.Master.NodeSelector,
+					InitContainers:     NewJenkinsMasterInitContainers(jenkins),
 					Containers:         newContainers(jenkins),
 					Volumes:            append(GetJenkinsMasterPodBaseVolumes(jenkins), jenkins.Spec.Master.Volumes...),
 					SecurityContext:    jenkins.Spec.Master.SecurityContext,
@@ -49,3 +51,27 @@ func NewJenkinsDeployment(objectMeta metav1.ObjectMeta, jenkins *v1alpha2.Jenkin
 func GetJenkinsDeploymentName(jenkins *v1alpha2.Jenkins) string {
 	return fmt.Sprintf("jenkins-%s", jenkins.Name)
 }
+
+// IsJenkinsHomeStorageReadWriteMany returns true when the Jenkins home persistent volume claim can be mounted by many nodes
+func IsJenkinsHomeStorageReadWriteMany(jenkins *v1alpha2.Jenkins) bool {
+	storage := jenkins.Spec.Master.JenkinsHomeStorage
+	if storage == nil {
+		return false
+	}
+	for _, accessMode := range storage.AccessModes {
+		if accessMode == corev1.ReadWriteMany {
+			return true
+		}
+	}
+	return false
+}
+
+// GetJenkinsDeploymentReplicas returns the number of Jenkins master replicas, 0 when Jenkins is stopped and 1 otherwise
+// because Jenkins doesn't support more than one active master
+func GetJenkinsDeploymentReplicas(jenkins *v1alpha2.Jenkins) int32 {
+	replicas := jenkins.Spec.Master.Replicas
+	if replicas != nil && *replicas == 0 {
+		return 0
+	}
+	return 1
+}
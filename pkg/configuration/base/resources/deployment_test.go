@@ -0,0 +1,72 @@
+package resources
+
+import (
+	"testing"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+)
+
+func TestNewJenkinsDeploymentReplicas(t *testing.T) {
+	newJenkins := func(replicas *int32) *v1alpha2.Jenkins {
+		return &v1alpha2.Jenkins{
+			ObjectMeta: metav1.ObjectMeta{Name: "example", Namespace: "default"},
+			Spec: v1alpha2.JenkinsSpec{
+				Master: v1alpha2.JenkinsMaster{
+					Containers: []v1alpha2.Container{{Name: JenkinsMasterContainerName, ReadinessProbe: &corev1.Probe{}}},
+					Replicas:   replicas,
+				},
+			},
+		}
+	}
+
+	t.Run("single replica by default", func(t *testing.T) {
+		deployment := NewJenkinsDeployment(NewResourceObjectMeta(newJenkins(nil)), newJenkins(nil))
+
+		assert.Equal(t, pointer.Int32Ptr(1), deployment.Spec.Replicas)
+	})
+	t.Run("zero replicas", func(t *testing.T) {
+		jenkins := newJenkins(pointer.Int32Ptr(0))
+
+		deployment := NewJenkinsDeployment(NewResourceObjectMeta(jenkins), jenkins)
+
+		assert.Equal(t, pointer.Int32Ptr(0), deployment.Spec.Replicas)
+	})
+	t.Run("never more than one replica", func(t *testing.T) {
+		jenkins := newJenkins(pointer.Int32Ptr(2))
+		jenkins.Spec.Master.JenkinsHomeStorage = &v1alpha2.JenkinsHomeStorage{
+			Size:        resource.MustParse("10Gi"),
+			AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteMany},
+		}
+
+		deployment := NewJenkinsDeployment(NewResourceObjectMeta(jenkins), jenkins)
+
+		assert.Equal(t, pointer.Int32Ptr(1), deployment.Spec.Replicas)
+	})
+}
+
+func TestNewJenkinsDeploymentAnnotations(t *testing.T) {
+	// given
+	jenkins := &v1alpha2.Jenkins{
+		ObjectMeta: metav1.ObjectMeta{Name: "example", Namespace: "default"},
+		Spec: v1alpha2.JenkinsSpec{
+			Master: v1alpha2.JenkinsMaster{
+				Annotations:           map[string]string{"pod": "annotation"},
+				DeploymentAnnotations: map[string]string{"argocd.argoproj.io/sync-wave": "1"},
+				Containers:            []v1alpha2.Container{{Name: JenkinsMasterContainerName, ReadinessProbe: &corev1.Probe{}}},
+			},
+		},
+	}
+
+	// when
+	deployment := NewJenkinsDeployment(NewResourceObjectMeta(jenkins), jenkins)
+
+	// then
+	assert.Equal(t, map[string]string{"argocd.argoproj.io/sync-wave": "1"}, deployment.Annotations)
+	assert.Equal(t, map[string]string{"pod": "annotation"}, deployment.Spec.Template.Annotations)
+}
@@ -0,0 +1,29 @@
+package resources
+
+import (
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+)
+
+// BuildGlobalEnvVarsConfiguration builds the globalNodeProperties section of the Configuration as Code which sets
+// the global environment variables of Jenkins
+func BuildGlobalEnvVarsConfiguration(globalEnvVars []v1alpha2.KeyValue) map[string]interface{} {
+	env := make([]interface{}, 0, len(globalEnvVars))
+	for _, envVar := range globalEnvVars {
+		env = append(env, map[string]interface{}{
+			"key":   envVar.Key,
+			"value": envVar.Value,
+		})
+	}
+
+	return map[string]interface{}{
+		"jenkins": map[string]interface{}{
+			"globalNodeProperties": []interface{}{
+				map[string]interface{}{
+					"envVars": map[string]interface{}{
+						"env": env,
+					},
+				},
+			},
+		},
+	}
+}
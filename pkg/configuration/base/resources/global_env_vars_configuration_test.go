@@ -0,0 +1,31 @@
+package resources
+
+import (
+	"testing"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/yaml"
+)
+
+func TestBuildGlobalEnvVarsConfiguration(t *testing.T) {
+	globalEnvVars := []v1alpha2.KeyValue{
+		{Key: "ENVIRONMENT", Value: "production"},
+		{Key: "DOCKER_REGISTRY", Value: "registry.example.com"},
+	}
+
+	got, err := yaml.Marshal(BuildGlobalEnvVarsConfiguration(globalEnvVars))
+
+	require.NoError(t, err)
+	assert.Equal(t, `jenkins:
+  globalNodeProperties:
+  - envVars:
+      env:
+      - key: ENVIRONMENT
+        value: production
+      - key: DOCKER_REGISTRY
+        value: registry.example.com
+`, string(got))
+}
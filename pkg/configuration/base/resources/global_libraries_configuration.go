@@ -0,0 +1,45 @@
+package resources
+
+import (
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+)
+
+type cascGlobalLibrary struct {
+	Name           string                 `json:"name"`
+	DefaultVersion string                 `json:"defaultVersion,omitempty"`
+	Implicit       bool                   `json:"implicit"`
+	Retriever      map[string]interface{} `json:"retriever"`
+}
+
+type cascGitSCM struct {
+	Remote        string `json:"remote"`
+	CredentialsID string `json:"credentialsId,omitempty"`
+}
+
+// BuildGlobalLibrariesConfiguration builds the globalLibraries section of the Configuration as Code from
+// spec.globalPipelineLibraries, the libraries are retrieved from Git with the modern SCM retriever
+func BuildGlobalLibrariesConfiguration(libraries []v1alpha2.Library) map[string]interface{} {
+	var cascLibraries []cascGlobalLibrary
+	for _, library := range libraries {
+		cascLibraries = append(cascLibraries, cascGlobalLibrary{
+			Name:           library.Name,
+			DefaultVersion: library.DefaultVersion,
+			Implicit:       library.Implicit,
+			Retriever: map[string]interface{}{
+				"modernSCM": map[string]interface{}{
+					"scm": map[string]interface{}{
+						"git": cascGitSCM{Remote: library.RepositoryURL, CredentialsID: library.CredentialID},
+					},
+				},
+			},
+		})
+	}
+
+	return map[string]interface{}{
+		"unclassified": map[string]interface{}{
+			"globalLibraries": map[string]interface{}{
+				"libraries": cascLibraries,
+			},
+		},
+	}
+}
@@ -0,0 +1,62 @@
+package resources
+
+import (
+	"testing"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestNewBaseConfigurationConfigMapGlobalPipelineLibraries(t *testing.T) {
+	// given
+	jenkins := &v1alpha2.Jenkins{
+		ObjectMeta: metav1.ObjectMeta{Name: "example", Namespace: "default"},
+		Spec: v1alpha2.JenkinsSpec{
+			Master: v1alpha2.JenkinsMaster{
+				Containers: []v1alpha2.Container{{Name: JenkinsMasterContainerName}},
+			},
+			GlobalPipelineLibraries: []v1alpha2.Library{
+				{
+					Name:           "pipeline-library",
+					RepositoryURL:  "https://github.com/example/pipeline-library.git",
+					DefaultVersion: "main",
+					Implicit:       true,
+				},
+				{
+					Name:          "deploy-library",
+					RepositoryURL: "git@github.com:example/deploy-library.git",
+					CredentialID:  "deploy-library-ssh",
+				},
+			},
+		},
+	}
+
+	// when
+	configMap, err := NewBaseConfigurationConfigMap(metav1.ObjectMeta{}, jenkins, "cluster.local")
+
+	// then
+	require.NoError(t, err)
+	assert.Contains(t, configMap.Data[configurationAsCodeGroovyScriptName], `def config = '''unclassified:
+  globalLibraries:
+    libraries:
+    - defaultVersion: main
+      implicit: true
+      name: pipeline-library
+      retriever:
+        modernSCM:
+          scm:
+            git:
+              remote: https://github.com/example/pipeline-library.git
+    - implicit: false
+      name: deploy-library
+      retriever:
+        modernSCM:
+          scm:
+            git:
+              credentialsId: deploy-library-ssh
+              remote: git@github.com:example/deploy-library.git
+'''`)
+}
@@ -12,10 +12,19 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
-const createOperatorUserFileName = "createOperatorUser.groovy"
+const (
+	createOperatorUserFileName = "createOperatorUser.groovy"
+	// systemConfigGroovyFileName is the name of the spec.systemConfigGroovy init script, the init scripts run in the
+	// alphabetical order so the name makes it run first
+	systemConfigGroovyFileName = "0-system-config.groovy"
+)
 
 var createOperatorUserGroovyFmtTemplate = template.Must(template.New(createOperatorUserFileName).Parse(`
 import hudson.security.*
+{{- if .SeedAPIToken }}
+import hudson.model.User
+import jenkins.security.ApiTokenProperty
+{{- end }}
 
 {{- if .Enable }}
 def jenkins = jenkins.model.Jenkins.getInstance()
@@ -36,20 +45,36 @@ if (!operatorUserCreatedFile.exists()) {
 	operatorUserCreatedFile.createNewFile()
 }
 {{- end }}
+
+{{- if .SeedAPIToken }}
+
+// the external security realm doesn't know the operator user, it authenticates with the fixed API token
+def operatorUser = User.getById(new File('{{ .OperatorCredentialsPath }}/{{ .OperatorUserNameFile }}').text, true)
+def apiTokenStore = operatorUser.getProperty(ApiTokenProperty.class).getTokenStore()
+apiTokenStore.getTokenListSortedByName().findAll { it.name == '{{ .OperatorAPITokenName }}' }.each { apiTokenStore.revokeToken(it.uuid) }
+apiTokenStore.addFixedNewToken('{{ .OperatorAPITokenName }}', new File('{{ .OperatorCredentialsPath }}/{{ .OperatorAPITokenFile }}').text)
+operatorUser.save()
+{{- end }}
 `))
 
 func buildCreateJenkinsOperatorUserGroovyScript(jenkins *v1alpha2.Jenkins) (*string, error) {
 	data := struct {
 		Enable                      bool
+		SeedAPIToken                bool
 		OperatorCredentialsPath     string
 		OperatorUserNameFile        string
 		OperatorPasswordFile        string
+		OperatorAPITokenFile        string
+		OperatorAPITokenName        string
 		OperatorUserCreatedFilePath string
 	}{
 		Enable:                      jenkins.Spec.JenkinsAPISettings.AuthorizationStrategy == v1alpha2.CreateUserAuthorizationStrategy,
+		SeedAPIToken:                IsOperatorAPITokenUsed(jenkins),
 		OperatorCredentialsPath:     jenkinsOperatorCredentialsVolumePath,
 		OperatorUserNameFile:        OperatorCredentialsSecretUserNameKey,
 		OperatorPasswordFile:        OperatorCredentialsSecretPasswordKey,
+		OperatorAPITokenFile:        OperatorCredentialsSecretAPITokenKey,
+		OperatorAPITokenName:        OperatorAPITokenName,
 		OperatorUserCreatedFilePath: getJenkinsHomePath(jenkins) + "/operatorUserCreated",
 	}
 
@@ -75,11 +100,16 @@ func NewInitConfigurationConfigMap(meta metav1.ObjectMeta, jenkins *v1alpha2.Jen
 		return nil, err
 	}
 
+	data := map[string]string{
+		createOperatorUserFileName: *createJenkinsOperatorUserGroovy,
+	}
+	if len(jenkins.Spec.SystemConfigGroovy) > 0 {
+		data[systemConfigGroovyFileName] = jenkins.Spec.SystemConfigGroovy
+	}
+
 	return &corev1.ConfigMap{
 		TypeMeta:   buildConfigMapTypeMeta(),
 		ObjectMeta: meta,
-		Data: map[string]string{
-			createOperatorUserFileName: *createJenkinsOperatorUserGroovy,
-		},
+		Data:       data,
 	}, nil
 }
@@ -0,0 +1,98 @@
+package resources
+
+import (
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestNewInitConfigurationConfigMapSystemConfigGroovy(t *testing.T) {
+	newJenkins := func(systemConfigGroovy string) *v1alpha2.Jenkins {
+		return &v1alpha2.Jenkins{
+			ObjectMeta: metav1.ObjectMeta{Name: "example", Namespace: "default"},
+			Spec: v1alpha2.JenkinsSpec{
+				Master:             v1alpha2.JenkinsMaster{Containers: []v1alpha2.Container{{Name: JenkinsMasterContainerName}}},
+				SystemConfigGroovy: systemConfigGroovy,
+			},
+		}
+	}
+
+	t.Run("runs before the other init scripts", func(t *testing.T) {
+		configMap, err := NewInitConfigurationConfigMap(metav1.ObjectMeta{}, newJenkins("Jenkins.instance.setNumExecutors(0)"))
+
+		require.NoError(t, err)
+		assert.Equal(t, "Jenkins.instance.setNumExecutors(0)", configMap.Data[systemConfigGroovyFileName])
+		var names []string
+		for name := range configMap.Data {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		assert.Equal(t, []string{systemConfigGroovyFileName, createOperatorUserFileName}, names)
+	})
+	t.Run("not set", func(t *testing.T) {
+		configMap, err := NewInitConfigurationConfigMap(metav1.ObjectMeta{}, newJenkins(""))
+
+		require.NoError(t, err)
+		assert.NotContains(t, configMap.Data, systemConfigGroovyFileName)
+	})
+	t.Run("replaced on every start", func(t *testing.T) {
+		initBashScript, err := buildInitBashScript(newJenkins("Jenkins.instance.setNumExecutors(0)"), false)
+
+		require.NoError(t, err)
+		assert.Contains(t, *initBashScript, "rm -f /var/lib/jenkins/init.groovy.d/0-system-config.groovy\n")
+		assert.Less(t, strings.Index(*initBashScript, "rm -f /var/lib/jenkins/init.groovy.d/0-system-config.groovy"),
+			strings.Index(*initBashScript, "cp -n /var/jenkins/init-configuration/*.groovy /var/lib/jenkins/init.groovy.d"))
+	})
+}
+
+func TestNewInitConfigurationConfigMapOperatorAPIToken(t *testing.T) {
+	newJenkins := func(ldap *v1alpha2.LDAP, oidc *v1alpha2.OIDC) *v1alpha2.Jenkins {
+		return &v1alpha2.Jenkins{
+			ObjectMeta: metav1.ObjectMeta{Name: "example", Namespace: "default"},
+			Spec: v1alpha2.JenkinsSpec{
+				JenkinsAPISettings: v1alpha2.JenkinsAPISettings{AuthorizationStrategy: v1alpha2.CreateUserAuthorizationStrategy},
+				Master: v1alpha2.JenkinsMaster{
+					Containers: []v1alpha2.Container{{Name: JenkinsMasterContainerName}},
+					LDAP:       ldap,
+					OIDC:       oidc,
+				},
+			},
+		}
+	}
+
+	t.Run("API token is seeded with LDAP security realm", func(t *testing.T) {
+		configMap, err := NewInitConfigurationConfigMap(metav1.ObjectMeta{}, newJenkins(&v1alpha2.LDAP{Server: "ldaps://ldap.example.com"}, nil))
+
+		require.NoError(t, err)
+		script := configMap.Data[createOperatorUserFileName]
+		assert.Contains(t, script, "import jenkins.security.ApiTokenProperty\n")
+		assert.Contains(t, script, "def operatorUser = User.getById(new File('/var/jenkins/operator-credentials/user').text, true)\n")
+		assert.Contains(t, script, "apiTokenStore.addFixedNewToken('jenkins-operator', new File('/var/jenkins/operator-credentials/apiToken').text)\n")
+		// the token is seeded on every start, not only when the operator user is created
+		assert.Greater(t, strings.Index(script, "apiTokenStore.addFixedNewToken"), strings.Index(script, "operatorUserCreatedFile.createNewFile()\n}"))
+	})
+	t.Run("API token is seeded with OpenID Connect security realm", func(t *testing.T) {
+		configMap, err := NewInitConfigurationConfigMap(metav1.ObjectMeta{}, newJenkins(nil, &v1alpha2.OIDC{Issuer: "https://accounts.example.com"}))
+
+		require.NoError(t, err)
+		assert.Contains(t, configMap.Data[createOperatorUserFileName], "apiTokenStore.addFixedNewToken('jenkins-operator', new File('/var/jenkins/operator-credentials/apiToken').text)\n")
+	})
+	t.Run("API token isn't seeded with local security realm", func(t *testing.T) {
+		configMap, err := NewInitConfigurationConfigMap(metav1.ObjectMeta{}, newJenkins(nil, nil))
+
+		require.NoError(t, err)
+		assert.NotContains(t, configMap.Data[createOperatorUserFileName], "ApiTokenProperty")
+	})
+}
+
+func TestNewOperatorCredentialsSecret(t *testing.T) {
+	secret := NewOperatorCredentialsSecret(metav1.ObjectMeta{}, &v1alpha2.Jenkins{ObjectMeta: metav1.ObjectMeta{Name: "example"}})
+
+	assert.Regexp(t, "^11[0-9a-f]{32}$", string(secret.Data[OperatorCredentialsSecretAPITokenKey]))
+}
@@ -0,0 +1,410 @@
+package resources
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+
+	stackerr "github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// KubernetesCloudName is the name of the Kubernetes plugin cloud of the Jenkins namespace
+	KubernetesCloudName = "kubernetes"
+
+	kubernetesCloudRetentionTimeout = 15
+	agentJNLPContainerName          = "jnlp"
+	// defaultAgentImage is the image of the jnlp container template when spec.master.agent.image is not set
+	defaultAgentImage = "jenkins/inbound-agent:4.10-3"
+)
+
+// KubernetesCloud defines the connection settings of the Kubernetes plugin cloud configured by the operator
+type KubernetesCloud struct {
+	ServerURL     string
+	Namespace     string
+	JenkinsURL    string
+	JenkinsTunnel string
+}
+
+type cascKubernetesCloud struct {
+	Name              string `json:"name"`
+	ServerURL         string `json:"serverUrl"`
+	CredentialsID     string `json:"credentialsId,omitempty"`
+	ServerCertificate string `json:"serverCertificate,omitempty"`
+
+	Namespace        string            `json:"namespace"`
+	JenkinsURL       string            `json:"jenkinsUrl"`
+	JenkinsTunnel    string            `json:"jenkinsTunnel"`
+	RetentionTimeout int               `json:"retentionTimeout"`
+	ContainerCapStr  string            `json:"containerCapStr,omitempty"`
+	WebSocket        *bool             `json:"webSocket,omitempty"`
+	Templates        []cascPodTemplate `json:"templates"`
+}
+
+type cascPodTemplate struct {
+	Name         string `json:"name"`
+	Label        string `json:"label"`
+	InheritFrom  string `json:"inheritFrom,omitempty"`
+	NodeSelector string `json:"nodeSelector,omitempty"`
+	IdleMinutes  int32  `json:"idleMinutes,omitempty"`
+	InstanceCap  int32  `json:"instanceCap,omitempty"`
+	YAML         string `json:"yaml,omitempty"`
+
+	YAMLs             []string `json:"yamls,omitempty"`
+	YAMLMergeStrategy string   `json:"yamlMergeStrategy,omitempty"`
+
+	SlaveConnectTimeout   int32 `json:"slaveConnectTimeout,omitempty"`
+	ActiveDeadlineSeconds int32 `json:"activeDeadlineSeconds,omitempty"`
+
+	WorkspaceVolume  map[string]interface{} `json:"workspaceVolume,omitempty"`
+	NodeProperties   []interface{}          `json:"nodeProperties,omitempty"`
+	ImagePullSecrets []cascImagePullSecret  `json:"imagePullSecrets,omitempty"`
+	Annotations      []cascPodAnnotation    `json:"annotations,omitempty"`
+
+	Containers []cascContainerTemplate `json:"containers,omitempty"`
+}
+
+// agentPod is the raw yaml of the agent pod merged by the Kubernetes plugin, only the fields set by the operator
+// are serialized
+type agentPod struct {
+	Metadata *agentPodMetadata `json:"metadata,omitempty"`
+	Spec     *agentPodSpec     `json:"spec,omitempty"`
+}
+
+type agentPodMetadata struct {
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+type agentPodSpec struct {
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+	Volumes     []corev1.Volume     `json:"volumes,omitempty"`
+	Containers  []agentPodContainer `json:"containers,omitempty"`
+}
+
+type agentPodContainer struct {
+	Name         string               `json:"name"`
+	VolumeMounts []corev1.VolumeMount `json:"volumeMounts,omitempty"`
+}
+
+type cascPodAnnotation struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+type cascImagePullSecret struct {
+	Name string `json:"name"`
+}
+
+type cascContainerTemplate struct {
+	Name            string `json:"name"`
+	Image           string `json:"image"`
+	AlwaysPullImage bool   `json:"alwaysPullImage,omitempty"`
+	WorkingDir      string `json:"workingDir,omitempty"`
+	Command         string `json:"command,omitempty"`
+	Args            string `json:"args,omitempty"`
+
+	ResourceRequestCPU    string `json:"resourceRequestCpu,omitempty"`
+	ResourceRequestMemory string `json:"resourceRequestMemory,omitempty"`
+	ResourceLimitCPU      string `json:"resourceLimitCpu,omitempty"`
+	ResourceLimitMemory   string `json:"resourceLimitMemory,omitempty"`
+}
+
+func quantityString(quantity *resource.Quantity) string {
+	if quantity == nil {
+		return ""
+	}
+	return quantity.String()
+}
+
+// buildContainerCommand joins the command or the arguments to the Kubernetes plugin format, the arguments with
+// whitespaces are double quoted
+func buildContainerCommand(parts []string) string {
+	quoted := make([]string, 0, len(parts))
+	for _, part := range parts {
+		if strings.ContainsAny(part, " \t\n") {
+			part = fmt.Sprintf(`"%s"`, part)
+		}
+		quoted = append(quoted, part)
+	}
+	return strings.Join(quoted, " ")
+}
+
+// buildCascJNLPContainer builds the jnlp container template, nil when neither the image, the remote FS root, the command
+// nor the resources are set
+func buildCascJNLPContainer(agent v1alpha2.JenkinsAgent, podTemplate v1alpha2.AgentPodTemplate) *cascContainerTemplate {
+	container := cascContainerTemplate{
+		Name:                  agentJNLPContainerName,
+		Image:                 agent.Image,
+		AlwaysPullImage:       agent.ImagePullPolicy == corev1.PullAlways,
+		WorkingDir:            podTemplate.RemoteFSRoot,
+		Command:               buildContainerCommand(podTemplate.Command),
+		Args:                  buildContainerCommand(podTemplate.Args),
+		ResourceRequestCPU:    quantityString(podTemplate.ResourceRequestCPU),
+		ResourceRequestMemory: quantityString(podTemplate.ResourceRequestMemory),
+		ResourceLimitCPU:      quantityString(podTemplate.ResourceLimitCPU),
+		ResourceLimitMemory:   quantityString(podTemplate.ResourceLimitMemory),
+	}
+	hasResources := len(container.ResourceRequestCPU) > 0 || len(container.ResourceRequestMemory) > 0 ||
+		len(container.ResourceLimitCPU) > 0 || len(container.ResourceLimitMemory) > 0
+	hasCommand := len(container.WorkingDir) > 0 || len(container.Command) > 0 || len(container.Args) > 0
+	if len(container.Image) == 0 {
+		if !hasResources && !hasCommand {
+			return nil
+		}
+		container.Image = defaultAgentImage
+	}
+	return &container
+}
+
+// buildNodeSelector serializes the node selector to the key=value,key=value format of the Kubernetes plugin
+func buildNodeSelector(nodeSelector map[string]string) string {
+	var selectors []string
+	for key, value := range nodeSelector {
+		selectors = append(selectors, fmt.Sprintf("%s=%s", key, value))
+	}
+	sort.Strings(selectors)
+	return strings.Join(selectors, ",")
+}
+
+// ToolDescriptors maps the tool types to the descriptors of the tool installations
+var ToolDescriptors = map[v1alpha2.ToolType]string{
+	v1alpha2.JDKToolType:    "hudson.model.JDK$DescriptorImpl",
+	v1alpha2.MavenToolType:  "hudson.tasks.Maven$MavenInstallation$DescriptorImpl",
+	v1alpha2.GradleToolType: "hudson.plugins.gradle.GradleInstallation$DescriptorImpl",
+}
+
+// buildCascNodeProperties builds the node properties of the Kubernetes plugin pod template
+func buildCascNodeProperties(nodeProperties v1alpha2.AgentNodeProperties) []interface{} {
+	var properties []interface{}
+	if len(nodeProperties.EnvVars) > 0 {
+		env := make([]interface{}, 0, len(nodeProperties.EnvVars))
+		for _, envVar := range nodeProperties.EnvVars {
+			env = append(env, map[string]interface{}{"key": envVar.Key, "value": envVar.Value})
+		}
+		properties = append(properties, map[string]interface{}{
+			"envVars": map[string]interface{}{"env": env},
+		})
+	}
+	if len(nodeProperties.ToolLocations) > 0 {
+		locations := make([]interface{}, 0, len(nodeProperties.ToolLocations))
+		for _, toolLocation := range nodeProperties.ToolLocations {
+			locations = append(locations, map[string]interface{}{
+				"key":  fmt.Sprintf("%s@%s", ToolDescriptors[toolLocation.Type], toolLocation.Name),
+				"home": toolLocation.Home,
+			})
+		}
+		properties = append(properties, map[string]interface{}{
+			"toolLocation": map[string]interface{}{"locations": locations},
+		})
+	}
+	return properties
+}
+
+// buildCascWorkspaceVolume builds the workspace volume of the Kubernetes plugin pod template
+func buildCascWorkspaceVolume(workspaceVolume v1alpha2.AgentWorkspaceVolume) map[string]interface{} {
+	switch {
+	case workspaceVolume.EmptyDir != nil:
+		return map[string]interface{}{
+			"emptyDirWorkspaceVolume": map[string]interface{}{
+				"memory": workspaceVolume.EmptyDir.Medium == corev1.StorageMediumMemory,
+			},
+		}
+	case workspaceVolume.PersistentVolumeClaim != nil:
+		return map[string]interface{}{
+			"persistentVolumeClaimWorkspaceVolume": map[string]interface{}{
+				"claimName": workspaceVolume.PersistentVolumeClaim.ClaimName,
+				"readOnly":  workspaceVolume.PersistentVolumeClaim.ReadOnly,
+			},
+		}
+	case workspaceVolume.Ephemeral != nil:
+		accessMode := workspaceVolume.Ephemeral.AccessMode
+		if len(accessMode) == 0 {
+			accessMode = corev1.ReadWriteOnce
+		}
+		ephemeral := map[string]interface{}{
+			"accessModes":  accessMode,
+			"requestsSize": workspaceVolume.Ephemeral.Size.String(),
+		}
+		if len(workspaceVolume.Ephemeral.StorageClassName) > 0 {
+			ephemeral["storageClassName"] = workspaceVolume.Ephemeral.StorageClassName
+		}
+		return map[string]interface{}{"genericEphemeralVolume": ephemeral}
+	}
+	return nil
+}
+
+func buildCascPodTemplate(agent v1alpha2.JenkinsAgent, podTemplate v1alpha2.AgentPodTemplate) (cascPodTemplate, error) {
+	template := cascPodTemplate{
+		Name:         podTemplate.Name,
+		Label:        podTemplate.Label,
+		InheritFrom:  strings.Join(podTemplate.InheritFrom, " "),
+		NodeSelector: buildNodeSelector(podTemplate.NodeSelector),
+	}
+	if len(template.Label) == 0 {
+		template.Label = podTemplate.Name
+	}
+	if podTemplate.IdleMinutes != nil {
+		template.IdleMinutes = *podTemplate.IdleMinutes
+	} else if agent.IdleMinutes != nil {
+		template.IdleMinutes = *agent.IdleMinutes
+	}
+	if podTemplate.InstanceCap != nil {
+		template.InstanceCap = *podTemplate.InstanceCap
+	}
+	if podTemplate.NodeProperties != nil {
+		template.NodeProperties = buildCascNodeProperties(*podTemplate.NodeProperties)
+	}
+	if podTemplate.WorkspaceVolume != nil {
+		template.WorkspaceVolume = buildCascWorkspaceVolume(*podTemplate.WorkspaceVolume)
+	}
+	if podTemplate.ConnectTimeout != nil {
+		template.SlaveConnectTimeout = *podTemplate.ConnectTimeout
+	} else if agent.ConnectTimeout != nil {
+		template.SlaveConnectTimeout = *agent.ConnectTimeout
+	}
+	if podTemplate.ActiveDeadlineSeconds != nil {
+		template.ActiveDeadlineSeconds = *podTemplate.ActiveDeadlineSeconds
+	}
+	var annotationKeys []string
+	for key := range podTemplate.Annotations {
+		annotationKeys = append(annotationKeys, key)
+	}
+	sort.Strings(annotationKeys)
+	for _, key := range annotationKeys {
+		template.Annotations = append(template.Annotations, cascPodAnnotation{Key: key, Value: podTemplate.Annotations[key]})
+	}
+	for _, imagePullSecret := range podTemplate.ImagePullSecrets {
+		template.ImagePullSecrets = append(template.ImagePullSecrets, cascImagePullSecret{Name: imagePullSecret.Name})
+	}
+	if container := buildCascJNLPContainer(agent, podTemplate); container != nil {
+		template.Containers = []cascContainerTemplate{*container}
+	}
+	spec := agentPodSpec{}
+	tolerations := podTemplate.Tolerations
+	if len(tolerations) == 0 && (podTemplate.InheritDefaultTolerations == nil || *podTemplate.InheritDefaultTolerations) {
+		tolerations = agent.DefaultPodTolerations
+	}
+	if len(tolerations) > 0 {
+		spec.Tolerations = tolerations
+	}
+	if len(podTemplate.Volumes) > 0 {
+		var volumeMounts []corev1.VolumeMount
+		for _, volume := range podTemplate.Volumes {
+			spec.Volumes = append(spec.Volumes, volume.Volume)
+			volumeMounts = append(volumeMounts, corev1.VolumeMount{Name: volume.Name, MountPath: volume.MountPath, ReadOnly: volume.ReadOnly})
+		}
+		// the Kubernetes plugin merges the container with the jnlp name into its default jnlp container
+		spec.Containers = []agentPodContainer{{Name: agentJNLPContainerName, VolumeMounts: volumeMounts}}
+	}
+	pod := agentPod{}
+	if !reflect.DeepEqual(spec, agentPodSpec{}) {
+		pod.Spec = &spec
+	}
+	// the Kubernetes plugin pod template has no labels setting, they are merged from the raw yaml
+	if len(podTemplate.Labels) > 0 {
+		pod.Metadata = &agentPodMetadata{Labels: podTemplate.Labels}
+	}
+	if pod.Metadata != nil || pod.Spec != nil {
+		podYAML, err := yaml.Marshal(pod)
+		if err != nil {
+			return cascPodTemplate{}, stackerr.WithStack(err)
+		}
+		template.YAML = string(podYAML)
+	}
+	// the Kubernetes plugin merges the yamls in order, so the raw yaml of the user overrides the generated one
+	if len(podTemplate.Yaml) > 0 {
+		if len(template.YAML) > 0 {
+			template.YAMLs = append(template.YAMLs, template.YAML)
+		}
+		template.YAMLs = append(template.YAMLs, podTemplate.Yaml)
+		template.YAML = ""
+	}
+	template.YAMLMergeStrategy = string(podTemplate.YamlMergeStrategy)
+	return template, nil
+}
+
+// buildCascPodTemplates builds the pod templates with the names, all pod templates when the names are empty
+func buildCascPodTemplates(agent v1alpha2.JenkinsAgent, names []string) ([]cascPodTemplate, error) {
+	selected := map[string]bool{}
+	for _, name := range names {
+		selected[name] = true
+	}
+	templates := []cascPodTemplate{}
+	for _, podTemplate := range agent.PodTemplates {
+		if len(names) > 0 && !selected[podTemplate.Name] {
+			continue
+		}
+		template, err := buildCascPodTemplate(agent, podTemplate)
+		if err != nil {
+			return nil, err
+		}
+		templates = append(templates, template)
+	}
+	return templates, nil
+}
+
+// HasKubernetesCloudConfiguration returns true if spec.master.agent sets any of the Kubernetes plugin cloud settings,
+// the cloud is configured by the Configuration as Code also without the pod templates
+func HasKubernetesCloudConfiguration(agent *v1alpha2.JenkinsAgent) bool {
+	if agent == nil {
+		return false
+	}
+	return len(agent.PodTemplates) > 0 || len(agent.Clouds) > 0 ||
+		agent.ContainerCap != nil || agent.WebSocket != nil || agent.ConnectTimeout != nil || agent.IdleMinutes != nil ||
+		len(agent.JenkinsURL) > 0 || len(agent.JenkinsTunnel) > 0 || len(agent.Image) > 0 || len(agent.ImagePullPolicy) > 0 ||
+		len(agent.DefaultPodTolerations) > 0
+}
+
+// BuildKubernetesCloudConfiguration builds the clouds section of the Configuration as Code with the Kubernetes plugin
+// cloud, the additional clouds from spec.master.agent.clouds and the agent pod templates
+func BuildKubernetesCloudConfiguration(cloud KubernetesCloud, agent v1alpha2.JenkinsAgent) (map[string]interface{}, error) {
+	kubernetes := cascKubernetesCloud{
+		Name:             KubernetesCloudName,
+		ServerURL:        cloud.ServerURL,
+		Namespace:        cloud.Namespace,
+		JenkinsURL:       cloud.JenkinsURL,
+		JenkinsTunnel:    cloud.JenkinsTunnel,
+		RetentionTimeout: kubernetesCloudRetentionTimeout,
+		WebSocket:        agent.WebSocket,
+	}
+	if agent.ContainerCap != nil {
+		kubernetes.ContainerCapStr = strconv.Itoa(int(*agent.ContainerCap))
+	}
+	templates, err := buildCascPodTemplates(agent, nil)
+	if err != nil {
+		return nil, err
+	}
+	kubernetes.Templates = templates
+	clouds := []interface{}{
+		map[string]interface{}{"kubernetes": kubernetes},
+	}
+
+	for _, additionalCloud := range agent.Clouds {
+		additional := kubernetes
+		additional.Name = additionalCloud.Name
+		additional.ServerURL = additionalCloud.ServerURL
+		additional.CredentialsID = additionalCloud.CredentialsID
+		additional.ServerCertificate = additionalCloud.ServerCertificate
+		additional.Namespace = additionalCloud.Namespace
+		additional.JenkinsURL = additionalCloud.JenkinsURL
+		additional.JenkinsTunnel = additionalCloud.JenkinsTunnel
+		additional.Templates, err = buildCascPodTemplates(agent, additionalCloud.PodTemplates)
+		if err != nil {
+			return nil, err
+		}
+		clouds = append(clouds, map[string]interface{}{"kubernetes": additional})
+	}
+
+	return map[string]interface{}{
+		"jenkins": map[string]interface{}{
+			"clouds": clouds,
+		},
+	}, nil
+}
@@ -0,0 +1,739 @@
+package resources
+
+import (
+	"testing"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+)
+
+func TestBuildKubernetesCloudConfiguration(t *testing.T) {
+	cloud := KubernetesCloud{
+		ServerURL:     "https://kubernetes.default.svc.cluster.local:443",
+		Namespace:     "default",
+		JenkinsURL:    "http://jenkins-operator-http-example.default.svc.cluster.local:8080",
+		JenkinsTunnel: "jenkins-operator-slave-example.default.svc.cluster.local:50000",
+	}
+
+	t.Run("pod templates with node selector and tolerations", func(t *testing.T) {
+		// given
+		podTemplates := []v1alpha2.AgentPodTemplate{
+			{
+				Name:         "linux",
+				NodeSelector: map[string]string{"kubernetes.io/os": "linux", "node-pool": "agents"},
+				Tolerations: []corev1.Toleration{
+					{Key: "dedicated", Operator: corev1.TolerationOpEqual, Value: "agents", Effect: corev1.TaintEffectNoSchedule},
+				},
+			},
+			{Name: "maven", Label: "maven java"},
+		}
+
+		// when
+		fragment, err := BuildKubernetesCloudConfiguration(cloud, v1alpha2.JenkinsAgent{PodTemplates: podTemplates})
+		require.NoError(t, err)
+		got, err := yaml.Marshal(fragment)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, `jenkins:
+  clouds:
+  - kubernetes:
+      jenkinsTunnel: jenkins-operator-slave-example.default.svc.cluster.local:50000
+      jenkinsUrl: http://jenkins-operator-http-example.default.svc.cluster.local:8080
+      name: kubernetes
+      namespace: default
+      retentionTimeout: 15
+      serverUrl: https://kubernetes.default.svc.cluster.local:443
+      templates:
+      - label: linux
+        name: linux
+        nodeSelector: kubernetes.io/os=linux,node-pool=agents
+        yaml: |
+          spec:
+            tolerations:
+            - effect: NoSchedule
+              key: dedicated
+              operator: Equal
+              value: agents
+      - label: maven java
+        name: maven
+`, string(got))
+	})
+	t.Run("default pod tolerations", func(t *testing.T) {
+		// given
+		inherit := false
+		agent := v1alpha2.JenkinsAgent{
+			DefaultPodTolerations: []corev1.Toleration{
+				{Key: "dedicated", Operator: corev1.TolerationOpEqual, Value: "agents", Effect: corev1.TaintEffectNoSchedule},
+			},
+			PodTemplates: []v1alpha2.AgentPodTemplate{
+				{Name: "linux"},
+				{Name: "gpu", Tolerations: []corev1.Toleration{{Key: "nvidia.com/gpu", Operator: corev1.TolerationOpExists}}},
+				{Name: "any", InheritDefaultTolerations: &inherit},
+				{Name: "empty", Tolerations: []corev1.Toleration{}},
+			},
+		}
+
+		// when
+		fragment, err := BuildKubernetesCloudConfiguration(cloud, agent)
+		require.NoError(t, err)
+		got, err := yaml.Marshal(fragment)
+
+		// then
+		require.NoError(t, err)
+		assert.Contains(t, string(got), `      templates:
+      - label: linux
+        name: linux
+        yaml: |
+          spec:
+            tolerations:
+            - effect: NoSchedule
+              key: dedicated
+              operator: Equal
+              value: agents
+      - label: gpu
+        name: gpu
+        yaml: |
+          spec:
+            tolerations:
+            - key: nvidia.com/gpu
+              operator: Exists
+      - label: any
+        name: any
+      - label: empty
+        name: empty
+        yaml: |
+          spec:
+            tolerations:
+            - effect: NoSchedule
+              key: dedicated
+              operator: Equal
+              value: agents
+`)
+	})
+	t.Run("idle minutes", func(t *testing.T) {
+		// given
+		agentIdleMinutes, podTemplateIdleMinutes := int32(10), int32(30)
+		agent := v1alpha2.JenkinsAgent{
+			IdleMinutes: &agentIdleMinutes,
+			PodTemplates: []v1alpha2.AgentPodTemplate{
+				{Name: "linux"},
+				{Name: "maven", IdleMinutes: &podTemplateIdleMinutes},
+			},
+		}
+
+		// when
+		fragment, err := BuildKubernetesCloudConfiguration(cloud, agent)
+		require.NoError(t, err)
+		got, err := yaml.Marshal(fragment)
+
+		// then
+		require.NoError(t, err)
+		assert.Contains(t, string(got), `      templates:
+      - idleMinutes: 10
+        label: linux
+        name: linux
+      - idleMinutes: 30
+        label: maven
+        name: maven
+`)
+	})
+	t.Run("instance cap", func(t *testing.T) {
+		// given
+		instanceCap := int32(5)
+		agent := v1alpha2.JenkinsAgent{
+			PodTemplates: []v1alpha2.AgentPodTemplate{
+				{Name: "linux"},
+				{Name: "maven", InstanceCap: &instanceCap},
+			},
+		}
+
+		// when
+		fragment, err := BuildKubernetesCloudConfiguration(cloud, agent)
+		require.NoError(t, err)
+		got, err := yaml.Marshal(fragment)
+
+		// then
+		require.NoError(t, err)
+		assert.Contains(t, string(got), `      templates:
+      - label: linux
+        name: linux
+      - instanceCap: 5
+        label: maven
+        name: maven
+`)
+	})
+	t.Run("connect timeout", func(t *testing.T) {
+		// given
+		connectTimeout := int32(300)
+		agent := v1alpha2.JenkinsAgent{
+			ConnectTimeout: &connectTimeout,
+			PodTemplates:   []v1alpha2.AgentPodTemplate{{Name: "linux"}},
+		}
+
+		// when
+		fragment, err := BuildKubernetesCloudConfiguration(cloud, agent)
+		require.NoError(t, err)
+		got, err := yaml.Marshal(fragment)
+
+		// then
+		require.NoError(t, err)
+		assert.Contains(t, string(got), `      templates:
+      - label: linux
+        name: linux
+        slaveConnectTimeout: 300
+`)
+	})
+	t.Run("active deadline and connect timeout of the pod template", func(t *testing.T) {
+		// given
+		connectTimeout, podTemplateConnectTimeout, activeDeadlineSeconds := int32(300), int32(600), int32(7200)
+		agent := v1alpha2.JenkinsAgent{
+			ConnectTimeout: &connectTimeout,
+			PodTemplates: []v1alpha2.AgentPodTemplate{
+				{Name: "maven", ActiveDeadlineSeconds: &activeDeadlineSeconds, ConnectTimeout: &podTemplateConnectTimeout},
+			},
+		}
+
+		// when
+		fragment, err := BuildKubernetesCloudConfiguration(cloud, agent)
+		require.NoError(t, err)
+		got, err := yaml.Marshal(fragment)
+
+		// then
+		require.NoError(t, err)
+		assert.Contains(t, string(got), `      templates:
+      - activeDeadlineSeconds: 7200
+        label: maven
+        name: maven
+        slaveConnectTimeout: 600
+`)
+	})
+	t.Run("container cap", func(t *testing.T) {
+		// given
+		containerCap := int32(20)
+		agent := v1alpha2.JenkinsAgent{
+			ContainerCap: &containerCap,
+			PodTemplates: []v1alpha2.AgentPodTemplate{{Name: "linux"}},
+		}
+
+		// when
+		fragment, err := BuildKubernetesCloudConfiguration(cloud, agent)
+		require.NoError(t, err)
+		got, err := yaml.Marshal(fragment)
+
+		// then
+		require.NoError(t, err)
+		assert.Contains(t, string(got), `  - kubernetes:
+      containerCapStr: "20"
+      jenkinsTunnel:`)
+	})
+	t.Run("WebSocket", func(t *testing.T) {
+		// given
+		webSocket := true
+		agent := v1alpha2.JenkinsAgent{
+			WebSocket:    &webSocket,
+			PodTemplates: []v1alpha2.AgentPodTemplate{{Name: "linux"}},
+		}
+
+		// when
+		fragment, err := BuildKubernetesCloudConfiguration(cloud, agent)
+		require.NoError(t, err)
+		got, err := yaml.Marshal(fragment)
+
+		// then
+		require.NoError(t, err)
+		assert.Contains(t, string(got), `      templates:
+      - label: linux
+        name: linux
+      webSocket: true
+`)
+	})
+	t.Run("annotations and labels", func(t *testing.T) {
+		// given
+		agent := v1alpha2.JenkinsAgent{
+			PodTemplates: []v1alpha2.AgentPodTemplate{{
+				Name:        "linux",
+				Annotations: map[string]string{"prometheus.io/scrape": "false", "cluster-autoscaler.kubernetes.io/safe-to-evict": "false"},
+				Labels:      map[string]string{"cost-center": "ci", "team": "platform"},
+			}},
+		}
+
+		// when
+		fragment, err := BuildKubernetesCloudConfiguration(cloud, agent)
+		require.NoError(t, err)
+		got, err := yaml.Marshal(fragment)
+
+		// then
+		require.NoError(t, err)
+		assert.Contains(t, string(got), `      templates:
+      - annotations:
+        - key: cluster-autoscaler.kubernetes.io/safe-to-evict
+          value: "false"
+        - key: prometheus.io/scrape
+          value: "false"
+        label: linux
+        name: linux
+        yaml: |
+          metadata:
+            labels:
+              cost-center: ci
+              team: platform
+`)
+	})
+	t.Run("yaml and yaml merge strategy", func(t *testing.T) {
+		// given
+		agent := v1alpha2.JenkinsAgent{
+			PodTemplates: []v1alpha2.AgentPodTemplate{
+				{Name: "base", Yaml: "spec:\n  hostNetwork: true\n"},
+				{
+					Name:              "maven",
+					InheritFrom:       []string{"base"},
+					Labels:            map[string]string{"team": "platform"},
+					Yaml:              "spec:\n  dnsPolicy: ClusterFirstWithHostNet\n",
+					YamlMergeStrategy: v1alpha2.YamlMergeStrategyMerge,
+				},
+			},
+		}
+
+		// when
+		fragment, err := BuildKubernetesCloudConfiguration(cloud, agent)
+		require.NoError(t, err)
+		got, err := yaml.Marshal(fragment)
+
+		// then
+		require.NoError(t, err)
+		assert.Contains(t, string(got), `      templates:
+      - label: base
+        name: base
+        yamls:
+        - |
+          spec:
+            hostNetwork: true
+      - inheritFrom: base
+        label: maven
+        name: maven
+        yamlMergeStrategy: merge
+        yamls:
+        - |
+          metadata:
+            labels:
+              team: platform
+        - |
+          spec:
+            dnsPolicy: ClusterFirstWithHostNet
+`)
+	})
+	t.Run("inherit from", func(t *testing.T) {
+		// given
+		agent := v1alpha2.JenkinsAgent{
+			PodTemplates: []v1alpha2.AgentPodTemplate{
+				{Name: "base"},
+				{Name: "java"},
+				{Name: "maven", InheritFrom: []string{"base", "java"}},
+			},
+		}
+
+		// when
+		fragment, err := BuildKubernetesCloudConfiguration(cloud, agent)
+		require.NoError(t, err)
+		got, err := yaml.Marshal(fragment)
+
+		// then
+		require.NoError(t, err)
+		assert.Contains(t, string(got), `      templates:
+      - label: base
+        name: base
+      - label: java
+        name: java
+      - inheritFrom: base java
+        label: maven
+        name: maven
+`)
+	})
+	t.Run("socket volumes", func(t *testing.T) {
+		// given
+		hostPathType := corev1.HostPathSocket
+		agent := v1alpha2.JenkinsAgent{
+			AllowHostPathVolumes: true,
+			PodTemplates: []v1alpha2.AgentPodTemplate{{
+				Name: "docker",
+				Volumes: []v1alpha2.AgentVolume{{
+					Volume: corev1.Volume{
+						Name: "docker-socket",
+						VolumeSource: corev1.VolumeSource{
+							HostPath: &corev1.HostPathVolumeSource{Path: "/var/run/docker.sock", Type: &hostPathType},
+						},
+					},
+					MountPath: "/var/run/docker.sock",
+				}},
+			}},
+		}
+
+		// when
+		fragment, err := BuildKubernetesCloudConfiguration(cloud, agent)
+		require.NoError(t, err)
+		got, err := yaml.Marshal(fragment)
+
+		// then
+		require.NoError(t, err)
+		assert.Contains(t, string(got), `      templates:
+      - label: docker
+        name: docker
+        yaml: |
+          spec:
+            containers:
+            - name: jnlp
+              volumeMounts:
+              - mountPath: /var/run/docker.sock
+                name: docker-socket
+            volumes:
+            - hostPath:
+                path: /var/run/docker.sock
+                type: Socket
+              name: docker-socket
+`)
+	})
+	t.Run("workspace volumes", func(t *testing.T) {
+		// given
+		agent := v1alpha2.JenkinsAgent{
+			PodTemplates: []v1alpha2.AgentPodTemplate{
+				{
+					Name:            "memory",
+					WorkspaceVolume: &v1alpha2.AgentWorkspaceVolume{EmptyDir: &corev1.EmptyDirVolumeSource{Medium: corev1.StorageMediumMemory}},
+				},
+				{
+					Name:            "cache",
+					WorkspaceVolume: &v1alpha2.AgentWorkspaceVolume{PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "workspace"}},
+				},
+				{
+					Name: "ephemeral",
+					WorkspaceVolume: &v1alpha2.AgentWorkspaceVolume{Ephemeral: &v1alpha2.AgentEphemeralWorkspaceVolume{
+						StorageClassName: "fast-ssd",
+						Size:             resource.MustParse("20Gi"),
+					}},
+				},
+			},
+		}
+
+		// when
+		fragment, err := BuildKubernetesCloudConfiguration(cloud, agent)
+		require.NoError(t, err)
+		got, err := yaml.Marshal(fragment)
+
+		// then
+		require.NoError(t, err)
+		assert.Contains(t, string(got), `      templates:
+      - label: memory
+        name: memory
+        workspaceVolume:
+          emptyDirWorkspaceVolume:
+            memory: true
+      - label: cache
+        name: cache
+        workspaceVolume:
+          persistentVolumeClaimWorkspaceVolume:
+            claimName: workspace
+            readOnly: false
+      - label: ephemeral
+        name: ephemeral
+        workspaceVolume:
+          genericEphemeralVolume:
+            accessModes: ReadWriteOnce
+            requestsSize: 20Gi
+            storageClassName: fast-ssd
+`)
+	})
+	t.Run("node properties", func(t *testing.T) {
+		// given
+		agent := v1alpha2.JenkinsAgent{
+			PodTemplates: []v1alpha2.AgentPodTemplate{{
+				Name: "maven",
+				NodeProperties: &v1alpha2.AgentNodeProperties{
+					EnvVars: []v1alpha2.KeyValue{{Key: "MAVEN_OPTS", Value: "-Xmx1g"}},
+					ToolLocations: []v1alpha2.ToolLocation{
+						{Type: v1alpha2.MavenToolType, Name: "maven-3", Home: "/opt/maven"},
+						{Type: v1alpha2.JDKToolType, Name: "jdk-17", Home: "/opt/java/openjdk"},
+					},
+				},
+			}},
+		}
+
+		// when
+		fragment, err := BuildKubernetesCloudConfiguration(cloud, agent)
+		require.NoError(t, err)
+		got, err := yaml.Marshal(fragment)
+
+		// then
+		require.NoError(t, err)
+		assert.Contains(t, string(got), `      templates:
+      - label: maven
+        name: maven
+        nodeProperties:
+        - envVars:
+            env:
+            - key: MAVEN_OPTS
+              value: -Xmx1g
+        - toolLocation:
+            locations:
+            - home: /opt/maven
+              key: hudson.tasks.Maven$MavenInstallation$DescriptorImpl@maven-3
+            - home: /opt/java/openjdk
+              key: hudson.model.JDK$DescriptorImpl@jdk-17
+`)
+	})
+	t.Run("additional clouds", func(t *testing.T) {
+		// given
+		agent := v1alpha2.JenkinsAgent{
+			PodTemplates: []v1alpha2.AgentPodTemplate{{Name: "linux"}, {Name: "maven"}},
+			Clouds: []v1alpha2.KubernetesCloud{
+				{
+					Name:          "eu",
+					ServerURL:     "https://eu.example.com:6443",
+					CredentialsID: "eu-token",
+					Namespace:     "agents",
+					JenkinsURL:    "https://jenkins.example.com/",
+					JenkinsTunnel: "jenkins-agents.example.com:50000",
+					PodTemplates:  []string{"maven"},
+				},
+				{
+					Name:          "us",
+					ServerURL:     "https://us.example.com:6443",
+					Namespace:     "agents",
+					JenkinsURL:    "https://jenkins.example.com/",
+					JenkinsTunnel: "jenkins-agents.example.com:50000",
+				},
+			},
+		}
+
+		// when
+		fragment, err := BuildKubernetesCloudConfiguration(cloud, agent)
+		require.NoError(t, err)
+		got, err := yaml.Marshal(fragment)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, `jenkins:
+  clouds:
+  - kubernetes:
+      jenkinsTunnel: jenkins-operator-slave-example.default.svc.cluster.local:50000
+      jenkinsUrl: http://jenkins-operator-http-example.default.svc.cluster.local:8080
+      name: kubernetes
+      namespace: default
+      retentionTimeout: 15
+      serverUrl: https://kubernetes.default.svc.cluster.local:443
+      templates:
+      - label: linux
+        name: linux
+      - label: maven
+        name: maven
+  - kubernetes:
+      credentialsId: eu-token
+      jenkinsTunnel: jenkins-agents.example.com:50000
+      jenkinsUrl: https://jenkins.example.com/
+      name: eu
+      namespace: agents
+      retentionTimeout: 15
+      serverUrl: https://eu.example.com:6443
+      templates:
+      - label: maven
+        name: maven
+  - kubernetes:
+      jenkinsTunnel: jenkins-agents.example.com:50000
+      jenkinsUrl: https://jenkins.example.com/
+      name: us
+      namespace: agents
+      retentionTimeout: 15
+      serverUrl: https://us.example.com:6443
+      templates:
+      - label: linux
+        name: linux
+      - label: maven
+        name: maven
+`, string(got))
+	})
+	t.Run("remote FS root", func(t *testing.T) {
+		// given
+		agent := v1alpha2.JenkinsAgent{
+			PodTemplates: []v1alpha2.AgentPodTemplate{{Name: "linux", RemoteFSRoot: "/var/jenkins/agent"}},
+		}
+
+		// when
+		fragment, err := BuildKubernetesCloudConfiguration(cloud, agent)
+		require.NoError(t, err)
+		got, err := yaml.Marshal(fragment)
+
+		// then
+		require.NoError(t, err)
+		assert.Contains(t, string(got), `      templates:
+      - containers:
+        - image: jenkins/inbound-agent:4.10-3
+          name: jnlp
+          workingDir: /var/jenkins/agent
+        label: linux
+        name: linux
+`)
+	})
+	t.Run("command and args", func(t *testing.T) {
+		// given
+		agent := v1alpha2.JenkinsAgent{
+			PodTemplates: []v1alpha2.AgentPodTemplate{{
+				Name:    "custom",
+				Command: []string{"/bin/sh", "-c"},
+				Args:    []string{"/usr/local/bin/start-agent.sh ${computer.jnlpmac} ${computer.name}"},
+			}},
+		}
+
+		// when
+		fragment, err := BuildKubernetesCloudConfiguration(cloud, agent)
+		require.NoError(t, err)
+		got, err := yaml.Marshal(fragment)
+
+		// then
+		require.NoError(t, err)
+		assert.Contains(t, string(got), `      templates:
+      - containers:
+        - args: '"/usr/local/bin/start-agent.sh ${computer.jnlpmac} ${computer.name}"'
+          command: /bin/sh -c
+          image: jenkins/inbound-agent:4.10-3
+          name: jnlp
+        label: custom
+        name: custom
+`)
+	})
+	t.Run("agent image and pull policy", func(t *testing.T) {
+		// given
+		agent := v1alpha2.JenkinsAgent{
+			Image:           "jenkins/inbound-agent:4.10-3",
+			ImagePullPolicy: corev1.PullAlways,
+			PodTemplates:    []v1alpha2.AgentPodTemplate{{Name: "linux"}},
+		}
+
+		// when
+		fragment, err := BuildKubernetesCloudConfiguration(cloud, agent)
+		require.NoError(t, err)
+		got, err := yaml.Marshal(fragment)
+
+		// then
+		require.NoError(t, err)
+		assert.Contains(t, string(got), `      templates:
+      - containers:
+        - alwaysPullImage: true
+          image: jenkins/inbound-agent:4.10-3
+          name: jnlp
+        label: linux
+        name: linux
+`)
+	})
+	t.Run("resources", func(t *testing.T) {
+		// given
+		requestCPU := resource.MustParse("500m")
+		requestMemory := resource.MustParse("512Mi")
+		limitMemory := resource.MustParse("1Gi")
+		agent := v1alpha2.JenkinsAgent{
+			PodTemplates: []v1alpha2.AgentPodTemplate{{
+				Name:                  "linux",
+				ResourceRequestCPU:    &requestCPU,
+				ResourceRequestMemory: &requestMemory,
+				ResourceLimitMemory:   &limitMemory,
+			}},
+		}
+
+		// when
+		fragment, err := BuildKubernetesCloudConfiguration(cloud, agent)
+		require.NoError(t, err)
+		got, err := yaml.Marshal(fragment)
+
+		// then
+		require.NoError(t, err)
+		assert.Contains(t, string(got), `      templates:
+      - containers:
+        - image: jenkins/inbound-agent:4.10-3
+          name: jnlp
+          resourceLimitMemory: 1Gi
+          resourceRequestCpu: 500m
+          resourceRequestMemory: 512Mi
+        label: linux
+        name: linux
+`)
+	})
+	t.Run("image pull secrets", func(t *testing.T) {
+		// given
+		agent := v1alpha2.JenkinsAgent{
+			PodTemplates: []v1alpha2.AgentPodTemplate{{
+				Name:             "private",
+				ImagePullSecrets: []corev1.LocalObjectReference{{Name: "registry"}, {Name: "mirror"}},
+			}},
+		}
+
+		// when
+		fragment, err := BuildKubernetesCloudConfiguration(cloud, agent)
+		require.NoError(t, err)
+		got, err := yaml.Marshal(fragment)
+
+		// then
+		require.NoError(t, err)
+		assert.Contains(t, string(got), `      templates:
+      - imagePullSecrets:
+        - name: registry
+        - name: mirror
+        label: private
+        name: private
+`)
+	})
+}
+
+func TestMergeConfigurationAsCode(t *testing.T) {
+	configurationAsCode := map[string]interface{}{}
+
+	mergeConfigurationAsCode(configurationAsCode, map[string]interface{}{
+		"unclassified": map[string]interface{}{"location": "location"},
+		"tool":         "tool",
+	})
+	mergeConfigurationAsCode(configurationAsCode, map[string]interface{}{
+		"unclassified": map[string]interface{}{"markupFormatter": "markupFormatter"},
+		"jenkins":      "jenkins",
+	})
+
+	assert.Equal(t, map[string]interface{}{
+		"unclassified": map[string]interface{}{"location": "location", "markupFormatter": "markupFormatter"},
+		"tool":         "tool",
+		"jenkins":      "jenkins",
+	}, configurationAsCode)
+}
+
+func TestNewBaseConfigurationConfigMapAgentPodTemplates(t *testing.T) {
+	jenkins := &v1alpha2.Jenkins{
+		ObjectMeta: metav1.ObjectMeta{Name: "example", Namespace: "default"},
+		Spec: v1alpha2.JenkinsSpec{
+			Master: v1alpha2.JenkinsMaster{
+				Containers: []v1alpha2.Container{{Name: JenkinsMasterContainerName}},
+				Agent:      &v1alpha2.JenkinsAgent{PodTemplates: []v1alpha2.AgentPodTemplate{{Name: "linux"}}},
+				JenkinsURL: "https://jenkins.example.com/",
+			},
+			Service:      v1alpha2.Service{Port: 8080},
+			SlaveService: v1alpha2.Service{Port: 50000},
+		},
+	}
+
+	configMap, err := NewBaseConfigurationConfigMap(metav1.ObjectMeta{}, jenkins, "cluster.local")
+
+	require.NoError(t, err)
+	script := configMap.Data[configurationAsCodeGroovyScriptName]
+	assert.Contains(t, script, `      jenkinsUrl: http://jenkins-operator-http-example.default.svc.cluster.local:8080
+      name: kubernetes
+      namespace: default
+      retentionTimeout: 15
+      serverUrl: https://kubernetes.default.svc.cluster.local:443
+      templates:
+      - label: linux
+        name: linux
+`)
+	assert.Contains(t, script, "    url: https://jenkins.example.com/\n")
+	assert.Contains(t, configMap.Data[configureKubernetesPluginGroovyScriptName], `kubernetes.setServerUrl("https://kubernetes.default.svc.cluster.local:443")`)
+}
@@ -0,0 +1,40 @@
+package resources
+
+import (
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+)
+
+// LDAPManagerPasswordEnvName is the name of the Jenkins master container env with the password of the LDAP manager DN
+const LDAPManagerPasswordEnvName = "LDAP_MANAGER_PASSWORD"
+
+type cascLDAPConfiguration struct {
+	Server                string `json:"server"`
+	RootDN                string `json:"rootDN,omitempty"`
+	UserSearchBase        string `json:"userSearchBase,omitempty"`
+	ManagerDN             string `json:"managerDN,omitempty"`
+	ManagerPasswordSecret string `json:"managerPasswordSecret,omitempty"`
+}
+
+// BuildLDAPConfiguration builds the securityRealm section of the Configuration as Code from spec.master.ldap,
+// the manager password is resolved by the Configuration as Code plugin from the Jenkins master container env
+func BuildLDAPConfiguration(ldap v1alpha2.LDAP) map[string]interface{} {
+	configuration := cascLDAPConfiguration{
+		Server:         ldap.Server,
+		RootDN:         ldap.RootDN,
+		UserSearchBase: ldap.UserSearchBase,
+		ManagerDN:      ldap.ManagerDN,
+	}
+	if ldap.ManagerDNSecretRef != nil {
+		configuration.ManagerPasswordSecret = "${" + LDAPManagerPasswordEnvName + "}"
+	}
+
+	return map[string]interface{}{
+		"jenkins": map[string]interface{}{
+			"securityRealm": map[string]interface{}{
+				"ldap": map[string]interface{}{
+					"configurations": []cascLDAPConfiguration{configuration},
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,76 @@
+package resources
+
+import (
+	"testing"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestNewBaseConfigurationConfigMapLDAP(t *testing.T) {
+	newJenkins := func(ldap *v1alpha2.LDAP) *v1alpha2.Jenkins {
+		return &v1alpha2.Jenkins{
+			ObjectMeta: metav1.ObjectMeta{Name: "example", Namespace: "default"},
+			Spec: v1alpha2.JenkinsSpec{
+				Master: v1alpha2.JenkinsMaster{
+					Containers: []v1alpha2.Container{{Name: JenkinsMasterContainerName}},
+					LDAP:       ldap,
+				},
+			},
+		}
+	}
+
+	t.Run("with manager password", func(t *testing.T) {
+		jenkins := newJenkins(&v1alpha2.LDAP{
+			Server:         "ldaps://ldap.example.com:636",
+			RootDN:         "dc=example,dc=com",
+			UserSearchBase: "ou=people",
+			ManagerDN:      "cn=jenkins,dc=example,dc=com",
+			ManagerDNSecretRef: &v1alpha2.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "ldap"},
+				Key:                  "password",
+			},
+		})
+
+		configMap, err := NewBaseConfigurationConfigMap(metav1.ObjectMeta{}, jenkins, "cluster.local")
+
+		require.NoError(t, err)
+		assert.Contains(t, configMap.Data[configurationAsCodeGroovyScriptName], `def config = '''jenkins:
+  securityRealm:
+    ldap:
+      configurations:
+      - managerDN: cn=jenkins,dc=example,dc=com
+        managerPasswordSecret: ${LDAP_MANAGER_PASSWORD}
+        rootDN: dc=example,dc=com
+        server: ldaps://ldap.example.com:636
+        userSearchBase: ou=people
+'''`)
+		assert.Contains(t, GetJenkinsMasterContainerBaseEnvs(jenkins), corev1.EnvVar{
+			Name: LDAPManagerPasswordEnvName,
+			ValueFrom: &corev1.EnvVarSource{SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "ldap"},
+				Key:                  "password",
+			}},
+		})
+	})
+	t.Run("anonymous bind", func(t *testing.T) {
+		jenkins := newJenkins(&v1alpha2.LDAP{Server: "ldap://ldap.example.com"})
+
+		configMap, err := NewBaseConfigurationConfigMap(metav1.ObjectMeta{}, jenkins, "cluster.local")
+
+		require.NoError(t, err)
+		assert.Contains(t, configMap.Data[configurationAsCodeGroovyScriptName], `def config = '''jenkins:
+  securityRealm:
+    ldap:
+      configurations:
+      - server: ldap://ldap.example.com
+'''`)
+		for _, env := range GetJenkinsMasterContainerBaseEnvs(jenkins) {
+			assert.NotEqual(t, LDAPManagerPasswordEnvName, env.Name)
+		}
+	})
+}
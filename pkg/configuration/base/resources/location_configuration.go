@@ -0,0 +1,24 @@
+package resources
+
+import (
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+)
+
+type cascLocation struct {
+	URL          string `json:"url,omitempty"`
+	AdminAddress string `json:"adminAddress,omitempty"`
+}
+
+// BuildLocationConfiguration builds the location section of the Configuration as Code from spec.master.jenkinsURL
+// and spec.master.adminEmail, returns nil when none of them is set
+func BuildLocationConfiguration(master v1alpha2.JenkinsMaster) map[string]interface{} {
+	if len(master.JenkinsURL) == 0 && len(master.AdminEmail) == 0 {
+		return nil
+	}
+
+	return map[string]interface{}{
+		"unclassified": map[string]interface{}{
+			"location": cascLocation{URL: master.JenkinsURL, AdminAddress: master.AdminEmail},
+		},
+	}
+}
@@ -0,0 +1,52 @@
+package resources
+
+import (
+	"testing"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestNewBaseConfigurationConfigMapLocation(t *testing.T) {
+	newJenkins := func(jenkinsURL, adminEmail string) *v1alpha2.Jenkins {
+		return &v1alpha2.Jenkins{
+			ObjectMeta: metav1.ObjectMeta{Name: "example", Namespace: "default"},
+			Spec: v1alpha2.JenkinsSpec{
+				Master: v1alpha2.JenkinsMaster{
+					Containers: []v1alpha2.Container{{Name: JenkinsMasterContainerName}},
+					JenkinsURL: jenkinsURL,
+					AdminEmail: adminEmail,
+				},
+			},
+		}
+	}
+
+	t.Run("without location", func(t *testing.T) {
+		configMap, err := NewBaseConfigurationConfigMap(metav1.ObjectMeta{}, newJenkins("", ""), "cluster.local")
+
+		require.NoError(t, err)
+		assert.NotContains(t, configMap.Data, configurationAsCodeGroovyScriptName)
+	})
+	t.Run("URL and admin email", func(t *testing.T) {
+		configMap, err := NewBaseConfigurationConfigMap(metav1.ObjectMeta{}, newJenkins("https://jenkins.example.com/", "jenkins@example.com"), "cluster.local")
+
+		require.NoError(t, err)
+		assert.Contains(t, configMap.Data[configurationAsCodeGroovyScriptName], `def config = '''unclassified:
+  location:
+    adminAddress: jenkins@example.com
+    url: https://jenkins.example.com/
+'''`)
+	})
+	t.Run("only URL", func(t *testing.T) {
+		configMap, err := NewBaseConfigurationConfigMap(metav1.ObjectMeta{}, newJenkins("https://jenkins.example.com/", ""), "cluster.local")
+
+		require.NoError(t, err)
+		assert.Contains(t, configMap.Data[configurationAsCodeGroovyScriptName], `def config = '''unclassified:
+  location:
+    url: https://jenkins.example.com/
+'''`)
+	})
+}
@@ -0,0 +1,52 @@
+package resources
+
+import (
+	"text/template"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/internal/render"
+)
+
+// defaultLoggerLevel is the level of the logger when it isn't set, the same as in the Jenkins UI
+const defaultLoggerLevel = "ALL"
+
+var configureLogRecordersTemplate = template.Must(template.New(configureLogRecordersGroovyScriptName).Funcs(template.FuncMap{
+	"quote": quoteGroovyString,
+	"level": func(level string) string {
+		if len(level) == 0 {
+			return defaultLoggerLevel
+		}
+		return level
+	},
+}).Parse(`
+import hudson.logging.LogRecorder
+import java.util.logging.Level
+import jenkins.model.Jenkins
+
+def logRecorderManager = Jenkins.instance.log
+def logRecorders = [
+{{- range .LogRecorders }}
+    [name: {{ quote .Name }}, loggers: [{{ range $index, $logger := .Loggers }}{{ if $index }}, {{ end }}[name: {{ quote $logger.Name }}, level: {{ quote (level $logger.Level) }}]{{ end }}]],
+{{- end }}
+]
+
+def recorders = new ArrayList(logRecorderManager.recorders)
+logRecorders.each { definition ->
+    def recorder = recorders.find { it.name == definition.name }
+    if (recorder == null) {
+        recorder = new LogRecorder(definition.name)
+        recorders.add(recorder)
+    }
+    recorder.setLoggers(definition.loggers.collect { new LogRecorder.Target(it.name, Level.parse(it.level)) })
+    recorder.save()
+}
+logRecorderManager.setRecorders(recorders)
+`))
+
+func buildConfigureLogRecordersGroovyScript(logRecorders []v1alpha2.LogRecorder) (string, error) {
+	return render.Render(configureLogRecordersTemplate, struct {
+		LogRecorders []v1alpha2.LogRecorder
+	}{
+		LogRecorders: logRecorders,
+	})
+}
@@ -0,0 +1,27 @@
+package resources
+
+import (
+	"testing"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildConfigureLogRecordersGroovyScript(t *testing.T) {
+	logRecorders := []v1alpha2.LogRecorder{
+		{Name: "git", Loggers: []v1alpha2.Logger{{Name: "hudson.plugins.git", Level: "FINE"}, {Name: "jenkins.plugins.git"}}},
+		{Name: "team's kubernetes", Loggers: []v1alpha2.Logger{{Name: "org.csanchez.jenkins.plugins.kubernetes", Level: "FINEST"}}},
+	}
+
+	got, err := buildConfigureLogRecordersGroovyScript(logRecorders)
+
+	require.NoError(t, err)
+	assert.Contains(t, got, `def logRecorders = [
+    [name: 'git', loggers: [[name: 'hudson.plugins.git', level: 'FINE'], [name: 'jenkins.plugins.git', level: 'ALL']]],
+    [name: 'team\'s kubernetes', loggers: [[name: 'org.csanchez.jenkins.plugins.kubernetes', level: 'FINEST']]],
+]
+`)
+	assert.Contains(t, got, "logRecorderManager.setRecorders(recorders)")
+}
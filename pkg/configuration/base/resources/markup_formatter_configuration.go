@@ -0,0 +1,30 @@
+package resources
+
+import (
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+)
+
+// BuildMarkupFormatterConfiguration builds the markupFormatter section of the Configuration as Code
+// from spec.master.markupFormatter, returns nil when it isn't set
+func BuildMarkupFormatterConfiguration(markupFormatter v1alpha2.MarkupFormatterName) map[string]interface{} {
+	var formatter interface{}
+	switch markupFormatter {
+	case v1alpha2.PlainTextMarkupFormatterName:
+		formatter = "plainText"
+	case v1alpha2.SafeHTMLMarkupFormatterName:
+		// the formatter of the antisamy-markup-formatter plugin is named rawHtml, although it allows only the safe HTML
+		formatter = map[string]interface{}{
+			"rawHtml": map[string]interface{}{"disableSyntaxHighlighting": false},
+		}
+	case v1alpha2.RawHTMLMarkupFormatterName:
+		formatter = "unsafe"
+	default:
+		return nil
+	}
+
+	return map[string]interface{}{
+		"jenkins": map[string]interface{}{
+			"markupFormatter": formatter,
+		},
+	}
+}
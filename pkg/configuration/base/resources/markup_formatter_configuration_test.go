@@ -0,0 +1,57 @@
+package resources
+
+import (
+	"testing"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestNewBaseConfigurationConfigMapMarkupFormatter(t *testing.T) {
+	newJenkins := func(markupFormatter v1alpha2.MarkupFormatterName) *v1alpha2.Jenkins {
+		return &v1alpha2.Jenkins{
+			ObjectMeta: metav1.ObjectMeta{Name: "example", Namespace: "default"},
+			Spec: v1alpha2.JenkinsSpec{
+				Master: v1alpha2.JenkinsMaster{
+					Containers:      []v1alpha2.Container{{Name: JenkinsMasterContainerName}},
+					MarkupFormatter: markupFormatter,
+				},
+			},
+		}
+	}
+
+	t.Run("safe HTML", func(t *testing.T) {
+		configMap, err := NewBaseConfigurationConfigMap(metav1.ObjectMeta{}, newJenkins(v1alpha2.SafeHTMLMarkupFormatterName), "cluster.local")
+
+		require.NoError(t, err)
+		assert.Contains(t, configMap.Data[configurationAsCodeGroovyScriptName], `def config = '''jenkins:
+  markupFormatter:
+    rawHtml:
+      disableSyntaxHighlighting: false
+'''`)
+	})
+	t.Run("plain text", func(t *testing.T) {
+		configMap, err := NewBaseConfigurationConfigMap(metav1.ObjectMeta{}, newJenkins(v1alpha2.PlainTextMarkupFormatterName), "cluster.local")
+
+		require.NoError(t, err)
+		assert.Contains(t, configMap.Data[configurationAsCodeGroovyScriptName], `def config = '''jenkins:
+  markupFormatter: plainText
+'''`)
+	})
+	t.Run("raw HTML", func(t *testing.T) {
+		configMap, err := NewBaseConfigurationConfigMap(metav1.ObjectMeta{}, newJenkins(v1alpha2.RawHTMLMarkupFormatterName), "cluster.local")
+
+		require.NoError(t, err)
+		assert.Contains(t, configMap.Data[configurationAsCodeGroovyScriptName], `  markupFormatter: unsafe
+`)
+	})
+	t.Run("not set", func(t *testing.T) {
+		configMap, err := NewBaseConfigurationConfigMap(metav1.ObjectMeta{}, newJenkins(""), "cluster.local")
+
+		require.NoError(t, err)
+		assert.NotContains(t, configMap.Data, configurationAsCodeGroovyScriptName)
+	})
+}
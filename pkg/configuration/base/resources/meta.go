@@ -20,21 +20,33 @@ func NewResourceObjectMeta(jenkins *v1alpha2.Jenkins) metav1.ObjectMeta {
 
 // BuildResourceLabels returns labels for all Kubernetes resources created by operator
 func BuildResourceLabels(jenkins *v1alpha2.Jenkins) map[string]string {
-	return map[string]string{
+	labels := map[string]string{
 		constants.LabelAppKey:       constants.LabelAppValue,
 		constants.LabelJenkinsCRKey: jenkins.Name,
 	}
+	addOperatorInstanceLabel(*jenkins, labels)
+	return labels
 }
 
 // BuildLabelsForWatchedResources returns labels for Kubernetes resources which operator want to watch
 // resources with that labels should not be deleted after Jenkins CR deletion, to prevent this situation don't set
 // any owner
 func BuildLabelsForWatchedResources(jenkins v1alpha2.Jenkins) map[string]string {
-	return map[string]string{
+	labels := map[string]string{
 		constants.LabelAppKey:       constants.LabelAppValue,
 		constants.LabelJenkinsCRKey: jenkins.Name,
 		constants.LabelWatchKey:     constants.LabelWatchValue,
 	}
+	addOperatorInstanceLabel(jenkins, labels)
+	return labels
+}
+
+// addOperatorInstanceLabel stamps the resources with the operator instance label of the Jenkins CR, so the operator
+// instances ignore the resources managed by the other instances
+func addOperatorInstanceLabel(jenkins v1alpha2.Jenkins, labels map[string]string) {
+	if instanceID := jenkins.Labels[constants.LabelOperatorInstanceKey]; len(instanceID) > 0 {
+		labels[constants.LabelOperatorInstanceKey] = instanceID
+	}
 }
 
 // GetResourceName returns name of Kubernetes resource base on Jenkins CR
@@ -0,0 +1,36 @@
+package resources
+
+import (
+	"strings"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+)
+
+// OIDCClientSecretEnvName is the name of the Jenkins master container env with the OpenID Connect client secret
+const OIDCClientSecretEnvName = "OIDC_CLIENT_SECRET"
+
+type cascOIDC struct {
+	ClientID                        string `json:"clientId"`
+	ClientSecret                    string `json:"clientSecret"`
+	WellKnownOpenIDConfigurationURL string `json:"wellKnownOpenIDConfigurationUrl"`
+	AutomanualConfigure             string `json:"automanualconfigure"`
+	Scopes                          string `json:"scopes,omitempty"`
+}
+
+// BuildOIDCConfiguration builds the oic securityRealm section of the Configuration as Code from spec.master.oidc,
+// the client secret is resolved by the Configuration as Code plugin from the Jenkins master container env
+func BuildOIDCConfiguration(oidc v1alpha2.OIDC) map[string]interface{} {
+	return map[string]interface{}{
+		"jenkins": map[string]interface{}{
+			"securityRealm": map[string]interface{}{
+				"oic": cascOIDC{
+					ClientID:                        oidc.ClientID,
+					ClientSecret:                    "${" + OIDCClientSecretEnvName + "}",
+					WellKnownOpenIDConfigurationURL: strings.TrimSuffix(oidc.Issuer, "/") + "/.well-known/openid-configuration",
+					AutomanualConfigure:             "auto",
+					Scopes:                          strings.Join(oidc.Scopes, " "),
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,52 @@
+package resources
+
+import (
+	"testing"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestNewBaseConfigurationConfigMapOIDC(t *testing.T) {
+	jenkins := &v1alpha2.Jenkins{
+		ObjectMeta: metav1.ObjectMeta{Name: "example", Namespace: "default"},
+		Spec: v1alpha2.JenkinsSpec{
+			Master: v1alpha2.JenkinsMaster{
+				Containers: []v1alpha2.Container{{Name: JenkinsMasterContainerName}},
+				OIDC: &v1alpha2.OIDC{
+					Issuer:   "https://accounts.example.com/",
+					ClientID: "jenkins",
+					ClientSecretRef: v1alpha2.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: "oidc"},
+						Key:                  "client-secret",
+					},
+					Scopes: []string{"openid", "email", "profile"},
+				},
+			},
+		},
+	}
+
+	configMap, err := NewBaseConfigurationConfigMap(metav1.ObjectMeta{}, jenkins, "cluster.local")
+
+	require.NoError(t, err)
+	assert.Contains(t, configMap.Data[configurationAsCodeGroovyScriptName], `def config = '''jenkins:
+  securityRealm:
+    oic:
+      automanualconfigure: auto
+      clientId: jenkins
+      clientSecret: ${OIDC_CLIENT_SECRET}
+      scopes: openid email profile
+      wellKnownOpenIDConfigurationUrl: https://accounts.example.com/.well-known/openid-configuration
+'''`)
+	assert.Contains(t, GetJenkinsMasterContainerBaseEnvs(jenkins), corev1.EnvVar{
+		Name: OIDCClientSecretEnvName,
+		ValueFrom: &corev1.EnvVarSource{SecretKeyRef: &corev1.SecretKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: "oidc"},
+			Key:                  "client-secret",
+		}},
+	})
+}
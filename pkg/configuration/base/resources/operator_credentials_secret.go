@@ -21,6 +21,11 @@ const (
 	OperatorCredentialsSecretTokenKey = "token"
 	// OperatorCredentialsSecretTokenCreationKey defines key of token creation time in operator credentials secret
 	OperatorCredentialsSecretTokenCreationKey = "tokenCreationTime"
+	// OperatorCredentialsSecretAPITokenKey defines key of the fixed API token of the operator user in operator
+	// credentials secret, Jenkins is seeded with it on every start when the operator user can't log in with the password
+	OperatorCredentialsSecretAPITokenKey = "apiToken"
+	// OperatorAPITokenName is the name of the fixed API token of the operator user
+	OperatorAPITokenName = "jenkins-operator"
 )
 
 func buildSecretTypeMeta() metav1.TypeMeta {
@@ -46,6 +51,24 @@ func NewOperatorCredentialsSecret(meta metav1.ObjectMeta, jenkins *v1alpha2.Jenk
 		Data: map[string][]byte{
 			OperatorCredentialsSecretUserNameKey: []byte(OperatorUserName),
 			OperatorCredentialsSecretPasswordKey: []byte(randomString(20)),
+			OperatorCredentialsSecretAPITokenKey: []byte(NewOperatorAPIToken()),
 		},
 	}
 }
+
+// NewOperatorAPIToken returns a random API token in the format accepted by Jenkins for the fixed API tokens,
+// the version prefix followed by 32 hexadecimal characters
+func NewOperatorAPIToken() string {
+	return "11" + randomHexString(32)
+}
+
+// UsesExternalSecurityRealm returns true if the security realm configured by the operator doesn't know the local
+// operator user, the operator authenticates with the fixed API token then
+func UsesExternalSecurityRealm(jenkins *v1alpha2.Jenkins) bool {
+	return jenkins.Spec.Master.LDAP != nil || jenkins.Spec.Master.OIDC != nil
+}
+
+// IsOperatorAPITokenUsed returns true if the operator created user authenticates with the fixed API token
+func IsOperatorAPITokenUsed(jenkins *v1alpha2.Jenkins) bool {
+	return jenkins.Spec.JenkinsAPISettings.AuthorizationStrategy == v1alpha2.CreateUserAuthorizationStrategy && UsesExternalSecurityRealm(jenkins)
+}
@@ -0,0 +1,96 @@
+package resources
+
+import (
+	"strings"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/pkg/constants"
+)
+
+const (
+	defaultPermanentAgentPort         = 22
+	defaultPermanentAgentRemoteFS     = "/home/jenkins"
+	defaultPermanentAgentNumExecutors = 1
+)
+
+type cascPermanentAgent struct {
+	Name            string                 `json:"name"`
+	NodeDescription string                 `json:"nodeDescription,omitempty"`
+	RemoteFS        string                 `json:"remoteFS"`
+	LabelString     string                 `json:"labelString,omitempty"`
+	NumExecutors    int32                  `json:"numExecutors"`
+	Mode            string                 `json:"mode"`
+	Launcher        map[string]interface{} `json:"launcher"`
+}
+
+type cascSSHLauncher struct {
+	Host                           string                 `json:"host"`
+	Port                           int32                  `json:"port"`
+	CredentialsID                  string                 `json:"credentialsId"`
+	SSHHostKeyVerificationStrategy map[string]interface{} `json:"sshHostKeyVerificationStrategy"`
+}
+
+// BuildPermanentAgentsConfiguration builds the nodes section of the Configuration as Code from spec.permanentAgents,
+// the agents are launched over SSH with the ssh-slaves plugin. The Configuration as Code plugin replaces all the Jenkins
+// nodes, so the seed job agent node is kept in the list when the seed jobs are configured
+func BuildPermanentAgentsConfiguration(jenkins *v1alpha2.Jenkins) map[string]interface{} {
+	var nodes []interface{}
+	if len(jenkins.Spec.SeedJobs) > 0 {
+		nodes = append(nodes, map[string]interface{}{"permanent": cascPermanentAgent{
+			Name:            constants.SeedJobAgentName,
+			NodeDescription: constants.SeedJobAgentDescription,
+			RemoteFS:        constants.SeedJobAgentRemoteFS,
+			LabelString:     constants.SeedJobAgentName,
+			NumExecutors:    constants.SeedJobAgentNumExecutors,
+			Mode:            "NORMAL",
+			Launcher:        map[string]interface{}{"jnlp": map[string]interface{}{}},
+		}})
+	}
+	for _, agent := range jenkins.Spec.PermanentAgents {
+		node := cascPermanentAgent{
+			Name:         agent.Name,
+			RemoteFS:     agent.RemoteFS,
+			LabelString:  strings.Join(agent.Labels, " "),
+			NumExecutors: defaultPermanentAgentNumExecutors,
+			Mode:         "NORMAL",
+		}
+		if len(node.RemoteFS) == 0 {
+			node.RemoteFS = defaultPermanentAgentRemoteFS
+		}
+		if agent.NumExecutors != nil {
+			node.NumExecutors = *agent.NumExecutors
+		}
+		launcher := cascSSHLauncher{
+			Host:                           agent.Host,
+			Port:                           defaultPermanentAgentPort,
+			CredentialsID:                  agent.CredentialID,
+			SSHHostKeyVerificationStrategy: buildSSHHostKeyVerificationStrategy(agent),
+		}
+		if agent.Port != nil {
+			launcher.Port = *agent.Port
+		}
+		node.Launcher = map[string]interface{}{"ssh": launcher}
+		nodes = append(nodes, map[string]interface{}{"permanent": node})
+	}
+
+	return map[string]interface{}{
+		"jenkins": map[string]interface{}{
+			"nodes": nodes,
+		},
+	}
+}
+
+func buildSSHHostKeyVerificationStrategy(agent v1alpha2.PermanentAgent) map[string]interface{} {
+	switch agent.HostKeyVerificationStrategy {
+	case v1alpha2.SSHHostKeyVerificationStrategyKnownHosts:
+		return map[string]interface{}{"knownHostsFileKeyVerificationStrategy": map[string]interface{}{}}
+	case v1alpha2.SSHHostKeyVerificationStrategyManuallyTrusted:
+		return map[string]interface{}{
+			"manuallyTrustedKeyVerificationStrategy": map[string]interface{}{"requireInitialManualTrust": true},
+		}
+	default:
+		return map[string]interface{}{
+			"manuallyProvidedKeyVerificationStrategy": map[string]interface{}{"key": agent.HostKey},
+		}
+	}
+}
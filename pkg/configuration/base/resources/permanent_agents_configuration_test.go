@@ -0,0 +1,107 @@
+package resources
+
+import (
+	"testing"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestNewBaseConfigurationConfigMapPermanentAgents(t *testing.T) {
+	// given
+	port := int32(2222)
+	numExecutors := int32(4)
+	jenkins := &v1alpha2.Jenkins{
+		ObjectMeta: metav1.ObjectMeta{Name: "example", Namespace: "default"},
+		Spec: v1alpha2.JenkinsSpec{
+			Master: v1alpha2.JenkinsMaster{
+				Containers: []v1alpha2.Container{{Name: JenkinsMasterContainerName}},
+			},
+			SeedJobs: []v1alpha2.SeedJob{{ID: "jenkins-operator"}},
+			PermanentAgents: []v1alpha2.PermanentAgent{
+				{
+					Name:         "build-1",
+					Host:         "build-1.example.com",
+					Port:         &port,
+					CredentialID: "agent-ssh",
+					Labels:       []string{"linux", "docker"},
+					RemoteFS:     "/var/lib/jenkins-agent",
+					NumExecutors: &numExecutors,
+					HostKey:      "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIDQhzXPS2tKOiPKvu936n31HhBhVNLtVLWubGrWlKStd",
+				},
+				{
+					Name:                        "build-2",
+					Host:                        "10.0.0.12",
+					CredentialID:                "agent-ssh",
+					HostKeyVerificationStrategy: v1alpha2.SSHHostKeyVerificationStrategyKnownHosts,
+				},
+				{
+					Name:                        "build-3",
+					Host:                        "10.0.0.13",
+					CredentialID:                "agent-ssh",
+					HostKeyVerificationStrategy: v1alpha2.SSHHostKeyVerificationStrategyManuallyTrusted,
+				},
+			},
+		},
+	}
+
+	// when
+	configMap, err := NewBaseConfigurationConfigMap(metav1.ObjectMeta{}, jenkins, "cluster.local")
+
+	// then
+	require.NoError(t, err)
+	assert.Contains(t, configMap.Data[configurationAsCodeGroovyScriptName], `def config = '''jenkins:
+  nodes:
+  - permanent:
+      labelString: seed-job-agent
+      launcher:
+        jnlp: {}
+      mode: NORMAL
+      name: seed-job-agent
+      nodeDescription: The jenkins-operator generated agent
+      numExecutors: 5
+      remoteFS: /home/jenkins
+  - permanent:
+      labelString: linux docker
+      launcher:
+        ssh:
+          credentialsId: agent-ssh
+          host: build-1.example.com
+          port: 2222
+          sshHostKeyVerificationStrategy:
+            manuallyProvidedKeyVerificationStrategy:
+              key: ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIDQhzXPS2tKOiPKvu936n31HhBhVNLtVLWubGrWlKStd
+      mode: NORMAL
+      name: build-1
+      numExecutors: 4
+      remoteFS: /var/lib/jenkins-agent
+  - permanent:
+      launcher:
+        ssh:
+          credentialsId: agent-ssh
+          host: 10.0.0.12
+          port: 22
+          sshHostKeyVerificationStrategy:
+            knownHostsFileKeyVerificationStrategy: {}
+      mode: NORMAL
+      name: build-2
+      numExecutors: 1
+      remoteFS: /home/jenkins
+  - permanent:
+      launcher:
+        ssh:
+          credentialsId: agent-ssh
+          host: 10.0.0.13
+          port: 22
+          sshHostKeyVerificationStrategy:
+            manuallyTrustedKeyVerificationStrategy:
+              requireInitialManualTrust: true
+      mode: NORMAL
+      name: build-3
+      numExecutors: 1
+      remoteFS: /home/jenkins
+`)
+}
@@ -0,0 +1,61 @@
+package resources
+
+import (
+	"fmt"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/pkg/constants"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// JenkinsHomeAnnotation is the annotation of the Jenkins home persistent volume claim, its value is the name of the Jenkins CR
+const JenkinsHomeAnnotation = "jenkins.io/jenkins-home"
+
+// GetJenkinsHomePersistentVolumeClaimName returns name of the Jenkins home persistent volume claim
+func GetJenkinsHomePersistentVolumeClaimName(jenkins *v1alpha2.Jenkins) string {
+	return fmt.Sprintf("%s-home-%s", constants.OperatorName, jenkins.ObjectMeta.Name)
+}
+
+// BuildJenkinsHomePersistentVolumeClaimAnnotations merges the user defined annotations with the annotations managed
+// by the operator, the operator managed annotations take precedence
+func BuildJenkinsHomePersistentVolumeClaimAnnotations(jenkins *v1alpha2.Jenkins) map[string]string {
+	annotations := map[string]string{}
+	if jenkins.Spec.Master.JenkinsHomeStorage != nil {
+		for key, value := range jenkins.Spec.Master.JenkinsHomeStorage.Annotations {
+			annotations[key] = value
+		}
+	}
+	annotations[JenkinsHomeAnnotation] = jenkins.ObjectMeta.Name
+	return annotations
+}
+
+// NewJenkinsHomePersistentVolumeClaim builds the Jenkins home persistent volume claim
+func NewJenkinsHomePersistentVolumeClaim(meta metav1.ObjectMeta, jenkins *v1alpha2.Jenkins) *corev1.PersistentVolumeClaim {
+	storage := jenkins.Spec.Master.JenkinsHomeStorage
+	meta.Name = GetJenkinsHomePersistentVolumeClaimName(jenkins)
+	meta.Annotations = BuildJenkinsHomePersistentVolumeClaimAnnotations(jenkins)
+
+	accessModes := storage.AccessModes
+	if len(accessModes) == 0 {
+		accessModes = []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce}
+	}
+
+	return &corev1.PersistentVolumeClaim{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "PersistentVolumeClaim",
+			APIVersion: "v1",
+		},
+		ObjectMeta: meta,
+		Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes:      accessModes,
+			StorageClassName: storage.StorageClassName,
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceStorage: storage.Size,
+				},
+			},
+		},
+	}
+}
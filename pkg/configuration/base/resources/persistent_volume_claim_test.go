@@ -0,0 +1,73 @@
+package resources
+
+import (
+	"testing"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestNewJenkinsHomePersistentVolumeClaim(t *testing.T) {
+	newJenkins := func(storage *v1alpha2.JenkinsHomeStorage) *v1alpha2.Jenkins {
+		return &v1alpha2.Jenkins{
+			ObjectMeta: metav1.ObjectMeta{Name: "example", Namespace: "default"},
+			Spec: v1alpha2.JenkinsSpec{
+				Master: v1alpha2.JenkinsMaster{JenkinsHomeStorage: storage},
+			},
+		}
+	}
+
+	t.Run("defaults", func(t *testing.T) {
+		jenkins := newJenkins(&v1alpha2.JenkinsHomeStorage{Size: resource.MustParse("10Gi")})
+
+		claim := NewJenkinsHomePersistentVolumeClaim(NewResourceObjectMeta(jenkins), jenkins)
+
+		assert.Equal(t, "jenkins-operator-home-example", claim.Name)
+		assert.Equal(t, "default", claim.Namespace)
+		assert.Equal(t, BuildResourceLabels(jenkins), claim.Labels)
+		assert.Equal(t, map[string]string{JenkinsHomeAnnotation: "example"}, claim.Annotations)
+		assert.Equal(t, []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce}, claim.Spec.AccessModes)
+		assert.Nil(t, claim.Spec.StorageClassName)
+		assert.Equal(t, resource.MustParse("10Gi"), claim.Spec.Resources.Requests[corev1.ResourceStorage])
+	})
+	t.Run("custom annotations are merged with operator annotations", func(t *testing.T) {
+		storageClassName := "fast"
+		jenkins := newJenkins(&v1alpha2.JenkinsHomeStorage{
+			Size:             resource.MustParse("10Gi"),
+			StorageClassName: &storageClassName,
+			AccessModes:      []corev1.PersistentVolumeAccessMode{corev1.ReadWriteMany},
+			Annotations: map[string]string{
+				"backup.velero.io/backup-volumes": "jenkins-home",
+				JenkinsHomeAnnotation:             "overridden",
+			},
+		})
+
+		claim := NewJenkinsHomePersistentVolumeClaim(NewResourceObjectMeta(jenkins), jenkins)
+
+		assert.Equal(t, map[string]string{
+			"backup.velero.io/backup-volumes": "jenkins-home",
+			JenkinsHomeAnnotation:             "example",
+		}, claim.Annotations)
+		assert.Equal(t, &storageClassName, claim.Spec.StorageClassName)
+		assert.Equal(t, []corev1.PersistentVolumeAccessMode{corev1.ReadWriteMany}, claim.Spec.AccessModes)
+	})
+	t.Run("Jenkins home volume uses the claim", func(t *testing.T) {
+		jenkins := newJenkins(&v1alpha2.JenkinsHomeStorage{Size: resource.MustParse("10Gi")})
+
+		volumes := GetJenkinsMasterPodBaseVolumes(jenkins)
+
+		assert.Equal(t, JenkinsHomeVolumeName, volumes[0].Name)
+		assert.Nil(t, volumes[0].EmptyDir)
+		assert.Equal(t, "jenkins-operator-home-example", volumes[0].PersistentVolumeClaim.ClaimName)
+	})
+	t.Run("Jenkins home volume is emptyDir without storage", func(t *testing.T) {
+		volumes := GetJenkinsMasterPodBaseVolumes(newJenkins(nil))
+
+		assert.Equal(t, JenkinsHomeVolumeName, volumes[0].Name)
+		assert.NotNil(t, volumes[0].EmptyDir)
+	})
+}
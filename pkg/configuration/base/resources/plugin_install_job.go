@@ -0,0 +1,136 @@
+package resources
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"strings"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/pkg/constants"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+)
+
+const (
+	// PluginsHashAnnotation is the annotation of the plugin installation Job with the hash of the installed plugins
+	PluginsHashAnnotation = "jenkins.io/plugins-hash"
+	// PluginInstallJobEnvName is the env set in the plugin installation Job, the init script installs the plugins
+	// only when it's set in the job mode
+	PluginInstallJobEnvName = "PLUGIN_INSTALL_JOB"
+
+	pluginInstallJobContainerName = "install-plugins"
+	pluginInstallJobBackoffLimit  = 2
+)
+
+// IsPluginInstallJobEnabled returns true when the plugins are installed by the plugin installation Job
+func IsPluginInstallJobEnabled(jenkins *v1alpha2.Jenkins) bool {
+	return jenkins.Spec.Master.PluginInstallMode == v1alpha2.PluginInstallModeJob
+}
+
+// GetPluginInstallJobName returns the name of the plugin installation Job
+func GetPluginInstallJobName(jenkins *v1alpha2.Jenkins) string {
+	return fmt.Sprintf("%s-plugins-%s", constants.OperatorName, jenkins.ObjectMeta.Name)
+}
+
+// getJenkinsHomePluginsPath returns the plugins directory of the Jenkins home
+func getJenkinsHomePluginsPath(jenkins *v1alpha2.Jenkins) string {
+	return getJenkinsHomePath(jenkins) + "/plugins"
+}
+
+// GetPluginsHash returns the hash of the base and user plugins, the plugin installation Job is recreated when it changes.
+// The Jenkins master image is included when spec.master.reinstallPluginsOnImageChange is set.
+func GetPluginsHash(jenkins *v1alpha2.Jenkins) string {
+	var plugins []string
+	for _, plugin := range append(append([]v1alpha2.Plugin{}, jenkins.Spec.Master.BasePlugins...), jenkins.Spec.Master.Plugins...) {
+		plugins = append(plugins, fmt.Sprintf("%s:%s:%s:%s", plugin.Name, plugin.Version, plugin.DownloadURL, plugin.OCIRef))
+	}
+	if jenkins.Spec.Master.ReinstallPluginsOnImageChange && len(jenkins.Spec.Master.Containers) > 0 {
+		plugins = append(plugins, fmt.Sprintf("image:%s", jenkins.Spec.Master.Containers[0].Image))
+	}
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(strings.Join(plugins, "\n"))))
+}
+
+// NewPluginInstallJob builds the Job which installs the plugins into the Jenkins home volume, it runs the init script
+// with the image, the envs and the volumes of the Jenkins master container. The container gets spec.master.initContainerResources
+// when set, the resources of the Jenkins master container otherwise.
+func NewPluginInstallJob(meta metav1.ObjectMeta, jenkins *v1alpha2.Jenkins) *batchv1.Job {
+	serviceAccountName := meta.Name
+	jenkinsContainer := jenkins.Spec.Master.Containers[0]
+	meta.Name = GetPluginInstallJobName(jenkins)
+	meta.Annotations = map[string]string{PluginsHashAnnotation: GetPluginsHash(jenkins)}
+
+	// the Job pod doesn't get the Jenkins master labels, otherwise it would be selected by the Jenkins services
+	podLabels := map[string]string{constants.LabelJenkinsCRKey: jenkins.Name}
+	addOperatorInstanceLabel(*jenkins, podLabels)
+
+	envs := GetJenkinsMasterContainerBaseEnvs(jenkins)
+	envs = append(envs, jenkinsContainer.Env...)
+	envs = append(envs,
+		corev1.EnvVar{Name: "JENKINS_HOME", Value: getJenkinsHomePath(jenkins)},
+		corev1.EnvVar{Name: PluginInstallJobEnvName, Value: "true"},
+	)
+
+	// the plugin installation often needs more memory than the steady-state Jenkins master
+	containerResources := jenkinsContainer.Resources
+	if jenkins.Spec.Master.InitContainerResources != nil {
+		containerResources = *jenkins.Spec.Master.InitContainerResources
+	}
+
+	return &batchv1.Job{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Job",
+			APIVersion: "batch/v1",
+		},
+		ObjectMeta: meta,
+		Spec: batchv1.JobSpec{
+			BackoffLimit: pointer.Int32Ptr(pluginInstallJobBackoffLimit),
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: podLabels},
+				Spec: corev1.PodSpec{
+					ServiceAccountName: serviceAccountName,
+					RestartPolicy:      corev1.RestartPolicyNever,
+					NodeSelector:       jenkins.Spec.Master.NodeSelector,
+					Containers: []corev1.Container{
+						{
+							Name:            pluginInstallJobContainerName,
+							Image:           jenkinsContainer.Image,
+							ImagePullPolicy: jenkinsContainer.ImagePullPolicy,
+							Command:         []string{"bash", "-c", fmt.Sprintf("%s/%s", JenkinsScriptsVolumePath, InitScriptName)},
+							SecurityContext: jenkinsContainer.SecurityContext,
+							Env:             envs,
+							EnvFrom:         jenkinsContainer.EnvFrom,
+							Resources:       containerResources,
+							VolumeMounts:    GetJenkinsMasterContainerBaseVolumeMounts(jenkins),
+						},
+					},
+					Volumes:           GetJenkinsMasterPodBaseVolumes(jenkins),
+					SecurityContext:   jenkins.Spec.Master.SecurityContext,
+					ImagePullSecrets:  jenkins.Spec.Master.ImagePullSecrets,
+					Tolerations:       jenkins.Spec.Master.Tolerations,
+					PriorityClassName: jenkins.Spec.Master.PriorityClassName,
+					HostAliases:       jenkins.Spec.Master.HostAliases,
+				},
+			},
+		},
+	}
+}
+
+// GetPluginInstallJobStatus returns true when the plugin installation Job has completed, an error is returned when
+// the Job has failed
+func GetPluginInstallJobStatus(job *batchv1.Job) (bool, error) {
+	for _, condition := range job.Status.Conditions {
+		if condition.Status != corev1.ConditionTrue {
+			continue
+		}
+		switch condition.Type {
+		case batchv1.JobComplete:
+			return true, nil
+		case batchv1.JobFailed:
+			return false, fmt.Errorf("plugin installation Job '%s' failed: %s", job.Name, condition.Message)
+		}
+	}
+	return job.Status.Succeeded > 0, nil
+}
@@ -0,0 +1,163 @@
+package resources
+
+import (
+	"testing"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/pkg/constants"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func newPluginInstallJobTestJenkins() *v1alpha2.Jenkins {
+	return &v1alpha2.Jenkins{
+		ObjectMeta: metav1.ObjectMeta{Name: "example", Namespace: "default"},
+		Spec: v1alpha2.JenkinsSpec{
+			Master: v1alpha2.JenkinsMaster{
+				PluginInstallMode:  v1alpha2.PluginInstallModeJob,
+				JenkinsHomeStorage: &v1alpha2.JenkinsHomeStorage{Size: resource.MustParse("10Gi")},
+				Containers: []v1alpha2.Container{
+					{
+						Name:  JenkinsMasterContainerName,
+						Image: "jenkins/jenkins:lts",
+						Env:   []corev1.EnvVar{{Name: "JAVA_OPTS", Value: "-Xmx1g"}},
+					},
+				},
+				BasePlugins: []v1alpha2.Plugin{{Name: "kubernetes", Version: "1.0"}},
+				Plugins:     []v1alpha2.Plugin{{Name: "git", Version: "2.0"}},
+			},
+		},
+	}
+}
+
+func TestNewPluginInstallJob(t *testing.T) {
+	// given
+	jenkins := newPluginInstallJobTestJenkins()
+
+	// when
+	job := NewPluginInstallJob(NewResourceObjectMeta(jenkins), jenkins)
+
+	// then
+	assert.Equal(t, "jenkins-operator-plugins-example", job.Name)
+	assert.Equal(t, "default", job.Namespace)
+	assert.Equal(t, map[string]string{PluginsHashAnnotation: GetPluginsHash(jenkins)}, job.Annotations)
+	assert.Equal(t, map[string]string{constants.LabelJenkinsCRKey: "example"}, job.Spec.Template.Labels)
+	assert.Equal(t, corev1.RestartPolicyNever, job.Spec.Template.Spec.RestartPolicy)
+	assert.Equal(t, GetResourceName(jenkins), job.Spec.Template.Spec.ServiceAccountName)
+	assert.Contains(t, job.Spec.Template.Spec.Volumes, corev1.Volume{
+		Name: JenkinsHomeVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "jenkins-operator-home-example"},
+		},
+	})
+	require.Len(t, job.Spec.Template.Spec.Containers, 1)
+	container := job.Spec.Template.Spec.Containers[0]
+	assert.Equal(t, "jenkins/jenkins:lts", container.Image)
+	assert.Equal(t, []string{"bash", "-c", "/var/jenkins/scripts/init.sh"}, container.Command)
+	assert.Contains(t, container.Env, corev1.EnvVar{Name: "JAVA_OPTS", Value: "-Xmx1g"})
+	assert.Contains(t, container.Env, corev1.EnvVar{Name: PluginInstallJobEnvName, Value: "true"})
+	assert.Contains(t, container.VolumeMounts, corev1.VolumeMount{Name: JenkinsHomeVolumeName, MountPath: "/var/lib/jenkins"})
+	assert.Equal(t, jenkins.Spec.Master.Containers[0].Resources, container.Resources)
+}
+
+func TestNewPluginInstallJobInitContainerResources(t *testing.T) {
+	// given
+	jenkins := newPluginInstallJobTestJenkins()
+	initContainerResources := NewResourceRequirements("500m", "512Mi", "1", "2Gi")
+	jenkins.Spec.Master.InitContainerResources = &initContainerResources
+
+	// when
+	job := NewPluginInstallJob(NewResourceObjectMeta(jenkins), jenkins)
+
+	// then
+	require.Len(t, job.Spec.Template.Spec.Containers, 1)
+	assert.Equal(t, initContainerResources, job.Spec.Template.Spec.Containers[0].Resources)
+}
+
+func TestGetPluginsHash(t *testing.T) {
+	jenkins := newPluginInstallJobTestJenkins()
+	hash := GetPluginsHash(jenkins)
+
+	assert.Equal(t, hash, GetPluginsHash(newPluginInstallJobTestJenkins()))
+	jenkins.Spec.Master.Plugins[0].Version = "2.1"
+	assert.NotEqual(t, hash, GetPluginsHash(jenkins))
+}
+
+func TestGetPluginsHashOnImageChange(t *testing.T) {
+	t.Run("image change is ignored by default", func(t *testing.T) {
+		jenkins := newPluginInstallJobTestJenkins()
+		hash := GetPluginsHash(jenkins)
+
+		jenkins.Spec.Master.Containers[0].Image = "jenkins/jenkins:2.426.1-lts"
+
+		assert.Equal(t, hash, GetPluginsHash(jenkins))
+	})
+	t.Run("reinstall plugins on image change", func(t *testing.T) {
+		jenkins := newPluginInstallJobTestJenkins()
+		jenkins.Spec.Master.ReinstallPluginsOnImageChange = true
+		hash := GetPluginsHash(jenkins)
+		job := NewPluginInstallJob(NewResourceObjectMeta(jenkins), jenkins)
+
+		jenkins.Spec.Master.Containers[0].Image = "jenkins/jenkins:2.426.1-lts"
+
+		assert.NotEqual(t, hash, GetPluginsHash(jenkins))
+		assert.NotEqual(t, job.Annotations[PluginsHashAnnotation], NewPluginInstallJob(NewResourceObjectMeta(jenkins), jenkins).Annotations[PluginsHashAnnotation])
+	})
+	t.Run("init script checksum changes on image change in the container mode", func(t *testing.T) {
+		jenkins := newPluginInstallJobTestJenkins()
+		jenkins.Spec.Master.PluginInstallMode = v1alpha2.PluginInstallModeContainer
+		jenkins.Spec.Master.ReinstallPluginsOnImageChange = true
+		jenkins.Spec.Master.Annotations = map[string]string{"team": "platform"}
+		checksum := GetJenkinsMasterPodAnnotations(jenkins)[InitScriptChecksumAnnotation]
+
+		jenkins.Spec.Master.Containers[0].Image = "jenkins/jenkins:2.426.1-lts"
+
+		annotations := GetJenkinsMasterPodAnnotations(jenkins)
+		assert.NotEmpty(t, checksum)
+		assert.NotEqual(t, checksum, annotations[InitScriptChecksumAnnotation])
+		assert.Equal(t, "platform", annotations["team"])
+		assert.NotContains(t, jenkins.Spec.Master.Annotations, InitScriptChecksumAnnotation)
+	})
+	t.Run("without init script checksum", func(t *testing.T) {
+		jenkins := newPluginInstallJobTestJenkins()
+		jenkins.Spec.Master.Annotations = map[string]string{"team": "platform"}
+
+		assert.Equal(t, map[string]string{"team": "platform"}, GetJenkinsMasterPodAnnotations(jenkins))
+	})
+}
+
+func TestGetPluginInstallJobStatus(t *testing.T) {
+	newJob := func(status batchv1.JobStatus) *batchv1.Job {
+		return &batchv1.Job{ObjectMeta: metav1.ObjectMeta{Name: "plugins"}, Status: status}
+	}
+
+	t.Run("running", func(t *testing.T) {
+		completed, err := GetPluginInstallJobStatus(newJob(batchv1.JobStatus{Active: 1}))
+
+		assert.NoError(t, err)
+		assert.False(t, completed)
+	})
+	t.Run("completed", func(t *testing.T) {
+		completed, err := GetPluginInstallJobStatus(newJob(batchv1.JobStatus{
+			Succeeded:  1,
+			Conditions: []batchv1.JobCondition{{Type: batchv1.JobComplete, Status: corev1.ConditionTrue}},
+		}))
+
+		assert.NoError(t, err)
+		assert.True(t, completed)
+	})
+	t.Run("failed", func(t *testing.T) {
+		completed, err := GetPluginInstallJobStatus(newJob(batchv1.JobStatus{
+			Failed:     3,
+			Conditions: []batchv1.JobCondition{{Type: batchv1.JobFailed, Status: corev1.ConditionTrue, Message: "Job has reached the specified backoff limit"}},
+		}))
+
+		assert.EqualError(t, err, "plugin installation Job 'plugins' failed: Job has reached the specified backoff limit")
+		assert.False(t, completed)
+	})
+}
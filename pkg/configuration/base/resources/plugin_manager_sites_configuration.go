@@ -0,0 +1,49 @@
+package resources
+
+import (
+	"text/template"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/internal/render"
+)
+
+// configurePluginManagerSitesTemplate adds the update sites to the plugin manager, the site with the same ID and
+// a different URL is replaced and the metadata of the sites is refreshed only when they have changed
+var configurePluginManagerSitesTemplate = template.Must(template.New(configurePluginManagerSitesGroovyScriptName).Funcs(template.FuncMap{
+	"quote": quoteGroovyString,
+}).Parse(`
+import hudson.model.UpdateSite
+import jenkins.model.Jenkins
+
+def updateCenter = Jenkins.instance.updateCenter
+def sites = [
+{{- range .Sites }}
+    [id: {{ quote .ID }}, url: {{ quote .URL }}],
+{{- end }}
+]
+
+def changed = false
+sites.each { definition ->
+    def site = updateCenter.getSite(definition.id)
+    if (site != null && site.url == definition.url) {
+        return
+    }
+    if (site != null) {
+        updateCenter.sites.remove(site)
+    }
+    updateCenter.sites.add(new UpdateSite(definition.id, definition.url))
+    changed = true
+}
+if (changed) {
+    updateCenter.save()
+    updateCenter.updateAllSites()
+}
+`))
+
+func buildConfigurePluginManagerSitesGroovyScript(sites []v1alpha2.PluginManagerSite) (string, error) {
+	return render.Render(configurePluginManagerSitesTemplate, struct {
+		Sites []v1alpha2.PluginManagerSite
+	}{
+		Sites: sites,
+	})
+}
@@ -0,0 +1,27 @@
+package resources
+
+import (
+	"testing"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildConfigurePluginManagerSitesGroovyScript(t *testing.T) {
+	sites := []v1alpha2.PluginManagerSite{
+		{ID: "default", URL: "https://updates.example.com/update-center.json"},
+		{ID: "team's", URL: "https://updates.example.com/team/update-center.json"},
+	}
+
+	got, err := buildConfigurePluginManagerSitesGroovyScript(sites)
+
+	require.NoError(t, err)
+	assert.Contains(t, got, `def sites = [
+    [id: 'default', url: 'https://updates.example.com/update-center.json'],
+    [id: 'team\'s', url: 'https://updates.example.com/team/update-center.json'],
+]
+`)
+	assert.Contains(t, got, "updateCenter.updateAllSites()")
+}
@@ -0,0 +1,142 @@
+package resources
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+
+	stackerr "github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// PluginProxyURLEnvName is the name of the env with the plugin proxy URL
+	PluginProxyURLEnvName = "PLUGIN_PROXY_URL"
+	// PluginProxyNoProxyEnvName is the name of the env with the hosts downloaded without the plugin proxy
+	PluginProxyNoProxyEnvName = "PLUGIN_PROXY_NO_PROXY"
+	// PluginProxyUsernameEnvName is the name of the env with the plugin proxy username
+	PluginProxyUsernameEnvName = "PLUGIN_PROXY_USERNAME"
+	// PluginProxyPasswordEnvName is the name of the env with the plugin proxy password
+	PluginProxyPasswordEnvName = "PLUGIN_PROXY_PASSWORD"
+
+	// ProxyUsernameSecretKey is the key of the proxy credentials Secret with the username
+	ProxyUsernameSecretKey = "username"
+	// ProxyPasswordSecretKey is the key of the proxy credentials Secret with the password
+	ProxyPasswordSecretKey = "password"
+)
+
+// pluginProxyScript holds the plugin proxy settings rendered into the init script, the credentials are read
+// from the env at runtime and never rendered
+type pluginProxyScript struct {
+	Scheme         string
+	Host           string
+	JavaOpts       string
+	HasCredentials bool
+}
+
+// ParsePluginProxyURL parses the plugin proxy URL, only the http and https URLs without the credentials are supported
+func ParsePluginProxyURL(proxyURL string) (*url.URL, error) {
+	parsed, err := url.Parse(proxyURL)
+	if err != nil {
+		return nil, stackerr.WithStack(err)
+	}
+	if parsed.Scheme != "http" && parsed.Scheme != "https" {
+		return nil, stackerr.Errorf("unsupported scheme '%s', supported schemes are: http, https", parsed.Scheme)
+	}
+	if len(parsed.Hostname()) == 0 {
+		return nil, stackerr.New("host can't be empty")
+	}
+	if parsed.User != nil {
+		return nil, stackerr.New("credentials must be set in the credentials secret")
+	}
+	return parsed, nil
+}
+
+// getPluginProxyPort returns the port of the proxy URL which defaults to the port of the URL scheme
+func getPluginProxyPort(proxyURL *url.URL) string {
+	if port := proxyURL.Port(); len(port) > 0 {
+		return port
+	}
+	if proxyURL.Scheme == "https" {
+		return "443"
+	}
+	return "80"
+}
+
+// buildPluginProxyScript builds the plugin proxy settings of the init script, jenkins-plugin-cli reads the proxy
+// from the Java system properties and curl from the http_proxy env
+func buildPluginProxyScript(proxy *v1alpha2.PluginProxy) (*pluginProxyScript, error) {
+	if proxy == nil {
+		return nil, nil
+	}
+	parsed, err := ParsePluginProxyURL(proxy.URL)
+	if err != nil {
+		return nil, stackerr.Wrap(err, "invalid plugin proxy URL")
+	}
+	host, port := parsed.Hostname(), getPluginProxyPort(parsed)
+
+	javaOpts := []string{
+		fmt.Sprintf("-Dhttp.proxyHost=%s", host),
+		fmt.Sprintf("-Dhttp.proxyPort=%s", port),
+		fmt.Sprintf("-Dhttps.proxyHost=%s", host),
+		fmt.Sprintf("-Dhttps.proxyPort=%s", port),
+	}
+	if len(proxy.NoProxy) > 0 {
+		var nonProxyHosts []string
+		for _, noProxy := range proxy.NoProxy {
+			if strings.HasPrefix(noProxy, ".") {
+				noProxy = "*" + noProxy
+			}
+			nonProxyHosts = append(nonProxyHosts, noProxy)
+		}
+		javaOpts = append(javaOpts, fmt.Sprintf("-Dhttp.nonProxyHosts=%s", strings.Join(nonProxyHosts, "|")))
+	}
+	if proxy.CredentialsSecretRef != nil {
+		// the Basic authentication of the HTTPS tunnels is disabled by default since Java 8u111
+		javaOpts = append(javaOpts, "-Djdk.http.auth.tunneling.disabledSchemes=")
+	}
+
+	return &pluginProxyScript{
+		Scheme:         parsed.Scheme,
+		Host:           net.JoinHostPort(host, port),
+		JavaOpts:       strings.Join(javaOpts, " "),
+		HasCredentials: proxy.CredentialsSecretRef != nil,
+	}, nil
+}
+
+// buildPluginProxyEnvs builds the envs of the plugin proxy read by the init script
+func buildPluginProxyEnvs(proxy *v1alpha2.PluginProxy) []corev1.EnvVar {
+	if proxy == nil {
+		return nil
+	}
+	envs := []corev1.EnvVar{
+		{Name: PluginProxyURLEnvName, Value: proxy.URL},
+		{Name: PluginProxyNoProxyEnvName, Value: strings.Join(proxy.NoProxy, ",")},
+	}
+	if proxy.CredentialsSecretRef != nil {
+		envs = append(envs,
+			corev1.EnvVar{
+				Name: PluginProxyUsernameEnvName,
+				ValueFrom: &corev1.EnvVarSource{
+					SecretKeyRef: &corev1.SecretKeySelector{
+						LocalObjectReference: *proxy.CredentialsSecretRef,
+						Key:                  ProxyUsernameSecretKey,
+					},
+				},
+			},
+			corev1.EnvVar{
+				Name: PluginProxyPasswordEnvName,
+				ValueFrom: &corev1.EnvVarSource{
+					SecretKeyRef: &corev1.SecretKeySelector{
+						LocalObjectReference: *proxy.CredentialsSecretRef,
+						Key:                  ProxyPasswordSecretKey,
+					},
+				},
+			},
+		)
+	}
+	return envs
+}
@@ -23,6 +23,9 @@ const (
 	JenkinsScriptsVolumePath = jenkinsPath + "/scripts"
 	// InitScriptName is the init script name which configures init.groovy.d, scripts and install plugins
 	InitScriptName = "init.sh"
+	// InitScriptChecksumAnnotation is the annotation of the Jenkins master pod with the checksum of the plugins installed
+	// by the init script and the Jenkins master image, it's set when spec.master.reinstallPluginsOnImageChange is set
+	InitScriptChecksumAnnotation = "jenkins.io/init-script-checksum"
 
 	jenkinsOperatorCredentialsVolumeName = "operator-credentials"
 	jenkinsOperatorCredentialsVolumePath = jenkinsPath + "/operator-credentials"
@@ -37,6 +40,47 @@ const (
 	// This script is provided by user
 	ConfigurationAsCodeSecretVolumePath = jenkinsPath + "/configuration-as-code-secrets"
 
+	caCertsVolumeName = "ca-certs"
+	// CACertsVolumePath is a path where are the certificates imported to the Jenkins truststore
+	CACertsVolumePath = jenkinsPath + "/ca-certs"
+	// truststoreFileName is the name of the Jenkins truststore in the Jenkins home
+	truststoreFileName = "cacerts"
+	// truststoreJavaArgsFileName is the name of the Java argument file with the Jenkins truststore options in the Jenkins home
+	truststoreJavaArgsFileName = "cacerts.args"
+
+	mavenSettingsVolumeName = "maven-settings"
+	// MavenSettingsVolumePath is a path where is the Maven settings.xml of spec.master.mavenSettingsSecretRef
+	MavenSettingsVolumePath = jenkinsPath + "/maven-settings"
+	// mavenSettingsFileName is the name of the Maven settings file in the MavenSettingsVolumePath
+	mavenSettingsFileName = "settings.xml"
+
+	webhookTokenVolumeName = "webhook-token"
+	// WebhookTokenVolumePath is a path where is the webhook token of spec.master.webhookTokenSecretRef
+	WebhookTokenVolumePath = jenkinsPath + "/webhook-token"
+	// webhookTokenFileName is the name of the webhook token file in the WebhookTokenVolumePath
+	webhookTokenFileName = "token"
+
+	pluginCacheVolumeName = "plugin-cache"
+	// PluginCacheVolumePath is a path where the plugins are downloaded to when spec.master.pluginCacheVolume is set,
+	// it's separate from the plugins reference directory so the plugins of the Jenkins master image aren't hidden
+	PluginCacheVolumePath = jenkinsPath + "/plugin-cache"
+
+	// DisableSetupWizardJavaOpt is the Java option which disables the Jenkins setup wizard
+	DisableSetupWizardJavaOpt = "-Djenkins.install.runSetupWizard=false"
+	// TimeZoneEnvName is the name of the env which sets the time zone of the Jenkins master container
+	TimeZoneEnvName = "TZ"
+	// TimeZoneSystemProperty is the system property which sets the time zone of the timestamps shown in the UI
+	TimeZoneSystemProperty = "org.apache.commons.jelly.tags.fmt.timeZone"
+	// ContentSecurityPolicySystemProperty is the system property which sets the Content-Security-Policy header of
+	// the files served by Jenkins
+	ContentSecurityPolicySystemProperty = "hudson.model.DirectoryBrowserSupport.CSP"
+	// AllowNonExistentUserToLoginJavaOpt is the Java option which lets the users unknown to the security realm
+	// authenticate with their API tokens
+	AllowNonExistentUserToLoginJavaOpt = "-Dhudson.model.User.allowNonExistentUserToLogin=true"
+
+	// forwardedHeadersJenkinsOpt is the Jenkins web server option which enables the X-Forwarded-* headers handling
+	forwardedHeadersJenkinsOpt = "forwardedHeaders"
+
 	httpPortName  = "http"
 	slavePortName = "slavelistener"
 )
@@ -74,6 +118,47 @@ func GetJenkinsMasterContainerBaseEnvs(jenkins *v1alpha2.Jenkins) []corev1.EnvVa
 		})
 	}
 
+	if ldap := jenkins.Spec.Master.LDAP; ldap != nil && ldap.ManagerDNSecretRef != nil {
+		envVars = append(envVars, corev1.EnvVar{
+			Name: LDAPManagerPasswordEnvName,
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: ldap.ManagerDNSecretRef.LocalObjectReference,
+					Key:                  ldap.ManagerDNSecretRef.Key,
+				},
+			},
+		})
+	}
+
+	if jenkins.Spec.Master.UpdateCenterChannel == v1alpha2.UpdateCenterChannelExperimental {
+		envVars = append(envVars, corev1.EnvVar{
+			Name:  ExperimentalUpdateCenterEnvName,
+			Value: ExperimentalUpdateCenterURL,
+		})
+	}
+
+	if reinstallID := jenkins.Annotations[v1alpha2.PluginsReinstallIDAnnotation]; len(reinstallID) > 0 {
+		envVars = append(envVars, corev1.EnvVar{
+			Name:  PluginsReinstallIDEnvName,
+			Value: reinstallID,
+		})
+	}
+
+	envVars = append(envVars, buildPluginProxyEnvs(jenkins.Spec.Master.PluginProxy)...)
+	envVars = append(envVars, buildJenkinsProxyEnvs(jenkins.Spec.Master.JenkinsProxy)...)
+
+	if oidc := jenkins.Spec.Master.OIDC; oidc != nil {
+		envVars = append(envVars, corev1.EnvVar{
+			Name: OIDCClientSecretEnvName,
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: oidc.ClientSecretRef.LocalObjectReference,
+					Key:                  oidc.ClientSecretRef.Key,
+				},
+			},
+		})
+	}
+
 	return envVars
 }
 
@@ -93,12 +178,20 @@ func GetJenkinsMasterPodBaseVolumes(jenkins *v1alpha2.Jenkins) []corev1.Volume {
 	configMapVolumeSourceDefaultMode := corev1.ConfigMapVolumeSourceDefaultMode
 	secretVolumeSourceDefaultMode := corev1.SecretVolumeSourceDefaultMode
 	var scriptsVolumeDefaultMode int32 = 0777
+	jenkinsHomeVolumeSource := corev1.VolumeSource{
+		EmptyDir: &corev1.EmptyDirVolumeSource{},
+	}
+	if jenkins.Spec.Master.JenkinsHomeStorage != nil {
+		jenkinsHomeVolumeSource = corev1.VolumeSource{
+			PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+				ClaimName: GetJenkinsHomePersistentVolumeClaimName(jenkins),
+			},
+		}
+	}
 	volumes := []corev1.Volume{
 		{
-			Name: JenkinsHomeVolumeName,
-			VolumeSource: corev1.VolumeSource{
-				EmptyDir: &corev1.EmptyDirVolumeSource{},
-			},
+			Name:         JenkinsHomeVolumeName,
+			VolumeSource: jenkinsHomeVolumeSource,
 		},
 		{
 			Name: jenkinsScriptsVolumeName,
@@ -155,6 +248,49 @@ func GetJenkinsMasterPodBaseVolumes(jenkins *v1alpha2.Jenkins) []corev1.Volume {
 			},
 		})
 	}
+	if jenkins.Spec.Master.CACertsSecretRef != nil {
+		volumes = append(volumes, corev1.Volume{
+			Name: caCertsVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					DefaultMode: &secretVolumeSourceDefaultMode,
+					SecretName:  jenkins.Spec.Master.CACertsSecretRef.Name,
+				},
+			},
+		})
+	}
+	if mavenSettings := jenkins.Spec.Master.MavenSettingsSecretRef; mavenSettings != nil {
+		volumes = append(volumes, corev1.Volume{
+			Name: mavenSettingsVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					DefaultMode: &secretVolumeSourceDefaultMode,
+					SecretName:  mavenSettings.Name,
+					Items:       []corev1.KeyToPath{{Key: mavenSettings.Key, Path: mavenSettingsFileName}},
+				},
+			},
+		})
+	}
+	if webhookToken := jenkins.Spec.Master.WebhookTokenSecretRef; webhookToken != nil {
+		volumes = append(volumes, corev1.Volume{
+			Name: webhookTokenVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					DefaultMode: &secretVolumeSourceDefaultMode,
+					SecretName:  webhookToken.Name,
+					Items:       []corev1.KeyToPath{{Key: webhookToken.Key, Path: webhookTokenFileName}},
+				},
+			},
+		})
+	}
+	if jenkins.Spec.Master.PluginCacheVolume != nil {
+		volumes = append(volumes, corev1.Volume{
+			Name: pluginCacheVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				PersistentVolumeClaim: jenkins.Spec.Master.PluginCacheVolume,
+			},
+		})
+	}
 
 	return volumes
 }
@@ -206,6 +342,33 @@ func GetJenkinsMasterContainerBaseVolumeMounts(jenkins *v1alpha2.Jenkins) []core
 			ReadOnly:  true,
 		})
 	}
+	if jenkins.Spec.Master.CACertsSecretRef != nil {
+		volumeMounts = append(volumeMounts, corev1.VolumeMount{
+			Name:      caCertsVolumeName,
+			MountPath: CACertsVolumePath,
+			ReadOnly:  true,
+		})
+	}
+	if jenkins.Spec.Master.MavenSettingsSecretRef != nil {
+		volumeMounts = append(volumeMounts, corev1.VolumeMount{
+			Name:      mavenSettingsVolumeName,
+			MountPath: MavenSettingsVolumePath,
+			ReadOnly:  true,
+		})
+	}
+	if jenkins.Spec.Master.WebhookTokenSecretRef != nil {
+		volumeMounts = append(volumeMounts, corev1.VolumeMount{
+			Name:      webhookTokenVolumeName,
+			MountPath: WebhookTokenVolumePath,
+			ReadOnly:  true,
+		})
+	}
+	if jenkins.Spec.Master.PluginCacheVolume != nil {
+		volumeMounts = append(volumeMounts, corev1.VolumeMount{
+			Name:      pluginCacheVolumeName,
+			MountPath: PluginCacheVolumePath,
+		})
+	}
 
 	return volumeMounts
 }
@@ -234,9 +397,21 @@ func NewJenkinsMasterContainer(jenkins *v1alpha2.Jenkins) corev1.Container {
 		envs = append(envs, jenkinsHomeEnvVar)
 	}
 
+	envs = setJenkinsOptsForwardedHeaders(jenkins, envs)
+	envs = setJavaOptsTruststore(jenkins, envs)
+	envs = setJavaOptsDisableSetupWizard(jenkins, envs)
+	envs = setJavaOptsSystemProperties(jenkins, envs)
+	envs = setTimeZone(jenkins, envs)
+	envs = setJavaOptsContentSecurityPolicy(jenkins, envs)
+	envs = setJavaOptsOperatorAPIToken(jenkins, envs)
+
 	if jenkins.Spec.Master.Containers[0].ReadinessProbe.HTTPGet != nil {
 		setLivenessAndReadinessPath(jenkins)
 	}
+	readinessProbe := jenkinsContainer.ReadinessProbe
+	if jenkins.Spec.Master.DeepReadiness {
+		readinessProbe = NewDeepReadinessProbe(jenkins, readinessProbe)
+	}
 
 	return corev1.Container{
 		Name:            JenkinsMasterContainerName,
@@ -244,7 +419,7 @@ func NewJenkinsMasterContainer(jenkins *v1alpha2.Jenkins) corev1.Container {
 		ImagePullPolicy: jenkinsContainer.ImagePullPolicy,
 		Command:         jenkinsContainer.Command,
 		LivenessProbe:   jenkinsContainer.LivenessProbe,
-		ReadinessProbe:  jenkinsContainer.ReadinessProbe,
+		ReadinessProbe:  readinessProbe,
 		Ports: []corev1.ContainerPort{
 			{
 				Name:          httpPortName,
@@ -269,7 +444,7 @@ func setLivenessAndReadinessPath(jenkins *v1alpha2.Jenkins) {
 	ReadinessProbePath := jenkins.Spec.Master.Containers[0].ReadinessProbe.HTTPGet.Path
 	LivenessProbePath := jenkins.Spec.Master.Containers[0].ReadinessProbe.HTTPGet.Path
 
-	if prefix, ok := GetJenkinsOpts(*jenkins)["prefix"]; ok {
+	if prefix := GetJenkinsContextPath(jenkins); len(prefix) > 0 {
 		if !strings.HasPrefix(ReadinessProbePath, prefix) {
 			jenkins.Spec.Master.Containers[0].ReadinessProbe.HTTPGet.Path = prefix + httpGetPath
 		}
@@ -286,6 +461,206 @@ func setLivenessAndReadinessPath(jenkins *v1alpha2.Jenkins) {
 	}
 }
 
+// setJenkinsOptsForwardedHeaders adds the forwarded headers option to the JENKINS_OPTS env when
+// spec.master.forwardedHeaders is set, the option already set there is kept
+func setJenkinsOptsForwardedHeaders(jenkins *v1alpha2.Jenkins, envs []corev1.EnvVar) []corev1.EnvVar {
+	if !jenkins.Spec.Master.ForwardedHeaders {
+		return envs
+	}
+	if _, ok := GetJenkinsOpts(*jenkins)[forwardedHeadersJenkinsOpt]; ok {
+		return envs
+	}
+	return appendJenkinsOpts(envs, fmt.Sprintf("--%s=true", forwardedHeadersJenkinsOpt))
+}
+
+// appendJenkinsOpts appends the options to the JENKINS_OPTS env, the env is added when it doesn't exist
+func appendJenkinsOpts(envs []corev1.EnvVar, opts string) []corev1.EnvVar {
+	for i, env := range envs {
+		if env.Name == "JENKINS_OPTS" {
+			envs[i].Value = strings.TrimSpace(env.Value + " " + opts)
+			return envs
+		}
+	}
+	return append(envs, corev1.EnvVar{Name: "JENKINS_OPTS", Value: opts})
+}
+
+// GetJenkinsTruststorePath returns the path of the Jenkins truststore with the certificates from spec.master.caCertsSecretRef
+func GetJenkinsTruststorePath(jenkins *v1alpha2.Jenkins) string {
+	return fmt.Sprintf("%s/%s", getJenkinsHomePath(jenkins), truststoreFileName)
+}
+
+// GetJenkinsTruststoreJavaArgsPath returns the path of the Java argument file with the Jenkins truststore options,
+// the file is written by the init script because the truststore password is generated on every start
+func GetJenkinsTruststoreJavaArgsPath(jenkins *v1alpha2.Jenkins) string {
+	return fmt.Sprintf("%s/%s", getJenkinsHomePath(jenkins), truststoreJavaArgsFileName)
+}
+
+// setJavaOptsTruststore adds the Java argument file with the Jenkins truststore options to the JAVA_OPTS env when
+// spec.master.caCertsSecretRef is set
+func setJavaOptsTruststore(jenkins *v1alpha2.Jenkins, envs []corev1.EnvVar) []corev1.EnvVar {
+	if jenkins.Spec.Master.CACertsSecretRef == nil {
+		return envs
+	}
+
+	return appendJavaOpts(envs, "@"+GetJenkinsTruststoreJavaArgsPath(jenkins))
+}
+
+// IsSetupWizardDisabled returns true if the Jenkins setup wizard is disabled by spec.master.disableSetupWizard, defaults to true
+func IsSetupWizardDisabled(jenkins *v1alpha2.Jenkins) bool {
+	return jenkins.Spec.Master.DisableSetupWizard == nil || *jenkins.Spec.Master.DisableSetupWizard
+}
+
+// setJavaOptsDisableSetupWizard adds the DisableSetupWizardJavaOpt to the JAVA_OPTS env when the setup wizard is disabled
+// and the option isn't set already
+func setJavaOptsDisableSetupWizard(jenkins *v1alpha2.Jenkins, envs []corev1.EnvVar) []corev1.EnvVar {
+	if !IsSetupWizardDisabled(jenkins) {
+		return envs
+	}
+
+	for _, env := range envs {
+		if env.Name == constants.JavaOpsVariableName && HasJavaOpt(env.Value, DisableSetupWizardJavaOpt) {
+			return envs
+		}
+	}
+	return appendJavaOpts(envs, DisableSetupWizardJavaOpt)
+}
+
+// setJavaOptsSystemProperties adds spec.master.systemProperties to the JAVA_OPTS env, the properties already set there
+// are skipped
+func setJavaOptsSystemProperties(jenkins *v1alpha2.Jenkins, envs []corev1.EnvVar) []corev1.EnvVar {
+	var javaOpts string
+	for _, env := range envs {
+		if env.Name == constants.JavaOpsVariableName {
+			javaOpts = env.Value
+		}
+	}
+
+	var opts []string
+	for _, property := range jenkins.Spec.Master.SystemProperties {
+		if hasSystemProperty(javaOpts, property.Key) {
+			continue
+		}
+		opts = append(opts, quoteJavaOpt(fmt.Sprintf("-D%s=%s", property.Key, property.Value)))
+	}
+	if len(opts) == 0 {
+		return envs
+	}
+	return appendJavaOpts(envs, strings.Join(opts, " "))
+}
+
+// setTimeZone sets the TZ env and the time zone of the Jelly fmt tags used by the UI to spec.master.timeZone,
+// the TZ env and the system property already set are kept
+func setTimeZone(jenkins *v1alpha2.Jenkins, envs []corev1.EnvVar) []corev1.EnvVar {
+	timeZone := jenkins.Spec.Master.TimeZone
+	if len(timeZone) == 0 {
+		return envs
+	}
+
+	var javaOpts string
+	hasTZ := false
+	for _, env := range envs {
+		switch env.Name {
+		case constants.JavaOpsVariableName:
+			javaOpts = env.Value
+		case TimeZoneEnvName:
+			hasTZ = true
+		}
+	}
+	if !hasTZ {
+		envs = append(envs, corev1.EnvVar{Name: TimeZoneEnvName, Value: timeZone})
+	}
+	if hasSystemProperty(javaOpts, TimeZoneSystemProperty) {
+		return envs
+	}
+	return appendJavaOpts(envs, quoteJavaOpt(fmt.Sprintf("-D%s=%s", TimeZoneSystemProperty, timeZone)))
+}
+
+// setJavaOptsContentSecurityPolicy adds spec.master.contentSecurityPolicy to the JAVA_OPTS env, the system property
+// already set there is kept
+func setJavaOptsContentSecurityPolicy(jenkins *v1alpha2.Jenkins, envs []corev1.EnvVar) []corev1.EnvVar {
+	csp := jenkins.Spec.Master.ContentSecurityPolicy
+	if csp == nil {
+		return envs
+	}
+	for _, env := range envs {
+		if env.Name == constants.JavaOpsVariableName && hasSystemProperty(env.Value, ContentSecurityPolicySystemProperty) {
+			return envs
+		}
+	}
+	return appendJavaOpts(envs, quoteJavaOpt(fmt.Sprintf("-D%s=%s", ContentSecurityPolicySystemProperty, *csp)))
+}
+
+// setJavaOptsOperatorAPIToken adds the AllowNonExistentUserToLoginJavaOpt to the JAVA_OPTS env when the operator user
+// authenticates with the fixed API token, the external security realm doesn't know the operator user
+func setJavaOptsOperatorAPIToken(jenkins *v1alpha2.Jenkins, envs []corev1.EnvVar) []corev1.EnvVar {
+	if !IsOperatorAPITokenUsed(jenkins) {
+		return envs
+	}
+	for _, env := range envs {
+		if env.Name == constants.JavaOpsVariableName && HasJavaOpt(env.Value, AllowNonExistentUserToLoginJavaOpt) {
+			return envs
+		}
+	}
+	return appendJavaOpts(envs, AllowNonExistentUserToLoginJavaOpt)
+}
+
+func hasSystemProperty(javaOpts, key string) bool {
+	for _, opt := range strings.Fields(javaOpts) {
+		opt = strings.Trim(opt, `'"`)
+		if opt == "-D"+key || strings.HasPrefix(opt, "-D"+key+"=") {
+			return true
+		}
+	}
+	return false
+}
+
+// quoteJavaOpt quotes the option with the shell single quotes when it contains whitespaces or quotes, JAVA_OPTS is
+// split into the options by xargs in the Jenkins image entrypoint
+func quoteJavaOpt(opt string) string {
+	if !strings.ContainsAny(opt, " \t\n'\"\\") {
+		return opt
+	}
+	return "'" + strings.ReplaceAll(opt, "'", `'"'"'`) + "'"
+}
+
+// HasJavaOpt returns true if the option is set in the Java options
+func HasJavaOpt(javaOpts, opt string) bool {
+	for _, setOpt := range strings.Fields(javaOpts) {
+		if setOpt == opt {
+			return true
+		}
+	}
+	return false
+}
+
+// appendJavaOpts appends the options to the JAVA_OPTS env, the env is added when it doesn't exist
+func appendJavaOpts(envs []corev1.EnvVar, opts string) []corev1.EnvVar {
+	for i, env := range envs {
+		if env.Name == constants.JavaOpsVariableName {
+			envs[i].Value = strings.TrimSpace(env.Value + " " + opts)
+			return envs
+		}
+	}
+	return append(envs, corev1.EnvVar{Name: constants.JavaOpsVariableName, Value: opts})
+}
+
+// GetJenkinsContextPath returns the path Jenkins is served under from spec.master.contextPath or the --prefix option
+// of JENKINS_OPTS, returns empty string when Jenkins is served under the root path
+func GetJenkinsContextPath(jenkins *v1alpha2.Jenkins) string {
+	if contextPath := strings.Trim(jenkins.Spec.Master.ContextPath, "/"); len(contextPath) > 0 {
+		return "/" + contextPath
+	}
+	if len(jenkins.Spec.Master.Containers) == 0 {
+		return ""
+	}
+	return GetJenkinsOpts(*jenkins)["prefix"]
+}
+
+// BuildJenkinsURL appends the context path to the Jenkins base URL
+func BuildJenkinsURL(jenkins *v1alpha2.Jenkins, baseURL string) string {
+	return strings.TrimSuffix(baseURL, "/") + GetJenkinsContextPath(jenkins)
+}
+
 // GetJenkinsOpts gets JENKINS_OPTS env parameter, parses it's values and returns it as a map`
 func GetJenkinsOpts(jenkins v1alpha2.Jenkins) map[string]string {
 	envs := jenkins.Spec.Master.Containers[0].Env
@@ -362,10 +737,51 @@ func GetJenkinsMasterPodLabels(jenkins v1alpha2.Jenkins) map[string]string {
 	return labels
 }
 
+// NewJenkinsMasterInitContainers builds the extra init containers of the Jenkins master pod, the Jenkins home volume
+// is mounted and the init container resources are set in every init container which doesn't define them
+func NewJenkinsMasterInitContainers(jenkins *v1alpha2.Jenkins) []corev1.Container {
+	var initContainers []corev1.Container
+	for _, extraInitContainer := range jenkins.Spec.Master.ExtraInitContainers {
+		initContainer := *extraInitContainer.DeepCopy()
+		if initContainerResources := jenkins.Spec.Master.InitContainerResources; initContainerResources != nil &&
+			len(initContainer.Resources.Requests) == 0 && len(initContainer.Resources.Limits) == 0 {
+			initContainer.Resources = *initContainerResources.DeepCopy()
+		}
+		jenkinsHomeMounted := false
+		for _, volumeMount := range initContainer.VolumeMounts {
+			if volumeMount.Name == JenkinsHomeVolumeName {
+				jenkinsHomeMounted = true
+			}
+		}
+		if !jenkinsHomeMounted {
+			initContainer.VolumeMounts = append(initContainer.VolumeMounts, corev1.VolumeMount{
+				Name:      JenkinsHomeVolumeName,
+				MountPath: getJenkinsHomePath(jenkins),
+			})
+		}
+		initContainers = append(initContainers, initContainer)
+	}
+	return initContainers
+}
+
+// GetJenkinsMasterPodAnnotations returns the annotations of the Jenkins master pod, spec.master.annotations and the
+// init script checksum which restarts the Jenkins master when the plugins or the image change
+func GetJenkinsMasterPodAnnotations(jenkins *v1alpha2.Jenkins) map[string]string {
+	if !jenkins.Spec.Master.ReinstallPluginsOnImageChange {
+		return jenkins.Spec.Master.Annotations
+	}
+	annotations := map[string]string{}
+	for key, value := range jenkins.Spec.Master.Annotations {
+		annotations[key] = value
+	}
+	annotations[InitScriptChecksumAnnotation] = GetPluginsHash(jenkins)
+	return annotations
+}
+
 // NewJenkinsMasterPod builds Jenkins Master Kubernetes Pod resource
 func NewJenkinsMasterPod(objectMeta metav1.ObjectMeta, jenkins *v1alpha2.Jenkins) *corev1.Pod {
 	serviceAccountName := objectMeta.Name
-	objectMeta.Annotations = jenkins.Spec.Master.Annotations
+	objectMeta.Annotations = GetJenkinsMasterPodAnnotations(jenkins)
 	objectMeta.Name = GetJenkinsMasterPodName(jenkins)
 	objectMeta.Labels = GetJenkinsMasterPodLabels(*jenkins)
 
@@ -376,6 +792,7 @@ func NewJenkinsMasterPod(objectMeta metav1.ObjectMeta, jenkins *v1alpha2.Jenkins
 			ServiceAccountName: serviceAccountName,
 			RestartPolicy:      corev1.RestartPolicyNever,
 			NodeSelector:       jenkins.Spec.Master.NodeSelector,
+			InitContainers:     NewJenkinsMasterInitContainers(jenkins),
 			Containers:         newContainers(jenkins),
 			Volumes:            append(GetJenkinsMasterPodBaseVolumes(jenkins), jenkins.Spec.Master.Volumes...),
 			SecurityContext:    jenkins.Spec.Master.SecurityContext,
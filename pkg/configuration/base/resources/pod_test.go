@@ -6,6 +6,9 @@ import (
 	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func TestGetJenkinsMasterPodBaseVolumes(t *testing.T) {
@@ -156,3 +159,486 @@ func checkSecretVolumesPresence(jenkins *v1alpha2.Jenkins) (groovyExists bool, c
 	}
 	return groovyExists, cascExists
 }
+
+func TestNewJenkinsMasterInitContainers(t *testing.T) {
+	t.Run("no extra init containers", func(t *testing.T) {
+		jenkins := &v1alpha2.Jenkins{}
+
+		assert.Nil(t, NewJenkinsMasterInitContainers(jenkins))
+	})
+	t.Run("Jenkins home is mounted", func(t *testing.T) {
+		jenkins := &v1alpha2.Jenkins{
+			Spec: v1alpha2.JenkinsSpec{
+				Master: v1alpha2.JenkinsMaster{
+					Containers: []v1alpha2.Container{{Name: JenkinsMasterContainerName, ReadinessProbe: &corev1.Probe{}}},
+					ExtraInitContainers: []corev1.Container{
+						{Name: "vault", Image: "vault:1.9"},
+						{
+							Name:         "custom-mount",
+							Image:        "busybox",
+							VolumeMounts: []corev1.VolumeMount{{Name: JenkinsHomeVolumeName, MountPath: "/home"}},
+						},
+					},
+				},
+			},
+		}
+
+		got := NewJenkinsMasterInitContainers(jenkins)
+
+		assert.Equal(t, []corev1.Container{
+			{
+				Name:         "vault",
+				Image:        "vault:1.9",
+				VolumeMounts: []corev1.VolumeMount{{Name: JenkinsHomeVolumeName, MountPath: "/var/lib/jenkins"}},
+			},
+			{
+				Name:         "custom-mount",
+				Image:        "busybox",
+				VolumeMounts: []corev1.VolumeMount{{Name: JenkinsHomeVolumeName, MountPath: "/home"}},
+			},
+		}, got)
+		assert.Empty(t, jenkins.Spec.Master.ExtraInitContainers[0].VolumeMounts)
+		assert.Equal(t, got, NewJenkinsMasterPod(metav1.ObjectMeta{}, jenkins).Spec.InitContainers)
+		assert.Equal(t, got, NewJenkinsDeployment(metav1.ObjectMeta{}, jenkins).Spec.Template.Spec.InitContainers)
+	})
+	t.Run("init container resources", func(t *testing.T) {
+		initContainerResources := NewResourceRequirements("250m", "256Mi", "1", "1Gi")
+		ownResources := NewResourceRequirements("50m", "50Mi", "100m", "100Mi")
+		jenkins := &v1alpha2.Jenkins{
+			Spec: v1alpha2.JenkinsSpec{
+				Master: v1alpha2.JenkinsMaster{
+					Containers: []v1alpha2.Container{{Name: JenkinsMasterContainerName, ReadinessProbe: &corev1.Probe{}}},
+					ExtraInitContainers: []corev1.Container{
+						{Name: "plugins", Image: "busybox"},
+						{Name: "vault", Image: "vault:1.9", Resources: ownResources},
+					},
+					InitContainerResources: &initContainerResources,
+				},
+			},
+		}
+
+		got := NewJenkinsDeployment(metav1.ObjectMeta{}, jenkins).Spec.Template.Spec.InitContainers
+
+		require.Len(t, got, 2)
+		assert.Equal(t, initContainerResources, got[0].Resources)
+		assert.Equal(t, ownResources, got[1].Resources)
+		assert.Empty(t, jenkins.Spec.Master.ExtraInitContainers[0].Resources)
+	})
+}
+
+func TestPluginCacheVolume(t *testing.T) {
+	newJenkins := func(pluginCacheVolume *corev1.PersistentVolumeClaimVolumeSource) *v1alpha2.Jenkins {
+		return &v1alpha2.Jenkins{
+			Spec: v1alpha2.JenkinsSpec{
+				Master: v1alpha2.JenkinsMaster{
+					Containers:        []v1alpha2.Container{{Name: JenkinsMasterContainerName, ReadinessProbe: &corev1.Probe{}}},
+					PluginCacheVolume: pluginCacheVolume,
+				},
+			},
+		}
+	}
+
+	t.Run("plugin cache volume is mounted", func(t *testing.T) {
+		jenkins := newJenkins(&corev1.PersistentVolumeClaimVolumeSource{ClaimName: "jenkins-plugins"})
+
+		volumes := GetJenkinsMasterPodBaseVolumes(jenkins)
+		container := NewJenkinsMasterContainer(jenkins)
+
+		assert.Contains(t, volumes, corev1.Volume{
+			Name:         pluginCacheVolumeName,
+			VolumeSource: corev1.VolumeSource{PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "jenkins-plugins"}},
+		})
+		assert.Contains(t, container.VolumeMounts, corev1.VolumeMount{Name: pluginCacheVolumeName, MountPath: "/var/jenkins/plugin-cache"})
+	})
+	t.Run("without plugin cache volume", func(t *testing.T) {
+		jenkins := newJenkins(nil)
+
+		for _, volume := range GetJenkinsMasterPodBaseVolumes(jenkins) {
+			assert.NotEqual(t, pluginCacheVolumeName, volume.Name)
+		}
+		for _, volumeMount := range NewJenkinsMasterContainer(jenkins).VolumeMounts {
+			assert.NotEqual(t, pluginCacheVolumeName, volumeMount.Name)
+		}
+	})
+}
+
+func TestBuildJenkinsURL(t *testing.T) {
+	newJenkins := func(contextPath, jenkinsOpts string) *v1alpha2.Jenkins {
+		container := v1alpha2.Container{Name: JenkinsMasterContainerName, ReadinessProbe: &corev1.Probe{}}
+		if len(jenkinsOpts) > 0 {
+			container.Env = []corev1.EnvVar{{Name: "JENKINS_OPTS", Value: jenkinsOpts}}
+		}
+		return &v1alpha2.Jenkins{
+			Spec: v1alpha2.JenkinsSpec{
+				Master: v1alpha2.JenkinsMaster{Containers: []v1alpha2.Container{container}, ContextPath: contextPath},
+			},
+		}
+	}
+
+	t.Run("root path", func(t *testing.T) {
+		assert.Equal(t, "http://jenkins:8080", BuildJenkinsURL(newJenkins("", ""), "http://jenkins:8080"))
+	})
+	t.Run("context path", func(t *testing.T) {
+		assert.Equal(t, "http://jenkins:8080/jenkins", BuildJenkinsURL(newJenkins("jenkins/", "--prefix=/jenkins"), "http://jenkins:8080/"))
+	})
+	t.Run("prefix from JENKINS_OPTS", func(t *testing.T) {
+		assert.Equal(t, "http://jenkins:8080/ci", BuildJenkinsURL(newJenkins("", "--prefix=/ci"), "http://jenkins:8080"))
+	})
+}
+
+func TestForwardedHeaders(t *testing.T) {
+	newJenkins := func(forwardedHeaders bool, jenkinsOpts string) *v1alpha2.Jenkins {
+		container := v1alpha2.Container{Name: JenkinsMasterContainerName, ReadinessProbe: &corev1.Probe{}}
+		if len(jenkinsOpts) > 0 {
+			container.Env = []corev1.EnvVar{{Name: "JENKINS_OPTS", Value: jenkinsOpts}}
+		}
+		return &v1alpha2.Jenkins{
+			Spec: v1alpha2.JenkinsSpec{
+				Master: v1alpha2.JenkinsMaster{
+					Containers:       []v1alpha2.Container{container},
+					ForwardedHeaders: forwardedHeaders,
+				},
+			},
+		}
+	}
+
+	t.Run("added to JENKINS_OPTS", func(t *testing.T) {
+		container := NewJenkinsMasterContainer(newJenkins(true, "--httpKeepAliveTimeout=30000"))
+
+		assert.Contains(t, container.Env, corev1.EnvVar{Name: "JENKINS_OPTS", Value: "--httpKeepAliveTimeout=30000 --forwardedHeaders=true"})
+	})
+	t.Run("option in JENKINS_OPTS is kept", func(t *testing.T) {
+		container := NewJenkinsMasterContainer(newJenkins(true, "--forwardedHeaders=false"))
+
+		assert.Contains(t, container.Env, corev1.EnvVar{Name: "JENKINS_OPTS", Value: "--forwardedHeaders=false"})
+	})
+	t.Run("disabled", func(t *testing.T) {
+		container := NewJenkinsMasterContainer(newJenkins(false, "--httpKeepAliveTimeout=30000"))
+
+		assert.Contains(t, container.Env, corev1.EnvVar{Name: "JENKINS_OPTS", Value: "--httpKeepAliveTimeout=30000"})
+	})
+}
+
+func TestCACertsSecret(t *testing.T) {
+	jenkins := &v1alpha2.Jenkins{
+		Spec: v1alpha2.JenkinsSpec{
+			Master: v1alpha2.JenkinsMaster{
+				Containers: []v1alpha2.Container{{
+					Name:           JenkinsMasterContainerName,
+					ReadinessProbe: &corev1.Probe{},
+					Env:            []corev1.EnvVar{{Name: "JAVA_OPTS", Value: "-Xmx1g -Djenkins.install.runSetupWizard=false"}},
+				}},
+				CACertsSecretRef: &corev1.LocalObjectReference{Name: "internal-ca"},
+			},
+		},
+	}
+
+	container := NewJenkinsMasterContainer(jenkins)
+
+	assert.Contains(t, GetJenkinsMasterPodBaseVolumes(jenkins), corev1.Volume{
+		Name: caCertsVolumeName,
+		VolumeSource: corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{
+			DefaultMode: &[]int32{corev1.SecretVolumeSourceDefaultMode}[0],
+			SecretName:  "internal-ca",
+		}},
+	})
+	assert.Contains(t, container.VolumeMounts, corev1.VolumeMount{Name: caCertsVolumeName, MountPath: "/var/jenkins/ca-certs", ReadOnly: true})
+	assert.Contains(t, container.Env, corev1.EnvVar{
+		Name:  "JAVA_OPTS",
+		Value: "-Xmx1g -Djenkins.install.runSetupWizard=false @/var/lib/jenkins/cacerts.args",
+	})
+	assert.Equal(t, "-Xmx1g -Djenkins.install.runSetupWizard=false", jenkins.Spec.Master.Containers[0].Env[0].Value)
+}
+
+func TestMavenSettingsSecret(t *testing.T) {
+	jenkins := &v1alpha2.Jenkins{
+		Spec: v1alpha2.JenkinsSpec{
+			Master: v1alpha2.JenkinsMaster{
+				Containers: []v1alpha2.Container{{Name: JenkinsMasterContainerName, ReadinessProbe: &corev1.Probe{}}},
+				MavenSettingsSecretRef: &v1alpha2.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "maven"},
+					Key:                  "global-settings.xml",
+				},
+			},
+		},
+	}
+
+	container := NewJenkinsMasterContainer(jenkins)
+
+	assert.Contains(t, GetJenkinsMasterPodBaseVolumes(jenkins), corev1.Volume{
+		Name: mavenSettingsVolumeName,
+		VolumeSource: corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{
+			DefaultMode: &[]int32{corev1.SecretVolumeSourceDefaultMode}[0],
+			SecretName:  "maven",
+			Items:       []corev1.KeyToPath{{Key: "global-settings.xml", Path: "settings.xml"}},
+		}},
+	})
+	assert.Contains(t, container.VolumeMounts, corev1.VolumeMount{Name: mavenSettingsVolumeName, MountPath: "/var/jenkins/maven-settings", ReadOnly: true})
+}
+
+func TestSystemProperties(t *testing.T) {
+	newJenkins := func(javaOpts string, systemProperties ...v1alpha2.KeyValue) *v1alpha2.Jenkins {
+		disabled := false
+		return &v1alpha2.Jenkins{
+			Spec: v1alpha2.JenkinsSpec{
+				Master: v1alpha2.JenkinsMaster{
+					Containers: []v1alpha2.Container{{
+						Name:           JenkinsMasterContainerName,
+						ReadinessProbe: &corev1.Probe{},
+						Env:            []corev1.EnvVar{{Name: "JAVA_OPTS", Value: javaOpts}},
+					}},
+					DisableSetupWizard: &disabled,
+					SystemProperties:   systemProperties,
+				},
+			},
+		}
+	}
+
+	t.Run("properties are appended", func(t *testing.T) {
+		container := NewJenkinsMasterContainer(newJenkins("-Xmx1g",
+			v1alpha2.KeyValue{Key: "hudson.model.DirectoryBrowserSupport.CSP", Value: ""},
+			v1alpha2.KeyValue{Key: "jenkins.model.Jenkins.buildsDir", Value: "/builds"},
+		))
+
+		assert.Contains(t, container.Env, corev1.EnvVar{
+			Name:  "JAVA_OPTS",
+			Value: "-Xmx1g -Dhudson.model.DirectoryBrowserSupport.CSP= -Djenkins.model.Jenkins.buildsDir=/builds",
+		})
+	})
+	t.Run("values are escaped", func(t *testing.T) {
+		container := NewJenkinsMasterContainer(newJenkins("",
+			v1alpha2.KeyValue{Key: "jenkins.instanceName", Value: "Jenkins CI"},
+			v1alpha2.KeyValue{Key: "jenkins.motd", Value: `it's "fine"`},
+		))
+
+		assert.Contains(t, container.Env, corev1.EnvVar{
+			Name:  "JAVA_OPTS",
+			Value: `'-Djenkins.instanceName=Jenkins CI' '-Djenkins.motd=it'"'"'s "fine"'`,
+		})
+	})
+	t.Run("properties set in JAVA_OPTS are not duplicated", func(t *testing.T) {
+		javaOpts := "-Xmx1g -Djenkins.model.Jenkins.buildsDir=/var/builds"
+
+		container := NewJenkinsMasterContainer(newJenkins(javaOpts, v1alpha2.KeyValue{Key: "jenkins.model.Jenkins.buildsDir", Value: "/builds"}))
+
+		assert.Contains(t, container.Env, corev1.EnvVar{Name: "JAVA_OPTS", Value: javaOpts})
+	})
+}
+
+func TestTimeZone(t *testing.T) {
+	newJenkins := func(timeZone string, envs ...corev1.EnvVar) *v1alpha2.Jenkins {
+		disabled := false
+		return &v1alpha2.Jenkins{
+			Spec: v1alpha2.JenkinsSpec{
+				Master: v1alpha2.JenkinsMaster{
+					Containers: []v1alpha2.Container{{
+						Name:           JenkinsMasterContainerName,
+						ReadinessProbe: &corev1.Probe{},
+						Env:            envs,
+					}},
+					DisableSetupWizard: &disabled,
+					TimeZone:           timeZone,
+				},
+			},
+		}
+	}
+
+	t.Run("not set", func(t *testing.T) {
+		container := NewJenkinsMasterContainer(newJenkins("", corev1.EnvVar{Name: "JAVA_OPTS", Value: "-Xmx1g"}))
+
+		assert.NotContains(t, container.Env, corev1.EnvVar{Name: "TZ", Value: ""})
+		assert.Contains(t, container.Env, corev1.EnvVar{Name: "JAVA_OPTS", Value: "-Xmx1g"})
+	})
+	t.Run("TZ env and system property", func(t *testing.T) {
+		container := NewJenkinsMasterContainer(newJenkins("Europe/Warsaw", corev1.EnvVar{Name: "JAVA_OPTS", Value: "-Xmx1g"}))
+
+		assert.Contains(t, container.Env, corev1.EnvVar{Name: "TZ", Value: "Europe/Warsaw"})
+		assert.Contains(t, container.Env, corev1.EnvVar{
+			Name:  "JAVA_OPTS",
+			Value: "-Xmx1g -Dorg.apache.commons.jelly.tags.fmt.timeZone=Europe/Warsaw",
+		})
+	})
+	t.Run("TZ env and system property already set", func(t *testing.T) {
+		javaOpts := "-Dorg.apache.commons.jelly.tags.fmt.timeZone=UTC"
+
+		container := NewJenkinsMasterContainer(newJenkins("Europe/Warsaw",
+			corev1.EnvVar{Name: "JAVA_OPTS", Value: javaOpts},
+			corev1.EnvVar{Name: "TZ", Value: "UTC"},
+		))
+
+		assert.Contains(t, container.Env, corev1.EnvVar{Name: "TZ", Value: "UTC"})
+		assert.NotContains(t, container.Env, corev1.EnvVar{Name: "TZ", Value: "Europe/Warsaw"})
+		assert.Contains(t, container.Env, corev1.EnvVar{Name: "JAVA_OPTS", Value: javaOpts})
+	})
+}
+
+func TestContentSecurityPolicy(t *testing.T) {
+	newJenkins := func(csp *string, javaOpts string) *v1alpha2.Jenkins {
+		disabled := false
+		return &v1alpha2.Jenkins{
+			Spec: v1alpha2.JenkinsSpec{
+				Master: v1alpha2.JenkinsMaster{
+					Containers: []v1alpha2.Container{{
+						Name:           JenkinsMasterContainerName,
+						ReadinessProbe: &corev1.Probe{},
+						Env:            []corev1.EnvVar{{Name: "JAVA_OPTS", Value: javaOpts}},
+					}},
+					DisableSetupWizard:    &disabled,
+					ContentSecurityPolicy: csp,
+				},
+			},
+		}
+	}
+
+	t.Run("not set", func(t *testing.T) {
+		container := NewJenkinsMasterContainer(newJenkins(nil, "-Xmx1g"))
+
+		assert.Contains(t, container.Env, corev1.EnvVar{Name: "JAVA_OPTS", Value: "-Xmx1g"})
+	})
+	t.Run("system property", func(t *testing.T) {
+		csp := "sandbox allow-scripts; default-src 'self'; style-src 'self' 'unsafe-inline';"
+
+		container := NewJenkinsMasterContainer(newJenkins(&csp, "-Xmx1g"))
+
+		assert.Contains(t, container.Env, corev1.EnvVar{
+			Name:  "JAVA_OPTS",
+			Value: `-Xmx1g '-Dhudson.model.DirectoryBrowserSupport.CSP=sandbox allow-scripts; default-src '"'"'self'"'"'; style-src '"'"'self'"'"' '"'"'unsafe-inline'"'"';'`,
+		})
+	})
+	t.Run("disabled header", func(t *testing.T) {
+		csp := ""
+
+		container := NewJenkinsMasterContainer(newJenkins(&csp, "-Xmx1g"))
+
+		assert.Contains(t, container.Env, corev1.EnvVar{Name: "JAVA_OPTS", Value: "-Xmx1g -Dhudson.model.DirectoryBrowserSupport.CSP="})
+	})
+	t.Run("system property already set", func(t *testing.T) {
+		csp := "sandbox"
+		javaOpts := "-Xmx1g -Dhudson.model.DirectoryBrowserSupport.CSP="
+
+		container := NewJenkinsMasterContainer(newJenkins(&csp, javaOpts))
+
+		assert.Contains(t, container.Env, corev1.EnvVar{Name: "JAVA_OPTS", Value: javaOpts})
+	})
+}
+
+func TestDisableSetupWizard(t *testing.T) {
+	newJenkins := func(disableSetupWizard *bool, javaOpts ...corev1.EnvVar) *v1alpha2.Jenkins {
+		return &v1alpha2.Jenkins{
+			Spec: v1alpha2.JenkinsSpec{
+				Master: v1alpha2.JenkinsMaster{
+					Containers: []v1alpha2.Container{{
+						Name:           JenkinsMasterContainerName,
+						ReadinessProbe: &corev1.Probe{},
+						Env:            javaOpts,
+					}},
+					DisableSetupWizard: disableSetupWizard,
+				},
+			},
+		}
+	}
+	disabled, enabled := true, false
+
+	t.Run("disabled by default", func(t *testing.T) {
+		container := NewJenkinsMasterContainer(newJenkins(nil, corev1.EnvVar{Name: "JAVA_OPTS", Value: "-Xmx1g"}))
+
+		assert.Contains(t, container.Env, corev1.EnvVar{Name: "JAVA_OPTS", Value: "-Xmx1g -Djenkins.install.runSetupWizard=false"})
+	})
+	t.Run("JAVA_OPTS not set", func(t *testing.T) {
+		container := NewJenkinsMasterContainer(newJenkins(&disabled))
+
+		assert.Contains(t, container.Env, corev1.EnvVar{Name: "JAVA_OPTS", Value: "-Djenkins.install.runSetupWizard=false"})
+	})
+	t.Run("option is not duplicated", func(t *testing.T) {
+		javaOpts := corev1.EnvVar{Name: "JAVA_OPTS", Value: "-Djenkins.install.runSetupWizard=false -Djava.awt.headless=true"}
+
+		container := NewJenkinsMasterContainer(newJenkins(&disabled, javaOpts))
+
+		assert.Contains(t, container.Env, javaOpts)
+	})
+	t.Run("setup wizard enabled", func(t *testing.T) {
+		javaOpts := corev1.EnvVar{Name: "JAVA_OPTS", Value: "-Xmx1g"}
+
+		container := NewJenkinsMasterContainer(newJenkins(&enabled, javaOpts))
+
+		assert.Contains(t, container.Env, javaOpts)
+	})
+}
+
+func TestPluginProxyEnvs(t *testing.T) {
+	t.Run("not set", func(t *testing.T) {
+		jenkins := &v1alpha2.Jenkins{Spec: v1alpha2.JenkinsSpec{Master: v1alpha2.JenkinsMaster{
+			Containers: []v1alpha2.Container{{Name: JenkinsMasterContainerName}},
+		}}}
+
+		for _, env := range GetJenkinsMasterContainerBaseEnvs(jenkins) {
+			assert.NotEqual(t, PluginProxyURLEnvName, env.Name)
+		}
+	})
+	t.Run("credentials are read from the secret", func(t *testing.T) {
+		jenkins := &v1alpha2.Jenkins{Spec: v1alpha2.JenkinsSpec{Master: v1alpha2.JenkinsMaster{
+			Containers: []v1alpha2.Container{{Name: JenkinsMasterContainerName}},
+			PluginProxy: &v1alpha2.PluginProxy{
+				URL:                  "http://proxy.example.com:3128",
+				NoProxy:              []string{"localhost", ".cluster.local"},
+				CredentialsSecretRef: &corev1.LocalObjectReference{Name: "proxy-credentials"},
+			},
+		}}}
+
+		envs := GetJenkinsMasterContainerBaseEnvs(jenkins)
+
+		assert.Contains(t, envs, corev1.EnvVar{Name: PluginProxyURLEnvName, Value: "http://proxy.example.com:3128"})
+		assert.Contains(t, envs, corev1.EnvVar{Name: PluginProxyNoProxyEnvName, Value: "localhost,.cluster.local"})
+		assert.Contains(t, envs, corev1.EnvVar{Name: PluginProxyPasswordEnvName, ValueFrom: &corev1.EnvVarSource{
+			SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "proxy-credentials"},
+				Key:                  ProxyPasswordSecretKey,
+			},
+		}})
+	})
+}
+
+func TestOperatorAPITokenJavaOpts(t *testing.T) {
+	newJenkins := func(authorizationStrategy v1alpha2.AuthorizationStrategy, ldap *v1alpha2.LDAP) *v1alpha2.Jenkins {
+		disabled := false
+		return &v1alpha2.Jenkins{
+			Spec: v1alpha2.JenkinsSpec{
+				JenkinsAPISettings: v1alpha2.JenkinsAPISettings{AuthorizationStrategy: authorizationStrategy},
+				Master: v1alpha2.JenkinsMaster{
+					Containers: []v1alpha2.Container{{
+						Name:           JenkinsMasterContainerName,
+						ReadinessProbe: &corev1.Probe{},
+						Env:            []corev1.EnvVar{{Name: "JAVA_OPTS", Value: "-Xmx1g"}},
+					}},
+					DisableSetupWizard: &disabled,
+					LDAP:               ldap,
+				},
+			},
+		}
+	}
+	ldap := &v1alpha2.LDAP{Server: "ldaps://ldap.example.com"}
+
+	t.Run("operator user is let in by the LDAP security realm", func(t *testing.T) {
+		container := NewJenkinsMasterContainer(newJenkins(v1alpha2.CreateUserAuthorizationStrategy, ldap))
+
+		assert.Contains(t, container.Env, corev1.EnvVar{Name: "JAVA_OPTS", Value: "-Xmx1g -Dhudson.model.User.allowNonExistentUserToLogin=true"})
+	})
+	t.Run("operator user is let in by the OpenID Connect security realm", func(t *testing.T) {
+		jenkins := newJenkins(v1alpha2.CreateUserAuthorizationStrategy, nil)
+		jenkins.Spec.Master.OIDC = &v1alpha2.OIDC{Issuer: "https://accounts.example.com"}
+
+		container := NewJenkinsMasterContainer(jenkins)
+
+		assert.Contains(t, container.Env, corev1.EnvVar{Name: "JAVA_OPTS", Value: "-Xmx1g -Dhudson.model.User.allowNonExistentUserToLogin=true"})
+	})
+	t.Run("local security realm", func(t *testing.T) {
+		container := NewJenkinsMasterContainer(newJenkins(v1alpha2.CreateUserAuthorizationStrategy, nil))
+
+		assert.Contains(t, container.Env, corev1.EnvVar{Name: "JAVA_OPTS", Value: "-Xmx1g"})
+	})
+	t.Run("service account authorization strategy", func(t *testing.T) {
+		container := NewJenkinsMasterContainer(newJenkins(v1alpha2.ServiceAccountAuthorizationStrategy, ldap))
+
+		assert.Contains(t, container.Env, corev1.EnvVar{Name: "JAVA_OPTS", Value: "-Xmx1g"})
+	})
+}
@@ -1,10 +1,19 @@
 package resources
 
 import (
+	"fmt"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/pkg/constants"
+
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
+// deepReadinessProbePath is the Jenkins API path queried by the deep readiness probe, it's served only when Jenkins
+// has fully started
+const deepReadinessProbePath = "/api/json?tree=mode"
+
 func NewProbe(uri string, port string, scheme corev1.URIScheme, initialDelaySeconds, timeoutSeconds, failureThreshold int32) *corev1.Probe {
 	return &corev1.Probe{
 		Handler: corev1.Handler{
@@ -21,3 +30,30 @@ func NewProbe(uri string, port string, scheme corev1.URIScheme, initialDelaySeco
 		PeriodSeconds:       int32(1),
 	}
 }
+
+// NewDeepReadinessProbe builds the readiness probe which queries the Jenkins API authenticated with the mounted operator
+// credentials, the timing settings of the given probe are kept
+func NewDeepReadinessProbe(jenkins *v1alpha2.Jenkins, probe *corev1.Probe) *corev1.Probe {
+	deepProbe := &corev1.Probe{}
+	if probe != nil {
+		deepProbe = probe.DeepCopy()
+	}
+	credentialsPath := jenkinsOperatorCredentialsVolumePath
+	secretKey := OperatorCredentialsSecretPasswordKey
+	if IsOperatorAPITokenUsed(jenkins) {
+		secretKey = OperatorCredentialsSecretAPITokenKey
+	}
+	url := fmt.Sprintf("http://localhost:%d%s%s", constants.DefaultHTTPPortInt32, GetJenkinsContextPath(jenkins), deepReadinessProbePath)
+	deepProbe.Handler = corev1.Handler{
+		Exec: &corev1.ExecAction{
+			Command: []string{
+				"bash",
+				"-c",
+				fmt.Sprintf(`curl -sSf -o /dev/null -u "$(cat %s/%s):$(cat %s/%s)" "%s"`,
+					credentialsPath, OperatorCredentialsSecretUserNameKey,
+					credentialsPath, secretKey, url),
+			},
+		},
+	}
+	return deepProbe
+}
@@ -0,0 +1,80 @@
+package resources
+
+import (
+	"testing"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestNewDeepReadinessProbe(t *testing.T) {
+	newJenkins := func(deepReadiness bool, contextPath string) *v1alpha2.Jenkins {
+		return &v1alpha2.Jenkins{
+			ObjectMeta: metav1.ObjectMeta{Name: "example", Namespace: "default"},
+			Spec: v1alpha2.JenkinsSpec{
+				Master: v1alpha2.JenkinsMaster{
+					DeepReadiness: deepReadiness,
+					ContextPath:   contextPath,
+					Containers: []v1alpha2.Container{
+						{
+							Name:           JenkinsMasterContainerName,
+							ReadinessProbe: NewProbe("/login", "http", corev1.URISchemeHTTP, 60, 10, 10),
+						},
+					},
+				},
+			},
+		}
+	}
+
+	t.Run("keeps the timing settings", func(t *testing.T) {
+		jenkins := newJenkins(true, "")
+
+		probe := NewDeepReadinessProbe(jenkins, jenkins.Spec.Master.Containers[0].ReadinessProbe)
+
+		assert.Nil(t, probe.HTTPGet)
+		require.NotNil(t, probe.Exec)
+		assert.Equal(t, []string{
+			"bash",
+			"-c",
+			`curl -sSf -o /dev/null -u "$(cat /var/jenkins/operator-credentials/user):$(cat /var/jenkins/operator-credentials/password)" "http://localhost:8080/api/json?tree=mode"`,
+		}, probe.Exec.Command)
+		assert.Equal(t, int32(60), probe.InitialDelaySeconds)
+		assert.Equal(t, int32(10), probe.TimeoutSeconds)
+		assert.Equal(t, int32(10), probe.FailureThreshold)
+		assert.NotNil(t, jenkins.Spec.Master.Containers[0].ReadinessProbe.HTTPGet)
+	})
+	t.Run("context path", func(t *testing.T) {
+		jenkins := newJenkins(true, "jenkins")
+
+		probe := NewDeepReadinessProbe(jenkins, nil)
+
+		require.NotNil(t, probe.Exec)
+		assert.Contains(t, probe.Exec.Command[2], `"http://localhost:8080/jenkins/api/json?tree=mode"`)
+	})
+	t.Run("API token with LDAP security realm", func(t *testing.T) {
+		jenkins := newJenkins(true, "")
+		jenkins.Spec.JenkinsAPISettings.AuthorizationStrategy = v1alpha2.CreateUserAuthorizationStrategy
+		jenkins.Spec.Master.LDAP = &v1alpha2.LDAP{Server: "ldaps://ldap.example.com"}
+
+		probe := NewDeepReadinessProbe(jenkins, nil)
+
+		require.NotNil(t, probe.Exec)
+		assert.Contains(t, probe.Exec.Command[2], `-u "$(cat /var/jenkins/operator-credentials/user):$(cat /var/jenkins/operator-credentials/apiToken)"`)
+	})
+	t.Run("Jenkins master container", func(t *testing.T) {
+		container := NewJenkinsMasterContainer(newJenkins(true, ""))
+
+		require.NotNil(t, container.ReadinessProbe.Exec)
+		assert.Nil(t, container.ReadinessProbe.HTTPGet)
+	})
+	t.Run("disabled by default", func(t *testing.T) {
+		container := NewJenkinsMasterContainer(newJenkins(false, ""))
+
+		assert.Nil(t, container.ReadinessProbe.Exec)
+		assert.Equal(t, "/login", container.ReadinessProbe.HTTPGet.Path)
+	})
+}
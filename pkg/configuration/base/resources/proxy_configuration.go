@@ -0,0 +1,71 @@
+package resources
+
+import (
+	"strings"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// JenkinsProxyUsernameEnvName is the name of the Jenkins master container env with the Jenkins proxy username
+	JenkinsProxyUsernameEnvName = "JENKINS_PROXY_USERNAME"
+	// JenkinsProxyPasswordEnvName is the name of the Jenkins master container env with the Jenkins proxy password
+	JenkinsProxyPasswordEnvName = "JENKINS_PROXY_PASSWORD"
+)
+
+type cascProxyConfiguration struct {
+	Name           string `json:"name"`
+	Port           int32  `json:"port"`
+	NoProxyHost    string `json:"noProxyHost,omitempty"`
+	UserName       string `json:"userName,omitempty"`
+	SecretPassword string `json:"secretPassword,omitempty"`
+}
+
+// BuildProxyConfiguration builds the proxy section of the Configuration as Code from spec.master.jenkinsProxy,
+// the credentials are resolved by the Configuration as Code plugin from the Jenkins master container env
+func BuildProxyConfiguration(proxy v1alpha2.JenkinsProxy) map[string]interface{} {
+	configuration := cascProxyConfiguration{
+		Name:        proxy.Host,
+		Port:        proxy.Port,
+		NoProxyHost: strings.Join(proxy.NoProxy, "\n"),
+	}
+	if proxy.CredentialsSecretRef != nil {
+		configuration.UserName = "${" + JenkinsProxyUsernameEnvName + "}"
+		configuration.SecretPassword = "${" + JenkinsProxyPasswordEnvName + "}"
+	}
+
+	return map[string]interface{}{
+		"jenkins": map[string]interface{}{
+			"proxy": configuration,
+		},
+	}
+}
+
+// buildJenkinsProxyEnvs builds the envs of the Jenkins proxy credentials
+func buildJenkinsProxyEnvs(proxy *v1alpha2.JenkinsProxy) []corev1.EnvVar {
+	if proxy == nil || proxy.CredentialsSecretRef == nil {
+		return nil
+	}
+	return []corev1.EnvVar{
+		{
+			Name: JenkinsProxyUsernameEnvName,
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: *proxy.CredentialsSecretRef,
+					Key:                  ProxyUsernameSecretKey,
+				},
+			},
+		},
+		{
+			Name: JenkinsProxyPasswordEnvName,
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: *proxy.CredentialsSecretRef,
+					Key:                  ProxyPasswordSecretKey,
+				},
+			},
+		},
+	}
+}
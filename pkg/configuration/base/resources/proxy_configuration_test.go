@@ -0,0 +1,71 @@
+package resources
+
+import (
+	"testing"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestNewBaseConfigurationConfigMapJenkinsProxy(t *testing.T) {
+	newJenkins := func(proxy *v1alpha2.JenkinsProxy) *v1alpha2.Jenkins {
+		return &v1alpha2.Jenkins{
+			ObjectMeta: metav1.ObjectMeta{Name: "example", Namespace: "default"},
+			Spec: v1alpha2.JenkinsSpec{
+				Master: v1alpha2.JenkinsMaster{
+					Containers:   []v1alpha2.Container{{Name: JenkinsMasterContainerName}},
+					JenkinsProxy: proxy,
+				},
+			},
+		}
+	}
+
+	t.Run("with credentials", func(t *testing.T) {
+		jenkins := newJenkins(&v1alpha2.JenkinsProxy{
+			Host:                 "proxy.example.com",
+			Port:                 3128,
+			NoProxy:              []string{"localhost", "*.cluster.local"},
+			CredentialsSecretRef: &corev1.LocalObjectReference{Name: "proxy-credentials"},
+		})
+
+		configMap, err := NewBaseConfigurationConfigMap(metav1.ObjectMeta{}, jenkins, "cluster.local")
+
+		require.NoError(t, err)
+		assert.Contains(t, configMap.Data[configurationAsCodeGroovyScriptName], `def config = '''jenkins:
+  proxy:
+    name: proxy.example.com
+    noProxyHost: |-
+      localhost
+      *.cluster.local
+    port: 3128
+    secretPassword: ${JENKINS_PROXY_PASSWORD}
+    userName: ${JENKINS_PROXY_USERNAME}
+`)
+		assert.Contains(t, GetJenkinsMasterContainerBaseEnvs(jenkins), corev1.EnvVar{
+			Name: JenkinsProxyPasswordEnvName,
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "proxy-credentials"},
+					Key:                  ProxyPasswordSecretKey,
+				},
+			},
+		})
+	})
+	t.Run("without credentials", func(t *testing.T) {
+		jenkins := newJenkins(&v1alpha2.JenkinsProxy{Host: "proxy.example.com", Port: 3128})
+
+		configMap, err := NewBaseConfigurationConfigMap(metav1.ObjectMeta{}, jenkins, "cluster.local")
+
+		require.NoError(t, err)
+		assert.Contains(t, configMap.Data[configurationAsCodeGroovyScriptName], `def config = '''jenkins:
+  proxy:
+    name: proxy.example.com
+    port: 3128
+`)
+		assert.NotContains(t, configMap.Data[configurationAsCodeGroovyScriptName], "JENKINS_PROXY_PASSWORD")
+	})
+}
@@ -7,6 +7,8 @@ import (
 
 var randomCharset = []rune("0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ")
 
+var randomHexCharset = []rune("0123456789abcdef")
+
 func randomString(n int) string {
 	b := make([]rune, n)
 	for i := range b {
@@ -15,6 +17,14 @@ func randomString(n int) string {
 	return string(b)
 }
 
+func randomHexString(n int) string {
+	b := make([]rune, n)
+	for i := range b {
+		b[i] = randomHexCharset[rand.Intn(len(randomHexCharset))]
+	}
+	return string(b)
+}
+
 func init() {
 	rand.Seed(time.Now().UnixNano())
 }
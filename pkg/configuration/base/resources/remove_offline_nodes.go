@@ -0,0 +1,73 @@
+package resources
+
+import (
+	"text/template"
+	"time"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/internal/render"
+)
+
+// defaultRemoveOfflineNodesInterval is the interval of the task removing the offline Kubernetes agent nodes when
+// spec.master.agent.removeOfflineNodesInterval isn't set
+const defaultRemoveOfflineNodesInterval = 10 * time.Minute
+
+// removeOfflineNodesTemplate schedules the task removing the offline Kubernetes agent nodes, the task scheduled by
+// the previous run of the script is kept in the system properties and canceled, so the script can be applied again
+var removeOfflineNodesTemplate = template.Must(template.New(removeOfflineNodesGroovyScriptName).Parse(`
+import java.util.concurrent.TimeUnit
+import java.util.logging.Level
+import java.util.logging.Logger
+import jenkins.model.Jenkins
+import jenkins.util.Timer
+import org.csanchez.jenkins.plugins.kubernetes.KubernetesSlave
+
+def taskKey = 'jenkins-operator.remove-offline-nodes'
+def previousTask = System.getProperties().remove(taskKey)
+if (previousTask != null) {
+    previousTask.cancel(false)
+}
+{{- if .Enabled }}
+
+def logger = Logger.getLogger('jenkins-operator.remove-offline-nodes')
+def offlineNodes = new HashSet<String>()
+def task = Timer.get().scheduleAtFixedRate({
+    try {
+        def stillOffline = new HashSet<String>()
+        Jenkins.instance.nodes.findAll { it instanceof KubernetesSlave }.each { node ->
+            def computer = node.toComputer()
+            if (computer == null || !computer.offline || computer.connecting) {
+                return
+            }
+            if (offlineNodes.contains(node.nodeName)) {
+                logger.info("Removing the offline Kubernetes agent node ${node.nodeName}")
+                Jenkins.instance.removeNode(node)
+            } else {
+                stillOffline.add(node.nodeName)
+            }
+        }
+        offlineNodes.clear()
+        offlineNodes.addAll(stillOffline)
+    } catch (Exception e) {
+        logger.log(Level.WARNING, 'Failed to remove the offline Kubernetes agent nodes', e)
+    }
+} as Runnable, {{ .IntervalSeconds }}, {{ .IntervalSeconds }}, TimeUnit.SECONDS)
+System.getProperties().put(taskKey, task)
+{{- end }}
+`))
+
+// buildRemoveOfflineNodesGroovyScript renders the script scheduling the task removing the offline Kubernetes agent
+// nodes, the script cancels the scheduled task when spec.master.agent.removeOfflineNodes is disabled
+func buildRemoveOfflineNodesGroovyScript(agent v1alpha2.JenkinsAgent) (string, error) {
+	interval := defaultRemoveOfflineNodesInterval
+	if agent.RemoveOfflineNodesInterval != nil {
+		interval = agent.RemoveOfflineNodesInterval.Duration
+	}
+	return render.Render(removeOfflineNodesTemplate, struct {
+		Enabled         bool
+		IntervalSeconds int64
+	}{
+		Enabled:         agent.RemoveOfflineNodes,
+		IntervalSeconds: int64(interval.Seconds()),
+	})
+}
@@ -0,0 +1,38 @@
+package resources
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestBuildRemoveOfflineNodesGroovyScript(t *testing.T) {
+	t.Run("schedules the task", func(t *testing.T) {
+		agent := v1alpha2.JenkinsAgent{RemoveOfflineNodes: true, RemoveOfflineNodesInterval: &metav1.Duration{Duration: 5 * time.Minute}}
+
+		got, err := buildRemoveOfflineNodesGroovyScript(agent)
+
+		require.NoError(t, err)
+		assert.Contains(t, got, "def previousTask = System.getProperties().remove(taskKey)")
+		assert.Contains(t, got, "Jenkins.instance.nodes.findAll { it instanceof KubernetesSlave }")
+		assert.Contains(t, got, "} as Runnable, 300, 300, TimeUnit.SECONDS)\nSystem.getProperties().put(taskKey, task)")
+	})
+	t.Run("default interval", func(t *testing.T) {
+		got, err := buildRemoveOfflineNodesGroovyScript(v1alpha2.JenkinsAgent{RemoveOfflineNodes: true})
+
+		require.NoError(t, err)
+		assert.Contains(t, got, "} as Runnable, 600, 600, TimeUnit.SECONDS)")
+	})
+	t.Run("disabled task is canceled", func(t *testing.T) {
+		got, err := buildRemoveOfflineNodesGroovyScript(v1alpha2.JenkinsAgent{})
+
+		require.NoError(t, err)
+		assert.Contains(t, got, "previousTask.cancel(false)")
+		assert.NotContains(t, got, "scheduleAtFixedRate")
+	})
+}
@@ -0,0 +1,37 @@
+package resources
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ResolvedPluginsConfigMapKey is the key of the resolved plugins ConfigMap containing the plugins in the name:version format
+const ResolvedPluginsConfigMapKey = "plugins.txt"
+
+// GetResolvedPluginsConfigMapName returns name of the Kubernetes config map with the plugins installed in Jenkins
+func GetResolvedPluginsConfigMapName(jenkins *v1alpha2.Jenkins) string {
+	return fmt.Sprintf("jenkins-%s-resolved-plugins", jenkins.ObjectMeta.Name)
+}
+
+// NewResolvedPluginsConfigMap builds Kubernetes config map with the plugins installed in Jenkins, one plugin per line
+func NewResolvedPluginsConfigMap(meta metav1.ObjectMeta, jenkins *v1alpha2.Jenkins, resolvedPlugins []string) *corev1.ConfigMap {
+	meta.Name = GetResolvedPluginsConfigMapName(jenkins)
+
+	data := ""
+	if len(resolvedPlugins) > 0 {
+		data = strings.Join(resolvedPlugins, "\n") + "\n"
+	}
+
+	return &corev1.ConfigMap{
+		TypeMeta:   buildConfigMapTypeMeta(),
+		ObjectMeta: meta,
+		Data: map[string]string{
+			ResolvedPluginsConfigMapKey: data,
+		},
+	}
+}
@@ -1,19 +1,59 @@
 package resources
 
 import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
 	"fmt"
+	"sort"
+	"strings"
 	"text/template"
+	"time"
 
 	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
 	"github.com/jenkinsci/kubernetes-operator/internal/render"
 	"github.com/jenkinsci/kubernetes-operator/pkg/constants"
 
+	stackerr "github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 const installPluginsCommand = "jenkins-plugin-cli"
 
+const pullOCIPluginCommand = "oras pull"
+
+const (
+	// ExperimentalUpdateCenterEnvName is the env with the experimental update center URL read by jenkins-plugin-cli
+	ExperimentalUpdateCenterEnvName = "JENKINS_UC_EXPERIMENTAL"
+	// ExperimentalUpdateCenterURL is the URL of the experimental update center of the Jenkins project
+	ExperimentalUpdateCenterURL = "https://updates.jenkins.io/experimental"
+
+	initLockFileName       = ".jenkins-operator-init.lock"
+	initLockFileDescriptor = 9
+	defaultInitLockTimeout = 10 * time.Minute
+
+	defaultPluginInstallRetryDelay = 30 * time.Second
+
+	// PluginsReinstallIDEnvName is the env with the value of the jenkins.io/plugins-reinstall-id annotation, the init
+	// script removes the installed plugins when it differs from the ID of the last reinstall
+	PluginsReinstallIDEnvName  = "PLUGINS_REINSTALL_ID"
+	pluginsReinstallIDFileName = ".jenkins-operator-plugins-reinstall-id"
+	pluginsImageFileName       = ".jenkins-operator-plugins-image"
+
+	latestPluginVersion       = "latest"
+	experimentalPluginVersion = "experimental"
+)
+
+const (
+	// compressedScriptSuffix is appended to the names of the scripts config map entries stored gzip compressed
+	// and base64 encoded
+	compressedScriptSuffix = ".gz.b64"
+	// scriptsConfigMapCompressionThreshold is the size in bytes of the scripts config map content above which
+	// the content is compressed, it keeps the config map well below the 1MiB etcd object size limit
+	scriptsConfigMapCompressionThreshold = 512 * 1024
+)
+
 // bash scripts installs single jenkins plugin with specific version
 const installPluginsBashScript = `#!/bin/bash -eu
 
@@ -331,15 +371,124 @@ fi
 
 # https://wiki.jenkins.io/display/JENKINS/Post-initialization+script
 mkdir -p {{ .JenkinsHomePath }}/init.groovy.d
+# the system config script is replaced on every start, it's removed when spec.systemConfigGroovy is cleared
+rm -f {{ .JenkinsHomePath }}/init.groovy.d/{{ .SystemConfigGroovyFileName }}
 cp -n {{ .InitConfigurationPath }}/*.groovy {{ .JenkinsHomePath }}/init.groovy.d
 
 mkdir -p {{ .JenkinsHomePath }}/scripts
 cp {{ .JenkinsScriptsVolumePath }}/*.sh {{ .JenkinsHomePath }}/scripts
 chmod +x {{ .JenkinsHomePath }}/scripts/*.sh
 
+{{- if .CACertsPath }}
+
+echo "Importing CA certificates to the truststore - begin"
+# the truststore password is not printed
+{ set +x; } 2>/dev/null
+# the truststore is recreated with a new random password on every start
+export TRUSTSTORE_PASSWORD="$(head -c 32 /dev/urandom | base64 | tr -dc 'A-Za-z0-9')"
+rm -f {{ .TruststorePath }}
+# changeit is the default password of the JVM truststore
+keytool -importkeystore -noprompt -srckeystore "${JAVA_HOME}/lib/security/cacerts" -srcstorepass changeit -destkeystore {{ .TruststorePath }} -deststorepass:env TRUSTSTORE_PASSWORD
+for ca_cert in {{ .CACertsPath }}/*; do
+    # every certificate of the PEM bundle is imported separately, keytool imports only the first one
+    ca_cert_parts="$(mktemp -d)"
+    awk -v parts="${ca_cert_parts}" '/-----BEGIN CERTIFICATE-----/ { part++; inside = 1 } inside { print > (parts "/" part ".pem") } /-----END CERTIFICATE-----/ { inside = 0 }' "${ca_cert}"
+    for ca_cert_part in "${ca_cert_parts}"/*.pem; do
+        keytool -importcert -noprompt -keystore {{ .TruststorePath }} -storepass:env TRUSTSTORE_PASSWORD -alias "$(basename "${ca_cert}")-$(basename "${ca_cert_part}" .pem)" -file "${ca_cert_part}"
+    done
+    rm -rf "${ca_cert_parts}"
+done
+(umask 077 && printf -- '-Djavax.net.ssl.trustStore=%s\n-Djavax.net.ssl.trustStorePassword=%s\n' {{ .TruststorePath }} "${TRUSTSTORE_PASSWORD}" > {{ .TruststoreJavaArgsPath }})
+unset TRUSTSTORE_PASSWORD
+set -x
+echo "Importing CA certificates to the truststore - end"
+{{- end }}
+
 {{- $jenkinsHomePath := .JenkinsHomePath }}
 {{- $installPluginsCommand := .InstallPluginsCommand }}
 
+{{- if .PluginInstallJob }}
+
+if [ "${PLUGIN_INSTALL_JOB:-false}" != "true" ]; then
+    echo "Plugins are installed by the plugin installation Job"
+    exit 0
+fi
+
+echo "Removing the plugins installed by the previous plugin installation Job"
+rm -rf "{{ .OCIPluginsPath }}"/*
+{{- end }}
+
+{{- if .PluginProxy }}
+
+echo "Configuring the plugin proxy"
+export JAVA_OPTS="${JAVA_OPTS} {{ .PluginProxy.JavaOpts }}"
+export no_proxy="${PLUGIN_PROXY_NO_PROXY}"
+{{- if .PluginProxy.HasCredentials }}
+# the proxy credentials are not printed
+{ set +x; } 2>/dev/null
+url_encode() {
+    local LC_ALL=C value="$1" encoded="" char i
+    for (( i = 0; i < ${#value}; i++ )); do
+        char="${value:i:1}"
+        case "${char}" in
+            [a-zA-Z0-9.~_-]) encoded+="${char}" ;;
+            *) printf -v char '%%%02X' "'${char}"; encoded+="${char}" ;;
+        esac
+    done
+    printf '%s' "${encoded}"
+}
+java_arg_escape() {
+    local value="${1//\\/\\\\}"
+    printf '%s' "${value//\"/\\\"}"
+}
+# the credentials are passed to jenkins-plugin-cli in a Java argument file, so they are not visible in its command line
+proxy_java_args=$(mktemp)
+trap 'rm -f "${proxy_java_args}"' EXIT
+for proxy_scheme in http https; do
+    printf '"-D%s.proxyUser=%s"\n"-D%s.proxyPassword=%s"\n' \
+        "${proxy_scheme}" "$(java_arg_escape "${PLUGIN_PROXY_USERNAME}")" \
+        "${proxy_scheme}" "$(java_arg_escape "${PLUGIN_PROXY_PASSWORD}")" >> "${proxy_java_args}"
+done
+export JAVA_OPTS="${JAVA_OPTS} @${proxy_java_args}"
+export http_proxy="{{ .PluginProxy.Scheme }}://$(url_encode "${PLUGIN_PROXY_USERNAME}"):$(url_encode "${PLUGIN_PROXY_PASSWORD}")@{{ .PluginProxy.Host }}"
+export https_proxy="${http_proxy}"
+set -x
+{{- else }}
+export http_proxy="${PLUGIN_PROXY_URL}"
+export https_proxy="${PLUGIN_PROXY_URL}"
+{{- end }}
+{{- end }}
+
+{{- if .InitLock }}
+
+echo "Acquiring the init lock {{ .InitLock.Path }}"
+exec {{ .InitLock.FileDescriptor }}>"{{ .InitLock.Path }}"
+if ! flock -w {{ .InitLock.TimeoutSeconds }} {{ .InitLock.FileDescriptor }}; then
+    echo "Failed to acquire the init lock {{ .InitLock.Path }} in {{ .InitLock.TimeoutSeconds }} seconds" >&2
+    exit 1
+fi
+{{- end }}
+{{- if .PluginsReinstall }}
+
+if [ -n "${PLUGINS_REINSTALL_ID:-}" ] && [ "$(cat "{{ .PluginsReinstall.IDPath }}" 2>/dev/null)" != "${PLUGINS_REINSTALL_ID}" ]; then
+    echo "Removing the installed plugins, the plugins reinstall ${PLUGINS_REINSTALL_ID} was requested"
+    rm -rf {{ .PluginsReinstall.Paths }}
+    printf '%s' "${PLUGINS_REINSTALL_ID}" > "{{ .PluginsReinstall.IDPath }}"
+fi
+{{- end }}
+{{- if .PluginsImage }}
+
+if [ "$(cat "{{ .PluginsImage.MarkerPath }}" 2>/dev/null)" != {{ .PluginsImage.Image }} ]; then
+    echo "Removing the installed plugins, the Jenkins master image has changed"
+    rm -rf {{ .PluginsImage.Paths }}
+    printf '%s' {{ .PluginsImage.Image }} > "{{ .PluginsImage.MarkerPath }}"
+fi
+{{- end }}
+{{- if .PluginInstallRetry }}
+
+install_plugins() {
+{{- end }}
+
 echo "Installing plugins required by Operator - begin"
 cat > {{ .JenkinsHomePath }}/base-plugins.txt << EOF
 {{ range $index, $plugin := .BasePlugins }}
@@ -350,15 +499,118 @@ EOF
 {{ $installPluginsCommand }} --verbose -f {{ .JenkinsHomePath }}/base-plugins.txt
 echo "Installing plugins required by Operator - end"
 
+{{- if .OCIPlugins }}
+
+echo "Pulling plugins from OCI artifacts - begin"
+oci_plugins_path="{{ .OCIPluginsPath }}"
+mkdir -p "${oci_plugins_path}"
+{{- range $index, $plugin := .OCIPlugins }}
+oci_plugin_path=$(mktemp -d)
+{{ $.PullOCIPluginCommand }} {{ $plugin.QuotedOCIRef }} --output "${oci_plugin_path}"
+mv "${oci_plugin_path}"/*.?pi "${oci_plugins_path}/{{ $plugin.Name }}.jpi"
+rm -rf "${oci_plugin_path}"
+{{- end }}
+echo "Pulling plugins from OCI artifacts - end"
+{{- end }}
+
+{{- if .PriorityPlugins }}
+
+echo "Installing high-priority plugins required by user - begin"
+cat > {{ .JenkinsHomePath }}/priority-plugins.txt << EOF
+{{ range $index, $plugin := .PriorityPlugins }}
+{{ $plugin.Name }}:{{ $plugin.Version }}{{if $plugin.DownloadURL}}:{{ $plugin.DownloadURL }}{{end}}
+{{ end }}
+EOF
+
+{{ $installPluginsCommand }} --verbose -f {{ .JenkinsHomePath }}/priority-plugins.txt
+echo "Installing high-priority plugins required by user - end"
+{{- end }}
+
 echo "Installing plugins required by user - begin"
-cat > {{ .JenkinsHomePath }}/user-plugins.txt << EOF
-{{ range $index, $plugin := .UserPlugins }}
+{{- range $batch := .UserPluginBatches }}
+cat > {{ $jenkinsHomePath }}/{{ $batch.FileName }} << EOF
+{{ range $index, $plugin := $batch.Plugins }}
 {{ $plugin.Name }}:{{ $plugin.Version }}{{if $plugin.DownloadURL}}:{{ $plugin.DownloadURL }}{{end}}
 {{ end }}
 EOF
 
-{{ $installPluginsCommand }} --verbose -f {{ .JenkinsHomePath }}/user-plugins.txt
+{{ $installPluginsCommand }} --verbose -f {{ $jenkinsHomePath }}/{{ $batch.FileName }}
+{{- end }}
 echo "Installing plugins required by user - end"
+{{- if .PluginInstallRetry }}
+}
+
+plugin_install_attempt=1
+while true; do
+    set +e
+    ( set -e; install_plugins )
+    plugin_install_status=$?
+    set -e
+    if [ "${plugin_install_status}" -eq 0 ]; then
+        break
+    fi
+    if [ "${plugin_install_attempt}" -gt {{ .PluginInstallRetry.Retries }} ]; then
+        echo "Plugin installation failed after ${plugin_install_attempt} attempts" >&2
+        exit "${plugin_install_status}"
+    fi
+    echo "Plugin installation failed, retrying in {{ .PluginInstallRetry.DelaySeconds }} seconds"
+    sleep {{ .PluginInstallRetry.DelaySeconds }}
+    plugin_install_attempt=$((plugin_install_attempt + 1))
+done
+{{- end }}
+{{- if .PluginCachePath }}
+
+echo "Copying plugins from the plugin cache volume to the plugins reference directory"
+plugin_ref_path="${REF:-/usr/share/jenkins/ref}/plugins"
+mkdir -p "${plugin_ref_path}"
+plugin_dependencies() {
+    unzip -p "{{ .PluginCachePath }}/${1}.jpi" META-INF/MANIFEST.MF | tr -d '\r' | sed -e ':a' -e 'N' -e '$!ba' -e 's/\n //g' |
+        sed -n 's/^Plugin-Dependencies: //p' | tr ',' '\n' | { grep -v 'resolution:=optional' || true; } | cut -d: -f1
+}
+# only the requested plugins and their mandatory dependencies are copied, the other cached plugins, e.g. the plugins
+# removed from the Jenkins CR, are removed from the cache
+declare -A required_plugins=()
+plugin_queue=($(cut -d: -f1 {{ .JenkinsHomePath }}/base-plugins.txt
+{{- if .PriorityPlugins }} {{ .JenkinsHomePath }}/priority-plugins.txt{{ end }}
+{{- range $batch := .UserPluginBatches }} {{ $jenkinsHomePath }}/{{ $batch.FileName }}{{ end }}))
+while [ "${#plugin_queue[@]}" -gt 0 ]; do
+    plugin="${plugin_queue[0]}"
+    plugin_queue=("${plugin_queue[@]:1}")
+    if [ -n "${required_plugins[${plugin}]:-}" ] || [ ! -f "{{ .PluginCachePath }}/${plugin}.jpi" ]; then
+        continue
+    fi
+    required_plugins[${plugin}]=true
+    plugin_queue+=($(plugin_dependencies "${plugin}"))
+done
+for cached_plugin in "{{ .PluginCachePath }}"/*.jpi; do
+    # the pattern is kept as is when the cache is empty
+    [ -f "${cached_plugin}" ] || continue
+    if [ -n "${required_plugins[$(basename "${cached_plugin}" .jpi)]:-}" ]; then
+        cp -f "${cached_plugin}" "${plugin_ref_path}"
+    else
+        rm -f "${cached_plugin}"
+    fi
+done
+{{- end }}
+{{- if .InitLock }}
+
+flock -u {{ .InitLock.FileDescriptor }}
+echo "Released the init lock {{ .InitLock.Path }}"
+{{- end }}
+`))
+
+var decompressInitBashTemplate = template.Must(template.New(InitScriptName).Parse(`#!/usr/bin/env bash
+set -e
+set -x
+
+# Scripts are stored gzip compressed and base64 encoded because of the config map size limit
+decompressed_scripts_path=$(mktemp -d)
+for compressed_script in {{ .JenkinsScriptsVolumePath }}/*{{ .CompressedScriptSuffix }}; do
+    base64 -d "${compressed_script}" | gunzip > "${decompressed_scripts_path}/$(basename "${compressed_script}" {{ .CompressedScriptSuffix }})"
+done
+chmod +x "${decompressed_scripts_path}"/*
+
+exec bash "${decompressed_scripts_path}/{{ .InitScriptName }}"
 `))
 
 func buildConfigMapTypeMeta() metav1.TypeMeta {
@@ -368,21 +620,268 @@ func buildConfigMapTypeMeta() metav1.TypeMeta {
 	}
 }
 
-func buildInitBashScript(jenkins *v1alpha2.Jenkins) (*string, error) {
+// initLockScript defines the file lock held by the init script while installing the plugins
+type initLockScript struct {
+	Path           string
+	FileDescriptor int
+	TimeoutSeconds int64
+}
+
+// buildInitLockScript returns the init lock of spec.master.initLockTimeout, the lock is enabled by default for the
+// ReadWriteMany Jenkins home storage shared by the old and the new Jenkins master pod during a rolling update of the
+// Deployment, nil when the lock is disabled
+func buildInitLockScript(jenkins *v1alpha2.Jenkins) *initLockScript {
+	timeout := defaultInitLockTimeout
+	if jenkins.Spec.Master.InitLockTimeout != nil {
+		timeout = jenkins.Spec.Master.InitLockTimeout.Duration
+	} else if !IsJenkinsHomeStorageReadWriteMany(jenkins) {
+		return nil
+	}
+	return &initLockScript{
+		Path:           getJenkinsHomePath(jenkins) + "/" + initLockFileName,
+		FileDescriptor: initLockFileDescriptor,
+		TimeoutSeconds: int64(timeout.Seconds()),
+	}
+}
+
+// pluginsReinstallScript defines the plugins removed by the init script once per plugins reinstall ID
+type pluginsReinstallScript struct {
+	IDPath string
+	Paths  string
+}
+
+// buildPluginsReinstallScript returns the plugins kept across the Jenkins master restarts, nil when the plugins
+// reinstall wasn't requested or nothing is kept. The ID of the last reinstall is stored next to the removed plugins, so
+// the plugins are removed only on the first start. The plugin installation Job removes the plugins itself
+func buildPluginsReinstallScript(jenkins *v1alpha2.Jenkins) *pluginsReinstallScript {
+	if len(jenkins.Annotations[v1alpha2.PluginsReinstallIDAnnotation]) == 0 || IsPluginInstallJobEnabled(jenkins) {
+		return nil
+	}
+	paths, markerDir := getKeptPluginsPaths(jenkins)
+	if len(paths) == 0 {
+		return nil
+	}
+	return &pluginsReinstallScript{IDPath: markerDir + "/" + pluginsReinstallIDFileName, Paths: paths}
+}
+
+// pluginsImageScript defines the plugins removed by the init script when the Jenkins master image changes
+type pluginsImageScript struct {
+	MarkerPath string
+	Image      string
+	Paths      string
+}
+
+// buildPluginsImageScript returns the plugins kept across the Jenkins master restarts, nil when
+// spec.master.reinstallPluginsOnImageChange isn't set or nothing is kept. The image of the last installation is stored
+// next to the kept plugins. The plugin installation Job removes the plugins itself
+func buildPluginsImageScript(jenkins *v1alpha2.Jenkins) *pluginsImageScript {
+	if !jenkins.Spec.Master.ReinstallPluginsOnImageChange || IsPluginInstallJobEnabled(jenkins) {
+		return nil
+	}
+	paths, markerDir := getKeptPluginsPaths(jenkins)
+	if len(paths) == 0 {
+		return nil
+	}
+	return &pluginsImageScript{
+		MarkerPath: markerDir + "/" + pluginsImageFileName,
+		Image:      fmt.Sprintf("%q", jenkins.Spec.Master.Containers[0].Image),
+		Paths:      paths,
+	}
+}
+
+// getKeptPluginsPaths returns the quoted plugin paths of the plugin cache volume and the persistent Jenkins home kept
+// across the Jenkins master restarts, and the directory of the markers of the kept plugins. The plugin cache volume is
+// preferred for the markers, since it's kept even when the Jenkins home is not
+func getKeptPluginsPaths(jenkins *v1alpha2.Jenkins) (string, string) {
+	var paths []string
+	markerDir := ""
+	if jenkins.Spec.Master.JenkinsHomeStorage != nil {
+		paths = append(paths, fmt.Sprintf("%q/*", getJenkinsHomePluginsPath(jenkins)))
+		markerDir = getJenkinsHomePath(jenkins)
+	}
+	if jenkins.Spec.Master.PluginCacheVolume != nil {
+		paths = append(paths, fmt.Sprintf("%q/*", PluginCacheVolumePath))
+		markerDir = PluginCacheVolumePath
+	}
+	return strings.Join(paths, " "), markerDir
+}
+
+// pluginInstallRetry defines the retries of the whole plugin installation
+type pluginInstallRetry struct {
+	Retries      int32
+	DelaySeconds int64
+}
+
+// buildPluginInstallRetry returns the retries of spec.master.pluginInstallRetries, nil when the plugin installation
+// isn't retried
+func buildPluginInstallRetry(jenkins *v1alpha2.Jenkins) *pluginInstallRetry {
+	if jenkins.Spec.Master.PluginInstallRetries <= 0 {
+		return nil
+	}
+	delay := defaultPluginInstallRetryDelay
+	if jenkins.Spec.Master.PluginInstallRetryDelay != nil {
+		delay = jenkins.Spec.Master.PluginInstallRetryDelay.Duration
+	}
+	return &pluginInstallRetry{
+		Retries:      jenkins.Spec.Master.PluginInstallRetries,
+		DelaySeconds: int64(delay.Seconds()),
+	}
+}
+
+// pluginBatch is a list of the plugins installed by a single plugin installation command
+type pluginBatch struct {
+	FileName string
+	Plugins  []v1alpha2.Plugin
+}
+
+// splitUserPluginsIntoBatches splits the plugins into the batches of at most batchSize plugins installed sequentially,
+// all plugins are installed in a single batch when batchSize isn't positive
+func splitUserPluginsIntoBatches(plugins []v1alpha2.Plugin, batchSize int32) []pluginBatch {
+	if batchSize <= 0 || len(plugins) <= int(batchSize) {
+		return []pluginBatch{{FileName: "user-plugins.txt", Plugins: plugins}}
+	}
+	var batches []pluginBatch
+	for start := 0; start < len(plugins); start += int(batchSize) {
+		end := start + int(batchSize)
+		if end > len(plugins) {
+			end = len(plugins)
+		}
+		batches = append(batches, pluginBatch{
+			FileName: fmt.Sprintf("user-plugins-%d.txt", len(batches)+1),
+			Plugins:  plugins[start:end],
+		})
+	}
+	return batches
+}
+
+// sortPluginsByPriority returns plugins sorted by descending priority, plugins with the same priority keep their order
+func sortPluginsByPriority(plugins []v1alpha2.Plugin) []v1alpha2.Plugin {
+	sorted := make([]v1alpha2.Plugin, len(plugins))
+	copy(sorted, plugins)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Priority > sorted[j].Priority
+	})
+	return sorted
+}
+
+// resolvePluginVersions returns the plugins with the latest version resolved from the experimental update center
+// when spec.master.updateCenterChannel is experimental
+func resolvePluginVersions(plugins []v1alpha2.Plugin, channel v1alpha2.UpdateCenterChannel) []v1alpha2.Plugin {
+	resolved := make([]v1alpha2.Plugin, len(plugins))
+	copy(resolved, plugins)
+	if channel != v1alpha2.UpdateCenterChannelExperimental {
+		return resolved
+	}
+	for i, plugin := range resolved {
+		if plugin.Version == latestPluginVersion {
+			resolved[i].Version = experimentalPluginVersion
+		}
+	}
+	return resolved
+}
+
+// ociPluginScript holds the plugin pulled from the OCI artifact by the init script
+type ociPluginScript struct {
+	Name         string
+	QuotedOCIRef string
+}
+
+func buildOCIPluginScripts(ociPlugins []v1alpha2.Plugin) []ociPluginScript {
+	var scripts []ociPluginScript
+	for _, plugin := range ociPlugins {
+		scripts = append(scripts, ociPluginScript{Name: plugin.Name, QuotedOCIRef: quoteShellArgument(plugin.OCIRef)})
+	}
+	return scripts
+}
+
+// quoteShellArgument quotes the value with the shell single quotes, so it is passed as a single argument as is
+func quoteShellArgument(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", `'"'"'`) + "'"
+}
+
+func buildInitBashScript(jenkins *v1alpha2.Jenkins, ociPluginsEnabled bool) (*string, error) {
+	channel := jenkins.Spec.Master.UpdateCenterChannel
+	var userPlugins, priorityPlugins, ociPlugins []v1alpha2.Plugin
+	for _, plugin := range sortPluginsByPriority(resolvePluginVersions(jenkins.Spec.Master.Plugins, channel)) {
+		switch {
+		case ociPluginsEnabled && len(plugin.OCIRef) > 0:
+			ociPlugins = append(ociPlugins, plugin)
+		case plugin.Priority > 0:
+			priorityPlugins = append(priorityPlugins, plugin)
+		default:
+			userPlugins = append(userPlugins, plugin)
+		}
+	}
+
+	// plugins already present in the cache volume with the same version are not downloaded again
+	pluginsCommand := installPluginsCommand
+	ociPluginsPath := "${REF:-/usr/share/jenkins/ref}/plugins"
+	pluginCachePath := ""
+	if jenkins.Spec.Master.PluginCacheVolume != nil {
+		pluginCachePath = PluginCacheVolumePath
+		pluginsCommand = fmt.Sprintf("%s --plugin-download-directory %s", installPluginsCommand, pluginCachePath)
+	}
+	// the plugin installation Job downloads the plugins directly to the Jenkins home volume
+	if IsPluginInstallJobEnabled(jenkins) {
+		ociPluginsPath = getJenkinsHomePluginsPath(jenkins)
+		pluginCachePath = ""
+		pluginsCommand = fmt.Sprintf("%s --plugin-download-directory %s", installPluginsCommand, ociPluginsPath)
+	}
+
+	pluginProxy, err := buildPluginProxyScript(jenkins.Spec.Master.PluginProxy)
+	if err != nil {
+		return nil, err
+	}
+
+	caCertsPath := ""
+	if jenkins.Spec.Master.CACertsSecretRef != nil {
+		caCertsPath = CACertsVolumePath
+	}
+
 	data := struct {
-		JenkinsHomePath          string
-		InitConfigurationPath    string
-		InstallPluginsCommand    string
-		JenkinsScriptsVolumePath string
-		BasePlugins              []v1alpha2.Plugin
-		UserPlugins              []v1alpha2.Plugin
+		JenkinsHomePath            string
+		CACertsPath                string
+		TruststorePath             string
+		TruststoreJavaArgsPath     string
+		InitConfigurationPath      string
+		SystemConfigGroovyFileName string
+		InstallPluginsCommand      string
+		PullOCIPluginCommand       string
+		OCIPluginsPath             string
+		PluginCachePath            string
+		PluginInstallJob           bool
+		InitLock                   *initLockScript
+		PluginsReinstall           *pluginsReinstallScript
+		PluginsImage               *pluginsImageScript
+		PluginInstallRetry         *pluginInstallRetry
+		JenkinsScriptsVolumePath   string
+		BasePlugins                []v1alpha2.Plugin
+		UserPluginBatches          []pluginBatch
+		PriorityPlugins            []v1alpha2.Plugin
+		OCIPlugins                 []ociPluginScript
+		PluginProxy                *pluginProxyScript
 	}{
-		JenkinsHomePath:          getJenkinsHomePath(jenkins),
-		InitConfigurationPath:    jenkinsInitConfigurationVolumePath,
-		BasePlugins:              jenkins.Spec.Master.BasePlugins,
-		UserPlugins:              jenkins.Spec.Master.Plugins,
-		InstallPluginsCommand:    installPluginsCommand,
-		JenkinsScriptsVolumePath: JenkinsScriptsVolumePath,
+		JenkinsHomePath:            getJenkinsHomePath(jenkins),
+		CACertsPath:                caCertsPath,
+		TruststorePath:             GetJenkinsTruststorePath(jenkins),
+		TruststoreJavaArgsPath:     GetJenkinsTruststoreJavaArgsPath(jenkins),
+		InitConfigurationPath:      jenkinsInitConfigurationVolumePath,
+		SystemConfigGroovyFileName: systemConfigGroovyFileName,
+		BasePlugins:                resolvePluginVersions(jenkins.Spec.Master.BasePlugins, channel),
+		UserPluginBatches:          splitUserPluginsIntoBatches(userPlugins, jenkins.Spec.Master.PluginInstallBatchSize),
+		PriorityPlugins:            priorityPlugins,
+		OCIPlugins:                 buildOCIPluginScripts(ociPlugins),
+		InstallPluginsCommand:      pluginsCommand,
+		PullOCIPluginCommand:       pullOCIPluginCommand,
+		OCIPluginsPath:             ociPluginsPath,
+		PluginCachePath:            pluginCachePath,
+		PluginInstallJob:           IsPluginInstallJobEnabled(jenkins),
+		InitLock:                   buildInitLockScript(jenkins),
+		PluginsReinstall:           buildPluginsReinstallScript(jenkins),
+		PluginsImage:               buildPluginsImageScript(jenkins),
+		PluginInstallRetry:         buildPluginInstallRetry(jenkins),
+		JenkinsScriptsVolumePath:   JenkinsScriptsVolumePath,
+		PluginProxy:                pluginProxy,
 	}
 
 	output, err := render.Render(initBashTemplate, data)
@@ -398,20 +897,79 @@ func getScriptsConfigMapName(jenkins *v1alpha2.Jenkins) string {
 }
 
 // NewScriptsConfigMap builds Kubernetes config map used to store scripts
-func NewScriptsConfigMap(meta metav1.ObjectMeta, jenkins *v1alpha2.Jenkins) (*corev1.ConfigMap, error) {
+func NewScriptsConfigMap(meta metav1.ObjectMeta, jenkins *v1alpha2.Jenkins, ociPluginsEnabled bool) (*corev1.ConfigMap, error) {
 	meta.Name = getScriptsConfigMapName(jenkins)
 
-	initBashScript, err := buildInitBashScript(jenkins)
+	initBashScript, err := buildInitBashScript(jenkins, ociPluginsEnabled)
 	if err != nil {
 		return nil, err
 	}
 
+	data := map[string]string{
+		InitScriptName:        *initBashScript,
+		installPluginsCommand: installPluginsBashScript,
+	}
+	if getScriptsSize(data) > scriptsConfigMapCompressionThreshold {
+		data, err = compressScripts(data)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return &corev1.ConfigMap{
 		TypeMeta:   buildConfigMapTypeMeta(),
 		ObjectMeta: meta,
-		Data: map[string]string{
-			InitScriptName:        *initBashScript,
-			installPluginsCommand: installPluginsBashScript,
-		},
+		Data:       data,
 	}, nil
 }
+
+func getScriptsSize(scripts map[string]string) int {
+	size := 0
+	for name, content := range scripts {
+		size += len(name) + len(content)
+	}
+	return size
+}
+
+// compressScripts gzips and base64 encodes every script, init script is replaced by the script which decompresses
+// all scripts and runs the original init script
+func compressScripts(scripts map[string]string) (map[string]string, error) {
+	compressed := map[string]string{}
+	for name, content := range scripts {
+		encoded, err := compressScript(content)
+		if err != nil {
+			return nil, err
+		}
+		compressed[name+compressedScriptSuffix] = encoded
+	}
+
+	data := struct {
+		JenkinsScriptsVolumePath string
+		CompressedScriptSuffix   string
+		InitScriptName           string
+	}{
+		JenkinsScriptsVolumePath: JenkinsScriptsVolumePath,
+		CompressedScriptSuffix:   compressedScriptSuffix,
+		InitScriptName:           InitScriptName,
+	}
+	decompressInitBashScript, err := render.Render(decompressInitBashTemplate, data)
+	if err != nil {
+		return nil, err
+	}
+	compressed[InitScriptName] = decompressInitBashScript
+
+	return compressed, nil
+}
+
+func compressScript(content string) (string, error) {
+	var buffer bytes.Buffer
+	writer := gzip.NewWriter(&buffer)
+	if _, err := writer.Write([]byte(content)); err != nil {
+		return "", stackerr.WithStack(err)
+	}
+	if err := writer.Close(); err != nil {
+		return "", stackerr.WithStack(err)
+	}
+
+	return base64.StdEncoding.EncodeToString(buffer.Bytes()), nil
+}
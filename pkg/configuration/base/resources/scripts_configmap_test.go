@@ -0,0 +1,427 @@
+package resources
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"fmt"
+	"io/ioutil"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func decompressScript(t *testing.T, encoded string) string {
+	compressed, err := base64.StdEncoding.DecodeString(encoded)
+	require.NoError(t, err)
+	reader, err := gzip.NewReader(bytes.NewReader(compressed))
+	require.NoError(t, err)
+	content, err := ioutil.ReadAll(reader)
+	require.NoError(t, err)
+
+	return string(content)
+}
+
+func newScriptsTestJenkins() *v1alpha2.Jenkins {
+	return &v1alpha2.Jenkins{
+		ObjectMeta: metav1.ObjectMeta{Name: "jenkins"},
+		Spec: v1alpha2.JenkinsSpec{
+			Master: v1alpha2.JenkinsMaster{
+				Containers: []v1alpha2.Container{{Name: JenkinsMasterContainerName}},
+			},
+		},
+	}
+}
+
+func TestNewScriptsConfigMap(t *testing.T) {
+	t.Run("small scripts are not compressed", func(t *testing.T) {
+		jenkins := newScriptsTestJenkins()
+
+		configMap, err := NewScriptsConfigMap(metav1.ObjectMeta{}, jenkins, false)
+
+		require.NoError(t, err)
+		assert.Len(t, configMap.Data, 2)
+		assert.Contains(t, configMap.Data, InitScriptName)
+		assert.Contains(t, configMap.Data, installPluginsCommand)
+		assert.Contains(t, configMap.Data[InitScriptName], "user-plugins.txt")
+	})
+	t.Run("large scripts are compressed", func(t *testing.T) {
+		jenkins := newScriptsTestJenkins()
+		for i := 0; i < 20000; i++ {
+			jenkins.Spec.Master.Plugins = append(jenkins.Spec.Master.Plugins, v1alpha2.Plugin{
+				Name:    fmt.Sprintf("plugin-with-a-rather-long-name-%d", i),
+				Version: "1.0.0",
+			})
+		}
+		initBashScript, err := buildInitBashScript(jenkins, false)
+		require.NoError(t, err)
+		require.Greater(t, len(*initBashScript), scriptsConfigMapCompressionThreshold)
+
+		configMap, err := NewScriptsConfigMap(metav1.ObjectMeta{}, jenkins, false)
+
+		require.NoError(t, err)
+		assert.Len(t, configMap.Data, 3)
+		assert.Less(t, getScriptsSize(configMap.Data), scriptsConfigMapCompressionThreshold)
+		assert.Equal(t, *initBashScript, decompressScript(t, configMap.Data[InitScriptName+compressedScriptSuffix]))
+		assert.Equal(t, installPluginsBashScript, decompressScript(t, configMap.Data[installPluginsCommand+compressedScriptSuffix]))
+		assert.Contains(t, configMap.Data[InitScriptName], "gunzip")
+		assert.Contains(t, configMap.Data[InitScriptName], JenkinsScriptsVolumePath+"/*"+compressedScriptSuffix)
+	})
+}
+
+func TestBuildInitBashScript(t *testing.T) {
+	ociPlugin := v1alpha2.Plugin{Name: "custom-plugin", Version: "1.0.0", OCIRef: "ghcr.io/org/plugins/custom-plugin:1.0.0"}
+
+	t.Run("pulls plugins from OCI artifacts", func(t *testing.T) {
+		jenkins := newScriptsTestJenkins()
+		jenkins.Spec.Master.Plugins = []v1alpha2.Plugin{{Name: "git", Version: "4.11.3"}, ociPlugin}
+
+		initBashScript, err := buildInitBashScript(jenkins, true)
+
+		require.NoError(t, err)
+		assert.Contains(t, *initBashScript, `oras pull 'ghcr.io/org/plugins/custom-plugin:1.0.0' --output "${oci_plugin_path}"`)
+		assert.Contains(t, *initBashScript, `mv "${oci_plugin_path}"/*.?pi "${oci_plugins_path}/custom-plugin.jpi"`)
+		assert.Contains(t, *initBashScript, "git:4.11.3")
+		assert.NotContains(t, *initBashScript, "custom-plugin:1.0.0\n")
+		assert.Less(t, strings.Index(*initBashScript, "oras pull"), strings.Index(*initBashScript, "user-plugins.txt"))
+	})
+	t.Run("quotes the OCI reference", func(t *testing.T) {
+		jenkins := newScriptsTestJenkins()
+		jenkins.Spec.Master.Plugins = []v1alpha2.Plugin{{Name: "custom-plugin", Version: "1.0.0", OCIRef: "ghcr.io/org/plugin'; id; '"}}
+
+		initBashScript, err := buildInitBashScript(jenkins, true)
+
+		require.NoError(t, err)
+		assert.Contains(t, *initBashScript, `oras pull 'ghcr.io/org/plugin'"'"'; id; '"'"'' --output`)
+	})
+	t.Run("OCI plugins are disabled", func(t *testing.T) {
+		jenkins := newScriptsTestJenkins()
+		jenkins.Spec.Master.Plugins = []v1alpha2.Plugin{ociPlugin}
+
+		initBashScript, err := buildInitBashScript(jenkins, false)
+
+		require.NoError(t, err)
+		assert.NotContains(t, *initBashScript, "oras pull")
+		assert.Contains(t, *initBashScript, "custom-plugin:1.0.0")
+	})
+	t.Run("installs plugins by priority", func(t *testing.T) {
+		jenkins := newScriptsTestJenkins()
+		jenkins.Spec.Master.Plugins = []v1alpha2.Plugin{
+			{Name: "git", Version: "4.11.3"},
+			{Name: "workflow-api", Version: "1153.vb_912c0e47fb_a_", Priority: 1},
+			{Name: "kubernetes", Version: "3600.v144b_cd192ca_a_", Priority: -1},
+			{Name: "credentials", Version: "1139.veb_9579fca_33b_", Priority: 10},
+			{Name: "job-dsl", Version: "1.79"},
+		}
+
+		initBashScript, err := buildInitBashScript(jenkins, false)
+
+		require.NoError(t, err)
+		assert.Contains(t, *initBashScript, "priority-plugins.txt << EOF\n\ncredentials:1139.veb_9579fca_33b_\n\nworkflow-api:1153.vb_912c0e47fb_a_\n\nEOF")
+		assert.Contains(t, *initBashScript, "user-plugins.txt << EOF\n\ngit:4.11.3\n\njob-dsl:1.79\n\nkubernetes:3600.v144b_cd192ca_a_\n\nEOF")
+		assert.Less(t, strings.Index(*initBashScript, "-f /var/lib/jenkins/priority-plugins.txt"), strings.Index(*initBashScript, "-f /var/lib/jenkins/user-plugins.txt"))
+	})
+	t.Run("installs plugins in batches", func(t *testing.T) {
+		jenkins := newScriptsTestJenkins()
+		jenkins.Spec.Master.PluginInstallBatchSize = 2
+		jenkins.Spec.Master.Plugins = []v1alpha2.Plugin{
+			{Name: "git", Version: "4.11.3"},
+			{Name: "job-dsl", Version: "1.79"},
+			{Name: "kubernetes", Version: "3600.v144b_cd192ca_a_"},
+		}
+
+		initBashScript, err := buildInitBashScript(jenkins, false)
+
+		require.NoError(t, err)
+		assert.Contains(t, *initBashScript, "user-plugins-1.txt << EOF\n\ngit:4.11.3\n\njob-dsl:1.79\n\nEOF")
+		assert.Contains(t, *initBashScript, "user-plugins-2.txt << EOF\n\nkubernetes:3600.v144b_cd192ca_a_\n\nEOF")
+		assert.NotContains(t, *initBashScript, "user-plugins.txt")
+		assert.Less(t, strings.Index(*initBashScript, "--verbose -f /var/lib/jenkins/user-plugins-1.txt"),
+			strings.Index(*initBashScript, "--verbose -f /var/lib/jenkins/user-plugins-2.txt"))
+	})
+	t.Run("installs plugins in a single batch by default", func(t *testing.T) {
+		jenkins := newScriptsTestJenkins()
+		jenkins.Spec.Master.Plugins = []v1alpha2.Plugin{{Name: "git", Version: "4.11.3"}, {Name: "job-dsl", Version: "1.79"}}
+
+		initBashScript, err := buildInitBashScript(jenkins, false)
+
+		require.NoError(t, err)
+		assert.Contains(t, *initBashScript, "user-plugins.txt << EOF\n\ngit:4.11.3\n\njob-dsl:1.79\n\nEOF")
+		assert.Equal(t, 1, strings.Count(*initBashScript, "-f /var/lib/jenkins/user-plugins"))
+	})
+	t.Run("without high-priority plugins", func(t *testing.T) {
+		jenkins := newScriptsTestJenkins()
+		jenkins.Spec.Master.Plugins = []v1alpha2.Plugin{{Name: "git", Version: "4.11.3"}}
+
+		initBashScript, err := buildInitBashScript(jenkins, false)
+
+		require.NoError(t, err)
+		assert.NotContains(t, *initBashScript, "priority-plugins.txt")
+	})
+	t.Run("plugins are downloaded to the plugin cache volume", func(t *testing.T) {
+		jenkins := newScriptsTestJenkins()
+		jenkins.Spec.Master.PluginCacheVolume = &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "plugin-cache"}
+
+		initBashScript, err := buildInitBashScript(jenkins, false)
+
+		require.NoError(t, err)
+		assert.Contains(t, *initBashScript, "jenkins-plugin-cli --plugin-download-directory /var/jenkins/plugin-cache --verbose -f /var/lib/jenkins/base-plugins.txt")
+		assert.Contains(t, *initBashScript, "jenkins-plugin-cli --plugin-download-directory /var/jenkins/plugin-cache --verbose -f /var/lib/jenkins/user-plugins.txt")
+		assert.Contains(t, *initBashScript, `plugin_queue=($(cut -d: -f1 /var/lib/jenkins/base-plugins.txt /var/lib/jenkins/user-plugins.txt))`)
+		assert.Contains(t, *initBashScript, `unzip -p "/var/jenkins/plugin-cache/${1}.jpi" META-INF/MANIFEST.MF`)
+		assert.Contains(t, *initBashScript, `for cached_plugin in "/var/jenkins/plugin-cache"/*.jpi; do
+    # the pattern is kept as is when the cache is empty
+    [ -f "${cached_plugin}" ] || continue`)
+		assert.Contains(t, *initBashScript, `cp -f "${cached_plugin}" "${plugin_ref_path}"`)
+		assert.Contains(t, *initBashScript, `rm -f "${cached_plugin}"`)
+		assert.Less(t, strings.Index(*initBashScript, "user-plugins.txt << EOF"), strings.Index(*initBashScript, "/var/jenkins/plugin-cache\"/*.jpi"))
+	})
+	t.Run("plugin cache copies the plugins of every plugins file", func(t *testing.T) {
+		jenkins := newScriptsTestJenkins()
+		jenkins.Spec.Master.PluginCacheVolume = &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "plugin-cache"}
+		jenkins.Spec.Master.Plugins = []v1alpha2.Plugin{{Name: "git", Version: "1.0"}, {Name: "job-dsl", Version: "1.0", Priority: 1}}
+
+		initBashScript, err := buildInitBashScript(jenkins, false)
+
+		require.NoError(t, err)
+		assert.Contains(t, *initBashScript, `plugin_queue=($(cut -d: -f1 /var/lib/jenkins/base-plugins.txt /var/lib/jenkins/priority-plugins.txt /var/lib/jenkins/user-plugins.txt))`)
+	})
+	t.Run("plugins are installed by the plugin installation Job", func(t *testing.T) {
+		jenkins := newScriptsTestJenkins()
+		jenkins.Spec.Master.PluginInstallMode = v1alpha2.PluginInstallModeJob
+
+		initBashScript, err := buildInitBashScript(jenkins, false)
+
+		require.NoError(t, err)
+		assert.Contains(t, *initBashScript, `if [ "${PLUGIN_INSTALL_JOB:-false}" != "true" ]; then`)
+		assert.Contains(t, *initBashScript, `rm -rf "/var/lib/jenkins/plugins"/*`)
+		assert.Contains(t, *initBashScript, "jenkins-plugin-cli --plugin-download-directory /var/lib/jenkins/plugins --verbose -f /var/lib/jenkins/base-plugins.txt")
+		assert.NotContains(t, *initBashScript, "plugin-cache")
+		assert.Less(t, strings.Index(*initBashScript, "PLUGIN_INSTALL_JOB"), strings.Index(*initBashScript, "base-plugins.txt"))
+	})
+	t.Run("latest plugin versions from the experimental update center", func(t *testing.T) {
+		jenkins := newScriptsTestJenkins()
+		jenkins.Spec.Master.UpdateCenterChannel = v1alpha2.UpdateCenterChannelExperimental
+		jenkins.Spec.Master.BasePlugins = []v1alpha2.Plugin{{Name: "kubernetes", Version: "latest"}}
+		jenkins.Spec.Master.Plugins = []v1alpha2.Plugin{{Name: "git", Version: "latest"}, {Name: "job-dsl", Version: "1.79"}}
+
+		initBashScript, err := buildInitBashScript(jenkins, false)
+
+		require.NoError(t, err)
+		assert.Contains(t, *initBashScript, "base-plugins.txt << EOF\n\nkubernetes:experimental\n\nEOF")
+		assert.Contains(t, *initBashScript, "user-plugins.txt << EOF\n\ngit:experimental\n\njob-dsl:1.79\n\nEOF")
+		assert.Equal(t, "latest", jenkins.Spec.Master.Plugins[0].Version)
+		assert.Contains(t, GetJenkinsMasterContainerBaseEnvs(jenkins), corev1.EnvVar{
+			Name:  "JENKINS_UC_EXPERIMENTAL",
+			Value: "https://updates.jenkins.io/experimental",
+		})
+	})
+	t.Run("latest plugin versions from the stable update center", func(t *testing.T) {
+		jenkins := newScriptsTestJenkins()
+		jenkins.Spec.Master.Plugins = []v1alpha2.Plugin{{Name: "git", Version: "latest"}}
+
+		initBashScript, err := buildInitBashScript(jenkins, false)
+
+		require.NoError(t, err)
+		assert.Contains(t, *initBashScript, "user-plugins.txt << EOF\n\ngit:latest\n\nEOF")
+	})
+	t.Run("without plugin cache volume", func(t *testing.T) {
+		initBashScript, err := buildInitBashScript(newScriptsTestJenkins(), false)
+
+		require.NoError(t, err)
+		assert.NotContains(t, *initBashScript, "--plugin-download-directory")
+	})
+	t.Run("imports CA certificates to the truststore", func(t *testing.T) {
+		jenkins := newScriptsTestJenkins()
+		jenkins.Spec.Master.CACertsSecretRef = &corev1.LocalObjectReference{Name: "ca-certs"}
+
+		initBashScript, err := buildInitBashScript(jenkins, false)
+
+		require.NoError(t, err)
+		assert.Contains(t, *initBashScript, `keytool -importkeystore -noprompt -srckeystore "${JAVA_HOME}/lib/security/cacerts" -srcstorepass changeit -destkeystore /var/lib/jenkins/cacerts -deststorepass:env TRUSTSTORE_PASSWORD`)
+		assert.Contains(t, *initBashScript, `for ca_cert in /var/jenkins/ca-certs/*; do`)
+		assert.Contains(t, *initBashScript, `    for ca_cert_part in "${ca_cert_parts}"/*.pem; do
+        keytool -importcert -noprompt -keystore /var/lib/jenkins/cacerts -storepass:env TRUSTSTORE_PASSWORD -alias "$(basename "${ca_cert}")-$(basename "${ca_cert_part}" .pem)" -file "${ca_cert_part}"
+    done`)
+		assert.Contains(t, *initBashScript, `'-Djavax.net.ssl.trustStore=%s\n-Djavax.net.ssl.trustStorePassword=%s\n' /var/lib/jenkins/cacerts "${TRUSTSTORE_PASSWORD}" > /var/lib/jenkins/cacerts.args`)
+		assert.Less(t, strings.Index(*initBashScript, "keytool"), strings.Index(*initBashScript, "base-plugins.txt"))
+		truststore := (*initBashScript)[strings.Index(*initBashScript, "{ set +x; } 2>/dev/null"):strings.Index(*initBashScript, "unset TRUSTSTORE_PASSWORD\nset -x\n")]
+		assert.Equal(t, 4, strings.Count(truststore, "TRUSTSTORE_PASSWORD"))
+	})
+	t.Run("downloads plugins through the proxy", func(t *testing.T) {
+		jenkins := newScriptsTestJenkins()
+		jenkins.Spec.Master.PluginProxy = &v1alpha2.PluginProxy{
+			URL:                  "http://proxy.example.com:3128",
+			NoProxy:              []string{"localhost", ".cluster.local"},
+			CredentialsSecretRef: &corev1.LocalObjectReference{Name: "proxy-credentials"},
+		}
+
+		initBashScript, err := buildInitBashScript(jenkins, false)
+
+		require.NoError(t, err)
+		assert.Contains(t, *initBashScript, `export JAVA_OPTS="${JAVA_OPTS} -Dhttp.proxyHost=proxy.example.com -Dhttp.proxyPort=3128 `+
+			`-Dhttps.proxyHost=proxy.example.com -Dhttps.proxyPort=3128 -Dhttp.nonProxyHosts=localhost|*.cluster.local `+
+			`-Djdk.http.auth.tunneling.disabledSchemes="`)
+		assert.Contains(t, *initBashScript, `export JAVA_OPTS="${JAVA_OPTS} @${proxy_java_args}"`)
+		assert.NotContains(t, *initBashScript, "-Dhttp.proxyPassword=${PLUGIN_PROXY_PASSWORD}")
+		assert.Contains(t, *initBashScript, `export http_proxy="http://$(url_encode "${PLUGIN_PROXY_USERNAME}"):`+
+			`$(url_encode "${PLUGIN_PROXY_PASSWORD}")@proxy.example.com:3128"`)
+		assert.Less(t, strings.Index(*initBashScript, "set +x"), strings.Index(*initBashScript, "PLUGIN_PROXY_PASSWORD"))
+		assert.Less(t, strings.Index(*initBashScript, "http_proxy"), strings.Index(*initBashScript, "base-plugins.txt"))
+	})
+	t.Run("downloads plugins through the proxy without credentials", func(t *testing.T) {
+		jenkins := newScriptsTestJenkins()
+		jenkins.Spec.Master.PluginProxy = &v1alpha2.PluginProxy{URL: "https://proxy.example.com"}
+
+		initBashScript, err := buildInitBashScript(jenkins, false)
+
+		require.NoError(t, err)
+		assert.Contains(t, *initBashScript, "-Dhttps.proxyHost=proxy.example.com -Dhttps.proxyPort=443")
+		assert.Contains(t, *initBashScript, `export http_proxy="${PLUGIN_PROXY_URL}"`)
+		assert.NotContains(t, *initBashScript, "PLUGIN_PROXY_PASSWORD")
+	})
+	t.Run("without CA certificates", func(t *testing.T) {
+		initBashScript, err := buildInitBashScript(newScriptsTestJenkins(), false)
+
+		require.NoError(t, err)
+		assert.NotContains(t, *initBashScript, "keytool")
+	})
+	t.Run("acquires the init lock", func(t *testing.T) {
+		jenkins := newScriptsTestJenkins()
+		jenkins.Spec.Master.InitLockTimeout = &metav1.Duration{Duration: 5 * time.Minute}
+
+		initBashScript, err := buildInitBashScript(jenkins, false)
+
+		require.NoError(t, err)
+		assert.Contains(t, *initBashScript, `exec 9>"/var/lib/jenkins/.jenkins-operator-init.lock"
+if ! flock -w 300 9; then
+    echo "Failed to acquire the init lock /var/lib/jenkins/.jenkins-operator-init.lock in 300 seconds" >&2
+    exit 1
+fi`)
+		assert.Less(t, strings.Index(*initBashScript, "flock -w 300 9"), strings.Index(*initBashScript, "Installing plugins required by Operator - begin"))
+		assert.Less(t, strings.Index(*initBashScript, "Installing plugins required by user - end"), strings.Index(*initBashScript, "flock -u 9"))
+	})
+	t.Run("acquires the init lock on ReadWriteMany storage", func(t *testing.T) {
+		jenkins := newScriptsTestJenkins()
+		jenkins.Spec.Master.JenkinsHomeStorage = &v1alpha2.JenkinsHomeStorage{
+			Size:        resource.MustParse("10Gi"),
+			AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteMany},
+		}
+
+		initBashScript, err := buildInitBashScript(jenkins, false)
+
+		require.NoError(t, err)
+		assert.Contains(t, *initBashScript, "flock -w 600 9")
+	})
+	t.Run("retries the plugin installation", func(t *testing.T) {
+		jenkins := newScriptsTestJenkins()
+		jenkins.Spec.Master.PluginInstallRetries = 3
+		jenkins.Spec.Master.PluginInstallRetryDelay = &metav1.Duration{Duration: time.Minute}
+
+		initBashScript, err := buildInitBashScript(jenkins, false)
+
+		require.NoError(t, err)
+		assert.Contains(t, *initBashScript, "install_plugins() {\n\necho \"Installing plugins required by Operator - begin\"")
+		assert.Contains(t, *initBashScript, `echo "Installing plugins required by user - end"
+}
+
+plugin_install_attempt=1
+while true; do
+    set +e
+    ( set -e; install_plugins )
+    plugin_install_status=$?
+    set -e
+    if [ "${plugin_install_status}" -eq 0 ]; then
+        break
+    fi
+    if [ "${plugin_install_attempt}" -gt 3 ]; then
+        echo "Plugin installation failed after ${plugin_install_attempt} attempts" >&2
+        exit "${plugin_install_status}"
+    fi
+    echo "Plugin installation failed, retrying in 60 seconds"
+    sleep 60
+    plugin_install_attempt=$((plugin_install_attempt + 1))
+done`)
+	})
+	t.Run("without plugin installation retries", func(t *testing.T) {
+		initBashScript, err := buildInitBashScript(newScriptsTestJenkins(), false)
+
+		require.NoError(t, err)
+		assert.NotContains(t, *initBashScript, "install_plugins")
+	})
+	t.Run("without init lock", func(t *testing.T) {
+		initBashScript, err := buildInitBashScript(newScriptsTestJenkins(), false)
+
+		require.NoError(t, err)
+		assert.NotContains(t, *initBashScript, "flock")
+	})
+	t.Run("removes the installed plugins once per plugins reinstall ID", func(t *testing.T) {
+		jenkins := newScriptsTestJenkins()
+		jenkins.Annotations = map[string]string{v1alpha2.PluginsReinstallIDAnnotation: "2026-10-16T10:00:00Z"}
+		jenkins.Spec.Master.JenkinsHomeStorage = &v1alpha2.JenkinsHomeStorage{Size: resource.MustParse("10Gi")}
+		jenkins.Spec.Master.PluginCacheVolume = &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "plugin-cache"}
+
+		initBashScript, err := buildInitBashScript(jenkins, false)
+
+		require.NoError(t, err)
+		assert.Contains(t, *initBashScript, `if [ -n "${PLUGINS_REINSTALL_ID:-}" ] && [ "$(cat "/var/jenkins/plugin-cache/.jenkins-operator-plugins-reinstall-id" 2>/dev/null)" != "${PLUGINS_REINSTALL_ID}" ]; then
+    echo "Removing the installed plugins, the plugins reinstall ${PLUGINS_REINSTALL_ID} was requested"
+    rm -rf "/var/lib/jenkins/plugins"/* "/var/jenkins/plugin-cache"/*
+    printf '%s' "${PLUGINS_REINSTALL_ID}" > "/var/jenkins/plugin-cache/.jenkins-operator-plugins-reinstall-id"
+fi`)
+		assert.Less(t, strings.Index(*initBashScript, "rm -rf \"/var/lib/jenkins/plugins\""), strings.Index(*initBashScript, "Installing plugins required by Operator - begin"))
+	})
+	t.Run("keeps the plugins reinstall ID in the Jenkins home", func(t *testing.T) {
+		jenkins := newScriptsTestJenkins()
+		jenkins.Annotations = map[string]string{v1alpha2.PluginsReinstallIDAnnotation: "2026-10-16T10:00:00Z"}
+		jenkins.Spec.Master.JenkinsHomeStorage = &v1alpha2.JenkinsHomeStorage{Size: resource.MustParse("10Gi")}
+
+		initBashScript, err := buildInitBashScript(jenkins, false)
+
+		require.NoError(t, err)
+		assert.Contains(t, *initBashScript, `rm -rf "/var/lib/jenkins/plugins"/*
+    printf '%s' "${PLUGINS_REINSTALL_ID}" > "/var/lib/jenkins/.jenkins-operator-plugins-reinstall-id"`)
+	})
+	t.Run("removes the cached plugins when the image changes", func(t *testing.T) {
+		jenkins := newScriptsTestJenkins()
+		jenkins.Spec.Master.ReinstallPluginsOnImageChange = true
+		jenkins.Spec.Master.Containers[0].Image = "jenkins/jenkins:2.426.1-lts"
+		jenkins.Spec.Master.PluginCacheVolume = &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "plugin-cache"}
+
+		initBashScript, err := buildInitBashScript(jenkins, false)
+
+		require.NoError(t, err)
+		assert.Contains(t, *initBashScript, `if [ "$(cat "/var/jenkins/plugin-cache/.jenkins-operator-plugins-image" 2>/dev/null)" != "jenkins/jenkins:2.426.1-lts" ]; then
+    echo "Removing the installed plugins, the Jenkins master image has changed"
+    rm -rf "/var/jenkins/plugin-cache"/*
+    printf '%s' "jenkins/jenkins:2.426.1-lts" > "/var/jenkins/plugin-cache/.jenkins-operator-plugins-image"
+fi`)
+		assert.Less(t, strings.Index(*initBashScript, "plugins-image"), strings.Index(*initBashScript, "Installing plugins required by Operator - begin"))
+	})
+	t.Run("keeps the cached plugins without reinstall on image change", func(t *testing.T) {
+		jenkins := newScriptsTestJenkins()
+		jenkins.Spec.Master.PluginCacheVolume = &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "plugin-cache"}
+
+		initBashScript, err := buildInitBashScript(jenkins, false)
+
+		require.NoError(t, err)
+		assert.NotContains(t, *initBashScript, "plugins-image")
+	})
+	t.Run("without plugins reinstall", func(t *testing.T) {
+		jenkins := newScriptsTestJenkins()
+		jenkins.Spec.Master.PluginCacheVolume = &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "plugin-cache"}
+
+		initBashScript, err := buildInitBashScript(jenkins, false)
+
+		require.NoError(t, err)
+		assert.NotContains(t, *initBashScript, "PLUGINS_REINSTALL_ID")
+	})
+}
@@ -11,11 +11,69 @@ import (
 
 	stackerr "github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
-// ServiceKind the kind name for Service
-const ServiceKind = "Service"
+const (
+	// ServiceKind the kind name for Service
+	ServiceKind = "Service"
+	// MetricsServiceLabelKey is the label of the metrics Service which tells it apart from the Jenkins HTTP Service,
+	// both select the Jenkins master pod
+	MetricsServiceLabelKey = "jenkins.io/service"
+	// MetricsServiceLabelValue is the value of the MetricsServiceLabelKey label
+	MetricsServiceLabelValue = "metrics"
+	// MetricsPortName is the name of the metrics Service port, referenced by the ServiceMonitor endpoints
+	MetricsPortName = "metrics"
+)
+
+// IsMetricsServiceEnabled returns true when the dedicated metrics Service is enabled
+func IsMetricsServiceEnabled(jenkins *v1alpha2.Jenkins) bool {
+	return jenkins.Spec.Master.Monitoring != nil && jenkins.Spec.Master.Monitoring.Service
+}
+
+// NewMetricsService builds the Service which exposes only the metrics port of the Jenkins master, the Service gets the
+// labels of spec.master.monitoring and selects the Jenkins master pod
+func NewMetricsService(meta metav1.ObjectMeta, jenkins *v1alpha2.Jenkins) corev1.Service {
+	monitoring := jenkins.Spec.Master.Monitoring
+	port := constants.DefaultHTTPPortInt32
+	if monitoring.Port != 0 {
+		port = monitoring.Port
+	}
+
+	labels := map[string]string{}
+	for key, value := range monitoring.Labels {
+		labels[key] = value
+	}
+	for key, value := range meta.Labels {
+		labels[key] = value
+	}
+	labels[MetricsServiceLabelKey] = MetricsServiceLabelValue
+
+	return corev1.Service{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       ServiceKind,
+			APIVersion: "v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      GetJenkinsMetricsServiceName(jenkins),
+			Namespace: meta.Namespace,
+			Labels:    labels,
+		},
+		Spec: corev1.ServiceSpec{
+			Type:     corev1.ServiceTypeClusterIP,
+			Selector: meta.Labels,
+			Ports: []corev1.ServicePort{
+				{
+					Name:       MetricsPortName,
+					Port:       port,
+					TargetPort: intstr.FromInt(int(constants.DefaultHTTPPortInt32)),
+					Protocol:   corev1.ProtocolTCP,
+				},
+			},
+		},
+	}
+}
 
 // UpdateService returns new service with override fields from config
 func UpdateService(actual corev1.Service, config v1alpha2.Service, targetPort int32) corev1.Service {
@@ -34,6 +92,7 @@ func UpdateService(actual corev1.Service, config v1alpha2.Service, targetPort in
 	if config.NodePort != 0 {
 		actual.Spec.Ports[0].NodePort = config.NodePort
 	}
+	actual.Spec.Ports[0].AppProtocol = config.AppProtocol
 
 	return actual
 }
@@ -48,6 +107,11 @@ func GetJenkinsSlavesServiceName(jenkins *v1alpha2.Jenkins) string {
 	return fmt.Sprintf("%s-slave-%s", constants.OperatorName, jenkins.ObjectMeta.Name)
 }
 
+// GetJenkinsMetricsServiceName returns Kubernetes service name used for expose Jenkins metrics endpoint
+func GetJenkinsMetricsServiceName(jenkins *v1alpha2.Jenkins) string {
+	return fmt.Sprintf("%s-metrics-%s", constants.OperatorName, jenkins.ObjectMeta.Name)
+}
+
 // GetJenkinsHTTPServiceFQDN returns Kubernetes service FQDN used for expose Jenkins HTTP endpoint
 func GetJenkinsHTTPServiceFQDN(jenkins *v1alpha2.Jenkins, kubernetesClusterDomain string) (string, error) {
 	clusterDomain, err := getClusterDomain(kubernetesClusterDomain)
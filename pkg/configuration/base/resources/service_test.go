@@ -0,0 +1,79 @@
+package resources
+
+import (
+	"testing"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func TestUpdateService(t *testing.T) {
+	t.Run("app protocol", func(t *testing.T) {
+		// given
+		appProtocol := "kubernetes.io/h2c"
+		config := v1alpha2.Service{Type: corev1.ServiceTypeClusterIP, Port: 8080, AppProtocol: &appProtocol}
+
+		// when
+		got := UpdateService(corev1.Service{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{}}}, config, 8080)
+
+		// then
+		assert.Equal(t, []corev1.ServicePort{{
+			Port:        8080,
+			TargetPort:  intstr.FromInt(8080),
+			AppProtocol: &appProtocol,
+		}}, got.Spec.Ports)
+	})
+	t.Run("app protocol removed", func(t *testing.T) {
+		// given
+		appProtocol := "https"
+		actual := corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{}},
+			Spec: corev1.ServiceSpec{
+				Ports: []corev1.ServicePort{{Port: 8080, AppProtocol: &appProtocol}},
+			},
+		}
+
+		// when
+		got := UpdateService(actual, v1alpha2.Service{Port: 8080}, 8080)
+
+		// then
+		assert.Nil(t, got.Spec.Ports[0].AppProtocol)
+	})
+}
+
+func TestNewMetricsService(t *testing.T) {
+	// given
+	jenkins := &v1alpha2.Jenkins{
+		ObjectMeta: metav1.ObjectMeta{Name: "example", Namespace: "default"},
+		Spec: v1alpha2.JenkinsSpec{
+			Master: v1alpha2.JenkinsMaster{
+				Monitoring: &v1alpha2.Monitoring{Service: true, Labels: map[string]string{"release": "prometheus"}},
+			},
+		},
+	}
+
+	// when
+	got := NewMetricsService(NewResourceObjectMeta(jenkins), jenkins)
+
+	// then
+	assert.Equal(t, "jenkins-operator-metrics-example", got.Name)
+	assert.Equal(t, "default", got.Namespace)
+	assert.Equal(t, map[string]string{
+		"app":                "jenkins-operator",
+		"jenkins-cr":         "example",
+		"jenkins.io/service": "metrics",
+		"release":            "prometheus",
+	}, got.Labels)
+	assert.Equal(t, map[string]string{"app": "jenkins-operator", "jenkins-cr": "example"}, got.Spec.Selector)
+	assert.Equal(t, corev1.ServiceTypeClusterIP, got.Spec.Type)
+	assert.Equal(t, []corev1.ServicePort{{
+		Name:       "metrics",
+		Port:       8080,
+		TargetPort: intstr.FromInt(8080),
+		Protocol:   corev1.ProtocolTCP,
+	}}, got.Spec.Ports)
+}
@@ -0,0 +1,98 @@
+package resources
+
+import (
+	"fmt"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+)
+
+// MavenSettingsConfigID is the ID of the global Maven settings file of spec.master.mavenSettingsSecretRef
+const MavenSettingsConfigID = "maven-global-settings"
+
+type cascToolInstallations struct {
+	Installations []cascToolInstallation `json:"installations"`
+}
+
+type cascToolInstallation struct {
+	Name       string                     `json:"name"`
+	Home       string                     `json:"home,omitempty"`
+	Properties []cascToolInstallationProp `json:"properties,omitempty"`
+}
+
+type cascToolInstallationProp struct {
+	InstallSource cascInstallSource `json:"installSource"`
+}
+
+type cascInstallSource struct {
+	Installers []cascInstaller `json:"installers"`
+}
+
+type cascInstaller struct {
+	Zip cascZipInstaller `json:"zip"`
+}
+
+type cascZipInstaller struct {
+	URL    string `json:"url"`
+	Subdir string `json:"subdir,omitempty"`
+	Label  string `json:"label,omitempty"`
+}
+
+func buildCascToolInstallations(installations []v1alpha2.ToolInstallation) *cascToolInstallations {
+	if len(installations) == 0 {
+		return nil
+	}
+
+	tool := &cascToolInstallations{}
+	for _, installation := range installations {
+		cascInstallation := cascToolInstallation{Name: installation.Name, Home: installation.Home}
+		if installation.Installer != nil {
+			cascInstallation.Properties = []cascToolInstallationProp{{
+				InstallSource: cascInstallSource{Installers: []cascInstaller{{Zip: cascZipInstaller{
+					URL:    installation.Installer.URL,
+					Subdir: installation.Installer.Subdir,
+					Label:  installation.Installer.Label,
+				}}}},
+			}}
+		}
+		tool.Installations = append(tool.Installations, cascInstallation)
+	}
+	return tool
+}
+
+// BuildToolConfiguration builds the tool section of the Configuration as Code from spec.toolConfig
+func BuildToolConfiguration(toolConfig v1alpha2.ToolConfig) map[string]interface{} {
+	tool := map[string]interface{}{}
+	if jdk := buildCascToolInstallations(toolConfig.JDKs); jdk != nil {
+		tool["jdk"] = jdk
+	}
+	if maven := buildCascToolInstallations(toolConfig.Maven); maven != nil {
+		tool["maven"] = maven
+	}
+	if gradle := buildCascToolInstallations(toolConfig.Gradle); gradle != nil {
+		tool["gradle"] = gradle
+	}
+	return map[string]interface{}{"tool": tool}
+}
+
+// BuildMavenSettingsConfiguration builds the global Maven settings file of the config-file-provider plugin from the
+// mounted settings.xml of spec.master.mavenSettingsSecretRef, the content is read by Jenkins and never stored in the
+// Configuration as Code
+func BuildMavenSettingsConfiguration() map[string]interface{} {
+	return map[string]interface{}{
+		"unclassified": map[string]interface{}{
+			"globalConfigFiles": map[string]interface{}{
+				"configs": []interface{}{
+					map[string]interface{}{
+						"globalMavenSettings": map[string]interface{}{
+							"id":           MavenSettingsConfigID,
+							"name":         MavenSettingsConfigID,
+							"comment":      "Managed by the Jenkins Operator",
+							"content":      fmt.Sprintf("${readFile:%s/%s}", MavenSettingsVolumePath, mavenSettingsFileName),
+							"isReplaceAll": true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
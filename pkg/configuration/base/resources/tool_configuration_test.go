@@ -0,0 +1,123 @@
+package resources
+
+import (
+	"testing"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+)
+
+func TestBuildToolConfiguration(t *testing.T) {
+	t.Run("jdk, maven and gradle installations", func(t *testing.T) {
+		// given
+		toolConfig := v1alpha2.ToolConfig{
+			JDKs: []v1alpha2.ToolInstallation{
+				{Name: "jdk11", Home: "/opt/java/openjdk"},
+				{Name: "jdk17", Installer: &v1alpha2.ToolInstaller{URL: "https://example.com/jdk17.zip", Subdir: "jdk-17", Label: "linux"}},
+			},
+			Maven:  []v1alpha2.ToolInstallation{{Name: "maven3", Installer: &v1alpha2.ToolInstaller{URL: "https://example.com/maven.zip"}}},
+			Gradle: []v1alpha2.ToolInstallation{{Name: "gradle7", Home: "/opt/gradle"}},
+		}
+
+		// when
+		got, err := yaml.Marshal(BuildToolConfiguration(toolConfig))
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, `tool:
+  gradle:
+    installations:
+    - home: /opt/gradle
+      name: gradle7
+  jdk:
+    installations:
+    - home: /opt/java/openjdk
+      name: jdk11
+    - name: jdk17
+      properties:
+      - installSource:
+          installers:
+          - zip:
+              label: linux
+              subdir: jdk-17
+              url: https://example.com/jdk17.zip
+  maven:
+    installations:
+    - name: maven3
+      properties:
+      - installSource:
+          installers:
+          - zip:
+              url: https://example.com/maven.zip
+`, string(got))
+	})
+	t.Run("only configured tools are rendered", func(t *testing.T) {
+		// given
+		toolConfig := v1alpha2.ToolConfig{JDKs: []v1alpha2.ToolInstallation{{Name: "jdk11", Home: "/opt/java/openjdk"}}}
+
+		// when
+		got, err := yaml.Marshal(BuildToolConfiguration(toolConfig))
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, `tool:
+  jdk:
+    installations:
+    - home: /opt/java/openjdk
+      name: jdk11
+`, string(got))
+	})
+}
+
+func TestNewBaseConfigurationConfigMapToolConfiguration(t *testing.T) {
+	newJenkins := func(toolConfig *v1alpha2.ToolConfig) *v1alpha2.Jenkins {
+		return &v1alpha2.Jenkins{
+			ObjectMeta: metav1.ObjectMeta{Name: "example", Namespace: "default"},
+			Spec: v1alpha2.JenkinsSpec{
+				Master:     v1alpha2.JenkinsMaster{Containers: []v1alpha2.Container{{Name: JenkinsMasterContainerName}}},
+				ToolConfig: toolConfig,
+			},
+		}
+	}
+
+	t.Run("without tool configuration", func(t *testing.T) {
+		configMap, err := NewBaseConfigurationConfigMap(metav1.ObjectMeta{}, newJenkins(nil), "cluster.local")
+
+		require.NoError(t, err)
+		assert.NotContains(t, configMap.Data, configurationAsCodeGroovyScriptName)
+	})
+	t.Run("with tool configuration", func(t *testing.T) {
+		toolConfig := &v1alpha2.ToolConfig{JDKs: []v1alpha2.ToolInstallation{{Name: "jdk's", Home: `C:\java`}}}
+
+		configMap, err := NewBaseConfigurationConfigMap(metav1.ObjectMeta{}, newJenkins(toolConfig), "cluster.local")
+
+		require.NoError(t, err)
+		assert.Contains(t, configMap.Data[configurationAsCodeGroovyScriptName], `def config = '''tool:
+  jdk:
+    installations:
+    - home: C:\\java
+      name: jdk\'s
+'''`)
+		assert.Contains(t, configMap.Data[configurationAsCodeGroovyScriptName], "ConfigurationAsCode.get().configureWith(")
+	})
+}
+
+func TestBuildMavenSettingsConfiguration(t *testing.T) {
+	got, err := yaml.Marshal(BuildMavenSettingsConfiguration())
+
+	require.NoError(t, err)
+	assert.Equal(t, `unclassified:
+  globalConfigFiles:
+    configs:
+    - globalMavenSettings:
+        comment: Managed by the Jenkins Operator
+        content: ${readFile:/var/jenkins/maven-settings/settings.xml}
+        id: maven-global-settings
+        isReplaceAll: true
+        name: maven-global-settings
+`, string(got))
+}
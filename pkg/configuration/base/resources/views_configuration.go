@@ -0,0 +1,67 @@
+package resources
+
+import (
+	"strings"
+	"text/template"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/internal/render"
+)
+
+// managedViewDescription marks the views managed by the operator, only these views are deleted
+// when they are removed from spec.views
+const managedViewDescription = "Managed by the Jenkins Operator"
+
+var configureUserViewsTemplate = template.Must(template.New(configureUserViewsGroovyScriptName).Funcs(template.FuncMap{
+	"quote": quoteGroovyString,
+}).Parse(`
+import hudson.model.ListView
+import jenkins.model.Jenkins
+
+def jenkins = Jenkins.instance
+def managedViewDescription = {{ quote .ManagedViewDescription }}
+def views = [
+{{- range .Views }}
+    [name: {{ quote .Name }}, regex: {{ if .Regex }}{{ quote .Regex }}{{ else }}null{{ end }}, jobNames: [{{ range $index, $jobName := .JobNames }}{{ if $index }}, {{ end }}{{ quote $jobName }}{{ end }}]],
+{{- end }}
+]
+
+views.each { definition ->
+    def view = jenkins.getView(definition.name)
+    if (view == null) {
+        view = new ListView(definition.name)
+        jenkins.addView(view)
+    } else if (!(view instanceof ListView)) {
+        println("View '${definition.name}' isn't a list view, skipping")
+        return
+    }
+    view.setDescription(managedViewDescription)
+    view.setIncludeRegex(definition.regex)
+    view.jobNames.clear()
+    view.jobNames.addAll(definition.jobNames)
+    view.save()
+}
+
+def viewNames = views.collect { it.name }
+jenkins.views.findAll { it.description == managedViewDescription && !viewNames.contains(it.viewName) }.each { view ->
+    println("Deleting view '${view.viewName}'")
+    jenkins.deleteView(view)
+}
+
+jenkins.save()
+`))
+
+// quoteGroovyString quotes the value as the single quoted groovy string
+func quoteGroovyString(value string) string {
+	return "'" + strings.NewReplacer(`\`, `\\`, `'`, `\'`, "\n", `\n`).Replace(value) + "'"
+}
+
+func buildConfigureUserViewsGroovyScript(views []v1alpha2.View) (string, error) {
+	return render.Render(configureUserViewsTemplate, struct {
+		ManagedViewDescription string
+		Views                  []v1alpha2.View
+	}{
+		ManagedViewDescription: managedViewDescription,
+		Views:                  views,
+	})
+}
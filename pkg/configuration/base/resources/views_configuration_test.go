@@ -0,0 +1,37 @@
+package resources
+
+import (
+	"testing"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildConfigureUserViewsGroovyScript(t *testing.T) {
+	t.Run("views", func(t *testing.T) {
+		views := []v1alpha2.View{
+			{Name: "backend", JobNames: []string{"api", "worker"}},
+			{Name: "team's jobs", Regex: `team-.*\.build`},
+		}
+
+		got, err := buildConfigureUserViewsGroovyScript(views)
+
+		require.NoError(t, err)
+		assert.Contains(t, got, `def managedViewDescription = 'Managed by the Jenkins Operator'
+def views = [
+    [name: 'backend', regex: null, jobNames: ['api', 'worker']],
+    [name: 'team\'s jobs', regex: 'team-.*\\.build', jobNames: []],
+]
+`)
+		assert.Contains(t, got, "jenkins.deleteView(view)")
+	})
+	t.Run("no views removes the managed views", func(t *testing.T) {
+		got, err := buildConfigureUserViewsGroovyScript(nil)
+
+		require.NoError(t, err)
+		assert.Contains(t, got, "def views = [\n]\n")
+		assert.Contains(t, got, "it.description == managedViewDescription && !viewNames.contains(it.viewName)")
+	})
+}
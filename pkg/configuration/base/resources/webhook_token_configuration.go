@@ -0,0 +1,49 @@
+package resources
+
+import (
+	"fmt"
+)
+
+// WebhookTokenCredentialsID is the ID of the secret text credentials with the webhook token of
+// spec.master.webhookTokenSecretRef
+const WebhookTokenCredentialsID = "jenkins-operator-webhook-token"
+
+// configureWebhookTokenFmt adds or updates the secret text credentials with the mounted webhook token, the token is
+// read by Jenkins and never printed
+const configureWebhookTokenFmt = `
+import com.cloudbees.plugins.credentials.CredentialsScope
+import com.cloudbees.plugins.credentials.SystemCredentialsProvider
+import com.cloudbees.plugins.credentials.domains.Domain
+import hudson.util.Secret
+import org.jenkinsci.plugins.plaincredentials.impl.StringCredentialsImpl
+
+def credentialsID = '%s'
+def token = new File('%s').text.trim()
+def credentials = new StringCredentialsImpl(CredentialsScope.GLOBAL, credentialsID, 'Webhook token managed by the Jenkins Operator', Secret.fromString(token))
+
+def store = SystemCredentialsProvider.instance.store
+def current = store.getCredentials(Domain.global()).find { it.id == credentialsID }
+if (current == null) {
+    store.addCredentials(Domain.global(), credentials)
+} else if (current.secret.plainText != token) {
+    store.updateCredentials(Domain.global(), current, credentials)
+}
+`
+
+func buildConfigureWebhookTokenGroovyScript() string {
+	return fmt.Sprintf(configureWebhookTokenFmt, WebhookTokenCredentialsID, fmt.Sprintf("%s/%s", WebhookTokenVolumePath, webhookTokenFileName))
+}
+
+// BuildWebhookTokenConfiguration builds the GitHub plugin hook secret section of the Configuration as Code,
+// the GitHub webhooks are verified with the webhook token credentials
+func BuildWebhookTokenConfiguration() map[string]interface{} {
+	return map[string]interface{}{
+		"unclassified": map[string]interface{}{
+			"gitHubPluginConfig": map[string]interface{}{
+				"hookSecretConfigs": []interface{}{
+					map[string]interface{}{"credentialsId": WebhookTokenCredentialsID},
+				},
+			},
+		},
+	}
+}
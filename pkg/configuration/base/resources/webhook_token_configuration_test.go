@@ -0,0 +1,56 @@
+package resources
+
+import (
+	"testing"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/yaml"
+)
+
+func TestWebhookTokenSecret(t *testing.T) {
+	jenkins := &v1alpha2.Jenkins{
+		Spec: v1alpha2.JenkinsSpec{
+			Master: v1alpha2.JenkinsMaster{
+				Containers: []v1alpha2.Container{{Name: JenkinsMasterContainerName, ReadinessProbe: &corev1.Probe{}}},
+				WebhookTokenSecretRef: &v1alpha2.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "github"},
+					Key:                  "webhook-secret",
+				},
+			},
+		},
+	}
+
+	container := NewJenkinsMasterContainer(jenkins)
+
+	assert.Contains(t, GetJenkinsMasterPodBaseVolumes(jenkins), corev1.Volume{
+		Name: webhookTokenVolumeName,
+		VolumeSource: corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{
+			DefaultMode: &[]int32{corev1.SecretVolumeSourceDefaultMode}[0],
+			SecretName:  "github",
+			Items:       []corev1.KeyToPath{{Key: "webhook-secret", Path: "token"}},
+		}},
+	})
+	assert.Contains(t, container.VolumeMounts, corev1.VolumeMount{Name: webhookTokenVolumeName, MountPath: "/var/jenkins/webhook-token", ReadOnly: true})
+}
+
+func TestBuildConfigureWebhookTokenGroovyScript(t *testing.T) {
+	got := buildConfigureWebhookTokenGroovyScript()
+
+	assert.Contains(t, got, "def credentialsID = 'jenkins-operator-webhook-token'")
+	assert.Contains(t, got, "def token = new File('/var/jenkins/webhook-token/token').text.trim()")
+}
+
+func TestBuildWebhookTokenConfiguration(t *testing.T) {
+	got, err := yaml.Marshal(BuildWebhookTokenConfiguration())
+
+	require.NoError(t, err)
+	assert.Equal(t, `unclassified:
+  gitHubPluginConfig:
+    hookSecretConfigs:
+    - credentialsId: jenkins-operator-webhook-token
+`, string(got))
+}
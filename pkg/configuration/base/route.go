@@ -21,6 +21,7 @@ func (r *JenkinsBaseConfigurationReconciler) createRoute(meta metav1.ObjectMeta,
 	name := fmt.Sprintf("jenkins-%s", config.ObjectMeta.Name)
 	err := r.Client.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: meta.Namespace}, &route)
 	if err != nil && apierrors.IsNotFound(err) {
+		r.notifyResourceRecreated("Route", name)
 		port := &routev1.RoutePort{
 			TargetPort: intstr.FromString(""),
 		}
@@ -50,6 +51,8 @@ func (r *JenkinsBaseConfigurationReconciler) createRoute(meta metav1.ObjectMeta,
 		}
 	} else if err != nil {
 		return stackerr.WithStack(err)
+	} else {
+		r.ObserveResource("Route", name)
 	}
 
 	route.ObjectMeta.Labels = meta.Labels // make sure that user won't break service by hand
@@ -2,6 +2,7 @@ package base
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
 	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/base/resources"
@@ -11,12 +12,14 @@ import (
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 func (r *JenkinsBaseConfigurationReconciler) createService(meta metav1.ObjectMeta, name string, config v1alpha2.Service, targetPort int32) error {
 	service := corev1.Service{}
 	err := r.Client.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: meta.Namespace}, &service)
 	if err != nil && apierrors.IsNotFound(err) {
+		r.notifyResourceRecreated("Service", name)
 		service = resources.UpdateService(corev1.Service{
 			ObjectMeta: metav1.ObjectMeta{
 				Name:      name,
@@ -27,14 +30,44 @@ func (r *JenkinsBaseConfigurationReconciler) createService(meta metav1.ObjectMet
 				Selector: meta.Labels,
 			},
 		}, config, targetPort)
-		if err = r.CreateResource(&service); err != nil {
-			return stackerr.WithStack(err)
-		}
+		return stackerr.WithStack(r.CreateResource(&service))
 	} else if err != nil {
 		return stackerr.WithStack(err)
 	}
+	r.ObserveResource("Service", name)
 
 	service.Spec.Selector = meta.Labels // make sure that user won't break service by hand
 	service = resources.UpdateService(service, config, targetPort)
 	return stackerr.WithStack(r.UpdateResource(&service))
 }
+
+// ensureMetricsService creates or updates the metrics Service when spec.master.monitoring.service is enabled and
+// deletes it otherwise
+func (r *JenkinsBaseConfigurationReconciler) ensureMetricsService(meta metav1.ObjectMeta) error {
+	name := resources.GetJenkinsMetricsServiceName(r.Configuration.Jenkins)
+	service := corev1.Service{}
+	err := r.Client.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: meta.Namespace}, &service)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return stackerr.WithStack(err)
+	}
+	found := err == nil
+
+	if !resources.IsMetricsServiceEnabled(r.Configuration.Jenkins) {
+		if !found {
+			return nil
+		}
+		r.logger.Info(fmt.Sprintf("Deleting the metrics Service %s/%s", service.Namespace, service.Name))
+		return stackerr.WithStack(client.IgnoreNotFound(r.Client.Delete(context.TODO(), &service)))
+	}
+
+	desired := resources.NewMetricsService(meta, r.Configuration.Jenkins)
+	if !found {
+		r.logger.Info(fmt.Sprintf("Creating the metrics Service %s/%s", desired.Namespace, desired.Name))
+		return stackerr.WithStack(r.CreateResource(&desired))
+	}
+
+	service.Labels = desired.Labels
+	service.Spec.Selector = desired.Spec.Selector
+	service.Spec.Ports = desired.Spec.Ports
+	return stackerr.WithStack(r.UpdateResource(&service))
+}
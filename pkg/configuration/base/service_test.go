@@ -0,0 +1,176 @@
+package base
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/pkg/client"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/base/resources"
+	"github.com/jenkinsci/kubernetes-operator/pkg/constants"
+	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/event"
+	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/reason"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestCreateService(t *testing.T) {
+	namespace := "default"
+	jenkinsName := "example"
+	serviceConfig := v1alpha2.Service{Type: corev1.ServiceTypeClusterIP, Port: constants.DefaultHTTPPortInt32}
+
+	newReconciler := func(jenkins *v1alpha2.Jenkins, notifications chan event.Event) *JenkinsBaseConfigurationReconciler {
+		err := v1alpha2.SchemeBuilder.AddToScheme(scheme.Scheme)
+		require.NoError(t, err)
+		config := configuration.Configuration{
+			Client:        fake.NewClientBuilder().Build(),
+			Jenkins:       jenkins,
+			Scheme:        scheme.Scheme,
+			Notifications: &notifications,
+		}
+		return New(config, client.JenkinsAPIConnectionSettings{})
+	}
+
+	t.Run("creates service without notification", func(t *testing.T) {
+		// given
+		jenkins := &v1alpha2.Jenkins{ObjectMeta: metav1.ObjectMeta{Name: jenkinsName, Namespace: namespace}}
+		notifications := make(chan event.Event, 1)
+		reconciler := newReconciler(jenkins, notifications)
+		metaObject := resources.NewResourceObjectMeta(jenkins)
+		serviceName := resources.GetJenkinsHTTPServiceName(jenkins)
+
+		// when
+		err := reconciler.createService(metaObject, serviceName, serviceConfig, constants.DefaultHTTPPortInt32)
+
+		// then
+		require.NoError(t, err)
+		service := &corev1.Service{}
+		err = reconciler.Client.Get(context.TODO(), types.NamespacedName{Name: serviceName, Namespace: namespace}, service)
+		require.NoError(t, err)
+		assert.Equal(t, metaObject.Labels, service.Spec.Selector)
+		assert.Empty(t, notifications)
+	})
+	t.Run("recreates deleted service", func(t *testing.T) {
+		// given
+		jenkins := &v1alpha2.Jenkins{ObjectMeta: metav1.ObjectMeta{Name: jenkinsName, Namespace: namespace}}
+		notifications := make(chan event.Event, 1)
+		reconciler := newReconciler(jenkins, notifications)
+		metaObject := resources.NewResourceObjectMeta(jenkins)
+		serviceName := resources.GetJenkinsHTTPServiceName(jenkins)
+		err := reconciler.createService(metaObject, serviceName, serviceConfig, constants.DefaultHTTPPortInt32)
+		require.NoError(t, err)
+		err = reconciler.createService(metaObject, serviceName, serviceConfig, constants.DefaultHTTPPortInt32)
+		require.NoError(t, err)
+
+		service := &corev1.Service{}
+		err = reconciler.Client.Get(context.TODO(), types.NamespacedName{Name: serviceName, Namespace: namespace}, service)
+		require.NoError(t, err)
+		err = reconciler.Client.Delete(context.TODO(), service)
+		require.NoError(t, err)
+
+		// when
+		err = reconciler.createService(metaObject, serviceName, serviceConfig, constants.DefaultHTTPPortInt32)
+
+		// then
+		require.NoError(t, err)
+		err = reconciler.Client.Get(context.TODO(), types.NamespacedName{Name: serviceName, Namespace: namespace}, &corev1.Service{})
+		assert.NoError(t, err)
+		require.Len(t, notifications, 1)
+		notification := <-notifications
+		assert.IsType(t, &reason.ResourceRecreated{}, notification.Reason)
+		assert.Equal(t, v1alpha2.NotificationLevelWarning, notification.Level)
+		assert.Contains(t, notification.Reason.Short()[0], serviceName)
+	})
+	t.Run("creates new service of provisioned Jenkins without notification", func(t *testing.T) {
+		// given
+		jenkins := &v1alpha2.Jenkins{ObjectMeta: metav1.ObjectMeta{Name: "provisioned", Namespace: namespace}}
+		now := metav1.Now()
+		jenkins.Status.ProvisionStartTime = &now
+		notifications := make(chan event.Event, 1)
+		reconciler := newReconciler(jenkins, notifications)
+		metaObject := resources.NewResourceObjectMeta(jenkins)
+
+		// when
+		err := reconciler.createService(metaObject, resources.GetJenkinsHTTPServiceName(jenkins), serviceConfig, constants.DefaultHTTPPortInt32)
+
+		// then
+		require.NoError(t, err)
+		assert.Empty(t, notifications)
+	})
+}
+
+func TestEnsureMetricsService(t *testing.T) {
+	require.NoError(t, v1alpha2.SchemeBuilder.AddToScheme(scheme.Scheme))
+	newJenkins := func(monitoring *v1alpha2.Monitoring) *v1alpha2.Jenkins {
+		return &v1alpha2.Jenkins{
+			ObjectMeta: metav1.ObjectMeta{Name: "example", Namespace: defaultNamespace},
+			Spec:       v1alpha2.JenkinsSpec{Master: v1alpha2.JenkinsMaster{Monitoring: monitoring}},
+		}
+	}
+	newReconciler := func(jenkins *v1alpha2.Jenkins) *JenkinsBaseConfigurationReconciler {
+		return New(configuration.Configuration{
+			Client:  fake.NewClientBuilder().Build(),
+			Scheme:  scheme.Scheme,
+			Jenkins: jenkins,
+		}, client.JenkinsAPIConnectionSettings{})
+	}
+	getService := func(reconciler *JenkinsBaseConfigurationReconciler) (*corev1.Service, error) {
+		service := &corev1.Service{}
+		err := reconciler.Client.Get(context.TODO(), types.NamespacedName{Name: "jenkins-operator-metrics-example", Namespace: defaultNamespace}, service)
+		return service, err
+	}
+
+	t.Run("creates and updates the Service", func(t *testing.T) {
+		// given
+		jenkins := newJenkins(&v1alpha2.Monitoring{Service: true})
+		reconciler := newReconciler(jenkins)
+		require.NoError(t, reconciler.ensureMetricsService(resources.NewResourceObjectMeta(jenkins)))
+		jenkins.Spec.Master.Monitoring.Port = 9090
+
+		// when
+		err := reconciler.ensureMetricsService(resources.NewResourceObjectMeta(jenkins))
+
+		// then
+		require.NoError(t, err)
+		service, err := getService(reconciler)
+		require.NoError(t, err)
+		require.Len(t, service.Spec.Ports, 1)
+		assert.Equal(t, int32(9090), service.Spec.Ports[0].Port)
+	})
+	t.Run("deletes the disabled Service", func(t *testing.T) {
+		// given
+		jenkins := newJenkins(&v1alpha2.Monitoring{Service: true})
+		reconciler := newReconciler(jenkins)
+		require.NoError(t, reconciler.ensureMetricsService(resources.NewResourceObjectMeta(jenkins)))
+		jenkins.Spec.Master.Monitoring = nil
+
+		// when
+		err := reconciler.ensureMetricsService(resources.NewResourceObjectMeta(jenkins))
+
+		// then
+		require.NoError(t, err)
+		_, err = getService(reconciler)
+		assert.True(t, apierrors.IsNotFound(err))
+	})
+	t.Run("disabled", func(t *testing.T) {
+		// given
+		jenkins := newJenkins(nil)
+		reconciler := newReconciler(jenkins)
+
+		// when
+		err := reconciler.ensureMetricsService(resources.NewResourceObjectMeta(jenkins))
+
+		// then
+		require.NoError(t, err)
+		_, err = getService(reconciler)
+		assert.True(t, apierrors.IsNotFound(err))
+	})
+}
@@ -21,12 +21,15 @@ func (r *JenkinsBaseConfigurationReconciler) createServiceAccount(meta metav1.Ob
 	msg := fmt.Sprintf("createServiceAccount with annotations %v", annotations)
 	r.logger.V(log.VDebug).Info(msg)
 	if err != nil && apierrors.IsNotFound(err) {
+		r.notifyResourceRecreated("ServiceAccount", meta.Name)
 		serviceAccount = resources.NewServiceAccount(meta, annotations)
 		if err = r.CreateResource(serviceAccount); err != nil {
 			return stackerr.WithStack(err)
 		}
 	} else if err != nil {
 		return stackerr.WithStack(err)
+	} else {
+		r.ObserveResource("ServiceAccount", meta.Name)
 	}
 
 	if !compareMap(annotations, serviceAccount.Annotations) {
@@ -2,24 +2,43 @@ package base
 
 import (
 	"context"
+	"encoding/pem"
 	"fmt"
+	"net"
+	"net/mail"
+	"net/url"
+	"path"
 	"regexp"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
+	// the time zone database is embedded because the operator image doesn't ship it
+	_ "time/tzdata"
 
 	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
 	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/base/resources"
 	"github.com/jenkinsci/kubernetes-operator/pkg/constants"
+	"github.com/jenkinsci/kubernetes-operator/pkg/log"
 	"github.com/jenkinsci/kubernetes-operator/pkg/plugins"
 
 	docker "github.com/docker/distribution/reference"
 	stackerr "github.com/pkg/errors"
+	"golang.org/x/crypto/ssh"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/yaml"
 )
 
 var (
-	dockerImageRegexp = regexp.MustCompile(`^` + docker.TagRegexp.String() + `$`)
+	dockerImageRegexp             = regexp.MustCompile(`^` + docker.TagRegexp.String() + `$`)
+	globalEnvVarKeyRegexp         = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+	systemPropertyKeyRegexp       = regexp.MustCompile(`^[A-Za-z0-9_.$-]+$`)
+	authorizationPermissionRegexp = regexp.MustCompile(`^[A-Za-z]+/[A-Za-z]+$`)
+	nodeLabelRegexp               = regexp.MustCompile(`^[^\s&|!()<>'"]+$`)
 )
 
 // Validate validates Jenkins CR Spec.master section
@@ -52,94 +71,1271 @@ func (r *JenkinsBaseConfigurationReconciler) Validate(jenkins *v1alpha2.Jenkins)
 		messages = append(messages, msg...)
 	}
 
+	if script := r.Configuration.Jenkins.Spec.SystemConfigGroovy; len(script) > 0 {
+		if err := validateGroovyBrackets(script); err != nil {
+			messages = append(messages, fmt.Sprintf("spec.systemConfigGroovy is invalid: %s", err))
+		}
+	}
+
 	if msg, err := r.validateCustomization(r.Configuration.Jenkins.Spec.GroovyScripts.Customization, "spec.groovyScripts"); err != nil {
 		return nil, err
 	} else if len(msg) > 0 {
 		messages = append(messages, msg...)
 	}
-	if msg, err := r.validateCustomization(r.Configuration.Jenkins.Spec.ConfigurationAsCode.Customization, "spec.configurationAsCode"); err != nil {
-		return nil, err
-	} else if len(msg) > 0 {
-		messages = append(messages, msg...)
+	if msg, err := r.validateCustomization(r.Configuration.Jenkins.Spec.ConfigurationAsCode.Customization, "spec.configurationAsCode"); err != nil {
+		return nil, err
+	} else if len(msg) > 0 {
+		messages = append(messages, msg...)
+	}
+
+	if storage := jenkins.Spec.Master.JenkinsHomeStorage; storage != nil && storage.Size.Sign() <= 0 {
+		messages = append(messages, "spec.master.jenkinsHomeStorage.size must be greater than zero")
+	}
+
+	if msg := r.validateContextPath(); len(msg) > 0 {
+		messages = append(messages, msg...)
+	}
+
+	if msg := r.validateAgentProtocols(); len(msg) > 0 {
+		messages = append(messages, msg...)
+	}
+
+	if msg := r.validateAgentPodTemplates(); len(msg) > 0 {
+		messages = append(messages, msg...)
+	}
+
+	if msg := r.validateExtraInitContainers(); len(msg) > 0 {
+		messages = append(messages, msg...)
+	}
+
+	if executors := jenkins.Spec.Master.Executors; executors != nil {
+		if *executors < 0 {
+			messages = append(messages, "spec.master.executors can't be negative")
+		} else if *executors > 0 {
+			r.logger.V(log.VWarn).Info(fmt.Sprintf("Jenkins master has %d executors, running builds on the master is discouraged, use agents instead", *executors))
+		}
+	}
+
+	if msg := r.validateReplicas(); len(msg) > 0 {
+		messages = append(messages, msg...)
+	}
+
+	if msg := r.validateDeepReadiness(); len(msg) > 0 {
+		messages = append(messages, msg...)
+	}
+
+	if msg, err := r.validateCACertsSecret(); err != nil {
+		return nil, err
+	} else if len(msg) > 0 {
+		messages = append(messages, msg...)
+	}
+
+	if msg, err := r.validateLDAP(); err != nil {
+		return nil, err
+	} else if len(msg) > 0 {
+		messages = append(messages, msg...)
+	}
+
+	if msg, err := r.validateOIDC(); err != nil {
+		return nil, err
+	} else if len(msg) > 0 {
+		messages = append(messages, msg...)
+	}
+
+	if mavenSettings := r.Configuration.Jenkins.Spec.Master.MavenSettingsSecretRef; mavenSettings != nil {
+		if msg, err := r.validateSecretKeySelector(*mavenSettings, "spec.master.mavenSettingsSecretRef"); err != nil {
+			return nil, err
+		} else if len(msg) > 0 {
+			messages = append(messages, msg...)
+		}
+	}
+
+	if webhookToken := r.Configuration.Jenkins.Spec.Master.WebhookTokenSecretRef; webhookToken != nil {
+		if msg, err := r.validateSecretKeySelector(*webhookToken, "spec.master.webhookTokenSecretRef"); err != nil {
+			return nil, err
+		} else if len(msg) > 0 {
+			messages = append(messages, msg...)
+		}
+	}
+
+	if msg, err := r.validateAgent(); err != nil {
+		return nil, err
+	} else if len(msg) > 0 {
+		messages = append(messages, msg...)
+	}
+
+	if msg := r.validateLocation(); len(msg) > 0 {
+		messages = append(messages, msg...)
+	}
+
+	if msg := r.validateAuthorization(); len(msg) > 0 {
+		messages = append(messages, msg...)
+	}
+
+	if msg := r.validateBuildDiscarder(); len(msg) > 0 {
+		messages = append(messages, msg...)
+	}
+
+	if msg := r.validateBuildDefaults(); len(msg) > 0 {
+		messages = append(messages, msg...)
+	}
+
+	if msg := r.validateDependsOn(); len(msg) > 0 {
+		messages = append(messages, msg...)
+	}
+
+	if msg := r.validateAuditLog(); len(msg) > 0 {
+		messages = append(messages, msg...)
+	}
+
+	if msg := r.validateAPIClient(); len(msg) > 0 {
+		messages = append(messages, msg...)
+	}
+
+	if msg := r.validateThemeCSS(); len(msg) > 0 {
+		messages = append(messages, msg...)
+	}
+
+	if msg := r.validateBranding(); len(msg) > 0 {
+		messages = append(messages, msg...)
+	}
+
+	if msg := r.validateMarkupFormatter(); len(msg) > 0 {
+		messages = append(messages, msg...)
+	}
+
+	if msg := r.validateGlobalEnvVars(); len(msg) > 0 {
+		messages = append(messages, msg...)
+	}
+
+	if msg := r.validateSystemProperties(); len(msg) > 0 {
+		messages = append(messages, msg...)
+	}
+
+	if msg := r.validateNodeLabels(); len(msg) > 0 {
+		messages = append(messages, msg...)
+	}
+
+	if msg := r.validatePluginInstallation(); len(msg) > 0 {
+		messages = append(messages, msg...)
+	}
+
+	if msg := r.validateJenkinsProxy(); len(msg) > 0 {
+		messages = append(messages, msg...)
+	}
+
+	if msg := r.validateTimeZone(); len(msg) > 0 {
+		messages = append(messages, msg...)
+	}
+
+	if msg := r.validateLogRecorders(); len(msg) > 0 {
+		messages = append(messages, msg...)
+	}
+
+	if msg := r.validateViews(); len(msg) > 0 {
+		messages = append(messages, msg...)
+	}
+
+	if msg := r.validateToolConfig(); len(msg) > 0 {
+		messages = append(messages, msg...)
+	}
+
+	if msg := r.validateGlobalPipelineLibraries(); len(msg) > 0 {
+		messages = append(messages, msg...)
+	}
+
+	if msg := r.validatePermanentAgents(); len(msg) > 0 {
+		messages = append(messages, msg...)
+	}
+
+	if export := jenkins.Spec.ConfigurationAsCodeExport; export != nil && export.Interval != nil && export.Interval.Duration <= 0 {
+		messages = append(messages, fmt.Sprintf("spec.configurationAsCodeExport.interval '%s' must be positive", export.Interval.Duration))
+	}
+
+	if jenkins.Spec.JenkinsAPISettings.AuthorizationStrategy != v1alpha2.CreateUserAuthorizationStrategy && jenkins.Spec.JenkinsAPISettings.AuthorizationStrategy != v1alpha2.ServiceAccountAuthorizationStrategy {
+		messages = append(messages, fmt.Sprintf("unrecognized '%s' spec.jenkinsAPISettings.authorizationStrategy", jenkins.Spec.JenkinsAPISettings.AuthorizationStrategy))
+	}
+
+	return messages, nil
+}
+
+func (r *JenkinsBaseConfigurationReconciler) validateJenkinsMasterContainerCommand() []string {
+	masterContainer := r.Configuration.GetJenkinsMasterContainer()
+	if masterContainer == nil {
+		return []string{}
+	}
+
+	jenkinsOperatorInitScript := fmt.Sprintf("%s/%s && ", resources.JenkinsScriptsVolumePath, resources.InitScriptName)
+	correctCommand := []string{
+		"bash",
+		"-c",
+		fmt.Sprintf("%s<optional-custom-command> && exec <command-which-start-jenkins>", jenkinsOperatorInitScript),
+	}
+	invalidCommandMessage := []string{fmt.Sprintf("spec.master.containers[%s].command is invalid, make sure it looks like '%v', otherwise the operator won't configure default user and install plugins. 'exec' is required to propagate signals to the Jenkins.", masterContainer.Name, correctCommand)}
+	if len(masterContainer.Command) != 3 {
+		return invalidCommandMessage
+	}
+	if masterContainer.Command[0] != correctCommand[0] {
+		return invalidCommandMessage
+	}
+	if masterContainer.Command[1] != correctCommand[1] {
+		return invalidCommandMessage
+	}
+	if !strings.HasPrefix(masterContainer.Command[2], jenkinsOperatorInitScript) {
+		return invalidCommandMessage
+	}
+	if !strings.Contains(masterContainer.Command[2], "exec") {
+		return invalidCommandMessage
+	}
+
+	return []string{}
+}
+
+func (r *JenkinsBaseConfigurationReconciler) validateImagePullSecrets() ([]string, error) {
+	var messages []string
+	for _, sr := range r.Configuration.Jenkins.Spec.Master.ImagePullSecrets {
+		msg, err := r.validateImagePullSecret(sr.Name)
+		if err != nil {
+			return nil, err
+		}
+		if len(msg) > 0 {
+			messages = append(messages, msg...)
+		}
+	}
+	return messages, nil
+}
+
+func (r *JenkinsBaseConfigurationReconciler) validateImagePullSecret(secretName string) ([]string, error) {
+	var messages []string
+	secret := &corev1.Secret{}
+	err := r.Client.Get(context.TODO(), types.NamespacedName{Name: secretName, Namespace: r.Configuration.Jenkins.ObjectMeta.Namespace}, secret)
+	if err != nil && apierrors.IsNotFound(err) {
+		messages = append(messages, fmt.Sprintf("Secret %s not found defined in spec.master.imagePullSecrets", secretName))
+	} else if err != nil && !apierrors.IsNotFound(err) {
+		return nil, stackerr.WithStack(err)
+	}
+
+	if secret.Data["docker-server"] == nil {
+		messages = append(messages, fmt.Sprintf("Secret '%s' defined in spec.master.imagePullSecrets doesn't have 'docker-server' key.", secretName))
+	}
+	if secret.Data["docker-username"] == nil {
+		messages = append(messages, fmt.Sprintf("Secret '%s' defined in spec.master.imagePullSecrets doesn't have 'docker-username' key.", secretName))
+	}
+	if secret.Data["docker-password"] == nil {
+		messages = append(messages, fmt.Sprintf("Secret '%s' defined in spec.master.imagePullSecrets doesn't have 'docker-password' key.", secretName))
+	}
+	if secret.Data["docker-email"] == nil {
+		messages = append(messages, fmt.Sprintf("Secret '%s' defined in spec.master.imagePullSecrets doesn't have 'docker-email' key.", secretName))
+	}
+
+	return messages, nil
+}
+
+func (r *JenkinsBaseConfigurationReconciler) validateCACertsSecret() ([]string, error) {
+	caCertsSecretRef := r.Configuration.Jenkins.Spec.Master.CACertsSecretRef
+	if caCertsSecretRef == nil {
+		return nil, nil
+	}
+	if len(caCertsSecretRef.Name) == 0 {
+		return []string{"spec.master.caCertsSecretRef secret name can't be empty"}, nil
+	}
+
+	secret := &corev1.Secret{}
+	err := r.Client.Get(context.TODO(), types.NamespacedName{Name: caCertsSecretRef.Name, Namespace: r.Configuration.Jenkins.ObjectMeta.Namespace}, secret)
+	if err != nil && apierrors.IsNotFound(err) {
+		return []string{fmt.Sprintf("Secret '%s' defined in spec.master.caCertsSecretRef not found", caCertsSecretRef.Name)}, nil
+	} else if err != nil {
+		return nil, stackerr.WithStack(err)
+	}
+	if len(secret.Data) == 0 {
+		return []string{fmt.Sprintf("Secret '%s' defined in spec.master.caCertsSecretRef doesn't have any certificate", caCertsSecretRef.Name)}, nil
+	}
+
+	var messages []string
+	for key, value := range secret.Data {
+		if block, _ := pem.Decode(value); block == nil || block.Type != "CERTIFICATE" {
+			messages = append(messages, fmt.Sprintf("Secret '%s' defined in spec.master.caCertsSecretRef key '%s' doesn't have any PEM encoded certificate", caCertsSecretRef.Name, key))
+		}
+	}
+	sort.Strings(messages)
+
+	return messages, nil
+}
+
+// validateContextPath checks that Jenkins is served under the context path the operator uses to build the Jenkins URLs,
+// the probes and the operator API calls fail when Jenkins isn't started with the matching --prefix option
+func (r *JenkinsBaseConfigurationReconciler) validateContextPath() []string {
+	jenkins := r.Configuration.Jenkins
+	if len(jenkins.Spec.Master.ContextPath) == 0 || len(jenkins.Spec.Master.Containers) == 0 {
+		return nil
+	}
+
+	contextPath := resources.GetJenkinsContextPath(jenkins)
+	prefix, ok := resources.GetJenkinsOpts(*jenkins)["prefix"]
+	if !ok {
+		return []string{fmt.Sprintf("spec.master.contextPath '%s' requires the JENKINS_OPTS --prefix=%s option of the Jenkins master container", jenkins.Spec.Master.ContextPath, contextPath)}
+	}
+	if "/"+strings.Trim(prefix, "/") != contextPath {
+		return []string{fmt.Sprintf("spec.master.contextPath '%s' doesn't match the JENKINS_OPTS --prefix '%s'", jenkins.Spec.Master.ContextPath, prefix)}
+	}
+	return nil
+}
+
+func (r *JenkinsBaseConfigurationReconciler) validateAgent() ([]string, error) {
+	agent := r.Configuration.Jenkins.Spec.Master.Agent
+	if agent == nil || agent.JNLPSecretRef == nil {
+		return nil, nil
+	}
+
+	jnlpSecretRef := agent.JNLPSecretRef
+	if len(jnlpSecretRef.Name) == 0 || len(jnlpSecretRef.Key) == 0 {
+		return []string{"spec.master.agent.jnlpSecretRef secret name and key can't be empty"}, nil
+	}
+
+	secret := &corev1.Secret{}
+	err := r.Client.Get(context.TODO(), types.NamespacedName{Name: jnlpSecretRef.Name, Namespace: r.Configuration.Jenkins.ObjectMeta.Namespace}, secret)
+	if err != nil && apierrors.IsNotFound(err) {
+		return []string{fmt.Sprintf("Secret '%s' defined in spec.master.agent.jnlpSecretRef not found", jnlpSecretRef.Name)}, nil
+	} else if err != nil {
+		return nil, stackerr.WithStack(err)
+	}
+
+	if len(secret.Data[jnlpSecretRef.Key]) == 0 {
+		return []string{fmt.Sprintf("Secret '%s' defined in spec.master.agent.jnlpSecretRef doesn't have '%s' key", jnlpSecretRef.Name, jnlpSecretRef.Key)}, nil
+	}
+
+	return nil, nil
+}
+
+func (r *JenkinsBaseConfigurationReconciler) validateLDAP() ([]string, error) {
+	ldap := r.Configuration.Jenkins.Spec.Master.LDAP
+	if ldap == nil {
+		return nil, nil
+	}
+
+	var messages []string
+	if r.Configuration.Jenkins.Spec.JenkinsAPISettings.AuthorizationStrategy != v1alpha2.CreateUserAuthorizationStrategy {
+		messages = append(messages, fmt.Sprintf("spec.master.ldap requires '%s' spec.jenkinsAPISettings.authorizationStrategy, the operator authenticates with the API token of its own user",
+			v1alpha2.CreateUserAuthorizationStrategy))
+	}
+	server, err := url.Parse(ldap.Server)
+	if err != nil || (server.Scheme != "ldap" && server.Scheme != "ldaps") || len(server.Host) == 0 {
+		messages = append(messages, fmt.Sprintf("spec.master.ldap.server '%s' must be a valid ldap or ldaps URL", ldap.Server))
+	}
+	if len(ldap.ManagerDN) > 0 && ldap.ManagerDNSecretRef == nil {
+		messages = append(messages, "spec.master.ldap.managerDNSecretRef must be set when spec.master.ldap.managerDN is set")
+	}
+	if ldap.ManagerDNSecretRef != nil {
+		if len(ldap.ManagerDN) == 0 {
+			messages = append(messages, "spec.master.ldap.managerDN must be set when spec.master.ldap.managerDNSecretRef is set")
+		}
+		msg, err := r.validateSecretKeySelector(*ldap.ManagerDNSecretRef, "spec.master.ldap.managerDNSecretRef")
+		if err != nil {
+			return nil, err
+		}
+		messages = append(messages, msg...)
+	}
+	return messages, nil
+}
+
+func (r *JenkinsBaseConfigurationReconciler) validateOIDC() ([]string, error) {
+	oidc := r.Configuration.Jenkins.Spec.Master.OIDC
+	if oidc == nil {
+		return nil, nil
+	}
+
+	var messages []string
+	if r.Configuration.Jenkins.Spec.Master.LDAP != nil {
+		messages = append(messages, "spec.master.oidc and spec.master.ldap can't be used together")
+	}
+	if r.Configuration.Jenkins.Spec.JenkinsAPISettings.AuthorizationStrategy != v1alpha2.CreateUserAuthorizationStrategy {
+		messages = append(messages, fmt.Sprintf("spec.master.oidc requires '%s' spec.jenkinsAPISettings.authorizationStrategy, the operator authenticates with the API token of its own user",
+			v1alpha2.CreateUserAuthorizationStrategy))
+	}
+	issuer, err := url.ParseRequestURI(oidc.Issuer)
+	if err != nil || issuer.Scheme != "https" || len(issuer.Host) == 0 {
+		messages = append(messages, fmt.Sprintf("spec.master.oidc.issuer '%s' must be a valid https URL", oidc.Issuer))
+	}
+	if len(oidc.ClientID) == 0 {
+		messages = append(messages, "spec.master.oidc.clientId can't be empty")
+	}
+	msg, err := r.validateSecretKeySelector(oidc.ClientSecretRef, "spec.master.oidc.clientSecretRef")
+	if err != nil {
+		return nil, err
+	}
+	return append(messages, msg...), nil
+}
+
+// validateSecretKeySelector checks if the selected Secret exists in the Jenkins namespace and has the selected key
+func (r *JenkinsBaseConfigurationReconciler) validateSecretKeySelector(secretRef v1alpha2.SecretKeySelector, path string) ([]string, error) {
+	if len(secretRef.Name) == 0 || len(secretRef.Key) == 0 {
+		return []string{fmt.Sprintf("%s secret name and key can't be empty", path)}, nil
+	}
+
+	secret := &corev1.Secret{}
+	err := r.Client.Get(context.TODO(), types.NamespacedName{Name: secretRef.Name, Namespace: r.Configuration.Jenkins.ObjectMeta.Namespace}, secret)
+	if err != nil && apierrors.IsNotFound(err) {
+		return []string{fmt.Sprintf("Secret '%s' defined in %s not found", secretRef.Name, path)}, nil
+	} else if err != nil {
+		return nil, stackerr.WithStack(err)
+	}
+
+	if len(secret.Data[secretRef.Key]) == 0 {
+		return []string{fmt.Sprintf("Secret '%s' defined in %s doesn't have '%s' key", secretRef.Name, path, secretRef.Key)}, nil
+	}
+	return nil, nil
+}
+
+func (r *JenkinsBaseConfigurationReconciler) validateExtraInitContainers() []string {
+	var messages []string
+	names := map[string]bool{}
+	for _, container := range r.Configuration.Jenkins.Spec.Master.Containers {
+		names[container.Name] = true
+	}
+	for i, initContainer := range r.Configuration.Jenkins.Spec.Master.ExtraInitContainers {
+		if len(initContainer.Name) == 0 {
+			messages = append(messages, fmt.Sprintf("spec.master.extraInitContainers[%d].name can't be empty", i))
+			continue
+		}
+		if names[initContainer.Name] {
+			messages = append(messages, fmt.Sprintf("spec.master.extraInitContainers name '%s' is already used by another container", initContainer.Name))
+		}
+		names[initContainer.Name] = true
+	}
+	return messages
+}
+
+func (r *JenkinsBaseConfigurationReconciler) validateReplicas() []string {
+	replicas := r.Configuration.Jenkins.Spec.Master.Replicas
+	if replicas == nil {
+		return nil
+	}
+
+	var messages []string
+	if !useDeploymentForJenkinsMaster(r.Configuration.Jenkins) {
+		messages = append(messages, "spec.master.replicas is supported only with the jenkins.io/use-deployment annotation")
+	}
+	// Jenkins has no active/active mode, many masters on the same Jenkins home corrupt it even on ReadWriteMany storage,
+	// and a Deployment can't keep the extra replicas passive
+	if *replicas != 0 && *replicas != 1 {
+		messages = append(messages, fmt.Sprintf("spec.master.replicas '%d' is invalid, it must be 0 or 1", *replicas))
+	}
+	return messages
+}
+
+// validateDeepReadiness checks that the operator user queried by the deep readiness probe exists, it's created only
+// with the createUser authorization strategy
+func (r *JenkinsBaseConfigurationReconciler) validateDeepReadiness() []string {
+	jenkins := r.Configuration.Jenkins
+	if jenkins.Spec.Master.DeepReadiness && jenkins.Spec.JenkinsAPISettings.AuthorizationStrategy != v1alpha2.CreateUserAuthorizationStrategy {
+		return []string{fmt.Sprintf("spec.master.deepReadiness requires the '%s' spec.jenkinsAPISettings.authorizationStrategy", v1alpha2.CreateUserAuthorizationStrategy)}
+	}
+	return nil
+}
+
+func (r *JenkinsBaseConfigurationReconciler) validateLocation() []string {
+	var messages []string
+	master := r.Configuration.Jenkins.Spec.Master
+	if len(master.JenkinsURL) > 0 {
+		jenkinsURL, err := url.ParseRequestURI(master.JenkinsURL)
+		if err != nil || (jenkinsURL.Scheme != "http" && jenkinsURL.Scheme != "https") || len(jenkinsURL.Host) == 0 {
+			messages = append(messages, fmt.Sprintf("spec.master.jenkinsURL '%s' must be a valid http or https URL", master.JenkinsURL))
+		}
+	}
+	if len(master.AdminEmail) > 0 {
+		if _, err := mail.ParseAddress(master.AdminEmail); err != nil {
+			messages = append(messages, fmt.Sprintf("spec.master.adminEmail '%s' must be a valid email address", master.AdminEmail))
+		}
+	}
+	return messages
+}
+
+func (r *JenkinsBaseConfigurationReconciler) validateAuthorization() []string {
+	authorization := r.Configuration.Jenkins.Spec.Master.Authorization
+	if authorization == nil {
+		return nil
+	}
+
+	switch authorization.Strategy {
+	case v1alpha2.LoggedInUsersCanDoAnythingAuthorizationStrategyName:
+		if len(authorization.Grants) > 0 {
+			return []string{fmt.Sprintf("spec.master.authorization.grants can't be used with the '%s' strategy", authorization.Strategy)}
+		}
+		return nil
+	case v1alpha2.GlobalMatrixAuthorizationStrategyName, v1alpha2.ProjectMatrixAuthorizationStrategyName:
+	default:
+		return []string{fmt.Sprintf("unrecognized '%s' spec.master.authorization.strategy, supported strategies are: %s, %s, %s", authorization.Strategy,
+			v1alpha2.LoggedInUsersCanDoAnythingAuthorizationStrategyName, v1alpha2.GlobalMatrixAuthorizationStrategyName, v1alpha2.ProjectMatrixAuthorizationStrategyName)}
+	}
+
+	var messages []string
+	for i, grant := range authorization.Grants {
+		if (len(grant.User) == 0) == (len(grant.Group) == 0) {
+			messages = append(messages, fmt.Sprintf("spec.master.authorization.grants[%d] must have either user or group", i))
+		}
+		if len(grant.Permissions) == 0 {
+			messages = append(messages, fmt.Sprintf("spec.master.authorization.grants[%d].permissions can't be empty", i))
+		}
+		for _, permission := range grant.Permissions {
+			if !authorizationPermissionRegexp.MatchString(permission) {
+				messages = append(messages, fmt.Sprintf("spec.master.authorization.grants[%d] permission '%s' must be in the Group/Name format, e.g. Job/Build", i, permission))
+			}
+		}
+	}
+	return messages
+}
+
+func (r *JenkinsBaseConfigurationReconciler) validateBuildDiscarder() []string {
+	buildDiscarder := r.Configuration.Jenkins.Spec.Master.BuildDiscarder
+	if buildDiscarder == nil {
+		return nil
+	}
+
+	var messages []string
+	if buildDiscarder.DaysToKeep != nil && *buildDiscarder.DaysToKeep < 0 {
+		messages = append(messages, fmt.Sprintf("spec.master.buildDiscarder.daysToKeep '%d' can't be negative", *buildDiscarder.DaysToKeep))
+	}
+	if buildDiscarder.NumToKeep != nil && *buildDiscarder.NumToKeep < 0 {
+		messages = append(messages, fmt.Sprintf("spec.master.buildDiscarder.numToKeep '%d' can't be negative", *buildDiscarder.NumToKeep))
+	}
+	return messages
+}
+
+func (r *JenkinsBaseConfigurationReconciler) validateBuildDefaults() []string {
+	master := r.Configuration.Jenkins.Spec.Master
+	var messages []string
+	if master.QuietPeriod != nil && *master.QuietPeriod < 0 {
+		messages = append(messages, fmt.Sprintf("spec.master.quietPeriod '%d' can't be negative", *master.QuietPeriod))
+	}
+	if master.ScmCheckoutRetryCount != nil && *master.ScmCheckoutRetryCount < 0 {
+		messages = append(messages, fmt.Sprintf("spec.master.scmCheckoutRetryCount '%d' can't be negative", *master.ScmCheckoutRetryCount))
+	}
+	return messages
+}
+
+func (r *JenkinsBaseConfigurationReconciler) validateDependsOn() []string {
+	var messages []string
+	names := map[string]bool{}
+	for i, dependency := range r.Configuration.Jenkins.Spec.DependsOn {
+		if len(dependency.Name) == 0 {
+			messages = append(messages, fmt.Sprintf("spec.dependsOn[%d].name can't be empty", i))
+		} else if names[dependency.Name] {
+			messages = append(messages, fmt.Sprintf("spec.dependsOn has duplicated Service '%s'", dependency.Name))
+		}
+		names[dependency.Name] = true
+	}
+	return messages
+}
+
+func (r *JenkinsBaseConfigurationReconciler) validateAuditLog() []string {
+	auditLog := r.Configuration.Jenkins.Spec.AuditLog
+	if auditLog != nil && auditLog.MaxEntries < 0 {
+		return []string{fmt.Sprintf("spec.auditLog.maxEntries '%d' can't be negative", auditLog.MaxEntries)}
+	}
+	return nil
+}
+
+func (r *JenkinsBaseConfigurationReconciler) validateAPIClient() []string {
+	master := r.Configuration.Jenkins.Spec.Master
+
+	var messages []string
+	if master.APIClientTimeout != nil && master.APIClientTimeout.Duration <= 0 {
+		messages = append(messages, fmt.Sprintf("spec.master.apiClientTimeout '%s' must be positive", master.APIClientTimeout.Duration))
+	}
+	if master.APIClientRetries < 0 {
+		messages = append(messages, fmt.Sprintf("spec.master.apiClientRetries '%d' can't be negative", master.APIClientRetries))
+	}
+	return messages
+}
+
+func (r *JenkinsBaseConfigurationReconciler) validateThemeCSS() []string {
+	themeCSS := r.Configuration.Jenkins.Spec.Master.ThemeCSS
+	if len(themeCSS) == 0 {
+		return nil
+	}
+
+	themeCSSURL, err := url.ParseRequestURI(themeCSS)
+	if err != nil || (themeCSSURL.Scheme != "http" && themeCSSURL.Scheme != "https") || len(themeCSSURL.Host) == 0 {
+		return []string{fmt.Sprintf("spec.master.themeCSS '%s' must be a valid http or https URL", themeCSS)}
+	}
+	return nil
+}
+
+func (r *JenkinsBaseConfigurationReconciler) validateBranding() []string {
+	branding := r.Configuration.Jenkins.Spec.Master.Branding
+	if branding == nil || len(branding.LogoURL) == 0 {
+		return nil
+	}
+
+	logoURL, err := url.ParseRequestURI(branding.LogoURL)
+	if err != nil || (logoURL.Scheme != "http" && logoURL.Scheme != "https") || len(logoURL.Host) == 0 {
+		return []string{fmt.Sprintf("spec.master.branding.logoURL '%s' must be a valid http or https URL", branding.LogoURL)}
+	}
+	return nil
+}
+
+// validateMarkupFormatter checks that the plugin of the rawHtml markup formatter is installed, the operator doesn't add it
+// to the base plugins since it allows any HTML including scripts
+func (r *JenkinsBaseConfigurationReconciler) validateMarkupFormatter() []string {
+	master := r.Configuration.Jenkins.Spec.Master
+	if master.MarkupFormatter != v1alpha2.RawHTMLMarkupFormatterName {
+		return nil
+	}
+	for _, plugin := range append(append([]v1alpha2.Plugin{}, master.BasePlugins...), master.Plugins...) {
+		if plugin.Name == plugins.AnythingGoesFormatterPluginName {
+			return nil
+		}
+	}
+	return []string{fmt.Sprintf("spec.master.markupFormatter '%s' requires the '%s' plugin in spec.master.plugins", master.MarkupFormatter, plugins.AnythingGoesFormatterPluginName)}
+}
+
+func (r *JenkinsBaseConfigurationReconciler) validateViews() []string {
+	var messages []string
+	names := map[string]bool{"all": true, "seed-jobs": true, "non-seed-jobs": true}
+	for i, view := range r.Configuration.Jenkins.Spec.Views {
+		if len(view.Name) == 0 {
+			messages = append(messages, fmt.Sprintf("spec.views[%d].name can't be empty", i))
+			continue
+		}
+		if names[view.Name] {
+			messages = append(messages, fmt.Sprintf("spec.views name '%s' is duplicated or reserved", view.Name))
+		}
+		names[view.Name] = true
+	}
+	return messages
+}
+
+func (r *JenkinsBaseConfigurationReconciler) validateGlobalPipelineLibraries() []string {
+	var messages []string
+	names := map[string]bool{}
+	for i, library := range r.Configuration.Jenkins.Spec.GlobalPipelineLibraries {
+		if len(library.Name) == 0 {
+			messages = append(messages, fmt.Sprintf("spec.globalPipelineLibraries[%d].name can't be empty", i))
+		} else if names[library.Name] {
+			messages = append(messages, fmt.Sprintf("spec.globalPipelineLibraries has duplicated library name '%s'", library.Name))
+		}
+		names[library.Name] = true
+
+		if len(library.RepositoryURL) == 0 {
+			messages = append(messages, fmt.Sprintf("spec.globalPipelineLibraries[%d].repositoryUrl can't be empty", i))
+		}
+		if library.Implicit && len(library.DefaultVersion) == 0 {
+			messages = append(messages, fmt.Sprintf("spec.globalPipelineLibraries[%d].defaultVersion is required for the implicitly loaded library", i))
+		}
+	}
+	return messages
+}
+
+func (r *JenkinsBaseConfigurationReconciler) validatePermanentAgents() []string {
+	var messages []string
+	names := map[string]bool{}
+	for i, agent := range r.Configuration.Jenkins.Spec.PermanentAgents {
+		if len(agent.Name) == 0 {
+			messages = append(messages, fmt.Sprintf("spec.permanentAgents[%d].name can't be empty", i))
+		} else if names[agent.Name] {
+			messages = append(messages, fmt.Sprintf("spec.permanentAgents has duplicated agent name '%s'", agent.Name))
+		}
+		names[agent.Name] = true
+
+		if net.ParseIP(agent.Host) == nil && len(validation.IsDNS1123Subdomain(agent.Host)) > 0 {
+			messages = append(messages, fmt.Sprintf("spec.permanentAgents[%d].host '%s' must be a valid host name or IP address", i, agent.Host))
+		}
+		if agent.Port != nil && (*agent.Port <= 0 || *agent.Port > 65535) {
+			messages = append(messages, fmt.Sprintf("spec.permanentAgents[%d].port '%d' must be between 1 and 65535", i, *agent.Port))
+		}
+		if len(agent.CredentialID) == 0 {
+			messages = append(messages, fmt.Sprintf("spec.permanentAgents[%d].credentialID can't be empty", i))
+		}
+		if agent.NumExecutors != nil && *agent.NumExecutors < 0 {
+			messages = append(messages, fmt.Sprintf("spec.permanentAgents[%d].numExecutors '%d' can't be negative", i, *agent.NumExecutors))
+		}
+		for _, label := range agent.Labels {
+			if !nodeLabelRegexp.MatchString(label) {
+				messages = append(messages, fmt.Sprintf("spec.permanentAgents[%d].labels label '%s' is invalid, it can't be empty or contain whitespaces and the label expression operators", i, label))
+			}
+		}
+		messages = append(messages, validatePermanentAgentHostKey(i, agent)...)
+	}
+	return messages
+}
+
+func validatePermanentAgentHostKey(i int, agent v1alpha2.PermanentAgent) []string {
+	switch agent.HostKeyVerificationStrategy {
+	case "", v1alpha2.SSHHostKeyVerificationStrategyManuallyProvided:
+		if len(agent.HostKey) == 0 {
+			return []string{fmt.Sprintf("spec.permanentAgents[%d].hostKey is required by the '%s' host key verification strategy", i, v1alpha2.SSHHostKeyVerificationStrategyManuallyProvided)}
+		}
+		if _, _, _, _, err := ssh.ParseAuthorizedKey([]byte(agent.HostKey)); err != nil {
+			return []string{fmt.Sprintf("spec.permanentAgents[%d].hostKey is invalid, it must be a public key in the OpenSSH format: %s", i, err)}
+		}
+	case v1alpha2.SSHHostKeyVerificationStrategyKnownHosts, v1alpha2.SSHHostKeyVerificationStrategyManuallyTrusted:
+		if len(agent.HostKey) > 0 {
+			return []string{fmt.Sprintf("spec.permanentAgents[%d].hostKey can be set only with the '%s' host key verification strategy", i, v1alpha2.SSHHostKeyVerificationStrategyManuallyProvided)}
+		}
+	default:
+		return []string{fmt.Sprintf("spec.permanentAgents[%d].hostKeyVerificationStrategy '%s' is invalid, it must be one of: %s, %s, %s", i, agent.HostKeyVerificationStrategy,
+			v1alpha2.SSHHostKeyVerificationStrategyManuallyProvided, v1alpha2.SSHHostKeyVerificationStrategyKnownHosts, v1alpha2.SSHHostKeyVerificationStrategyManuallyTrusted)}
+	}
+	return nil
+}
+
+func (r *JenkinsBaseConfigurationReconciler) validateToolConfig() []string {
+	toolConfig := r.Configuration.Jenkins.Spec.ToolConfig
+	if toolConfig == nil {
+		return nil
+	}
+
+	var messages []string
+	messages = append(messages, validateToolInstallations(toolConfig.JDKs, "spec.toolConfig.jdks")...)
+	messages = append(messages, validateToolInstallations(toolConfig.Maven, "spec.toolConfig.maven")...)
+	messages = append(messages, validateToolInstallations(toolConfig.Gradle, "spec.toolConfig.gradle")...)
+	return messages
+}
+
+func validateToolInstallations(installations []v1alpha2.ToolInstallation, path string) []string {
+	var messages []string
+	names := map[string]bool{}
+	for i, installation := range installations {
+		if len(installation.Name) == 0 {
+			messages = append(messages, fmt.Sprintf("%s[%d].name can't be empty", path, i))
+		} else if names[installation.Name] {
+			messages = append(messages, fmt.Sprintf("%s has duplicated installation name '%s'", path, installation.Name))
+		}
+		names[installation.Name] = true
+
+		if len(installation.Home) == 0 && installation.Installer == nil {
+			messages = append(messages, fmt.Sprintf("%s[%d] home or installer must be set", path, i))
+		}
+		if installation.Installer == nil {
+			continue
+		}
+		installerURL, err := url.ParseRequestURI(installation.Installer.URL)
+		if err != nil || (installerURL.Scheme != "http" && installerURL.Scheme != "https") || len(installerURL.Host) == 0 {
+			messages = append(messages, fmt.Sprintf("%s[%d].installer.url '%s' must be a valid http or https URL", path, i, installation.Installer.URL))
+		}
+	}
+	return messages
+}
+
+func (r *JenkinsBaseConfigurationReconciler) validateAgentProtocols() []string {
+	var messages []string
+	for _, protocol := range r.Configuration.Jenkins.Spec.Master.AgentProtocols {
+		known := false
+		for _, knownProtocol := range resources.KnownAgentProtocols {
+			if protocol == knownProtocol {
+				known = true
+			}
+		}
+		if !known {
+			messages = append(messages, fmt.Sprintf("spec.master.agentProtocols '%s' is unknown, supported protocols are: %s",
+				protocol, strings.Join(resources.KnownAgentProtocols, ", ")))
+		}
 	}
+	return messages
+}
 
-	if jenkins.Spec.JenkinsAPISettings.AuthorizationStrategy != v1alpha2.CreateUserAuthorizationStrategy && jenkins.Spec.JenkinsAPISettings.AuthorizationStrategy != v1alpha2.ServiceAccountAuthorizationStrategy {
-		messages = append(messages, fmt.Sprintf("unrecognized '%s' spec.jenkinsAPISettings.authorizationStrategy", jenkins.Spec.JenkinsAPISettings.AuthorizationStrategy))
+func (r *JenkinsBaseConfigurationReconciler) validateGlobalEnvVars() []string {
+	var messages []string
+	keys := map[string]bool{}
+	for _, envVar := range r.Configuration.Jenkins.Spec.Master.GlobalEnvVars {
+		if !globalEnvVarKeyRegexp.MatchString(envVar.Key) {
+			messages = append(messages, fmt.Sprintf("spec.master.globalEnvVars key '%s' must be a valid identifier", envVar.Key))
+			continue
+		}
+		if keys[envVar.Key] {
+			messages = append(messages, fmt.Sprintf("spec.master.globalEnvVars key '%s' is duplicated", envVar.Key))
+		}
+		keys[envVar.Key] = true
 	}
+	return messages
+}
 
-	return messages, nil
+func (r *JenkinsBaseConfigurationReconciler) validateSystemProperties() []string {
+	var messages []string
+	keys := map[string]bool{}
+	for _, property := range r.Configuration.Jenkins.Spec.Master.SystemProperties {
+		if !systemPropertyKeyRegexp.MatchString(property.Key) {
+			messages = append(messages, fmt.Sprintf("spec.master.systemProperties key '%s' is invalid, it can contain only letters, digits and '_.$-' characters", property.Key))
+			continue
+		}
+		if keys[property.Key] {
+			messages = append(messages, fmt.Sprintf("spec.master.systemProperties key '%s' is duplicated", property.Key))
+		}
+		keys[property.Key] = true
+	}
+	return messages
 }
 
-func (r *JenkinsBaseConfigurationReconciler) validateJenkinsMasterContainerCommand() []string {
-	masterContainer := r.Configuration.GetJenkinsMasterContainer()
-	if masterContainer == nil {
-		return []string{}
+func (r *JenkinsBaseConfigurationReconciler) validateNodeLabels() []string {
+	var messages []string
+	labels := map[string]bool{}
+	for _, label := range r.Configuration.Jenkins.Spec.Master.NodeLabels {
+		if !nodeLabelRegexp.MatchString(label) {
+			messages = append(messages, fmt.Sprintf("spec.master.nodeLabels label '%s' is invalid, it can't be empty or contain whitespaces and the label expression operators", label))
+			continue
+		}
+		if labels[label] {
+			messages = append(messages, fmt.Sprintf("spec.master.nodeLabels label '%s' is duplicated", label))
+		}
+		labels[label] = true
 	}
+	return messages
+}
 
-	jenkinsOperatorInitScript := fmt.Sprintf("%s/%s && ", resources.JenkinsScriptsVolumePath, resources.InitScriptName)
-	correctCommand := []string{
-		"bash",
-		"-c",
-		fmt.Sprintf("%s<optional-custom-command> && exec <command-which-start-jenkins>", jenkinsOperatorInitScript),
+func (r *JenkinsBaseConfigurationReconciler) validatePluginInstallation() []string {
+	var messages []string
+	if batchSize := r.Configuration.Jenkins.Spec.Master.PluginInstallBatchSize; batchSize < 0 {
+		messages = append(messages, fmt.Sprintf("spec.master.pluginInstallBatchSize '%d' can't be negative", batchSize))
 	}
-	invalidCommandMessage := []string{fmt.Sprintf("spec.master.containers[%s].command is invalid, make sure it looks like '%v', otherwise the operator won't configure default user and install plugins. 'exec' is required to propagate signals to the Jenkins.", masterContainer.Name, correctCommand)}
-	if len(masterContainer.Command) != 3 {
-		return invalidCommandMessage
+	switch channel := r.Configuration.Jenkins.Spec.Master.UpdateCenterChannel; channel {
+	case "", v1alpha2.UpdateCenterChannelStable, v1alpha2.UpdateCenterChannelExperimental:
+	default:
+		messages = append(messages, fmt.Sprintf("spec.master.updateCenterChannel '%s' is invalid, it must be one of: %s, %s",
+			channel, v1alpha2.UpdateCenterChannelStable, v1alpha2.UpdateCenterChannelExperimental))
 	}
-	if masterContainer.Command[0] != correctCommand[0] {
-		return invalidCommandMessage
+	if retries := r.Configuration.Jenkins.Spec.Master.PluginInstallRetries; retries < 0 {
+		messages = append(messages, fmt.Sprintf("spec.master.pluginInstallRetries '%d' can't be negative", retries))
 	}
-	if masterContainer.Command[1] != correctCommand[1] {
-		return invalidCommandMessage
+	if delay := r.Configuration.Jenkins.Spec.Master.PluginInstallRetryDelay; delay != nil && delay.Duration < time.Second {
+		messages = append(messages, fmt.Sprintf("spec.master.pluginInstallRetryDelay '%s' must be at least 1s", delay.Duration))
 	}
-	if !strings.HasPrefix(masterContainer.Command[2], jenkinsOperatorInitScript) {
-		return invalidCommandMessage
+	ids := map[string]bool{}
+	for i, site := range r.Configuration.Jenkins.Spec.Master.PluginManagerSites {
+		if len(site.ID) == 0 {
+			messages = append(messages, fmt.Sprintf("spec.master.pluginManagerSites[%d].id can't be empty", i))
+		} else if ids[site.ID] {
+			messages = append(messages, fmt.Sprintf("spec.master.pluginManagerSites has duplicated site '%s'", site.ID))
+		}
+		ids[site.ID] = true
+		siteURL, err := url.ParseRequestURI(site.URL)
+		if err != nil || (siteURL.Scheme != "http" && siteURL.Scheme != "https") || len(siteURL.Host) == 0 {
+			messages = append(messages, fmt.Sprintf("spec.master.pluginManagerSites[%d].url '%s' must be a valid http or https URL", i, site.URL))
+		}
 	}
-	if !strings.Contains(masterContainer.Command[2], "exec") {
-		return invalidCommandMessage
+	if timeout := r.Configuration.Jenkins.Spec.Master.InitLockTimeout; timeout != nil && timeout.Duration < time.Second {
+		messages = append(messages, fmt.Sprintf("spec.master.initLockTimeout '%s' must be at least 1s", timeout.Duration))
+	}
+	if resources.IsPluginInstallJobEnabled(r.Configuration.Jenkins) {
+		if r.Configuration.Jenkins.Spec.Master.JenkinsHomeStorage == nil {
+			messages = append(messages, "spec.master.pluginInstallMode 'job' requires spec.master.jenkinsHomeStorage")
+		}
+		if r.Configuration.Jenkins.Spec.Master.PluginCacheVolume != nil {
+			messages = append(messages, "spec.master.pluginInstallMode 'job' can't be used with spec.master.pluginCacheVolume")
+		}
 	}
 
-	return []string{}
+	proxy := r.Configuration.Jenkins.Spec.Master.PluginProxy
+	if proxy == nil {
+		return messages
+	}
+
+	if _, err := resources.ParsePluginProxyURL(proxy.URL); err != nil {
+		messages = append(messages, fmt.Sprintf("spec.master.pluginProxy.url '%s' is invalid: %s", proxy.URL, err))
+	}
+	for _, noProxy := range proxy.NoProxy {
+		if len(noProxy) == 0 || strings.ContainsAny(noProxy, " \t\n,|") {
+			messages = append(messages, fmt.Sprintf("spec.master.pluginProxy.noProxy '%s' is invalid, it must be a host or a domain starting with a dot", noProxy))
+		}
+	}
+	if proxy.CredentialsSecretRef != nil && len(proxy.CredentialsSecretRef.Name) == 0 {
+		messages = append(messages, "spec.master.pluginProxy.credentialsSecretRef.name can't be empty")
+	}
+	return messages
 }
 
-func (r *JenkinsBaseConfigurationReconciler) validateImagePullSecrets() ([]string, error) {
+func (r *JenkinsBaseConfigurationReconciler) validateJenkinsProxy() []string {
+	proxy := r.Configuration.Jenkins.Spec.Master.JenkinsProxy
+	if proxy == nil {
+		return nil
+	}
+
 	var messages []string
-	for _, sr := range r.Configuration.Jenkins.Spec.Master.ImagePullSecrets {
-		msg, err := r.validateImagePullSecret(sr.Name)
-		if err != nil {
-			return nil, err
+	if net.ParseIP(proxy.Host) == nil && len(validation.IsDNS1123Subdomain(proxy.Host)) > 0 {
+		messages = append(messages, fmt.Sprintf("spec.master.jenkinsProxy.host '%s' must be a valid host name or IP address", proxy.Host))
+	}
+	if proxy.Port <= 0 || proxy.Port > 65535 {
+		messages = append(messages, fmt.Sprintf("spec.master.jenkinsProxy.port '%d' must be between 1 and 65535", proxy.Port))
+	}
+	for _, noProxy := range proxy.NoProxy {
+		if len(noProxy) == 0 || strings.ContainsAny(noProxy, " \t\n") {
+			messages = append(messages, fmt.Sprintf("spec.master.jenkinsProxy.noProxy '%s' can't be empty or contain whitespaces", noProxy))
 		}
-		if len(msg) > 0 {
-			messages = append(messages, msg...)
+	}
+	if proxy.CredentialsSecretRef != nil && len(proxy.CredentialsSecretRef.Name) == 0 {
+		messages = append(messages, "spec.master.jenkinsProxy.credentialsSecretRef.name can't be empty")
+	}
+	return messages
+}
+
+func (r *JenkinsBaseConfigurationReconciler) validateTimeZone() []string {
+	timeZone := r.Configuration.Jenkins.Spec.Master.TimeZone
+	if len(timeZone) == 0 {
+		return nil
+	}
+	if _, err := time.LoadLocation(timeZone); err != nil || timeZone == "Local" {
+		return []string{fmt.Sprintf("spec.master.timeZone '%s' is not a valid IANA time zone name", timeZone)}
+	}
+	return nil
+}
+
+func (r *JenkinsBaseConfigurationReconciler) validateLogRecorders() []string {
+	var messages []string
+	names := map[string]bool{}
+	for i, logRecorder := range r.Configuration.Jenkins.Spec.Master.LogRecorders {
+		if len(logRecorder.Name) == 0 {
+			messages = append(messages, fmt.Sprintf("spec.master.logRecorders[%d].name can't be empty", i))
+		} else if names[logRecorder.Name] {
+			messages = append(messages, fmt.Sprintf("spec.master.logRecorders has duplicated log recorder name '%s'", logRecorder.Name))
+		}
+		names[logRecorder.Name] = true
+
+		if len(logRecorder.Loggers) == 0 {
+			messages = append(messages, fmt.Sprintf("spec.master.logRecorders[%d].loggers can't be empty", i))
+		}
+		for j, logger := range logRecorder.Loggers {
+			if len(logger.Name) == 0 {
+				messages = append(messages, fmt.Sprintf("spec.master.logRecorders[%d].loggers[%d].name can't be empty", i, j))
+			}
 		}
 	}
-	return messages, nil
+	return messages
 }
 
-func (r *JenkinsBaseConfigurationReconciler) validateImagePullSecret(secretName string) ([]string, error) {
+func (r *JenkinsBaseConfigurationReconciler) validateAgentPodTemplates() []string {
+	agent := r.Configuration.Jenkins.Spec.Master.Agent
+	if agent == nil {
+		return nil
+	}
+
 	var messages []string
-	secret := &corev1.Secret{}
-	err := r.Client.Get(context.TODO(), types.NamespacedName{Name: secretName, Namespace: r.Configuration.Jenkins.ObjectMeta.Namespace}, secret)
-	if err != nil && apierrors.IsNotFound(err) {
-		messages = append(messages, fmt.Sprintf("Secret %s not found defined in spec.master.imagePullSecrets", secretName))
-	} else if err != nil && !apierrors.IsNotFound(err) {
-		return nil, stackerr.WithStack(err)
+	if agent.IdleMinutes != nil && *agent.IdleMinutes <= 0 {
+		messages = append(messages, fmt.Sprintf("spec.master.agent.idleMinutes '%d' must be positive", *agent.IdleMinutes))
+	}
+	if agent.ConnectTimeout != nil && *agent.ConnectTimeout <= 0 {
+		messages = append(messages, fmt.Sprintf("spec.master.agent.connectTimeout '%d' must be positive", *agent.ConnectTimeout))
+	}
+	if agent.ContainerCap != nil && *agent.ContainerCap <= 0 {
+		messages = append(messages, fmt.Sprintf("spec.master.agent.containerCap '%d' must be positive", *agent.ContainerCap))
+	}
+	if len(agent.Image) > 0 && !dockerImageRegexp.MatchString(agent.Image) && !docker.ReferenceRegexp.MatchString(agent.Image) {
+		messages = append(messages, fmt.Sprintf("spec.master.agent.image '%s' is invalid", agent.Image))
+	}
+	switch agent.ImagePullPolicy {
+	case "", corev1.PullAlways, corev1.PullIfNotPresent, corev1.PullNever:
+	default:
+		messages = append(messages, fmt.Sprintf("spec.master.agent.imagePullPolicy '%s' is invalid, supported values are: %s, %s, %s",
+			agent.ImagePullPolicy, corev1.PullAlways, corev1.PullIfNotPresent, corev1.PullNever))
+	}
+	if len(agent.JenkinsURL) > 0 {
+		jenkinsURL, err := url.ParseRequestURI(agent.JenkinsURL)
+		if err != nil || (jenkinsURL.Scheme != "http" && jenkinsURL.Scheme != "https") || len(jenkinsURL.Host) == 0 ||
+			strings.ContainsAny(agent.JenkinsURL, `"$\`) {
+			messages = append(messages, fmt.Sprintf("spec.master.agent.jenkinsUrl '%s' must be a valid http or https URL", agent.JenkinsURL))
+		}
+	}
+	if interval := agent.RemoveOfflineNodesInterval; interval != nil && interval.Duration < time.Second {
+		messages = append(messages, fmt.Sprintf("spec.master.agent.removeOfflineNodesInterval '%s' must be at least 1s", interval.Duration))
+	}
+	if len(agent.JenkinsTunnel) > 0 && !isValidHostPort(agent.JenkinsTunnel) {
+		messages = append(messages, fmt.Sprintf("spec.master.agent.jenkinsTunnel '%s' must be in the host:port format", agent.JenkinsTunnel))
+	}
+	names := map[string]bool{}
+	for i, podTemplate := range agent.PodTemplates {
+		if podTemplate.IdleMinutes != nil && *podTemplate.IdleMinutes <= 0 {
+			messages = append(messages, fmt.Sprintf("spec.master.agent.podTemplates[%d].idleMinutes '%d' must be positive", i, *podTemplate.IdleMinutes))
+		}
+		if podTemplate.InstanceCap != nil && *podTemplate.InstanceCap <= 0 {
+			messages = append(messages, fmt.Sprintf("spec.master.agent.podTemplates[%d].instanceCap '%d' must be positive", i, *podTemplate.InstanceCap))
+		}
+		if podTemplate.ActiveDeadlineSeconds != nil && *podTemplate.ActiveDeadlineSeconds <= 0 {
+			messages = append(messages, fmt.Sprintf("spec.master.agent.podTemplates[%d].activeDeadlineSeconds '%d' must be positive", i, *podTemplate.ActiveDeadlineSeconds))
+		}
+		if podTemplate.ConnectTimeout != nil && *podTemplate.ConnectTimeout <= 0 {
+			messages = append(messages, fmt.Sprintf("spec.master.agent.podTemplates[%d].connectTimeout '%d' must be positive", i, *podTemplate.ConnectTimeout))
+		}
+		switch podTemplate.YamlMergeStrategy {
+		case "", v1alpha2.YamlMergeStrategyOverride, v1alpha2.YamlMergeStrategyMerge:
+		default:
+			messages = append(messages, fmt.Sprintf("spec.master.agent.podTemplates[%d].yamlMergeStrategy '%s' is invalid, it must be one of: %s, %s",
+				i, podTemplate.YamlMergeStrategy, v1alpha2.YamlMergeStrategyOverride, v1alpha2.YamlMergeStrategyMerge))
+		}
+		if len(podTemplate.Yaml) > 0 {
+			pod := map[string]interface{}{}
+			if err := yaml.Unmarshal([]byte(podTemplate.Yaml), &pod); err != nil {
+				messages = append(messages, fmt.Sprintf("spec.master.agent.podTemplates[%d].yaml is invalid: %s", i, err))
+			}
+		}
+		if len(podTemplate.Name) == 0 {
+			messages = append(messages, fmt.Sprintf("spec.master.agent.podTemplates[%d].name can't be empty", i))
+		} else if names[podTemplate.Name] {
+			messages = append(messages, fmt.Sprintf("spec.master.agent.podTemplates has duplicated pod template name '%s'", podTemplate.Name))
+		}
+		names[podTemplate.Name] = true
+		for j, imagePullSecret := range podTemplate.ImagePullSecrets {
+			if len(imagePullSecret.Name) == 0 {
+				messages = append(messages, fmt.Sprintf("spec.master.agent.podTemplates[%d].imagePullSecrets[%d].name can't be empty", i, j))
+			}
+		}
+
+		volumeNames := map[string]bool{}
+		for j, volume := range podTemplate.Volumes {
+			path := fmt.Sprintf("spec.master.agent.podTemplates[%d].volumes[%d]", i, j)
+			if len(volume.Name) == 0 {
+				messages = append(messages, fmt.Sprintf("%s.name can't be empty", path))
+			} else if volumeNames[volume.Name] {
+				messages = append(messages, fmt.Sprintf("%s.name '%s' is duplicated", path, volume.Name))
+			}
+			volumeNames[volume.Name] = true
+			if !strings.HasPrefix(volume.MountPath, "/") {
+				messages = append(messages, fmt.Sprintf("%s.mountPath '%s' must be an absolute path", path, volume.MountPath))
+			}
+			if volume.HostPath != nil && !agent.AllowHostPathVolumes {
+				messages = append(messages, fmt.Sprintf("%s hostPath volume requires spec.master.agent.allowHostPathVolumes", path))
+			}
+		}
+
+		if len(podTemplate.RemoteFSRoot) > 0 && !path.IsAbs(podTemplate.RemoteFSRoot) {
+			messages = append(messages, fmt.Sprintf("spec.master.agent.podTemplates[%d].remoteFSRoot '%s' must be an absolute path", i, podTemplate.RemoteFSRoot))
+		}
+		messages = append(messages, validateAgentCommand(podTemplate.Command, fmt.Sprintf("spec.master.agent.podTemplates[%d].command", i))...)
+		messages = append(messages, validateAgentCommand(podTemplate.Args, fmt.Sprintf("spec.master.agent.podTemplates[%d].args", i))...)
+		messages = append(messages, validateAgentResources(podTemplate.ResourceRequestCPU, podTemplate.ResourceLimitCPU, fmt.Sprintf("spec.master.agent.podTemplates[%d]", i), "Cpu")...)
+		messages = append(messages, validateAgentResources(podTemplate.ResourceRequestMemory, podTemplate.ResourceLimitMemory, fmt.Sprintf("spec.master.agent.podTemplates[%d]", i), "Memory")...)
+		if podTemplate.NodeProperties != nil {
+			messages = append(messages, validateAgentNodeProperties(*podTemplate.NodeProperties, fmt.Sprintf("spec.master.agent.podTemplates[%d].nodeProperties", i))...)
+		}
+		if podTemplate.WorkspaceVolume != nil {
+			messages = append(messages, validateAgentWorkspaceVolume(*podTemplate.WorkspaceVolume, fmt.Sprintf("spec.master.agent.podTemplates[%d].workspaceVolume", i))...)
+		}
+
+		messages = append(messages, validateAgentPodMetadata(podTemplate, fmt.Sprintf("spec.master.agent.podTemplates[%d]", i))...)
+
+		var keys []string
+		for key := range podTemplate.NodeSelector {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			value := podTemplate.NodeSelector[key]
+			for _, msg := range validation.IsQualifiedName(key) {
+				messages = append(messages, fmt.Sprintf("spec.master.agent.podTemplates[%d].nodeSelector key '%s' is invalid: %s", i, key, msg))
+			}
+			for _, msg := range validation.IsValidLabelValue(value) {
+				messages = append(messages, fmt.Sprintf("spec.master.agent.podTemplates[%d].nodeSelector value '%s' is invalid: %s", i, value, msg))
+			}
+		}
 	}
+	messages = append(messages, validateAgentClouds(*agent)...)
+	return append(messages, validateAgentPodTemplatesInheritance(agent.PodTemplates)...)
+}
 
-	if secret.Data["docker-server"] == nil {
-		messages = append(messages, fmt.Sprintf("Secret '%s' defined in spec.master.imagePullSecrets doesn't have 'docker-server' key.", secretName))
+// validateAgentClouds checks the additional Kubernetes plugin clouds and the pod templates they provision
+func validateAgentClouds(agent v1alpha2.JenkinsAgent) []string {
+	podTemplates := map[string]bool{}
+	for _, podTemplate := range agent.PodTemplates {
+		podTemplates[podTemplate.Name] = true
 	}
-	if secret.Data["docker-username"] == nil {
-		messages = append(messages, fmt.Sprintf("Secret '%s' defined in spec.master.imagePullSecrets doesn't have 'docker-username' key.", secretName))
+
+	var messages []string
+	names := map[string]bool{resources.KubernetesCloudName: true}
+	for i, cloud := range agent.Clouds {
+		path := fmt.Sprintf("spec.master.agent.clouds[%d]", i)
+		if len(cloud.Name) == 0 {
+			messages = append(messages, fmt.Sprintf("%s.name can't be empty", path))
+		} else if names[cloud.Name] {
+			messages = append(messages, fmt.Sprintf("spec.master.agent.clouds has duplicated cloud name '%s'", cloud.Name))
+		}
+		names[cloud.Name] = true
+		serverURL, err := url.ParseRequestURI(cloud.ServerURL)
+		if err != nil || (serverURL.Scheme != "http" && serverURL.Scheme != "https") || len(serverURL.Host) == 0 {
+			messages = append(messages, fmt.Sprintf("%s.serverUrl '%s' must be a valid http or https URL", path, cloud.ServerURL))
+		}
+		for _, msg := range validation.IsDNS1123Label(cloud.Namespace) {
+			messages = append(messages, fmt.Sprintf("%s.namespace '%s' is invalid: %s", path, cloud.Namespace, msg))
+		}
+		// the in-cluster Jenkins address can't be resolved by the agents running in the other cluster
+		if len(cloud.JenkinsURL) == 0 {
+			messages = append(messages, fmt.Sprintf("%s.jenkinsUrl can't be empty", path))
+		} else if jenkinsURL, err := url.ParseRequestURI(cloud.JenkinsURL); err != nil || (jenkinsURL.Scheme != "http" && jenkinsURL.Scheme != "https") || len(jenkinsURL.Host) == 0 {
+			messages = append(messages, fmt.Sprintf("%s.jenkinsUrl '%s' must be a valid http or https URL", path, cloud.JenkinsURL))
+		}
+		if len(cloud.JenkinsTunnel) == 0 {
+			messages = append(messages, fmt.Sprintf("%s.jenkinsTunnel can't be empty", path))
+		} else if !isValidHostPort(cloud.JenkinsTunnel) {
+			messages = append(messages, fmt.Sprintf("%s.jenkinsTunnel '%s' must be in the host:port format", path, cloud.JenkinsTunnel))
+		}
+		for _, podTemplate := range cloud.PodTemplates {
+			if !podTemplates[podTemplate] {
+				messages = append(messages, fmt.Sprintf("%s.podTemplates '%s' doesn't exist in spec.master.agent.podTemplates", path, podTemplate))
+			}
+		}
 	}
-	if secret.Data["docker-password"] == nil {
-		messages = append(messages, fmt.Sprintf("Secret '%s' defined in spec.master.imagePullSecrets doesn't have 'docker-password' key.", secretName))
+	return messages
+}
+
+// validateAgentPodMetadata checks the annotation keys and the labels of the agent pods
+func validateAgentPodMetadata(podTemplate v1alpha2.AgentPodTemplate, path string) []string {
+	var messages []string
+	var annotationKeys []string
+	for key := range podTemplate.Annotations {
+		annotationKeys = append(annotationKeys, key)
 	}
-	if secret.Data["docker-email"] == nil {
-		messages = append(messages, fmt.Sprintf("Secret '%s' defined in spec.master.imagePullSecrets doesn't have 'docker-email' key.", secretName))
+	sort.Strings(annotationKeys)
+	for _, key := range annotationKeys {
+		for _, msg := range validation.IsQualifiedName(key) {
+			messages = append(messages, fmt.Sprintf("%s.annotations key '%s' is invalid: %s", path, key, msg))
+		}
 	}
 
-	return messages, nil
+	var labelKeys []string
+	for key := range podTemplate.Labels {
+		labelKeys = append(labelKeys, key)
+	}
+	sort.Strings(labelKeys)
+	for _, key := range labelKeys {
+		for _, msg := range validation.IsQualifiedName(key) {
+			messages = append(messages, fmt.Sprintf("%s.labels key '%s' is invalid: %s", path, key, msg))
+		}
+		for _, msg := range validation.IsValidLabelValue(podTemplate.Labels[key]) {
+			messages = append(messages, fmt.Sprintf("%s.labels value '%s' is invalid: %s", path, podTemplate.Labels[key], msg))
+		}
+	}
+	return messages
+}
+
+// validateAgentPodTemplatesInheritance checks that the pod templates inherit from the existing pod templates without cycles
+func validateAgentPodTemplatesInheritance(podTemplates []v1alpha2.AgentPodTemplate) []string {
+	parents := map[string][]string{}
+	for _, podTemplate := range podTemplates {
+		parents[podTemplate.Name] = podTemplate.InheritFrom
+	}
+
+	var messages []string
+	for i, podTemplate := range podTemplates {
+		valid := true
+		for _, parent := range podTemplate.InheritFrom {
+			if parent == podTemplate.Name {
+				valid = false
+				messages = append(messages, fmt.Sprintf("spec.master.agent.podTemplates[%d].inheritFrom '%s' can't reference the pod template itself", i, parent))
+			} else if _, found := parents[parent]; !found {
+				valid = false
+				messages = append(messages, fmt.Sprintf("spec.master.agent.podTemplates[%d].inheritFrom '%s' doesn't exist in spec.master.agent.podTemplates", i, parent))
+			}
+		}
+		if valid && inheritsFrom(parents, podTemplate.Name, podTemplate.Name, map[string]bool{}) {
+			messages = append(messages, fmt.Sprintf("spec.master.agent.podTemplates[%d].inheritFrom of '%s' is cyclic", i, podTemplate.Name))
+		}
+	}
+	return messages
+}
+
+// inheritsFrom returns true if the ancestor is reachable through the inheritFrom of the pod template
+func inheritsFrom(parents map[string][]string, name, ancestor string, visited map[string]bool) bool {
+	for _, parent := range parents[name] {
+		if parent == ancestor {
+			return true
+		}
+		if visited[parent] {
+			continue
+		}
+		visited[parent] = true
+		if inheritsFrom(parents, parent, ancestor, visited) {
+			return true
+		}
+	}
+	return false
+}
+
+// isValidHostPort returns true if the value is a host or an IP address with the port
+func isValidHostPort(value string) bool {
+	host, port, err := net.SplitHostPort(value)
+	if err != nil || len(host) == 0 {
+		return false
+	}
+	if net.ParseIP(host) == nil && len(validation.IsDNS1123Subdomain(host)) > 0 {
+		return false
+	}
+	portNumber, err := strconv.Atoi(port)
+	return err == nil && portNumber > 0 && portNumber <= 65535
+}
+
+// validateAgentCommand checks the command or the arguments of the jnlp container, the Kubernetes plugin splits them
+// by whitespaces and groups the double quoted ones
+func validateAgentCommand(parts []string, path string) []string {
+	var messages []string
+	for j, part := range parts {
+		if len(strings.TrimSpace(part)) == 0 {
+			messages = append(messages, fmt.Sprintf("%s[%d] can't be empty", path, j))
+		} else if strings.Contains(part, `"`) {
+			messages = append(messages, fmt.Sprintf("%s[%d] '%s' can't contain double quotes", path, j, part))
+		}
+	}
+	return messages
+}
+
+func validateAgentResources(request, limit *resource.Quantity, path, resourceName string) []string {
+	var messages []string
+	if request != nil && request.Sign() < 0 {
+		messages = append(messages, fmt.Sprintf("%s.resourceRequest%s '%s' can't be negative", path, resourceName, request))
+	}
+	if limit != nil && limit.Sign() < 0 {
+		messages = append(messages, fmt.Sprintf("%s.resourceLimit%s '%s' can't be negative", path, resourceName, limit))
+	}
+	if request != nil && limit != nil && request.Cmp(*limit) > 0 {
+		messages = append(messages, fmt.Sprintf("%s.resourceRequest%s '%s' can't be greater than resourceLimit%s '%s'", path, resourceName, request, resourceName, limit))
+	}
+	return messages
+}
+
+func validateAgentNodeProperties(nodeProperties v1alpha2.AgentNodeProperties, path string) []string {
+	var messages []string
+	keys := map[string]bool{}
+	for _, envVar := range nodeProperties.EnvVars {
+		if !globalEnvVarKeyRegexp.MatchString(envVar.Key) {
+			messages = append(messages, fmt.Sprintf("%s.envVars key '%s' must be a valid identifier", path, envVar.Key))
+			continue
+		}
+		if keys[envVar.Key] {
+			messages = append(messages, fmt.Sprintf("%s.envVars key '%s' is duplicated", path, envVar.Key))
+		}
+		keys[envVar.Key] = true
+	}
+	for i, toolLocation := range nodeProperties.ToolLocations {
+		if _, ok := resources.ToolDescriptors[toolLocation.Type]; !ok {
+			messages = append(messages, fmt.Sprintf("%s.toolLocations[%d].type '%s' is invalid, supported types are: jdk, maven, gradle", path, i, toolLocation.Type))
+		}
+		if len(toolLocation.Name) == 0 {
+			messages = append(messages, fmt.Sprintf("%s.toolLocations[%d].name can't be empty", path, i))
+		}
+		if len(toolLocation.Home) == 0 {
+			messages = append(messages, fmt.Sprintf("%s.toolLocations[%d].home can't be empty", path, i))
+		}
+	}
+	return messages
+}
+
+func validateAgentWorkspaceVolume(workspaceVolume v1alpha2.AgentWorkspaceVolume, path string) []string {
+	volumeTypes := 0
+	var messages []string
+	if workspaceVolume.EmptyDir != nil {
+		volumeTypes++
+	}
+	if workspaceVolume.PersistentVolumeClaim != nil {
+		volumeTypes++
+		if len(workspaceVolume.PersistentVolumeClaim.ClaimName) == 0 {
+			messages = append(messages, fmt.Sprintf("%s.persistentVolumeClaim.claimName can't be empty", path))
+		}
+	}
+	if workspaceVolume.Ephemeral != nil {
+		volumeTypes++
+		if workspaceVolume.Ephemeral.Size.Sign() <= 0 {
+			messages = append(messages, fmt.Sprintf("%s.ephemeral.size must be positive", path))
+		}
+	}
+	if volumeTypes != 1 {
+		messages = append([]string{fmt.Sprintf("%s must have exactly one of emptyDir, persistentVolumeClaim or ephemeral set", path)}, messages...)
+	}
+	return messages
 }
 
 func (r *JenkinsBaseConfigurationReconciler) validateVolumes() ([]string, error) {
@@ -295,9 +1491,13 @@ func (r *JenkinsBaseConfigurationReconciler) validateJenkinsMasterPodEnvs() []st
 		}
 	}
 
+	if !resources.IsSetupWizardDisabled(r.Configuration.Jenkins) && resources.HasJavaOpt(javaOpts.Value, resources.DisableSetupWizardJavaOpt) {
+		messages = append(messages, fmt.Sprintf("Jenkins Master container env '%s' flag '%s' conflicts with spec.master.disableSetupWizard",
+			constants.JavaOpsVariableName, resources.DisableSetupWizardJavaOpt))
+	}
+
 	requiredFlags := map[string]bool{
-		"-Djenkins.install.runSetupWizard=false": false,
-		"-Djava.awt.headless=true":               false,
+		"-Djava.awt.headless=true": false,
 	}
 	for _, setFlag := range strings.Split(javaOpts.Value, " ") {
 		for requiredFlag := range requiredFlags {
@@ -342,6 +1542,19 @@ func (r *JenkinsBaseConfigurationReconciler) validatePlugins(requiredBasePlugins
 		}
 	}
 
+	for _, jenkinsPlugin := range userPlugins {
+		if len(jenkinsPlugin.OCIRef) > 0 && !docker.ReferenceRegexp.MatchString(jenkinsPlugin.OCIRef) {
+			messages = append(messages, fmt.Sprintf("Plugin '%s' ociRef '%s' is invalid, it must be a valid OCI reference, e.g. ghcr.io/org/plugins/my-plugin:1.0.0", jenkinsPlugin.Name, jenkinsPlugin.OCIRef))
+		}
+	}
+	if !r.OCIPluginsEnabled {
+		for _, jenkinsPlugin := range userPlugins {
+			if len(jenkinsPlugin.OCIRef) > 0 {
+				messages = append(messages, fmt.Sprintf("Plugin '%s' is referenced by OCI artifact but pulling plugins from OCI artifacts is disabled, enable it with --enable-oci-plugins", jenkinsPlugin.Name))
+			}
+		}
+	}
+
 	if msg := plugins.VerifyDependencies(allPlugins); len(msg) > 0 {
 		messages = append(messages, msg...)
 	}
@@ -372,6 +1585,71 @@ func (r *JenkinsBaseConfigurationReconciler) verifyBasePlugins(requiredBasePlugi
 	return messages
 }
 
+// validateGroovyBrackets checks that the braces, brackets and parentheses of the Groovy script are balanced, the brackets
+// in the comments and the string literals are skipped
+func validateGroovyBrackets(script string) error {
+	closing := map[byte]byte{')': '(', ']': '[', '}': '{'}
+	var open []byte
+	line := 1
+	for i := 0; i < len(script); i++ {
+		c := script[i]
+		switch {
+		case c == '\n':
+			line++
+		case strings.HasPrefix(script[i:], "//"):
+			end := strings.IndexByte(script[i:], '\n')
+			if end < 0 {
+				i = len(script)
+			} else {
+				i += end - 1
+			}
+		case strings.HasPrefix(script[i:], "/*"):
+			end := strings.Index(script[i+2:], "*/")
+			if end < 0 {
+				return fmt.Errorf("unterminated comment on line %d", line)
+			}
+			line += strings.Count(script[i:i+2+end], "\n")
+			i += end + 3
+		case c == '\'' || c == '"':
+			quote := script[i : i+1]
+			if strings.HasPrefix(script[i:], strings.Repeat(quote, 3)) {
+				quote = strings.Repeat(quote, 3)
+			}
+			end := indexUnescaped(script[i+len(quote):], quote)
+			if end < 0 {
+				return fmt.Errorf("unterminated string on line %d", line)
+			}
+			line += strings.Count(script[i:i+len(quote)+end], "\n")
+			i += len(quote) + end + len(quote) - 1
+		case c == '(' || c == '[' || c == '{':
+			open = append(open, c)
+		case closing[c] != 0:
+			if len(open) == 0 || open[len(open)-1] != closing[c] {
+				return fmt.Errorf("unexpected '%c' on line %d", c, line)
+			}
+			open = open[:len(open)-1]
+		}
+	}
+	if len(open) > 0 {
+		return fmt.Errorf("unclosed '%c'", open[len(open)-1])
+	}
+	return nil
+}
+
+// indexUnescaped returns the index of the first occurrence of substr in s which isn't escaped by a backslash
+func indexUnescaped(s, substr string) int {
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' {
+			i++
+			continue
+		}
+		if strings.HasPrefix(s[i:], substr) {
+			return i
+		}
+	}
+	return -1
+}
+
 func (r *JenkinsBaseConfigurationReconciler) validateCustomization(customization v1alpha2.Customization, name string) ([]string, error) {
 	var messages []string
 	if len(customization.Secret.Name) == 0 && len(customization.Configurations) == 0 {
@@ -3,14 +3,18 @@ package configuration
 import (
 	"bytes"
 	"context"
+	"fmt"
+	"sync"
 	"time"
 
 	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
 	jenkinsclient "github.com/jenkinsci/kubernetes-operator/pkg/client"
 	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/base/resources"
+	"github.com/jenkinsci/kubernetes-operator/pkg/log"
 	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/event"
 	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/reason"
 
+	"github.com/go-logr/logr"
 	stackerr "github.com/pkg/errors"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
@@ -23,6 +27,7 @@ import (
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/remotecommand"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 )
 
@@ -36,6 +41,68 @@ type Configuration struct {
 	Config                       *rest.Config
 	JenkinsAPIConnectionSettings jenkinsclient.JenkinsAPIConnectionSettings
 	KubernetesClusterDomain      string
+	OCIPluginsEnabled            bool
+	ServerSideApply              bool
+	Logger                       logr.Logger
+}
+
+// GetLogger returns the logger of the Jenkins controller, log.Log when the Logger isn't set
+func (c *Configuration) GetLogger() logr.Logger {
+	if c.Logger == nil {
+		return log.Log
+	}
+	return c.Logger
+}
+
+// FieldManager is the name of the field manager used by the operator for server-side apply
+const FieldManager = "jenkins-operator"
+
+// observedResources are the resources managed by the operator which have been found, only their removal is notified.
+// They are kept by the UID of the Jenkins CR, so a Jenkins CR recreated with the same name starts with none observed.
+var observedResources = struct {
+	sync.Mutex
+	keys map[types.UID]map[string]bool
+	uids map[types.NamespacedName]types.UID
+}{keys: map[types.UID]map[string]bool{}, uids: map[types.NamespacedName]types.UID{}}
+
+func observedResourceKey(kind, name string) string {
+	return fmt.Sprintf("%s/%s", kind, name)
+}
+
+// ObserveResource remembers that the resource managed by the operator exists
+func (c *Configuration) ObserveResource(kind, name string) {
+	observedResources.Lock()
+	defer observedResources.Unlock()
+	jenkinsName := types.NamespacedName{Namespace: c.Jenkins.Namespace, Name: c.Jenkins.Name}
+	if uid, ok := observedResources.uids[jenkinsName]; ok && uid != c.Jenkins.UID {
+		delete(observedResources.keys, uid)
+	}
+	observedResources.uids[jenkinsName] = c.Jenkins.UID
+	if observedResources.keys[c.Jenkins.UID] == nil {
+		observedResources.keys[c.Jenkins.UID] = map[string]bool{}
+	}
+	observedResources.keys[c.Jenkins.UID][observedResourceKey(kind, name)] = true
+}
+
+// ForgetResource forgets the resource managed by the operator, e.g. when the operator deletes it, and returns true
+// when the resource has been observed
+func (c *Configuration) ForgetResource(kind, name string) bool {
+	key := observedResourceKey(kind, name)
+	observedResources.Lock()
+	defer observedResources.Unlock()
+	observed := observedResources.keys[c.Jenkins.UID][key]
+	delete(observedResources.keys[c.Jenkins.UID], key)
+	return observed
+}
+
+// ForgetJenkinsResources forgets all observed resources of the Jenkins CR, it's called when the Jenkins CR is deleted
+func ForgetJenkinsResources(jenkinsName types.NamespacedName) {
+	observedResources.Lock()
+	defer observedResources.Unlock()
+	if uid, ok := observedResources.uids[jenkinsName]; ok {
+		delete(observedResources.keys, uid)
+		delete(observedResources.uids, jenkinsName)
+	}
 }
 
 // RestartJenkinsMasterPod terminate Jenkins master pod and notifies about it.
@@ -56,6 +123,7 @@ func (c *Configuration) RestartJenkinsMasterPod(reason reason.Reason) error {
 		Reason:  reason,
 	}
 
+	c.ForgetResource("Pod", currentJenkinsMasterPod.Name)
 	return stackerr.WithStack(c.Client.Delete(context.TODO(), currentJenkinsMasterPod))
 }
 
@@ -124,6 +192,10 @@ func (c *Configuration) CreateOrUpdateResource(obj metav1.Object) error {
 	// set Jenkins instance as the owner and controller, don't check error(can be already set)
 	_ = controllerutil.SetControllerReference(c.Jenkins, obj, c.Scheme)
 
+	if c.ServerSideApply {
+		return c.applyResource(clientObj)
+	}
+
 	err := c.Client.Create(context.TODO(), clientObj)
 	if err != nil && errors.IsAlreadyExists(err) {
 		return c.UpdateResource(obj)
@@ -134,6 +206,24 @@ func (c *Configuration) CreateOrUpdateResource(obj metav1.Object) error {
 	return nil
 }
 
+// applyResource applies kubernetes resource with server-side apply, on conflict the fields set by the operator
+// are applied again with forced ownership
+func (c *Configuration) applyResource(obj client.Object) error {
+	gvk, err := apiutil.GVKForObject(obj, c.Scheme)
+	if err != nil {
+		return stackerr.WithStack(err)
+	}
+	obj.GetObjectKind().SetGroupVersionKind(gvk)
+	obj.SetResourceVersion("")
+	obj.SetManagedFields(nil)
+
+	err = c.Client.Patch(context.TODO(), obj, client.Apply, client.FieldOwner(FieldManager))
+	if err != nil && errors.IsConflict(err) {
+		return stackerr.WithStack(c.Client.Patch(context.TODO(), obj, client.Apply, client.FieldOwner(FieldManager), client.ForceOwnership))
+	}
+	return stackerr.WithStack(err)
+}
+
 // Exec executes command in the given pod and it's container.
 func (c *Configuration) Exec(podName, containerName string, command []string) (stdout, stderr bytes.Buffer, err error) {
 	req := c.ClientSet.CoreV1().RESTClient().Post().
@@ -201,10 +291,7 @@ func (c *Configuration) getJenkinsAPIUrl() (string, error) {
 		return "", err
 	}
 	jenkinsURL := c.JenkinsAPIConnectionSettings.BuildJenkinsAPIUrl(service.Name, service.Namespace, service.Spec.Ports[0].Port, service.Spec.Ports[0].NodePort)
-	if prefix, ok := resources.GetJenkinsOpts(*c.Jenkins)["prefix"]; ok {
-		jenkinsURL += prefix
-	}
-	return jenkinsURL, nil
+	return resources.BuildJenkinsURL(c.Jenkins, jenkinsURL), nil
 }
 
 // GetJenkinsClientFromServiceAccount gets jenkins client from a serviceAccount.
@@ -220,7 +307,7 @@ func (c *Configuration) GetJenkinsClientFromServiceAccount() (jenkinsclient.Jenk
 		return nil, err
 	}
 
-	return jenkinsclient.NewBearerTokenAuthorization(jenkinsAPIUrl, token.String())
+	return jenkinsclient.NewBearerTokenAuthorization(jenkinsAPIUrl, token.String(), c.getJenkinsClientOptions())
 }
 
 // GetJenkinsClientFromSecret gets jenkins client from a secret.
@@ -234,6 +321,18 @@ func (c *Configuration) GetJenkinsClientFromSecret() (jenkinsclient.Jenkins, err
 	if err != nil {
 		return nil, stackerr.WithStack(err)
 	}
+	if resources.IsOperatorAPITokenUsed(c.Jenkins) {
+		// the password login doesn't work with the external security realm, Jenkins is seeded with the fixed API token
+		apiToken := credentialsSecret.Data[resources.OperatorCredentialsSecretAPITokenKey]
+		if len(apiToken) == 0 {
+			return nil, stackerr.Errorf("operator credentials secret '%s' doesn't have '%s' key", credentialsSecret.Name, resources.OperatorCredentialsSecretAPITokenKey)
+		}
+		return jenkinsclient.NewUserAndPasswordAuthorization(
+			jenkinsURL,
+			string(credentialsSecret.Data[resources.OperatorCredentialsSecretUserNameKey]),
+			string(apiToken),
+			c.getJenkinsClientOptions())
+	}
 	currentJenkinsMasterPod, err := c.GetJenkinsMasterPod()
 	if err != nil {
 		return nil, err
@@ -254,7 +353,8 @@ func (c *Configuration) GetJenkinsClientFromSecret() (jenkinsclient.Jenkins, err
 		jenkinsClient, err := jenkinsclient.NewUserAndPasswordAuthorization(
 			jenkinsURL,
 			userName,
-			string(credentialsSecret.Data[resources.OperatorCredentialsSecretPasswordKey]))
+			string(credentialsSecret.Data[resources.OperatorCredentialsSecretPasswordKey]),
+			c.getJenkinsClientOptions())
 		if err != nil {
 			return nil, err
 		}
@@ -275,5 +375,14 @@ func (c *Configuration) GetJenkinsClientFromSecret() (jenkinsclient.Jenkins, err
 	return jenkinsclient.NewUserAndPasswordAuthorization(
 		jenkinsURL,
 		string(credentialsSecret.Data[resources.OperatorCredentialsSecretUserNameKey]),
-		string(credentialsSecret.Data[resources.OperatorCredentialsSecretTokenKey]))
+		string(credentialsSecret.Data[resources.OperatorCredentialsSecretTokenKey]),
+		c.getJenkinsClientOptions())
+}
+
+func (c *Configuration) getJenkinsClientOptions() jenkinsclient.ClientOptions {
+	options := jenkinsclient.ClientOptions{Retries: int(c.Jenkins.Spec.Master.APIClientRetries)}
+	if c.Jenkins.Spec.Master.APIClientTimeout != nil {
+		options.Timeout = c.Jenkins.Spec.Master.APIClientTimeout.Duration
+	}
+	return options
 }
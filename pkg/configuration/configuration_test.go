@@ -0,0 +1,144 @@
+package configuration
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/base/resources"
+	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/event"
+	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/reason"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+type applyPatch struct {
+	object       client.Object
+	patchOptions client.PatchOptions
+}
+
+// applyClient records the server-side apply patches, the fake client doesn't support them
+type applyClient struct {
+	client.Client
+	patches   []applyPatch
+	conflicts int
+}
+
+func (c *applyClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	if patch.Type() != types.ApplyPatchType {
+		return c.Client.Patch(ctx, obj, patch, opts...)
+	}
+
+	patchOptions := client.PatchOptions{}
+	patchOptions.ApplyOptions(opts)
+	c.patches = append(c.patches, applyPatch{object: obj.DeepCopyObject().(client.Object), patchOptions: patchOptions})
+	if c.conflicts > 0 {
+		c.conflicts--
+		return apierrors.NewConflict(schema.GroupResource{Resource: "configmaps"}, obj.GetName(), nil)
+	}
+	return nil
+}
+
+func TestCreateOrUpdateResource(t *testing.T) {
+	require.NoError(t, v1alpha2.SchemeBuilder.AddToScheme(scheme.Scheme))
+	jenkins := &v1alpha2.Jenkins{ObjectMeta: metav1.ObjectMeta{Name: "jenkins", Namespace: "default", UID: "jenkins-uid"}}
+	newConfigMap := func(value string) *corev1.ConfigMap {
+		return &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "config", Namespace: "default"},
+			Data:       map[string]string{"key": value},
+		}
+	}
+
+	t.Run("update", func(t *testing.T) {
+		fakeClient := fake.NewClientBuilder().WithObjects(newConfigMap("old")).Build()
+		config := Configuration{Client: fakeClient, Jenkins: jenkins, Scheme: scheme.Scheme}
+
+		err := config.CreateOrUpdateResource(newConfigMap("new"))
+
+		require.NoError(t, err)
+		configMap := &corev1.ConfigMap{}
+		require.NoError(t, fakeClient.Get(context.TODO(), types.NamespacedName{Name: "config", Namespace: "default"}, configMap))
+		assert.Equal(t, "new", configMap.Data["key"])
+		assert.Equal(t, "jenkins", configMap.OwnerReferences[0].Name)
+	})
+	t.Run("server-side apply", func(t *testing.T) {
+		applyClient := &applyClient{Client: fake.NewClientBuilder().Build()}
+		config := Configuration{Client: applyClient, Jenkins: jenkins, Scheme: scheme.Scheme, ServerSideApply: true}
+
+		err := config.CreateOrUpdateResource(newConfigMap("new"))
+
+		require.NoError(t, err)
+		require.Len(t, applyClient.patches, 1)
+		applied := applyClient.patches[0]
+		assert.Equal(t, FieldManager, applied.patchOptions.FieldManager)
+		assert.Nil(t, applied.patchOptions.Force)
+		assert.Equal(t, schema.GroupVersionKind{Version: "v1", Kind: "ConfigMap"}, applied.object.GetObjectKind().GroupVersionKind())
+		assert.Equal(t, "jenkins", applied.object.GetOwnerReferences()[0].Name)
+	})
+	t.Run("server-side apply conflict", func(t *testing.T) {
+		applyClient := &applyClient{Client: fake.NewClientBuilder().Build(), conflicts: 1}
+		config := Configuration{Client: applyClient, Jenkins: jenkins, Scheme: scheme.Scheme, ServerSideApply: true}
+
+		err := config.CreateOrUpdateResource(newConfigMap("new"))
+
+		require.NoError(t, err)
+		require.Len(t, applyClient.patches, 2)
+		assert.Nil(t, applyClient.patches[0].patchOptions.Force)
+		if assert.NotNil(t, applyClient.patches[1].patchOptions.Force) {
+			assert.True(t, *applyClient.patches[1].patchOptions.Force)
+		}
+	})
+}
+
+func TestRestartJenkinsMasterPod(t *testing.T) {
+	// given
+	jenkins := &v1alpha2.Jenkins{ObjectMeta: metav1.ObjectMeta{Name: "restarted", Namespace: "default"}}
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: resources.GetJenkinsMasterPodName(jenkins), Namespace: "default"}}
+	notifications := make(chan event.Event, 1)
+	config := Configuration{Client: fake.NewClientBuilder().WithObjects(pod).Build(), Jenkins: jenkins, Notifications: &notifications}
+	config.ObserveResource("Pod", pod.Name)
+
+	// when
+	err := config.RestartJenkinsMasterPod(reason.NewPodRestart(reason.OperatorSource, []string{"restart"}))
+
+	// then
+	require.NoError(t, err)
+	assert.Len(t, notifications, 1)
+	assert.False(t, config.ForgetResource("Pod", pod.Name), "the pod deleted by the operator hasn't been removed")
+}
+
+func TestObservedResources(t *testing.T) {
+	newConfiguration := func(uid types.UID) Configuration {
+		return Configuration{Jenkins: &v1alpha2.Jenkins{ObjectMeta: metav1.ObjectMeta{Name: "observed", Namespace: "default", UID: uid}}}
+	}
+
+	t.Run("recreated Jenkins CR", func(t *testing.T) {
+		first := newConfiguration("first-uid")
+		first.ObserveResource("Secret", "credentials")
+
+		config := newConfiguration("second-uid")
+
+		config.ObserveResource("Pod", "master")
+		assert.NotContains(t, observedResources.keys, types.UID("first-uid"))
+		assert.False(t, config.ForgetResource("Secret", "credentials"))
+	})
+	t.Run("deleted Jenkins CR", func(t *testing.T) {
+		config := newConfiguration("deleted-uid")
+		config.ObserveResource("Secret", "credentials")
+
+		ForgetJenkinsResources(types.NamespacedName{Name: "observed", Namespace: "default"})
+
+		assert.False(t, config.ForgetResource("Secret", "credentials"))
+		assert.NotContains(t, observedResources.keys, types.UID("deleted-uid"))
+		assert.NotContains(t, observedResources.uids, types.NamespacedName{Name: "observed", Namespace: "default"})
+	})
+}
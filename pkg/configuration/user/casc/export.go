@@ -0,0 +1,102 @@
+package casc
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+	jenkinsclient "github.com/jenkinsci/kubernetes-operator/pkg/client"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/base/resources"
+
+	stackerr "github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	// ExportSecretKey is the key of the exported Configuration as Code YAML in the export Secret
+	ExportSecretKey = "jenkins.yaml"
+	// ExportedAtAnnotation is the annotation of the export Secret with the time of the last export
+	ExportedAtAnnotation = "jenkins.io/casc-exported-at"
+
+	defaultExportInterval = time.Hour
+)
+
+// GetExportSecretName returns the name of the Secret with the exported Configuration as Code
+func GetExportSecretName(jenkins *v1alpha2.Jenkins) string {
+	return fmt.Sprintf("jenkins-%s-casc-export", jenkins.Name)
+}
+
+// GetExportInterval returns the interval between the exports of the Configuration as Code
+func GetExportInterval(export v1alpha2.ConfigurationAsCodeExport) time.Duration {
+	if export.Interval == nil {
+		return defaultExportInterval
+	}
+	return export.Interval.Duration
+}
+
+// Export stores the Configuration as Code exported from Jenkins in the export Secret when
+// spec.configurationAsCodeExport is set and the export interval has elapsed since the last export.
+// The export holds the credentials of Jenkins so it is never stored in a ConfigMap.
+// It returns the time remaining to the next export, zero when the export is disabled.
+func Export(jenkinsClient jenkinsclient.Jenkins, config configuration.Configuration, now time.Time) (time.Duration, error) {
+	export := config.Jenkins.Spec.ConfigurationAsCodeExport
+	if export == nil {
+		return 0, nil
+	}
+	interval := GetExportInterval(*export)
+
+	secret := &corev1.Secret{}
+	err := config.Client.Get(context.TODO(), types.NamespacedName{Name: GetExportSecretName(config.Jenkins), Namespace: config.Jenkins.Namespace}, secret)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return 0, stackerr.WithStack(err)
+	}
+	if err == nil {
+		exportedAt, err := time.Parse(time.RFC3339, secret.Annotations[ExportedAtAnnotation])
+		if err == nil && now.Before(exportedAt.Add(interval)) {
+			return exportedAt.Add(interval).Sub(now), nil
+		}
+	}
+
+	exported, err := jenkinsClient.ExportConfigurationAsCode()
+	if err != nil {
+		return 0, err
+	}
+
+	secret = &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        GetExportSecretName(config.Jenkins),
+			Namespace:   config.Jenkins.Namespace,
+			Labels:      resources.BuildResourceLabels(config.Jenkins),
+			Annotations: map[string]string{ExportedAtAnnotation: now.UTC().Format(time.RFC3339)},
+		},
+		Type: corev1.SecretTypeOpaque,
+		Data: map[string][]byte{ExportSecretKey: []byte(exported)},
+	}
+	if err := config.CreateOrUpdateResource(secret); err != nil {
+		return 0, stackerr.WithStack(err)
+	}
+	if err := deleteLegacyExportConfigMap(config); err != nil {
+		return 0, err
+	}
+	return interval, nil
+}
+
+// deleteLegacyExportConfigMap removes the export ConfigMap written by the previous operator versions
+func deleteLegacyExportConfigMap(config configuration.Configuration) error {
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      GetExportSecretName(config.Jenkins),
+			Namespace: config.Jenkins.Namespace,
+		},
+	}
+	err := config.Client.Delete(context.TODO(), configMap)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return stackerr.WithStack(err)
+	}
+	return nil
+}
@@ -0,0 +1,124 @@
+package casc
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+	jenkinsclient "github.com/jenkinsci/kubernetes-operator/pkg/client"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestExport(t *testing.T) {
+	require.NoError(t, v1alpha2.SchemeBuilder.AddToScheme(scheme.Scheme))
+	now := time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC)
+	newConfiguration := func(export *v1alpha2.ConfigurationAsCodeExport) configuration.Configuration {
+		return configuration.Configuration{
+			Client: fake.NewClientBuilder().Build(),
+			Scheme: scheme.Scheme,
+			Jenkins: &v1alpha2.Jenkins{
+				ObjectMeta: metav1.ObjectMeta{Name: "example", Namespace: "default", UID: "uid"},
+				Spec:       v1alpha2.JenkinsSpec{ConfigurationAsCodeExport: export},
+			},
+		}
+	}
+	getExportSecret := func(t *testing.T, config configuration.Configuration) *corev1.Secret {
+		secret := &corev1.Secret{}
+		err := config.Client.Get(context.TODO(), types.NamespacedName{Name: "jenkins-example-casc-export", Namespace: "default"}, secret)
+		require.NoError(t, err)
+		return secret
+	}
+
+	t.Run("disabled", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		next, err := Export(jenkinsclient.NewMockJenkins(ctrl), newConfiguration(nil), now)
+
+		require.NoError(t, err)
+		assert.Equal(t, time.Duration(0), next)
+	})
+	t.Run("export is stored in Secret", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		jenkinsClient := jenkinsclient.NewMockJenkins(ctrl)
+		jenkinsClient.EXPECT().ExportConfigurationAsCode().Return("jenkins:\n  numExecutors: 0\n", nil)
+		config := newConfiguration(&v1alpha2.ConfigurationAsCodeExport{Interval: &metav1.Duration{Duration: 30 * time.Minute}})
+
+		next, err := Export(jenkinsClient, config, now)
+
+		require.NoError(t, err)
+		assert.Equal(t, 30*time.Minute, next)
+		secret := getExportSecret(t, config)
+		assert.Equal(t, "jenkins:\n  numExecutors: 0\n", string(secret.Data[ExportSecretKey]))
+		assert.Equal(t, "2021-06-01T12:00:00Z", secret.Annotations[ExportedAtAnnotation])
+		assert.Equal(t, "example", secret.OwnerReferences[0].Name)
+	})
+	t.Run("export is skipped until interval elapses", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		jenkinsClient := jenkinsclient.NewMockJenkins(ctrl)
+		jenkinsClient.EXPECT().ExportConfigurationAsCode().Return("jenkins:\n  numExecutors: 0\n", nil)
+		jenkinsClient.EXPECT().ExportConfigurationAsCode().Return("jenkins:\n  numExecutors: 1\n", nil)
+		config := newConfiguration(&v1alpha2.ConfigurationAsCodeExport{})
+
+		_, err := Export(jenkinsClient, config, now)
+		require.NoError(t, err)
+		next, err := Export(jenkinsClient, config, now.Add(20*time.Minute))
+		require.NoError(t, err)
+
+		assert.Equal(t, 40*time.Minute, next)
+		assert.Equal(t, "jenkins:\n  numExecutors: 0\n", string(getExportSecret(t, config).Data[ExportSecretKey]))
+
+		_, err = Export(jenkinsClient, config, now.Add(time.Hour))
+		require.NoError(t, err)
+
+		secret := getExportSecret(t, config)
+		assert.Equal(t, "jenkins:\n  numExecutors: 1\n", string(secret.Data[ExportSecretKey]))
+		assert.Equal(t, "2021-06-01T13:00:00Z", secret.Annotations[ExportedAtAnnotation])
+	})
+	t.Run("legacy export ConfigMap is removed", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		jenkinsClient := jenkinsclient.NewMockJenkins(ctrl)
+		jenkinsClient.EXPECT().ExportConfigurationAsCode().Return("jenkins:\n  numExecutors: 0\n", nil)
+		config := newConfiguration(&v1alpha2.ConfigurationAsCodeExport{})
+		legacyConfigMap := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "jenkins-example-casc-export", Namespace: "default"},
+			Data:       map[string]string{"jenkins.yaml": "jenkins:\n  numExecutors: 0\n"},
+		}
+		require.NoError(t, config.Client.Create(context.TODO(), legacyConfigMap))
+
+		_, err := Export(jenkinsClient, config, now)
+
+		require.NoError(t, err)
+		err = config.Client.Get(context.TODO(), types.NamespacedName{Name: "jenkins-example-casc-export", Namespace: "default"}, &corev1.ConfigMap{})
+		assert.True(t, apierrors.IsNotFound(err))
+		assert.Equal(t, "jenkins:\n  numExecutors: 0\n", string(getExportSecret(t, config).Data[ExportSecretKey]))
+	})
+	t.Run("failed export is returned", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		jenkinsClient := jenkinsclient.NewMockJenkins(ctrl)
+		jenkinsClient.EXPECT().ExportConfigurationAsCode().Return("", errors.New("export failed"))
+		config := newConfiguration(&v1alpha2.ConfigurationAsCodeExport{})
+
+		_, err := Export(jenkinsClient, config, now)
+
+		require.Error(t, err)
+		err = config.Client.Get(context.TODO(), types.NamespacedName{Name: "jenkins-example-casc-export", Namespace: "default"}, &corev1.Secret{})
+		assert.True(t, apierrors.IsNotFound(err))
+	})
+}
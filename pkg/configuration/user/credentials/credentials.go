@@ -0,0 +1,177 @@
+package credentials
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"strings"
+	"text/template"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/internal/render"
+	jenkinsclient "github.com/jenkinsci/kubernetes-operator/pkg/client"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/user/seedjobs"
+	"github.com/jenkinsci/kubernetes-operator/pkg/groovy"
+
+	"github.com/go-logr/logr"
+	stackerr "github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	// TextSecretKey is text data key in Kubernetes secret used to create Jenkins secret text credential
+	TextSecretKey = "text"
+
+	configurationType           = "credentials"
+	credentialsGroovyScriptName = "credentials.groovy"
+)
+
+// credentialsGroovyScriptTemplate creates or updates the credentials domains and the credentials, every value is
+// base64 encoded so the user input can't break out of the Groovy strings. A credential moved to another domain
+// is removed from the previous one.
+var credentialsGroovyScriptTemplate = template.Must(template.New(credentialsGroovyScriptName).Funcs(template.FuncMap{
+	"encode": func(value string) string {
+		return base64.StdEncoding.EncodeToString([]byte(value))
+	},
+	"join": func(values []string) string {
+		return strings.Join(values, ",")
+	},
+}).Parse(`
+import com.cloudbees.jenkins.plugins.sshcredentials.impl.BasicSSHUserPrivateKey
+import com.cloudbees.plugins.credentials.CredentialsScope
+import com.cloudbees.plugins.credentials.SystemCredentialsProvider
+import com.cloudbees.plugins.credentials.domains.Domain
+import com.cloudbees.plugins.credentials.domains.DomainSpecification
+import com.cloudbees.plugins.credentials.domains.HostnameSpecification
+import com.cloudbees.plugins.credentials.domains.SchemeSpecification
+import com.cloudbees.plugins.credentials.impl.UsernamePasswordCredentialsImpl
+import hudson.util.Secret
+import org.jenkinsci.plugins.plaincredentials.impl.StringCredentialsImpl
+
+def decode(String value) {
+    return new String(value.decodeBase64(), 'UTF-8')
+}
+
+def ensureDomain(store, Domain domain) {
+    def current = store.getDomainByName(domain.getName())
+    if (current == null) {
+        store.addDomain(domain)
+    } else {
+        store.updateDomain(current, domain)
+    }
+    return domain
+}
+
+def ensureCredentials(store, Domain domain, credentials) {
+    store.getDomains().findAll { it.getName() != domain.getName() }.each { other ->
+        store.getCredentials(other).findAll { it.id == credentials.id }.each { store.removeCredentials(other, it) }
+    }
+    def current = store.getCredentials(domain).find { it.id == credentials.id }
+    if (current == null) {
+        store.addCredentials(domain, credentials)
+    } else {
+        store.updateCredentials(domain, current, credentials)
+    }
+}
+
+def store = SystemCredentialsProvider.getInstance().getStore()
+def domain
+{{ range .Credentials }}
+{{- if .Domain }}
+domain = ensureDomain(store, new Domain(decode('{{ encode .Domain.Name }}'), decode('{{ encode .Domain.Description }}'), [
+{{- if or .Domain.Hostnames .Domain.ExcludedHostnames }}
+    new HostnameSpecification(decode('{{ encode (join .Domain.Hostnames) }}'), decode('{{ encode (join .Domain.ExcludedHostnames) }}')),
+{{- end }}
+{{- if .Domain.Schemes }}
+    new SchemeSpecification(decode('{{ encode (join .Domain.Schemes) }}')),
+{{- end }}
+] as List<DomainSpecification>))
+{{- else }}
+domain = Domain.global()
+{{- end }}
+{{- if eq .Type "usernamePassword" }}
+ensureCredentials(store, domain, new UsernamePasswordCredentialsImpl(CredentialsScope.GLOBAL, decode('{{ encode .ID }}'), decode('{{ encode .Description }}'),
+    decode('{{ encode .Username }}'), decode('{{ encode .Password }}')))
+{{- else if eq .Type "basicSSHUserPrivateKey" }}
+ensureCredentials(store, domain, new BasicSSHUserPrivateKey(CredentialsScope.GLOBAL, decode('{{ encode .ID }}'), decode('{{ encode .Username }}'),
+    new BasicSSHUserPrivateKey.DirectEntryPrivateKeySource(decode('{{ encode .PrivateKey }}')), null, decode('{{ encode .Description }}')))
+{{- else if eq .Type "secretText" }}
+ensureCredentials(store, domain, new StringCredentialsImpl(CredentialsScope.GLOBAL, decode('{{ encode .ID }}'), decode('{{ encode .Description }}'),
+    Secret.fromString(decode('{{ encode .Text }}'))))
+{{- end }}
+{{ end }}
+`))
+
+type credentialValues struct {
+	v1alpha2.Credential
+	Username   string
+	Password   string
+	PrivateKey string
+	Text       string
+}
+
+// Credentials defines API for the Jenkins credentials defined in the Jenkins CR
+type Credentials interface {
+	EnsureCredentials(jenkins *v1alpha2.Jenkins) (requeue bool, err error)
+	ValidateCredentials(jenkins v1alpha2.Jenkins) ([]string, error)
+}
+
+type credentials struct {
+	configuration.Configuration
+	jenkinsClient jenkinsclient.Jenkins
+	logger        logr.Logger
+}
+
+// New creates Credentials object
+func New(jenkinsClient jenkinsclient.Jenkins, config configuration.Configuration) Credentials {
+	return &credentials{
+		Configuration: config,
+		jenkinsClient: jenkinsClient,
+		logger:        config.GetLogger().WithValues("cr", config.Jenkins.Name),
+	}
+}
+
+// EnsureCredentials creates or updates the Jenkins credentials and their credentials domains from Jenkins.Spec.Credentials,
+// the credentials removed from the Jenkins CR are kept in Jenkins
+func (c *credentials) EnsureCredentials(jenkins *v1alpha2.Jenkins) (requeue bool, err error) {
+	if len(jenkins.Spec.Credentials) == 0 {
+		return false, nil
+	}
+
+	var values []credentialValues
+	for _, credential := range jenkins.Spec.Credentials {
+		secret := &corev1.Secret{}
+		err := c.Client.Get(context.TODO(), types.NamespacedName{Namespace: jenkins.Namespace, Name: credential.SecretRef.Name}, secret)
+		if err != nil {
+			return true, stackerr.WithStack(err)
+		}
+		values = append(values, credentialValues{
+			Credential: credential,
+			Username:   string(secret.Data[seedjobs.UsernameSecretKey]),
+			Password:   string(secret.Data[seedjobs.PasswordSecretKey]),
+			PrivateKey: string(secret.Data[seedjobs.PrivateKeySecretKey]),
+			Text:       string(secret.Data[TextSecretKey]),
+		})
+	}
+
+	groovyScript, err := credentialsGroovyScript(values)
+	if err != nil {
+		return true, err
+	}
+
+	hash := sha256.Sum256([]byte(groovyScript))
+	groovyClient := groovy.New(c.jenkinsClient, c.Client, jenkins, configurationType, v1alpha2.Customization{}, c.logger)
+	return groovyClient.EnsureSingle(jenkins.Name, credentialsGroovyScriptName, base64.URLEncoding.EncodeToString(hash[:]), groovyScript)
+}
+
+func credentialsGroovyScript(values []credentialValues) (string, error) {
+	data := struct {
+		Credentials []credentialValues
+	}{
+		Credentials: values,
+	}
+
+	return render.Render(credentialsGroovyScriptTemplate, data)
+}
@@ -0,0 +1,159 @@
+package credentials
+
+import (
+	"context"
+	"encoding/base64"
+	"testing"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+	jenkinsclient "github.com/jenkinsci/kubernetes-operator/pkg/client"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func encode(value string) string {
+	return base64.StdEncoding.EncodeToString([]byte(value))
+}
+
+func TestCredentialsGroovyScript(t *testing.T) {
+	t.Run("global domain", func(t *testing.T) {
+		// given
+		values := []credentialValues{
+			{
+				Credential: v1alpha2.Credential{ID: "token", Type: v1alpha2.SecretTextCredentialType},
+				Text:       "s3cr3t",
+			},
+		}
+
+		// when
+		script, err := credentialsGroovyScript(values)
+
+		// then
+		require.NoError(t, err)
+		assert.Contains(t, script, "domain = Domain.global()")
+		assert.Contains(t, script, "new StringCredentialsImpl(CredentialsScope.GLOBAL, decode('"+encode("token")+"')")
+		assert.Contains(t, script, "Secret.fromString(decode('"+encode("s3cr3t")+"'))")
+		assert.NotContains(t, script, "s3cr3t")
+		assert.NotContains(t, script, "new Domain(")
+	})
+	t.Run("domain scoped", func(t *testing.T) {
+		// given
+		values := []credentialValues{
+			{
+				Credential: v1alpha2.Credential{
+					ID:          "github",
+					Description: "GitHub's user",
+					Type:        v1alpha2.UsernamePasswordCredentialType,
+					Domain: &v1alpha2.CredentialsDomain{
+						Name:              "github",
+						Description:       "GitHub",
+						Hostnames:         []string{"github.com", "*.github.com"},
+						ExcludedHostnames: []string{"gist.github.com"},
+						Schemes:           []string{"https"},
+					},
+				},
+				Username: "user",
+				Password: "pass'word",
+			},
+			{
+				Credential: v1alpha2.Credential{
+					ID:     "deploy-key",
+					Type:   v1alpha2.BasicSSHCredentialType,
+					Domain: &v1alpha2.CredentialsDomain{Name: "internal", Schemes: []string{"ssh"}},
+				},
+				Username:   "git",
+				PrivateKey: "private-key",
+			},
+		}
+
+		// when
+		script, err := credentialsGroovyScript(values)
+
+		// then
+		require.NoError(t, err)
+		assert.Contains(t, script, "domain = ensureDomain(store, new Domain(decode('"+encode("github")+"'), decode('"+encode("GitHub")+"'), [\n"+
+			"    new HostnameSpecification(decode('"+encode("github.com,*.github.com")+"'), decode('"+encode("gist.github.com")+"')),\n"+
+			"    new SchemeSpecification(decode('"+encode("https")+"')),\n"+
+			"] as List<DomainSpecification>))\n"+
+			"ensureCredentials(store, domain, new UsernamePasswordCredentialsImpl(CredentialsScope.GLOBAL, decode('"+encode("github")+"'), decode('"+encode("GitHub's user")+"'),\n"+
+			"    decode('"+encode("user")+"'), decode('"+encode("pass'word")+"')))")
+		assert.Contains(t, script, "domain = ensureDomain(store, new Domain(decode('"+encode("internal")+"'), decode(''), [\n"+
+			"    new SchemeSpecification(decode('"+encode("ssh")+"')),\n"+
+			"] as List<DomainSpecification>))\n"+
+			"ensureCredentials(store, domain, new BasicSSHUserPrivateKey(CredentialsScope.GLOBAL, decode('"+encode("deploy-key")+"'), decode('"+encode("git")+"'),\n"+
+			"    new BasicSSHUserPrivateKey.DirectEntryPrivateKeySource(decode('"+encode("private-key")+"')), null, decode('')))")
+		assert.NotContains(t, script, "Domain.global()")
+	})
+}
+
+func TestEnsureCredentials(t *testing.T) {
+	t.Run("no credentials", func(t *testing.T) {
+		// given
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		jenkins := &v1alpha2.Jenkins{ObjectMeta: metav1.ObjectMeta{Name: "jenkins", Namespace: "default"}}
+		config := configuration.Configuration{Client: fake.NewClientBuilder().Build(), Jenkins: jenkins}
+
+		// when
+		requeue, err := New(jenkinsclient.NewMockJenkins(ctrl), config).EnsureCredentials(jenkins)
+
+		// then
+		assert.NoError(t, err)
+		assert.False(t, requeue)
+	})
+	t.Run("runs the script once", func(t *testing.T) {
+		// given
+		ctrl := gomock.NewController(t)
+		ctx := context.TODO()
+		defer ctrl.Finish()
+
+		err := v1alpha2.SchemeBuilder.AddToScheme(scheme.Scheme)
+		require.NoError(t, err)
+		fakeClient := fake.NewClientBuilder().Build()
+		jenkins := &v1alpha2.Jenkins{
+			ObjectMeta: metav1.ObjectMeta{Name: "jenkins", Namespace: "default"},
+			Spec: v1alpha2.JenkinsSpec{
+				Credentials: []v1alpha2.Credential{
+					{
+						ID:        "token",
+						Type:      v1alpha2.SecretTextCredentialType,
+						SecretRef: corev1.LocalObjectReference{Name: "token"},
+						Domain:    &v1alpha2.CredentialsDomain{Name: "internal", Hostnames: []string{"*.internal"}},
+					},
+				},
+			},
+		}
+		require.NoError(t, fakeClient.Create(ctx, jenkins))
+		require.NoError(t, fakeClient.Create(ctx, &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "token", Namespace: "default"},
+			Data:       map[string][]byte{TextSecretKey: []byte("s3cr3t")},
+		}))
+		config := configuration.Configuration{Client: fakeClient, Jenkins: jenkins}
+
+		script, err := credentialsGroovyScript([]credentialValues{{Credential: jenkins.Spec.Credentials[0], Text: "s3cr3t"}})
+		require.NoError(t, err)
+		jenkinsClient := jenkinsclient.NewMockJenkins(ctrl)
+		jenkinsClient.EXPECT().ExecuteScript(script).Return("", nil).Times(1)
+		credentialsClient := New(jenkinsClient, config)
+
+		// when
+		_, err = credentialsClient.EnsureCredentials(jenkins)
+		require.NoError(t, err)
+		requeue, err := credentialsClient.EnsureCredentials(jenkins)
+
+		// then
+		assert.NoError(t, err)
+		assert.False(t, requeue)
+		require.Len(t, jenkins.Status.AppliedGroovyScripts, 1)
+		assert.Equal(t, configurationType, jenkins.Status.AppliedGroovyScripts[0].ConfigurationType)
+		assert.Equal(t, credentialsGroovyScriptName, jenkins.Status.AppliedGroovyScripts[0].Name)
+	})
+}
@@ -0,0 +1,2 @@
+// Package credentials creates the Jenkins credentials and the credentials domains defined in the Jenkins CR
+package credentials
@@ -0,0 +1,103 @@
+package credentials
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/user/seedjobs"
+
+	stackerr "github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// ValidateCredentials verifies the Jenkins credentials configuration
+func (c *credentials) ValidateCredentials(jenkins v1alpha2.Jenkins) ([]string, error) {
+	var messages []string
+
+	if msg := validateIfIDIsUnique(jenkins.Spec.Credentials); len(msg) > 0 {
+		messages = append(messages, msg...)
+	}
+	if msg := validateIfDomainNameIsUnique(jenkins.Spec.Credentials); len(msg) > 0 {
+		messages = append(messages, msg...)
+	}
+
+	for i, credential := range jenkins.Spec.Credentials {
+		if len(credential.ID) == 0 {
+			messages = append(messages, fmt.Sprintf("spec.credentials[%d].id can't be empty", i))
+		}
+		if credential.Domain != nil && len(credential.Domain.Name) == 0 {
+			messages = append(messages, fmt.Sprintf("spec.credentials[%d].domain.name can't be empty", i))
+		}
+
+		var requiredKeys []string
+		switch credential.Type {
+		case v1alpha2.UsernamePasswordCredentialType:
+			requiredKeys = []string{seedjobs.UsernameSecretKey, seedjobs.PasswordSecretKey}
+		case v1alpha2.BasicSSHCredentialType:
+			requiredKeys = []string{seedjobs.UsernameSecretKey, seedjobs.PrivateKeySecretKey}
+		case v1alpha2.SecretTextCredentialType:
+			requiredKeys = []string{TextSecretKey}
+		default:
+			messages = append(messages, fmt.Sprintf("spec.credentials[%d] unknown credential type '%s'", i, credential.Type))
+			continue
+		}
+
+		if len(credential.SecretRef.Name) == 0 {
+			messages = append(messages, fmt.Sprintf("spec.credentials[%d].secretRef.name can't be empty", i))
+			continue
+		}
+		secret := &corev1.Secret{}
+		err := c.Client.Get(context.TODO(), types.NamespacedName{Namespace: jenkins.Namespace, Name: credential.SecretRef.Name}, secret)
+		if err != nil && apierrors.IsNotFound(err) {
+			messages = append(messages, fmt.Sprintf("Secret '%s' defined in spec.credentials[%d].secretRef not found", credential.SecretRef.Name, i))
+			continue
+		} else if err != nil {
+			return nil, stackerr.WithStack(err)
+		}
+		for _, key := range requiredKeys {
+			if len(secret.Data[key]) == 0 {
+				messages = append(messages, fmt.Sprintf("required data '%s' not found in secret '%s'", key, secret.Name))
+			}
+		}
+	}
+
+	return messages, nil
+}
+
+func validateIfIDIsUnique(credentials []v1alpha2.Credential) []string {
+	var messages []string
+	ids := map[string]bool{}
+	for _, credential := range credentials {
+		if ids[credential.ID] {
+			messages = append(messages, fmt.Sprintf("'%s' credential ID is not unique", credential.ID))
+		}
+		ids[credential.ID] = true
+	}
+	return messages
+}
+
+// validateIfDomainNameIsUnique checks that a credentials domain name identifies a single credentials domain, the
+// credentials sharing a domain must define it the same way
+func validateIfDomainNameIsUnique(credentials []v1alpha2.Credential) []string {
+	var messages []string
+	domains := map[string]*v1alpha2.CredentialsDomain{}
+	for _, credential := range credentials {
+		if credential.Domain == nil {
+			continue
+		}
+		domain, found := domains[credential.Domain.Name]
+		if !found {
+			domains[credential.Domain.Name] = credential.Domain
+			continue
+		}
+		if !reflect.DeepEqual(domain, credential.Domain) {
+			messages = append(messages, fmt.Sprintf("'%s' credentials domain name is not unique, credential '%s' defines another domain with the same name",
+				credential.Domain.Name, credential.ID))
+		}
+	}
+	return messages
+}
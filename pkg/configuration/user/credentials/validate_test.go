@@ -0,0 +1,100 @@
+package credentials
+
+import (
+	"testing"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+	jenkinsclient "github.com/jenkinsci/kubernetes-operator/pkg/client"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/user/seedjobs"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestValidateCredentials(t *testing.T) {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "github", Namespace: "default"},
+		Data: map[string][]byte{
+			seedjobs.UsernameSecretKey: []byte("user"),
+			seedjobs.PasswordSecretKey: []byte("password"),
+		},
+	}
+	validate := func(t *testing.T, credentials ...v1alpha2.Credential) []string {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		jenkins := &v1alpha2.Jenkins{
+			ObjectMeta: metav1.ObjectMeta{Name: "jenkins", Namespace: "default"},
+			Spec:       v1alpha2.JenkinsSpec{Credentials: credentials},
+		}
+		config := configuration.Configuration{Client: fake.NewClientBuilder().WithObjects(secret).Build(), Jenkins: jenkins}
+
+		messages, err := New(jenkinsclient.NewMockJenkins(ctrl), config).ValidateCredentials(*jenkins)
+		assert.NoError(t, err)
+		return messages
+	}
+	githubDomain := func() *v1alpha2.CredentialsDomain {
+		return &v1alpha2.CredentialsDomain{Name: "github", Hostnames: []string{"github.com"}}
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		messages := validate(t,
+			v1alpha2.Credential{ID: "github", Type: v1alpha2.UsernamePasswordCredentialType, SecretRef: corev1.LocalObjectReference{Name: "github"}, Domain: githubDomain()},
+			v1alpha2.Credential{ID: "github-api", Type: v1alpha2.UsernamePasswordCredentialType, SecretRef: corev1.LocalObjectReference{Name: "github"}, Domain: githubDomain()},
+			v1alpha2.Credential{ID: "global", Type: v1alpha2.UsernamePasswordCredentialType, SecretRef: corev1.LocalObjectReference{Name: "github"}},
+		)
+
+		assert.Empty(t, messages)
+	})
+	t.Run("domain name is not unique", func(t *testing.T) {
+		otherDomain := githubDomain()
+		otherDomain.Schemes = []string{"https"}
+
+		messages := validate(t,
+			v1alpha2.Credential{ID: "github", Type: v1alpha2.UsernamePasswordCredentialType, SecretRef: corev1.LocalObjectReference{Name: "github"}, Domain: githubDomain()},
+			v1alpha2.Credential{ID: "github-api", Type: v1alpha2.UsernamePasswordCredentialType, SecretRef: corev1.LocalObjectReference{Name: "github"}, Domain: otherDomain},
+		)
+
+		assert.Equal(t, []string{"'github' credentials domain name is not unique, credential 'github-api' defines another domain with the same name"}, messages)
+	})
+	t.Run("credential ID is not unique", func(t *testing.T) {
+		messages := validate(t,
+			v1alpha2.Credential{ID: "github", Type: v1alpha2.UsernamePasswordCredentialType, SecretRef: corev1.LocalObjectReference{Name: "github"}},
+			v1alpha2.Credential{ID: "github", Type: v1alpha2.UsernamePasswordCredentialType, SecretRef: corev1.LocalObjectReference{Name: "github"}, Domain: githubDomain()},
+		)
+
+		assert.Equal(t, []string{"'github' credential ID is not unique"}, messages)
+	})
+	t.Run("empty domain name", func(t *testing.T) {
+		messages := validate(t,
+			v1alpha2.Credential{ID: "github", Type: v1alpha2.UsernamePasswordCredentialType, SecretRef: corev1.LocalObjectReference{Name: "github"}, Domain: &v1alpha2.CredentialsDomain{}},
+		)
+
+		assert.Equal(t, []string{"spec.credentials[0].domain.name can't be empty"}, messages)
+	})
+	t.Run("missing secret key", func(t *testing.T) {
+		messages := validate(t,
+			v1alpha2.Credential{ID: "github", Type: v1alpha2.SecretTextCredentialType, SecretRef: corev1.LocalObjectReference{Name: "github"}},
+		)
+
+		assert.Equal(t, []string{"required data 'text' not found in secret 'github'"}, messages)
+	})
+	t.Run("missing secret", func(t *testing.T) {
+		messages := validate(t,
+			v1alpha2.Credential{ID: "github", Type: v1alpha2.SecretTextCredentialType, SecretRef: corev1.LocalObjectReference{Name: "missing"}},
+		)
+
+		assert.Equal(t, []string{"Secret 'missing' defined in spec.credentials[0].secretRef not found"}, messages)
+	})
+	t.Run("unknown type", func(t *testing.T) {
+		messages := validate(t,
+			v1alpha2.Credential{ID: "github", Type: v1alpha2.GithubAppCredentialType, SecretRef: corev1.LocalObjectReference{Name: "github"}},
+		)
+
+		assert.Equal(t, []string{"spec.credentials[0] unknown credential type 'githubApp'"}, messages)
+	})
+}
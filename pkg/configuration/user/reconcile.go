@@ -1,7 +1,9 @@
 package user
 
 import (
+	"fmt"
 	"strings"
+	"time"
 
 	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
 	jenkinsclient "github.com/jenkinsci/kubernetes-operator/pkg/client"
@@ -9,9 +11,12 @@ import (
 	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/backuprestore"
 	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/base/resources"
 	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/user/casc"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/user/credentials"
 	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/user/seedjobs"
 	"github.com/jenkinsci/kubernetes-operator/pkg/groovy"
 	"github.com/jenkinsci/kubernetes-operator/pkg/log"
+	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/event"
+	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/reason"
 
 	"github.com/go-logr/logr"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
@@ -35,7 +40,7 @@ func New(configuration configuration.Configuration, jenkinsClient jenkinsclient.
 	return &reconcileUserConfiguration{
 		Configuration: configuration,
 		jenkinsClient: jenkinsClient,
-		logger:        log.Log.WithValues("cr", configuration.Jenkins.Name),
+		logger:        configuration.GetLogger().WithValues("cr", configuration.Jenkins.Name),
 	}
 }
 
@@ -56,7 +61,15 @@ func (r *reconcileUserConfiguration) ReconcileCasc() (reconcile.Result, error) {
 func (r *reconcileUserConfiguration) ReconcileOthers() (reconcile.Result, error) {
 	backupAndRestore := backuprestore.New(r.Configuration, r.logger)
 
-	result, err := r.ensureSeedJobs()
+	result, err := r.ensureCredentials()
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	if result.Requeue {
+		return result, nil
+	}
+
+	result, err = r.ensureSeedJobs()
 	if err != nil {
 		return reconcile.Result{}, err
 	}
@@ -75,6 +88,36 @@ func (r *reconcileUserConfiguration) ReconcileOthers() (reconcile.Result, error)
 		return reconcile.Result{}, err
 	}
 
+	return reconcile.Result{RequeueAfter: r.exportCasc()}, nil
+}
+
+// exportCasc runs the Configuration as Code export, a failed export is reported and retried after the export interval
+// because it mustn't block the user configuration
+func (r *reconcileUserConfiguration) exportCasc() time.Duration {
+	nextExport, err := casc.Export(r.jenkinsClient, r.Configuration, time.Now())
+	if err == nil {
+		return nextExport
+	}
+
+	message := fmt.Sprintf("Configuration as Code export failed: %s", err)
+	r.logger.V(log.VWarn).Info(message)
+	*r.Notifications <- event.Event{
+		Jenkins: *r.Configuration.Jenkins,
+		Phase:   event.PhaseUser,
+		Level:   v1alpha2.NotificationLevelWarning,
+		Reason:  reason.NewUndefined(reason.OperatorSource, []string{message}),
+	}
+	return casc.GetExportInterval(*r.Configuration.Jenkins.Spec.ConfigurationAsCodeExport)
+}
+
+func (r *reconcileUserConfiguration) ensureCredentials() (reconcile.Result, error) {
+	requeue, err := credentials.New(r.jenkinsClient, r.Configuration).EnsureCredentials(r.Configuration.Jenkins)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	if requeue {
+		return reconcile.Result{Requeue: true}, nil
+	}
 	return reconcile.Result{}, nil
 }
 
@@ -91,7 +134,7 @@ func (r *reconcileUserConfiguration) ensureSeedJobs() (reconcile.Result, error)
 }
 
 func (r *reconcileUserConfiguration) ensureCasc(jenkinsClient jenkinsclient.Jenkins) (reconcile.Result, error) {
-	configurationAsCodeClient := casc.New(jenkinsClient, r.Client, r.Configuration.Jenkins)
+	configurationAsCodeClient := casc.New(jenkinsClient, r.Client, r.Configuration.Jenkins, r.logger)
 	requeue, err := configurationAsCodeClient.Ensure(r.Configuration.Jenkins)
 	if err != nil {
 		return reconcile.Result{}, err
@@ -100,7 +143,7 @@ func (r *reconcileUserConfiguration) ensureCasc(jenkinsClient jenkinsclient.Jenk
 		return reconcile.Result{Requeue: true}, nil
 	}
 
-	groovyClient := groovy.New(jenkinsClient, r.Client, r.Configuration.Jenkins, "user-groovy", r.Configuration.Jenkins.Spec.GroovyScripts.Customization)
+	groovyClient := groovy.New(jenkinsClient, r.Client, r.Configuration.Jenkins, "user-groovy", r.Configuration.Jenkins.Spec.GroovyScripts.Customization, r.logger)
 	requeue, err = groovyClient.WaitForSecretSynchronization(resources.GroovyScriptsSecretVolumePath)
 	if err != nil {
 		return reconcile.Result{}, err
@@ -0,0 +1,53 @@
+package user
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+	jenkinsclient "github.com/jenkinsci/kubernetes-operator/pkg/client"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration"
+	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/event"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestExportCasc(t *testing.T) {
+	t.Run("failed export is reported and retried after interval", func(t *testing.T) {
+		// given
+		require.NoError(t, v1alpha2.SchemeBuilder.AddToScheme(scheme.Scheme))
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		jenkinsClient := jenkinsclient.NewMockJenkins(ctrl)
+		jenkinsClient.EXPECT().ExportConfigurationAsCode().Return("", errors.New("export failed"))
+		notifications := make(chan event.Event, 1)
+		jenkins := &v1alpha2.Jenkins{
+			ObjectMeta: metav1.ObjectMeta{Name: "example", Namespace: "default"},
+			Spec: v1alpha2.JenkinsSpec{
+				ConfigurationAsCodeExport: &v1alpha2.ConfigurationAsCodeExport{Interval: &metav1.Duration{Duration: 30 * time.Minute}},
+			},
+		}
+		r := New(configuration.Configuration{
+			Client:        fake.NewClientBuilder().Build(),
+			Scheme:        scheme.Scheme,
+			Jenkins:       jenkins,
+			Notifications: &notifications,
+		}, jenkinsClient).(*reconcileUserConfiguration)
+
+		// when
+		next := r.exportCasc()
+
+		// then
+		assert.Equal(t, 30*time.Minute, next)
+		require.Len(t, notifications, 1)
+		notification := <-notifications
+		assert.Equal(t, v1alpha2.NotificationLevelWarning, notification.Level)
+		assert.Contains(t, notification.Reason.Short()[0], "export failed")
+	})
+}
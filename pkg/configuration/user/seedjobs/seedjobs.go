@@ -43,7 +43,7 @@ const (
 	JenkinsCredentialTypeLabelName = "jenkins.io/credentials-type"
 
 	// AgentName is the name of seed job agent
-	AgentName = "seed-job-agent"
+	AgentName = constants.SeedJobAgentName
 
 	// DefaultAgentImage is the default image used for the seed-job agent
 	defaultAgentImage = "jenkins/inbound-agent:4.10-3"
@@ -55,6 +55,10 @@ const (
 
 	workspaceVolumeName = "workspace"
 	workspaceVolumePath = "/home/jenkins/workspace"
+
+	jnlpSecretVolumeName = "jnlp-secret"
+	jnlpSecretVolumePath = "/var/run/secrets/jenkins-agent"
+	jnlpSecretFileName   = "secret"
 )
 
 var seedJobGroovyScriptTemplate = template.Must(template.New(creatingGroovyScriptName).Parse(`
@@ -179,7 +183,7 @@ func New(jenkinsClient jenkinsclient.Jenkins, config configuration.Configuration
 	return &seedJobs{
 		Configuration: config,
 		jenkinsClient: jenkinsClient,
-		logger:        log.Log.WithValues("cr", config.Jenkins.Name),
+		logger:        config.GetLogger().WithValues("cr", config.Jenkins.Name),
 	}
 }
 
@@ -263,7 +267,7 @@ func (s *seedJobs) waitForSeedJobAgent(agentName string) (requeue bool, err erro
 
 // createJob is responsible for creating jenkins job which configures jenkins seed jobs and deploy keys
 func (s *seedJobs) createJobs(jenkins *v1alpha2.Jenkins) (requeue bool, err error) {
-	groovyClient := groovy.New(s.jenkinsClient, s.Client, jenkins, "seed-jobs", jenkins.Spec.GroovyScripts.Customization)
+	groovyClient := groovy.New(s.jenkinsClient, s.Client, jenkins, "seed-jobs", jenkins.Spec.GroovyScripts.Customization, s.logger)
 	for _, seedJob := range jenkins.Spec.SeedJobs {
 		credentialValue, err := s.credentialValue(jenkins.Namespace, seedJob)
 		if err != nil {
@@ -373,7 +377,7 @@ func (s *seedJobs) createAgent(jenkinsClient jenkinsclient.Jenkins, k8sClient cl
 
 	// Create node if not exists
 	if err != nil && err.Error() == "No node found" {
-		_, err = jenkinsClient.CreateNode(agentName, 5, "The jenkins-operator generated agent", "/home/jenkins", agentName)
+		_, err = jenkinsClient.CreateNode(agentName, constants.SeedJobAgentNumExecutors, constants.SeedJobAgentDescription, constants.SeedJobAgentRemoteFS, agentName)
 		if err != nil {
 			return stackerr.WithStack(err)
 		}
@@ -385,6 +389,11 @@ func (s *seedJobs) createAgent(jenkinsClient jenkinsclient.Jenkins, k8sClient cl
 	if err != nil {
 		return err
 	}
+	if jnlpSecretRef := getJNLPSecretRef(jenkinsManifest); jnlpSecretRef != nil {
+		if err := s.verifyJNLPSecret(k8sClient, namespace, agentName, jnlpSecretRef, secret); err != nil {
+			return err
+		}
+	}
 
 	deployment, err := agentDeployment(jenkinsManifest, namespace, agentName, secret, s.KubernetesClusterDomain)
 	if err != nil {
@@ -408,6 +417,29 @@ func agentDeploymentName(jenkins v1alpha2.Jenkins, agentName string) string {
 	return fmt.Sprintf("%s-%s", agentName, jenkins.Name)
 }
 
+// verifyJNLPSecret compares the Secret referenced by spec.master.agent.jnlpSecretRef with the JNLP secret computed
+// by Jenkins for the agent, the Secret belongs to the user and it's never written by the operator
+func (s *seedJobs) verifyJNLPSecret(k8sClient client.Client, namespace, agentName string, jnlpSecretRef *v1alpha2.SecretKeySelector, value string) error {
+	secret := &corev1.Secret{}
+	err := k8sClient.Get(context.TODO(), types.NamespacedName{Name: jnlpSecretRef.Name, Namespace: namespace}, secret)
+	if err != nil {
+		return stackerr.WithStack(err)
+	}
+
+	if string(secret.Data[jnlpSecretRef.Key]) != value {
+		s.logger.V(log.VWarn).Info(fmt.Sprintf("Secret '%s' key '%s' defined in spec.master.agent.jnlpSecretRef doesn't match the JNLP secret of the '%s' node, the agent can't connect to Jenkins",
+			jnlpSecretRef.Name, jnlpSecretRef.Key, agentName))
+	}
+	return nil
+}
+
+func getJNLPSecretRef(jenkins *v1alpha2.Jenkins) *v1alpha2.SecretKeySelector {
+	if jenkins.Spec.Master.Agent == nil {
+		return nil
+	}
+	return jenkins.Spec.Master.Agent.JNLPSecretRef
+}
+
 func agentDeployment(jenkins *v1alpha2.Jenkins, namespace string, agentName string, secret string, kubernetesDomainName string) (*appsv1.Deployment, error) {
 	jenkinsSlavesServiceFQDN, err := resources.GetJenkinsSlavesServiceFQDN(jenkins, kubernetesDomainName)
 	if err != nil {
@@ -423,10 +455,51 @@ func agentDeployment(jenkins *v1alpha2.Jenkins, namespace string, agentName stri
 		agentImage = defaultAgentImage
 	}
 
-	suffix := ""
-	if prefix, ok := resources.GetJenkinsOpts(*jenkins)["prefix"]; ok {
-		suffix = prefix
+	suffix := resources.GetJenkinsContextPath(jenkins)
+
+	volumeMounts := []corev1.VolumeMount{
+		{
+			Name:      homeVolumeName,
+			MountPath: homeVolumePath,
+		},
+		{
+			Name:      workspaceVolumeName,
+			MountPath: workspaceVolumePath,
+		},
+	}
+	volumes := []corev1.Volume{
+		{
+			Name: homeVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				EmptyDir: &corev1.EmptyDirVolumeSource{},
+			},
+		},
+		{
+			Name: workspaceVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				EmptyDir: &corev1.EmptyDirVolumeSource{},
+			},
+		},
+	}
+	// the agent reads the secret from the file when the value starts with '@'
+	if jnlpSecretRef := getJNLPSecretRef(jenkins); jnlpSecretRef != nil {
+		secret = fmt.Sprintf("@%s/%s", jnlpSecretVolumePath, jnlpSecretFileName)
+		volumeMounts = append(volumeMounts, corev1.VolumeMount{
+			Name:      jnlpSecretVolumeName,
+			MountPath: jnlpSecretVolumePath,
+			ReadOnly:  true,
+		})
+		volumes = append(volumes, corev1.Volume{
+			Name: jnlpSecretVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: jnlpSecretRef.Name,
+					Items:      []corev1.KeyToPath{{Key: jnlpSecretRef.Key, Path: jnlpSecretFileName}},
+				},
+			},
+		})
 	}
+
 	return &appsv1.Deployment{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      agentDeploymentName(*jenkins, agentName),
@@ -480,32 +553,10 @@ func agentDeployment(jenkins *v1alpha2.Jenkins, namespace string, agentName stri
 									Value: homeVolumePath,
 								},
 							},
-							VolumeMounts: []corev1.VolumeMount{
-								{
-									Name:      homeVolumeName,
-									MountPath: homeVolumePath,
-								},
-								{
-									Name:      workspaceVolumeName,
-									MountPath: workspaceVolumePath,
-								},
-							},
-						},
-					},
-					Volumes: []corev1.Volume{
-						{
-							Name: homeVolumeName,
-							VolumeSource: corev1.VolumeSource{
-								EmptyDir: &corev1.EmptyDirVolumeSource{},
-							},
-						},
-						{
-							Name: workspaceVolumeName,
-							VolumeSource: corev1.VolumeSource{
-								EmptyDir: &corev1.EmptyDirVolumeSource{},
-							},
+							VolumeMounts: volumeMounts,
 						},
 					},
+					Volumes: volumes,
 				},
 				ObjectMeta: metav1.ObjectMeta{
 					Labels: map[string]string{
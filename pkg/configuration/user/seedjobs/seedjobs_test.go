@@ -11,6 +11,7 @@ import (
 
 	"github.com/bndr/gojenkins"
 	"github.com/golang/mock/gomock"
+	stackerr "github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
@@ -207,6 +208,118 @@ func TestCreateAgent(t *testing.T) {
 	})
 }
 
+func TestAgentDeployment(t *testing.T) {
+	t.Run("JNLP secret fetched from Jenkins", func(t *testing.T) {
+		// given
+		jenkins := jenkinsCustomResource()
+
+		// when
+		deployment, err := agentDeployment(jenkins, jenkins.Namespace, AgentName, agentSecret, "cluster.local")
+
+		// then
+		assert.NoError(t, err)
+		podSpec := deployment.Spec.Template.Spec
+		assert.Contains(t, podSpec.Containers[0].Env, corev1.EnvVar{Name: "JENKINS_SECRET", Value: agentSecret})
+		assert.Len(t, podSpec.Volumes, 2)
+		assert.Len(t, podSpec.Containers[0].VolumeMounts, 2)
+	})
+	t.Run("JNLP secret from referenced Secret", func(t *testing.T) {
+		// given
+		jenkins := jenkinsCustomResource()
+		jenkins.Spec.Master.Agent = &v1alpha2.JenkinsAgent{
+			JNLPSecretRef: &v1alpha2.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "jnlp-secret"},
+				Key:                  "seed-job-agent",
+			},
+		}
+
+		// when
+		deployment, err := agentDeployment(jenkins, jenkins.Namespace, AgentName, "", "cluster.local")
+
+		// then
+		assert.NoError(t, err)
+		podSpec := deployment.Spec.Template.Spec
+		assert.Contains(t, podSpec.Containers[0].Env, corev1.EnvVar{Name: "JENKINS_SECRET", Value: "@/var/run/secrets/jenkins-agent/secret"})
+		assert.Contains(t, podSpec.Containers[0].VolumeMounts, corev1.VolumeMount{
+			Name:      jnlpSecretVolumeName,
+			MountPath: jnlpSecretVolumePath,
+			ReadOnly:  true,
+		})
+		assert.Contains(t, podSpec.Volumes, corev1.Volume{
+			Name: jnlpSecretVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: "jnlp-secret",
+					Items:      []corev1.KeyToPath{{Key: "seed-job-agent", Path: jnlpSecretFileName}},
+				},
+			},
+		})
+	})
+	t.Run("referenced JNLP Secret is not written", func(t *testing.T) {
+		// given
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		jenkins := jenkinsCustomResource()
+		jenkins.Spec.Master.Agent = &v1alpha2.JenkinsAgent{
+			JNLPSecretRef: &v1alpha2.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "jnlp-secret"},
+				Key:                  "seed-job-agent",
+			},
+		}
+		jnlpSecret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: jenkins.Namespace, Name: "jnlp-secret"},
+			Data:       map[string][]byte{"seed-job-agent": []byte("user-secret")},
+		}
+		jenkinsClient := jenkinsclient.NewMockJenkins(ctrl)
+		jenkinsClient.EXPECT().GetNode(AgentName).Return(nil, nil)
+		jenkinsClient.EXPECT().GetNodeSecret(AgentName).Return(agentSecret, nil)
+		fakeClient := fake.NewClientBuilder().WithObjects(jnlpSecret).Build()
+		seedJobsClient := New(jenkinsClient, configuration.Configuration{Client: fakeClient, Jenkins: jenkins})
+
+		// when
+		err := seedJobsClient.createAgent(jenkinsClient, fakeClient, jenkins, jenkins.Namespace, AgentName)
+
+		// then
+		assert.NoError(t, err)
+		var deployment appsv1.Deployment
+		err = fakeClient.Get(context.TODO(), types.NamespacedName{Namespace: jenkins.Namespace, Name: agentDeploymentName(*jenkins, AgentName)}, &deployment)
+		assert.NoError(t, err)
+		var secret corev1.Secret
+		err = fakeClient.Get(context.TODO(), types.NamespacedName{Namespace: jenkins.Namespace, Name: "jnlp-secret"}, &secret)
+		assert.NoError(t, err)
+		assert.Equal(t, "user-secret", string(secret.Data["seed-job-agent"]))
+		assert.Equal(t, jnlpSecret.ResourceVersion, secret.ResourceVersion)
+	})
+	t.Run("missing referenced JNLP Secret is not created", func(t *testing.T) {
+		// given
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		jenkins := jenkinsCustomResource()
+		jenkins.Spec.Master.Agent = &v1alpha2.JenkinsAgent{
+			JNLPSecretRef: &v1alpha2.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "jnlp-secret"},
+				Key:                  "seed-job-agent",
+			},
+		}
+		jenkinsClient := jenkinsclient.NewMockJenkins(ctrl)
+		jenkinsClient.EXPECT().GetNode(AgentName).Return(nil, nil)
+		jenkinsClient.EXPECT().GetNodeSecret(AgentName).Return(agentSecret, nil)
+		fakeClient := fake.NewClientBuilder().Build()
+		seedJobsClient := New(jenkinsClient, configuration.Configuration{Client: fakeClient, Jenkins: jenkins})
+
+		// when
+		err := seedJobsClient.createAgent(jenkinsClient, fakeClient, jenkins, jenkins.Namespace, AgentName)
+
+		// then
+		assert.True(t, errors.IsNotFound(stackerr.Cause(err)))
+		var secret corev1.Secret
+		err = fakeClient.Get(context.TODO(), types.NamespacedName{Namespace: jenkins.Namespace, Name: "jnlp-secret"}, &secret)
+		assert.True(t, errors.IsNotFound(err))
+	})
+}
+
 func TestSeedJobs_isRecreatePodNeeded(t *testing.T) {
 	config := configuration.Configuration{
 		Client:        nil,
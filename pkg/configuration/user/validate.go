@@ -3,6 +3,7 @@ package user
 import (
 	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
 	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/backuprestore"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/user/credentials"
 	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/user/seedjobs"
 )
 
@@ -13,6 +14,11 @@ func (r *reconcileUserConfiguration) Validate(jenkins *v1alpha2.Jenkins) ([]stri
 		return msg, nil
 	}
 
+	credentialsClient := credentials.New(r.jenkinsClient, r.Configuration)
+	if msg, err := credentialsClient.ValidateCredentials(*jenkins); err != nil || len(msg) > 0 {
+		return msg, err
+	}
+
 	seedJobs := seedjobs.New(r.jenkinsClient, r.Configuration)
 	return seedJobs.ValidateSeedJobs(*jenkins)
 }
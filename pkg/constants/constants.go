@@ -15,4 +15,12 @@ const (
 	DefaultSlavePortInt32 = int32(50000)
 	// JavaOpsVariableName is the name of environment variable which consists Jenkins Java options
 	JavaOpsVariableName = "JAVA_OPTS"
+	// SeedJobAgentName is the name of the seed job agent node
+	SeedJobAgentName = "seed-job-agent"
+	// SeedJobAgentNumExecutors is the number of the executors of the seed job agent node
+	SeedJobAgentNumExecutors = 5
+	// SeedJobAgentDescription is the description of the seed job agent node
+	SeedJobAgentDescription = "The jenkins-operator generated agent"
+	// SeedJobAgentRemoteFS is the root directory of the seed job agent node
+	SeedJobAgentRemoteFS = "/home/jenkins"
 )
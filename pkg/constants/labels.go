@@ -13,4 +13,7 @@ const (
 
 	// LabelJenkinsCRKey Kubernetes label name which contains Jenkins CR name
 	LabelJenkinsCRKey = "jenkins-cr"
+
+	// LabelOperatorInstanceKey Kubernetes label name which contains ID of the operator instance managing the resource
+	LabelOperatorInstanceKey = "jenkins.io/operator-instance"
 )
@@ -0,0 +1,56 @@
+package log
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// JenkinsControllerName is the name of the Jenkins controller used in the controller log level flag.
+const JenkinsControllerName = "jenkins"
+
+// ParseControllerLogLevels parses comma separated list of controller=level pairs, e.g. "jenkins=debug".
+// Level is one of debug, info, warn, error or a number which defines the verbosity, the greater number
+// the more verbose output.
+func ParseControllerLogLevels(value string) (map[string]zapcore.Level, error) {
+	levels := map[string]zapcore.Level{}
+	if len(strings.TrimSpace(value)) == 0 {
+		return levels, nil
+	}
+
+	for _, pair := range strings.Split(value, ",") {
+		parts := strings.SplitN(strings.TrimSpace(pair), "=", 2)
+		if len(parts) != 2 || len(strings.TrimSpace(parts[0])) == 0 {
+			return nil, fmt.Errorf("invalid controller log level '%s', expected format is controller=level", pair)
+		}
+		controller := strings.TrimSpace(parts[0])
+		if _, found := levels[controller]; found {
+			return nil, fmt.Errorf("log level for controller '%s' is defined more than once", controller)
+		}
+		level, err := parseLevel(strings.TrimSpace(parts[1]))
+		if err != nil {
+			return nil, fmt.Errorf("invalid log level for controller '%s': %s", controller, err)
+		}
+		levels[controller] = level
+	}
+
+	return levels, nil
+}
+
+func parseLevel(value string) (zapcore.Level, error) {
+	if verbosity, err := strconv.Atoi(value); err == nil {
+		if verbosity < 0 {
+			return zapcore.InfoLevel, fmt.Errorf("verbosity '%d' can't be negative", verbosity)
+		}
+		// logr verbosity is mapped to the negative zap levels
+		return zapcore.Level(-verbosity), nil
+	}
+
+	var level zapcore.Level
+	if err := level.UnmarshalText([]byte(value)); err != nil {
+		return zapcore.InfoLevel, err
+	}
+	return level, nil
+}
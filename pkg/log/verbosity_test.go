@@ -0,0 +1,59 @@
+package log
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestParseControllerLogLevels(t *testing.T) {
+	t.Run("empty", func(t *testing.T) {
+		levels, err := ParseControllerLogLevels("")
+
+		require.NoError(t, err)
+		assert.Empty(t, levels)
+	})
+	t.Run("level names", func(t *testing.T) {
+		levels, err := ParseControllerLogLevels("jenkins=debug, webhook=error")
+
+		require.NoError(t, err)
+		assert.Equal(t, map[string]zapcore.Level{
+			"jenkins": zapcore.DebugLevel,
+			"webhook": zapcore.ErrorLevel,
+		}, levels)
+	})
+	t.Run("numeric verbosity", func(t *testing.T) {
+		levels, err := ParseControllerLogLevels("jenkins=2")
+
+		require.NoError(t, err)
+		assert.Equal(t, map[string]zapcore.Level{"jenkins": zapcore.Level(-2)}, levels)
+		assert.True(t, levels["jenkins"].Enabled(zapcore.Level(-VDebug)))
+	})
+	t.Run("missing level", func(t *testing.T) {
+		_, err := ParseControllerLogLevels("jenkins")
+
+		assert.Error(t, err)
+	})
+	t.Run("missing controller name", func(t *testing.T) {
+		_, err := ParseControllerLogLevels("=debug")
+
+		assert.Error(t, err)
+	})
+	t.Run("unknown level", func(t *testing.T) {
+		_, err := ParseControllerLogLevels("jenkins=verbose")
+
+		assert.Error(t, err)
+	})
+	t.Run("negative verbosity", func(t *testing.T) {
+		_, err := ParseControllerLogLevels("jenkins=-1")
+
+		assert.Error(t, err)
+	})
+	t.Run("duplicated controller", func(t *testing.T) {
+		_, err := ParseControllerLogLevels("jenkins=debug,jenkins=info")
+
+		assert.Error(t, err)
+	})
+}
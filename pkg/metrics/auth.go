@@ -0,0 +1,129 @@
+package metrics
+
+import (
+	"context"
+	"crypto/subtle"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jenkinsci/kubernetes-operator/pkg/log"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	// Path is the path on which the operator metrics are served
+	Path = "/metrics"
+	// TokenSecretKey is the key of the Secret data which holds the metrics bearer token
+	TokenSecretKey = "token"
+
+	bearerPrefix    = "Bearer "
+	shutdownTimeout = 5 * time.Second
+	// tokenCacheTTL is how long the token read from the Secret is reused, a rotated token is accepted at the latest
+	// after the TTL
+	tokenCacheTTL = 30 * time.Second
+)
+
+// TokenProvider returns the bearer token required to access the metrics
+type TokenProvider func(ctx context.Context) (string, error)
+
+// SecretTokenProvider reads the bearer token from the TokenSecretKey of the given Secret, the token is cached for
+// tokenCacheTTL, so the token can be rotated without restarting the operator and the scrapes don't hit the API server
+func SecretTokenProvider(reader k8sclient.Reader, secretName types.NamespacedName) TokenProvider {
+	return secretTokenProvider(reader, secretName, tokenCacheTTL)
+}
+
+func secretTokenProvider(reader k8sclient.Reader, secretName types.NamespacedName, ttl time.Duration) TokenProvider {
+	var (
+		mutex     sync.Mutex
+		token     string
+		expiresAt time.Time
+	)
+	return func(ctx context.Context) (string, error) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		if len(token) > 0 && time.Now().Before(expiresAt) {
+			return token, nil
+		}
+
+		secret := &corev1.Secret{}
+		if err := reader.Get(ctx, secretName, secret); err != nil {
+			return "", errors.WithStack(err)
+		}
+		secretToken, found := secret.Data[TokenSecretKey]
+		if !found || len(secretToken) == 0 {
+			return "", errors.Errorf("secret '%s' doesn't contain the '%s' key", secretName, TokenSecretKey)
+		}
+		token = string(secretToken)
+		expiresAt = time.Now().Add(ttl)
+		return token, nil
+	}
+}
+
+// NewAuthHandler wraps the handler with the bearer token authentication, the requests without a valid token
+// are rejected with 401 Unauthorized
+func NewAuthHandler(handler http.Handler, tokenProvider TokenProvider) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization := r.Header.Get("Authorization")
+		if !strings.HasPrefix(authorization, bearerPrefix) {
+			http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+			return
+		}
+		token, err := tokenProvider(r.Context())
+		if err != nil {
+			log.Log.Error(err, "Failed to get the metrics bearer token")
+			http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+			return
+		}
+		requestToken := strings.TrimPrefix(authorization, bearerPrefix)
+		if subtle.ConstantTimeCompare([]byte(requestToken), []byte(token)) != 1 {
+			http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+			return
+		}
+		handler.ServeHTTP(w, r)
+	})
+}
+
+// Server serves the controller-runtime metrics registry behind the bearer token authentication.
+// It replaces the built-in controller manager metrics endpoint which can't be protected.
+type Server struct {
+	Address       string
+	TokenProvider TokenProvider
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable, metrics are served by every operator replica
+func (s *Server) NeedLeaderElection() bool {
+	return false
+}
+
+// Start implements manager.Runnable
+func (s *Server) Start(ctx context.Context) error {
+	mux := http.NewServeMux()
+	handler := promhttp.HandlerFor(metrics.Registry, promhttp.HandlerOpts{ErrorHandling: promhttp.HTTPErrorOnError})
+	mux.Handle(Path, NewAuthHandler(handler, s.TokenProvider))
+	server := &http.Server{Addr: s.Address, Handler: mux}
+
+	errs := make(chan error, 1)
+	go func() {
+		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			errs <- errors.WithStack(err)
+		}
+		close(errs)
+	}()
+
+	select {
+	case err := <-errs:
+		return err
+	case <-ctx.Done():
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		return errors.WithStack(server.Shutdown(shutdownCtx))
+	}
+}
@@ -0,0 +1,120 @@
+package metrics
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestNewAuthHandler(t *testing.T) {
+	okHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("metrics"))
+	})
+	staticToken := func(context.Context) (string, error) { return "secret-token", nil }
+
+	tests := []struct {
+		name          string
+		authorization string
+		tokenProvider TokenProvider
+		wantStatus    int
+	}{
+		{name: "valid token", authorization: "Bearer secret-token", tokenProvider: staticToken, wantStatus: http.StatusOK},
+		{name: "missing token", tokenProvider: staticToken, wantStatus: http.StatusUnauthorized},
+		{name: "invalid token", authorization: "Bearer other-token", tokenProvider: staticToken, wantStatus: http.StatusUnauthorized},
+		{name: "basic authorization", authorization: "Basic c2VjcmV0LXRva2Vu", tokenProvider: staticToken, wantStatus: http.StatusUnauthorized},
+		{
+			name:          "token provider failure",
+			authorization: "Bearer secret-token",
+			tokenProvider: func(context.Context) (string, error) { return "", errors.New("not found") },
+			wantStatus:    http.StatusUnauthorized,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := httptest.NewRequest(http.MethodGet, Path, nil)
+			if len(tt.authorization) > 0 {
+				request.Header.Set("Authorization", tt.authorization)
+			}
+			recorder := httptest.NewRecorder()
+
+			NewAuthHandler(okHandler, tt.tokenProvider).ServeHTTP(recorder, request)
+
+			assert.Equal(t, tt.wantStatus, recorder.Code)
+			if tt.wantStatus == http.StatusOK {
+				assert.Equal(t, "metrics", recorder.Body.String())
+			}
+		})
+	}
+}
+
+func TestSecretTokenProvider(t *testing.T) {
+	secretName := types.NamespacedName{Namespace: "default", Name: "metrics-token"}
+	t.Run("token from secret", func(t *testing.T) {
+		reader := fake.NewFakeClient(&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: secretName.Namespace, Name: secretName.Name},
+			Data:       map[string][]byte{TokenSecretKey: []byte("secret-token")},
+		})
+
+		token, err := SecretTokenProvider(reader, secretName)(context.TODO())
+
+		require.NoError(t, err)
+		assert.Equal(t, "secret-token", token)
+	})
+	t.Run("missing key", func(t *testing.T) {
+		reader := fake.NewFakeClient(&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: secretName.Namespace, Name: secretName.Name},
+		})
+
+		_, err := SecretTokenProvider(reader, secretName)(context.TODO())
+
+		assert.EqualError(t, err, "secret 'default/metrics-token' doesn't contain the 'token' key")
+	})
+	t.Run("missing secret", func(t *testing.T) {
+		_, err := SecretTokenProvider(fake.NewFakeClient(), secretName)(context.TODO())
+
+		assert.Error(t, err)
+	})
+	t.Run("cached token", func(t *testing.T) {
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: secretName.Namespace, Name: secretName.Name},
+			Data:       map[string][]byte{TokenSecretKey: []byte("secret-token")},
+		}
+		reader := fake.NewFakeClient(secret)
+		tokenProvider := SecretTokenProvider(reader, secretName)
+		_, err := tokenProvider(context.TODO())
+		require.NoError(t, err)
+
+		secret.Data[TokenSecretKey] = []byte("rotated-token")
+		require.NoError(t, reader.Update(context.TODO(), secret))
+		token, err := tokenProvider(context.TODO())
+
+		require.NoError(t, err)
+		assert.Equal(t, "secret-token", token)
+	})
+	t.Run("rotated token after the cache expires", func(t *testing.T) {
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: secretName.Namespace, Name: secretName.Name},
+			Data:       map[string][]byte{TokenSecretKey: []byte("secret-token")},
+		}
+		reader := fake.NewFakeClient(secret)
+		tokenProvider := secretTokenProvider(reader, secretName, 0)
+		_, err := tokenProvider(context.TODO())
+		require.NoError(t, err)
+
+		secret.Data[TokenSecretKey] = []byte("rotated-token")
+		require.NoError(t, reader.Update(context.TODO(), secret))
+		token, err := tokenProvider(context.TODO())
+
+		require.NoError(t, err)
+		assert.Equal(t, "rotated-token", token)
+	})
+}
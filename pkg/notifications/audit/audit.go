@@ -0,0 +1,135 @@
+// Package audit keeps the rolling audit log of the operator actions of a Jenkins in a ConfigMap.
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/base/resources"
+	"github.com/jenkinsci/kubernetes-operator/pkg/constants"
+	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/event"
+
+	stackerr "github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// DataKey is the key of the audit ConfigMap with the records, one JSON record per line from the oldest one
+	DataKey = "audit.log"
+	// DefaultMaxEntries is the number of the records kept when spec.auditLog.maxEntries is not set
+	DefaultMaxEntries = 100
+)
+
+// Record is a timestamped action of the operator.
+type Record struct {
+	Time    time.Time `json:"time"`
+	Phase   string    `json:"phase"`
+	Level   string    `json:"level"`
+	Reason  string    `json:"reason"`
+	Message string    `json:"message"`
+}
+
+// GetConfigMapName returns the name of the audit ConfigMap of the Jenkins.
+func GetConfigMapName(jenkins *v1alpha2.Jenkins) string {
+	return fmt.Sprintf("%s-audit-%s", constants.OperatorName, jenkins.Name)
+}
+
+// NewRecord returns the record of the event.
+func NewRecord(e event.Event, now time.Time) Record {
+	reasonType := reflect.TypeOf(e.Reason)
+	if reasonType.Kind() == reflect.Ptr {
+		reasonType = reasonType.Elem()
+	}
+	return Record{
+		Time:    now.UTC(),
+		Phase:   string(e.Phase),
+		Level:   string(e.Level),
+		Reason:  reasonType.Name(),
+		Message: strings.Join(e.Reason.Short(), "; "),
+	}
+}
+
+// appendRecord appends the record to the records, the oldest records over maxEntries are removed.
+func appendRecord(records, record string, maxEntries int) string {
+	var lines []string
+	if trimmed := strings.TrimSuffix(records, "\n"); len(trimmed) > 0 {
+		lines = strings.Split(trimmed, "\n")
+	}
+	lines = append(lines, record)
+	if len(lines) > maxEntries {
+		lines = lines[len(lines)-maxEntries:]
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
+
+// newConfigMap builds the audit ConfigMap, it's garbage collected with the Jenkins CR but the owner reference isn't
+// a controller reference, so the ConfigMap updates don't enqueue the Jenkins CR, otherwise every record would trigger
+// a reconciliation which may send a notification and append another record
+func newConfigMap(jenkins *v1alpha2.Jenkins) *corev1.ConfigMap {
+	gvk := v1alpha2.SchemeGroupVersion.WithKind(v1alpha2.Kind)
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      GetConfigMapName(jenkins),
+			Namespace: jenkins.Namespace,
+			Labels:    resources.BuildResourceLabels(jenkins),
+			OwnerReferences: []metav1.OwnerReference{
+				{
+					APIVersion: gvk.GroupVersion().String(),
+					Kind:       gvk.Kind,
+					Name:       jenkins.Name,
+					UID:        jenkins.UID,
+				},
+			},
+		},
+		Data: map[string]string{},
+	}
+}
+
+// Append appends the record of the event to the audit ConfigMap of the Jenkins, the ConfigMap is created when it
+// doesn't exist and the oldest records are removed when spec.auditLog.maxEntries is exceeded.
+func Append(k8sClient k8sclient.Client, e event.Event, now time.Time) error {
+	jenkins := &e.Jenkins
+	if jenkins.Spec.AuditLog == nil {
+		return nil
+	}
+	maxEntries := DefaultMaxEntries
+	if jenkins.Spec.AuditLog.MaxEntries > 0 {
+		maxEntries = int(jenkins.Spec.AuditLog.MaxEntries)
+	}
+	record, err := json.Marshal(NewRecord(e, now))
+	if err != nil {
+		return stackerr.WithStack(err)
+	}
+
+	isRetriable := func(err error) bool {
+		return apierrors.IsConflict(err) || apierrors.IsAlreadyExists(err)
+	}
+	err = retry.OnError(retry.DefaultRetry, isRetriable, func() error {
+		configMap := &corev1.ConfigMap{}
+		err := k8sClient.Get(context.TODO(), types.NamespacedName{Name: GetConfigMapName(jenkins), Namespace: jenkins.Namespace}, configMap)
+		if err != nil && apierrors.IsNotFound(err) {
+			configMap = newConfigMap(jenkins)
+			configMap.Data[DataKey] = appendRecord("", string(record), maxEntries)
+			return k8sClient.Create(context.TODO(), configMap)
+		} else if err != nil {
+			return err
+		}
+
+		if configMap.Data == nil {
+			configMap.Data = map[string]string{}
+		}
+		configMap.Data[DataKey] = appendRecord(configMap.Data[DataKey], string(record), maxEntries)
+		return k8sClient.Update(context.TODO(), configMap)
+	})
+	return stackerr.WithStack(err)
+}
@@ -0,0 +1,108 @@
+package audit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/event"
+	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/reason"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	ctrlevent "sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+)
+
+func TestAppendRecord(t *testing.T) {
+	t.Run("empty records", func(t *testing.T) {
+		assert.Equal(t, "a\n", appendRecord("", "a", 3))
+	})
+	t.Run("below the max entries", func(t *testing.T) {
+		assert.Equal(t, "a\nb\nc\n", appendRecord("a\nb\n", "c", 3))
+	})
+	t.Run("rotation removes the oldest records", func(t *testing.T) {
+		assert.Equal(t, "b\nc\nd\n", appendRecord("a\nb\nc\n", "d", 3))
+	})
+	t.Run("lowered max entries", func(t *testing.T) {
+		assert.Equal(t, "d\ne\n", appendRecord("a\nb\nc\nd\n", "e", 2))
+	})
+}
+
+func TestAppend(t *testing.T) {
+	require.NoError(t, v1alpha2.SchemeBuilder.AddToScheme(scheme.Scheme))
+	jenkins := v1alpha2.Jenkins{
+		ObjectMeta: metav1.ObjectMeta{Name: "example", Namespace: "default"},
+		Spec:       v1alpha2.JenkinsSpec{AuditLog: &v1alpha2.AuditLog{MaxEntries: 2}},
+	}
+	newEvent := func(message string) event.Event {
+		return event.Event{
+			Jenkins: jenkins,
+			Phase:   event.PhaseBase,
+			Level:   v1alpha2.NotificationLevelInfo,
+			Reason:  reason.NewPodCreation(reason.OperatorSource, []string{message}),
+		}
+	}
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("disabled", func(t *testing.T) {
+		k8sClient := fake.NewClientBuilder().Build()
+		e := newEvent("created")
+		e.Jenkins.Spec.AuditLog = nil
+
+		require.NoError(t, Append(k8sClient, e, now))
+
+		configMaps := &corev1.ConfigMapList{}
+		require.NoError(t, k8sClient.List(context.TODO(), configMaps))
+		assert.Empty(t, configMaps.Items)
+	})
+	t.Run("creates and rotates the ConfigMap", func(t *testing.T) {
+		k8sClient := fake.NewClientBuilder().Build()
+
+		require.NoError(t, Append(k8sClient, newEvent("first"), now))
+		require.NoError(t, Append(k8sClient, newEvent("second"), now.Add(time.Minute)))
+		require.NoError(t, Append(k8sClient, newEvent("third"), now.Add(2*time.Minute)))
+
+		configMap := &corev1.ConfigMap{}
+		require.NoError(t, k8sClient.Get(context.TODO(), types.NamespacedName{Name: "jenkins-operator-audit-example", Namespace: "default"}, configMap))
+		assert.Equal(t, `{"time":"2026-01-01T00:01:00Z","phase":"base","level":"info","reason":"PodCreation","message":"second"}
+{"time":"2026-01-01T00:02:00Z","phase":"base","level":"info","reason":"PodCreation","message":"third"}
+`, configMap.Data[DataKey])
+		require.Len(t, configMap.OwnerReferences, 1)
+		assert.Equal(t, "example", configMap.OwnerReferences[0].Name)
+	})
+	t.Run("append doesn't enqueue the Jenkins CR", func(t *testing.T) {
+		k8sClient := fake.NewClientBuilder().Build()
+		// the handler of the Owns(&corev1.ConfigMap{}) watch of the Jenkins controller
+		ownerHandler := &handler.EnqueueRequestForOwner{OwnerType: &v1alpha2.Jenkins{}, IsController: true}
+		mapper := meta.NewDefaultRESTMapper([]schema.GroupVersion{v1alpha2.SchemeGroupVersion})
+		mapper.Add(v1alpha2.SchemeGroupVersion.WithKind(v1alpha2.Kind), meta.RESTScopeNamespace)
+		require.NoError(t, ownerHandler.InjectScheme(scheme.Scheme))
+		require.NoError(t, ownerHandler.InjectMapper(mapper))
+		enqueued := func(configMap *corev1.ConfigMap) int {
+			queue := workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+			defer queue.ShutDown()
+			ownerHandler.Update(ctrlevent.UpdateEvent{ObjectOld: configMap, ObjectNew: configMap}, queue)
+			return queue.Len()
+		}
+
+		require.NoError(t, Append(k8sClient, newEvent("first"), now))
+		configMap := &corev1.ConfigMap{}
+		require.NoError(t, k8sClient.Get(context.TODO(), types.NamespacedName{Name: "jenkins-operator-audit-example", Namespace: "default"}, configMap))
+
+		assert.Equal(t, 0, enqueued(configMap))
+		controlled := configMap.DeepCopy()
+		controlled.OwnerReferences[0].Controller = pointer.BoolPtr(true)
+		assert.Equal(t, 1, enqueued(controlled))
+	})
+}
@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
 	"strings"
 
 	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
@@ -107,6 +108,21 @@ func (t Teams) generateMessage(e event.Event) Message {
 	return tm
 }
 
+// validateWebHookURL checks that the web hook URL stored in the Secret is an absolute HTTP(S) URL
+func validateWebHookURL(webHookURL string) error {
+	parsedURL, err := url.Parse(webHookURL)
+	if err != nil {
+		return errors.Wrap(err, "invalid Microsoft Teams WebHook URL")
+	}
+	if parsedURL.Scheme != "https" && parsedURL.Scheme != "http" {
+		return errors.Errorf("Microsoft Teams WebHook URL must use the http or https scheme, got '%s'", parsedURL.Scheme)
+	}
+	if len(parsedURL.Host) == 0 {
+		return errors.New("Microsoft Teams WebHook URL must contain the host")
+	}
+	return nil
+}
+
 // Send is function for sending directly to API
 func (t Teams) Send(e event.Event) error {
 	secret := &corev1.Secret{}
@@ -122,6 +138,9 @@ func (t Teams) Send(e event.Event) error {
 	if secretValue == "" {
 		return errors.Errorf("Microsoft Teams WebHook URL is empty in secret '%s/%s[%s]", e.Jenkins.Namespace, selector.Name, selector.Key)
 	}
+	if err := validateWebHookURL(secretValue); err != nil {
+		return errors.Wrapf(err, "secret '%s/%s[%s]'", e.Jenkins.Namespace, selector.Name, selector.Key)
+	}
 
 	msg, err := json.Marshal(t.generateMessage(e))
 	if err != nil {
@@ -66,6 +66,9 @@ func TestTeams_Send(t *testing.T) {
 			t.Fatal(err)
 		}
 
+		assert.Equal(t, "MessageCard", message.Type)
+		assert.Equal(t, "https://schema.org/extensions", message.Context)
+		assert.Equal(t, strings.Join(e.Reason.Short(), "\n\n - "), message.Summary)
 		assert.Equal(t, message.Title, provider.NotificationTitle(e))
 		assert.Equal(t, message.ThemeColor, teams.getStatusColor(e.Level))
 
@@ -113,6 +116,53 @@ func TestTeams_Send(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestValidateWebHookURL(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		assert.NoError(t, validateWebHookURL("https://example.webhook.office.com/webhookb2/abc"))
+	})
+	t.Run("not absolute", func(t *testing.T) {
+		assert.Error(t, validateWebHookURL("example.webhook.office.com/webhookb2/abc"))
+	})
+	t.Run("invalid scheme", func(t *testing.T) {
+		assert.Error(t, validateWebHookURL("ftp://example.webhook.office.com/webhookb2/abc"))
+	})
+	t.Run("missing host", func(t *testing.T) {
+		assert.Error(t, validateWebHookURL("https:///webhookb2/abc"))
+	})
+	t.Run("invalid", func(t *testing.T) {
+		assert.Error(t, validateWebHookURL("https://example.com/%zz"))
+	})
+}
+
+func TestTeams_SendInvalidWebHookURL(t *testing.T) {
+	// given
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-secret", Namespace: testNamespace},
+		Data:       map[string][]byte{"url": []byte("not-a-url")},
+	}
+	fakeClient := fake.NewClientBuilder().WithObjects(secret).Build()
+	teams := Teams{k8sClient: fakeClient, config: v1alpha2.Notification{
+		Teams: &v1alpha2.MicrosoftTeams{
+			WebHookURLSecretKeySelector: v1alpha2.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "test-secret"},
+				Key:                  "url",
+			},
+		},
+	}}
+	e := event.Event{
+		Jenkins: v1alpha2.Jenkins{ObjectMeta: metav1.ObjectMeta{Name: testCrName, Namespace: testNamespace}},
+		Phase:   testPhase,
+		Level:   testLevel,
+		Reason:  testReason,
+	}
+
+	// when
+	err := teams.Send(e)
+
+	// then
+	assert.EqualError(t, err, "secret 'default/test-secret[url]': Microsoft Teams WebHook URL must use the http or https scheme, got ''")
+}
+
 func TestGenerateMessages(t *testing.T) {
 	t.Run("happy", func(t *testing.T) {
 		crName := "test-jenkins"
@@ -35,6 +35,11 @@ type PodCreation struct {
 	Undefined
 }
 
+// ResourceRecreated informs that removed Kubernetes resource managed by operator has been created again.
+type ResourceRecreated struct {
+	Undefined
+}
+
 // ReconcileLoopFailed defines the reason why the reconcile loop failed.
 type ReconcileLoopFailed struct {
 	Undefined
@@ -45,6 +50,16 @@ type GroovyScriptExecutionFailed struct {
 	Undefined
 }
 
+// PluginsNotActive informs that some of the required plugins have not been installed or activated.
+type PluginsNotActive struct {
+	Undefined
+}
+
+// ReferencesMissing informs that some of the Secrets or ConfigMaps referenced by the Jenkins CR don't exist.
+type ReferencesMissing struct {
+	Undefined
+}
+
 // BaseConfigurationFailed defines the reason why base configuration phase failed.
 type BaseConfigurationFailed struct {
 	Undefined
@@ -105,6 +120,17 @@ func NewPodCreation(source Source, short []string, verbose ...string) *PodCreati
 	}
 }
 
+// NewResourceRecreated returns new instance of ResourceRecreated.
+func NewResourceRecreated(source Source, short []string, verbose ...string) *ResourceRecreated {
+	return &ResourceRecreated{
+		Undefined{
+			source:  source,
+			short:   short,
+			verbose: checkIfVerboseEmpty(short, verbose),
+		},
+	}
+}
+
 // NewReconcileLoopFailed returns new instance of ReconcileLoopFailed.
 func NewReconcileLoopFailed(source Source, short []string, verbose ...string) *ReconcileLoopFailed {
 	return &ReconcileLoopFailed{
@@ -127,6 +153,28 @@ func NewGroovyScriptExecutionFailed(source Source, short []string, verbose ...st
 	}
 }
 
+// NewPluginsNotActive returns new instance of PluginsNotActive.
+func NewPluginsNotActive(source Source, short []string, verbose ...string) *PluginsNotActive {
+	return &PluginsNotActive{
+		Undefined{
+			source:  source,
+			short:   short,
+			verbose: checkIfVerboseEmpty(short, verbose),
+		},
+	}
+}
+
+// NewReferencesMissing returns new instance of ReferencesMissing.
+func NewReferencesMissing(source Source, short []string, verbose ...string) *ReferencesMissing {
+	return &ReferencesMissing{
+		Undefined{
+			source:  source,
+			short:   short,
+			verbose: checkIfVerboseEmpty(short, verbose),
+		},
+	}
+}
+
 // NewBaseConfigurationFailed returns new instance of BaseConfigurationFailed.
 func NewBaseConfigurationFailed(source Source, short []string, verbose ...string) *BaseConfigurationFailed {
 	return &BaseConfigurationFailed{
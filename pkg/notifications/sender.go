@@ -5,15 +5,18 @@ import (
 	"net/http"
 	"reflect"
 	"strings"
+	"time"
 
 	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
 	k8sevent "github.com/jenkinsci/kubernetes-operator/pkg/event"
 	"github.com/jenkinsci/kubernetes-operator/pkg/log"
+	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/audit"
 	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/event"
 	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/mailgun"
 	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/msteams"
 	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/slack"
 	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/smtp"
+	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/webhook"
 
 	"github.com/pkg/errors"
 	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
@@ -27,6 +30,7 @@ type Provider interface {
 // Listen listens for incoming events and send it as notifications.
 func Listen(events chan event.Event, k8sEvent k8sevent.Recorder, k8sClient k8sclient.Client) {
 	httpClient := http.Client{}
+	throttle := NewThrottle()
 	for e := range events {
 		logger := log.Log.WithValues("cr", e.Jenkins.Name)
 
@@ -41,8 +45,16 @@ func Listen(events chan event.Event, k8sEvent k8sevent.Recorder, k8sClient k8scl
 			strings.Join(e.Reason.Short(), "; "),
 		)
 
+		if err := audit.Append(k8sClient, e, time.Now()); err != nil {
+			logger.Error(nil, fmt.Sprintf("failed to append the audit record: %s", err))
+		}
+
+		if window := e.Jenkins.Spec.NotificationThrottleWindow; window != nil && !throttle.Allow(e, window.Duration) {
+			logger.V(log.VDebug).Info(fmt.Sprintf("Skipping notification sent within the throttle window: %s", strings.Join(e.Reason.Short(), "; ")))
+			continue
+		}
+
 		for _, notificationConfig := range e.Jenkins.Spec.Notifications {
-			var err error
 			var provider Provider
 			switch {
 			case notificationConfig.Slack != nil:
@@ -53,6 +65,8 @@ func Listen(events chan event.Event, k8sEvent k8sevent.Recorder, k8sClient k8scl
 				provider = mailgun.New(k8sClient, notificationConfig)
 			case notificationConfig.SMTP != nil:
 				provider = smtp.New(k8sClient, notificationConfig)
+			case notificationConfig.Webhook != nil:
+				provider = webhook.New(k8sClient, notificationConfig, httpClient)
 			default:
 				logger.V(log.VWarn).Info(fmt.Sprintf("Unknown notification service `%+v`", notificationConfig))
 				continue
@@ -64,9 +78,8 @@ func Listen(events chan event.Event, k8sEvent k8sevent.Recorder, k8sClient k8scl
 				continue // skip the event
 			}
 
-			go func(notificationConfig v1alpha2.Notification) {
-				err = provider.Send(e)
-				if err != nil {
+			go func(notificationConfig v1alpha2.Notification, provider Provider, e event.Event) {
+				if err := provider.Send(e); err != nil {
 					wrapped := errors.WithMessage(err,
 						fmt.Sprintf("failed to send notification '%s'", notificationConfig.Name))
 					if log.Debug {
@@ -75,7 +88,7 @@ func Listen(events chan event.Event, k8sEvent k8sevent.Recorder, k8sClient k8scl
 						logger.Error(nil, fmt.Sprintf("%s", wrapped))
 					}
 				}
-			}(notificationConfig)
+			}(notificationConfig, provider, e)
 		}
 	}
 }
@@ -37,6 +37,7 @@ func New(k8sClient k8sclient.Client, config v1alpha2.Notification, httpClient ht
 
 // Message is representation of json message.
 type Message struct {
+	Channel     string       `json:"channel,omitempty"`
 	Text        string       `json:"text"`
 	Attachments []Attachment `json:"attachments"`
 }
@@ -114,6 +115,10 @@ func (s Slack) generateMessage(e event.Event) Message {
 		},
 	}
 
+	if s.config.Slack != nil {
+		sm.Channel = s.config.Slack.Channel
+	}
+
 	return sm
 }
 
@@ -56,6 +56,7 @@ func TestSlack_Send(t *testing.T) {
 				},
 				Key: testURLSelectorKeyName,
 			},
+			Channel: "#jenkins",
 		},
 	}}
 
@@ -68,6 +69,7 @@ func TestSlack_Send(t *testing.T) {
 			t.Fatal(err)
 		}
 
+		assert.Equal(t, "#jenkins", message.Channel)
 		mainAttachment := message.Attachments[0]
 
 		assert.Equal(t, mainAttachment.Title, provider.NotificationTitle(e))
@@ -4,6 +4,9 @@ import (
 	"context"
 	"crypto/tls"
 	"fmt"
+	"net"
+	netsmtp "net/smtp"
+	"strconv"
 	"strings"
 
 	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
@@ -111,10 +114,19 @@ func (s SMTP) Send(e event.Event) error {
 		return errors.Errorf("SMTP password is empty in secret '%s/%s[%s]", e.Jenkins.Namespace, passwordSelector.Name, passwordSelector.Key)
 	}
 
+	tlsConfig := &tls.Config{ServerName: s.config.SMTP.Server, InsecureSkipVerify: s.config.SMTP.TLSInsecureSkipVerify}
+	message := s.generateMessage(e)
+	if s.config.SMTP.TLS == v1alpha2.SMTPTLSModeStartTLS {
+		auth := netsmtp.PlainAuth("", usernameSecretValue, passwordSecretValue, s.config.SMTP.Server)
+		return s.sendWithStartTLS(tlsConfig, auth, message)
+	}
+
 	mailer := gomail.NewDialer(s.config.SMTP.Server, s.config.SMTP.Port, usernameSecretValue, passwordSecretValue)
-	mailer.TLSConfig = &tls.Config{InsecureSkipVerify: s.config.SMTP.TLSInsecureSkipVerify}
+	mailer.TLSConfig = tlsConfig
+	if s.config.SMTP.TLS == v1alpha2.SMTPTLSModeTLS {
+		mailer.SSL = true
+	}
 
-	message := s.generateMessage(e)
 	if err := mailer.DialAndSend(message); err != nil {
 		return err
 	}
@@ -122,6 +134,44 @@ func (s SMTP) Send(e event.Event) error {
 	return nil
 }
 
+// sendWithStartTLS sends the message over a connection upgraded with STARTTLS, unlike the gomail dialer it doesn't
+// fall back to the plain text connection when the server doesn't support the extension
+func (s SMTP) sendWithStartTLS(tlsConfig *tls.Config, auth netsmtp.Auth, message *gomail.Message) error {
+	client, err := netsmtp.Dial(net.JoinHostPort(s.config.SMTP.Server, strconv.Itoa(s.config.SMTP.Port)))
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	defer func() { _ = client.Close() }()
+
+	if ok, _ := client.Extension("STARTTLS"); !ok {
+		return errors.Errorf("SMTP server '%s' doesn't support STARTTLS", s.config.SMTP.Server)
+	}
+	if err := client.StartTLS(tlsConfig); err != nil {
+		return errors.WithStack(err)
+	}
+	if err := client.Auth(auth); err != nil {
+		return errors.WithStack(err)
+	}
+	if err := client.Mail(s.config.SMTP.From); err != nil {
+		return errors.WithStack(err)
+	}
+	if err := client.Rcpt(s.config.SMTP.To); err != nil {
+		return errors.WithStack(err)
+	}
+	writer, err := client.Data()
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	if _, err := message.WriteTo(writer); err != nil {
+		return errors.WithStack(err)
+	}
+	if err := writer.Close(); err != nil {
+		return errors.WithStack(err)
+	}
+
+	return errors.WithStack(client.Quit())
+}
+
 func (s SMTP) getStatusColor(logLevel v1alpha2.NotificationLevel) event.StatusColor {
 	switch logLevel {
 	case v1alpha2.NotificationLevelInfo:
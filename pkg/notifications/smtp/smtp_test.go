@@ -2,10 +2,17 @@ package smtp
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
 	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
+	"math/big"
 	"mime/quotedprintable"
 	"net"
 	"regexp"
@@ -210,6 +217,89 @@ func TestSMTP_Send(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func newTestCertificate(t *testing.T) tls.Certificate {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "localhost"},
+		DNSNames:     []string{"localhost"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	certificate, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	assert.NoError(t, err)
+	return tls.Certificate{Certificate: [][]byte{certificate}, PrivateKey: key}
+}
+
+func TestSMTP_SendStartTLS(t *testing.T) {
+	e := event.Event{
+		Jenkins: v1alpha2.Jenkins{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      testCrName,
+				Namespace: testNamespace,
+			},
+		},
+		Phase:  testPhase,
+		Level:  testLevel,
+		Reason: testReason,
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-secret", Namespace: testNamespace},
+		Data: map[string][]byte{
+			"username": []byte(testSMTPUsername),
+			"password": []byte(testSMTPPassword),
+		},
+	}
+	startServer := func(t *testing.T, tlsConfig *tls.Config) int {
+		s := smtp.NewServer(&testServer{event: e})
+		s.Domain = "localhost"
+		s.ReadTimeout = 10 * time.Second
+		s.WriteTimeout = 10 * time.Second
+		s.TLSConfig = tlsConfig
+		l, err := net.Listen("tcp", "127.0.0.1:0")
+		assert.NoError(t, err)
+		go func() { _ = s.Serve(l) }()
+		t.Cleanup(s.Close)
+		return l.Addr().(*net.TCPAddr).Port
+	}
+	newSMTP := func(port int) SMTP {
+		return SMTP{k8sClient: fake.NewClientBuilder().WithObjects(secret.DeepCopy()).Build(), config: v1alpha2.Notification{
+			SMTP: &v1alpha2.SMTP{
+				Server:                "localhost",
+				Port:                  port,
+				From:                  testFrom,
+				To:                    testTo,
+				TLS:                   v1alpha2.SMTPTLSModeStartTLS,
+				TLSInsecureSkipVerify: true,
+				UsernameSecretKeySelector: v1alpha2.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "test-secret"},
+					Key:                  "username",
+				},
+				PasswordSecretKeySelector: v1alpha2.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "test-secret"},
+					Key:                  "password",
+				},
+			},
+		}}
+	}
+
+	t.Run("server supports STARTTLS", func(t *testing.T) {
+		port := startServer(t, &tls.Config{Certificates: []tls.Certificate{newTestCertificate(t)}})
+
+		err := newSMTP(port).Send(e)
+
+		assert.NoError(t, err)
+	})
+	t.Run("server doesn't support STARTTLS", func(t *testing.T) {
+		port := startServer(t, nil)
+
+		err := newSMTP(port).Send(e)
+
+		assert.EqualError(t, err, "SMTP server 'localhost' doesn't support STARTTLS")
+	})
+}
+
 func TestGenerateMessage(t *testing.T) {
 	t.Run("happy", func(t *testing.T) {
 		crName := "test-jenkins"
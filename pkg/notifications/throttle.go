@@ -0,0 +1,62 @@
+package notifications
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/event"
+)
+
+type throttleKey struct {
+	namespace string
+	name      string
+	level     string
+	reason    string
+	message   string
+}
+
+// Throttle suppresses the identical notifications of a Jenkins sent within a window, it's kept in memory so
+// the suppressed notifications are sent again after the operator restart.
+type Throttle struct {
+	mutex     sync.Mutex
+	now       func() time.Time
+	expiresAt map[throttleKey]time.Time
+}
+
+// NewThrottle returns new instance of Throttle.
+func NewThrottle() *Throttle {
+	return &Throttle{now: time.Now, expiresAt: map[throttleKey]time.Time{}}
+}
+
+// Allow returns false when an identical notification of the same Jenkins has been allowed within the window,
+// a window lower or equal to zero allows all notifications.
+func (t *Throttle) Allow(e event.Event, window time.Duration) bool {
+	if window <= 0 {
+		return true
+	}
+
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	now := t.now()
+	for key, expiresAt := range t.expiresAt {
+		if !now.Before(expiresAt) {
+			delete(t.expiresAt, key)
+		}
+	}
+
+	key := throttleKey{
+		namespace: e.Jenkins.Namespace,
+		name:      e.Jenkins.Name,
+		level:     string(e.Level),
+		reason:    fmt.Sprintf("%T", e.Reason),
+		message:   strings.Join(e.Reason.Short(), "\n"),
+	}
+	if _, found := t.expiresAt[key]; found {
+		return false
+	}
+	t.expiresAt[key] = now.Add(window)
+	return true
+}
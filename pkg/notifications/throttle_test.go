@@ -0,0 +1,63 @@
+package notifications
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/event"
+	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/reason"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestThrottle_Allow(t *testing.T) {
+	newEvent := func(name, message string) event.Event {
+		return event.Event{
+			Jenkins: v1alpha2.Jenkins{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"}},
+			Phase:   event.PhaseBase,
+			Level:   v1alpha2.NotificationLevelWarning,
+			Reason:  reason.NewPodRestart(reason.KubernetesSource, []string{message}),
+		}
+	}
+	newThrottle := func(now *time.Time) *Throttle {
+		throttle := NewThrottle()
+		throttle.now = func() time.Time { return *now }
+		return throttle
+	}
+	window := 10 * time.Minute
+
+	t.Run("identical notification within the window", func(t *testing.T) {
+		now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+		throttle := newThrottle(&now)
+
+		assert.True(t, throttle.Allow(newEvent("jenkins", "killed"), window))
+		now = now.Add(window - time.Second)
+		assert.False(t, throttle.Allow(newEvent("jenkins", "killed"), window))
+	})
+	t.Run("identical notification after the window", func(t *testing.T) {
+		now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+		throttle := newThrottle(&now)
+
+		assert.True(t, throttle.Allow(newEvent("jenkins", "killed"), window))
+		now = now.Add(window)
+		assert.True(t, throttle.Allow(newEvent("jenkins", "killed"), window))
+		assert.Len(t, throttle.expiresAt, 1)
+	})
+	t.Run("different message or Jenkins", func(t *testing.T) {
+		now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+		throttle := newThrottle(&now)
+
+		assert.True(t, throttle.Allow(newEvent("jenkins", "killed"), window))
+		assert.True(t, throttle.Allow(newEvent("jenkins", "evicted"), window))
+		assert.True(t, throttle.Allow(newEvent("other", "killed"), window))
+	})
+	t.Run("disabled", func(t *testing.T) {
+		now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+		throttle := newThrottle(&now)
+
+		assert.True(t, throttle.Allow(newEvent("jenkins", "killed"), 0))
+		assert.True(t, throttle.Allow(newEvent("jenkins", "killed"), 0))
+	})
+}
@@ -0,0 +1,126 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"reflect"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/event"
+	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/provider"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Webhook is a generic webhook notification service.
+type Webhook struct {
+	httpClient http.Client
+	k8sClient  k8sclient.Client
+	config     v1alpha2.Notification
+}
+
+// New returns instance of Webhook.
+func New(k8sClient k8sclient.Client, config v1alpha2.Notification, httpClient http.Client) *Webhook {
+	return &Webhook{k8sClient: k8sClient, config: config, httpClient: httpClient}
+}
+
+// Payload is the JSON body POSTed to the webhook.
+type Payload struct {
+	// Title is the notification title which depends on the level
+	Title string `json:"title"`
+	// Level is the notification level, info or warning
+	Level v1alpha2.NotificationLevel `json:"level"`
+	// Phase is the reconcile phase, base or user
+	Phase event.Phase `json:"phase"`
+	// Namespace of the Jenkins custom resource
+	Namespace string `json:"namespace"`
+	// Name of the Jenkins custom resource
+	Name string `json:"name"`
+	// Reason is the type of the reason, e.g. PodRestart
+	Reason string `json:"reason"`
+	// Messages are the short or the verbose messages of the reason
+	Messages []string `json:"messages"`
+}
+
+// getReasonName returns the type name of the event reason, the reasons are created as pointers
+func getReasonName(e event.Event) string {
+	reasonType := reflect.TypeOf(e.Reason)
+	if reasonType.Kind() == reflect.Ptr {
+		reasonType = reasonType.Elem()
+	}
+	return reasonType.Name()
+}
+
+func (w Webhook) generatePayload(e event.Event) Payload {
+	messages := e.Reason.Short()
+	if w.config.Verbose {
+		messages = e.Reason.Verbose()
+	}
+
+	return Payload{
+		Title:     provider.NotificationTitle(e),
+		Level:     e.Level,
+		Phase:     e.Phase,
+		Namespace: e.Jenkins.Namespace,
+		Name:      e.Jenkins.Name,
+		Reason:    getReasonName(e),
+		Messages:  messages,
+	}
+}
+
+func (w Webhook) getAuthorizationHeader(namespace string) (string, error) {
+	selector := w.config.Webhook.AuthorizationHeaderSecretKeySelector
+	if selector == nil {
+		return "", nil
+	}
+
+	secret := &corev1.Secret{}
+	err := w.k8sClient.Get(context.TODO(), types.NamespacedName{Name: selector.Name, Namespace: namespace}, secret)
+	if err != nil {
+		return "", err
+	}
+
+	value := string(secret.Data[selector.Key])
+	if value == "" {
+		return "", errors.Errorf("Webhook Authorization header is empty in secret '%s/%s[%s]", namespace, selector.Name, selector.Key)
+	}
+	return value, nil
+}
+
+// Send is function for sending directly to API.
+func (w Webhook) Send(e event.Event) error {
+	authorization, err := w.getAuthorizationHeader(e.Jenkins.Namespace)
+	if err != nil {
+		return err
+	}
+
+	payload, err := json.Marshal(w.generatePayload(e))
+	if err != nil {
+		return err
+	}
+
+	request, err := http.NewRequest("POST", w.config.Webhook.URL, bytes.NewBuffer(payload))
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	if authorization != "" {
+		request.Header.Set("Authorization", authorization)
+	}
+
+	resp, err := w.httpClient.Do(request)
+	if err != nil {
+		return err
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return errors.Errorf("Webhook responded with status '%s'", resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,129 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/event"
+	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/provider"
+	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/reason"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var testEvent = event.Event{
+	Jenkins: v1alpha2.Jenkins{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-cr",
+			Namespace: "default",
+		},
+	},
+	Phase: event.PhaseUser,
+	Level: v1alpha2.NotificationLevelWarning,
+	Reason: reason.NewPodRestart(
+		reason.KubernetesSource,
+		[]string{"test-reason-1"},
+		[]string{"test-verbose-1"}...,
+	),
+}
+
+func TestWebhook_Send(t *testing.T) {
+	t.Run("with authorization header", func(t *testing.T) {
+		var got Payload
+		var authorization, contentType string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			authorization = r.Header.Get("Authorization")
+			contentType = r.Header.Get("Content-Type")
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&got))
+		}))
+		defer server.Close()
+
+		fakeClient := fake.NewClientBuilder().Build()
+		err := fakeClient.Create(context.TODO(), &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "webhook", Namespace: "default"},
+			Data:       map[string][]byte{"authorization": []byte("Bearer token")},
+		})
+		require.NoError(t, err)
+		webhook := New(fakeClient, v1alpha2.Notification{
+			Webhook: &v1alpha2.Webhook{
+				URL: server.URL,
+				AuthorizationHeaderSecretKeySelector: &v1alpha2.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "webhook"},
+					Key:                  "authorization",
+				},
+			},
+		}, http.Client{})
+
+		err = webhook.Send(testEvent)
+
+		require.NoError(t, err)
+		assert.Equal(t, "Bearer token", authorization)
+		assert.Equal(t, "application/json", contentType)
+		assert.Equal(t, Payload{
+			Title:     provider.WarnTitleText,
+			Level:     v1alpha2.NotificationLevelWarning,
+			Phase:     event.PhaseUser,
+			Namespace: "default",
+			Name:      "test-cr",
+			Reason:    "PodRestart",
+			Messages:  testEvent.Reason.Short(),
+		}, got)
+	})
+	t.Run("verbose without authorization header", func(t *testing.T) {
+		var got Payload
+		var authorization string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			authorization = r.Header.Get("Authorization")
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&got))
+		}))
+		defer server.Close()
+
+		webhook := New(fake.NewClientBuilder().Build(), v1alpha2.Notification{
+			Verbose: true,
+			Webhook: &v1alpha2.Webhook{URL: server.URL},
+		}, http.Client{})
+
+		err := webhook.Send(testEvent)
+
+		require.NoError(t, err)
+		assert.Empty(t, authorization)
+		assert.Equal(t, testEvent.Reason.Verbose(), got.Messages)
+	})
+	t.Run("error status", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer server.Close()
+
+		webhook := New(fake.NewClientBuilder().Build(), v1alpha2.Notification{
+			Webhook: &v1alpha2.Webhook{URL: server.URL},
+		}, http.Client{})
+
+		err := webhook.Send(testEvent)
+
+		assert.EqualError(t, err, "Webhook responded with status '500 Internal Server Error'")
+	})
+	t.Run("missing secret", func(t *testing.T) {
+		webhook := New(fake.NewClientBuilder().Build(), v1alpha2.Notification{
+			Webhook: &v1alpha2.Webhook{
+				URL: "http://localhost",
+				AuthorizationHeaderSecretKeySelector: &v1alpha2.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "webhook"},
+					Key:                  "authorization",
+				},
+			},
+		}, http.Client{})
+
+		err := webhook.Send(testEvent)
+
+		assert.Error(t, err)
+	})
+}
@@ -8,8 +8,18 @@ const (
 	kubernetesCredentialsProviderPlugin = "kubernetes-credentials-provider:1.209.v862c6e5fb_1ef"
 	workflowAggregatorPlugin            = "workflow-aggregator:590.v6a_d052e5a_a_b_5"
 	workflowJobPlugin                   = "workflow-job:1282.ve6d865025906"
+	oicAuthPlugin                       = "oic-auth:2.6"
+	antisamyMarkupFormatterPlugin       = "antisamy-markup-formatter:159.v25b_c67cd35fb_"
+	sshSlavesPlugin                     = "ssh-slaves:2.916.vd17b_43357ce4"
+	matrixAuthPlugin                    = "matrix-auth:3.1.5"
+	ldapPlugin                          = "ldap:2.12"
+	gradlePlugin                        = "gradle:2.2"
 )
 
+// AnythingGoesFormatterPluginName is the name of the plugin required by the rawHtml markup formatter, it isn't added to
+// the base plugins, the user has to install it explicitly.
+const AnythingGoesFormatterPluginName = "anything-goes-formatter"
+
 // basePluginsList contains plugins to install by operator.
 var basePluginsList = []Plugin{
 	Must(New(configurationAsCodePlugin)),
@@ -21,6 +31,24 @@ var basePluginsList = []Plugin{
 	Must(New(workflowAggregatorPlugin)),
 }
 
+// OICAuthPlugin is the plugin added to the base plugins when the OpenID Connect security realm is configured.
+var OICAuthPlugin = Must(New(oicAuthPlugin))
+
+// AntisamyMarkupFormatterPlugin is the plugin added to the base plugins when the safeHtml markup formatter is configured.
+var AntisamyMarkupFormatterPlugin = Must(New(antisamyMarkupFormatterPlugin))
+
+// SSHSlavesPlugin is the plugin added to the base plugins when the permanent agents are configured.
+var SSHSlavesPlugin = Must(New(sshSlavesPlugin))
+
+// MatrixAuthPlugin is the plugin added to the base plugins when the matrix authorization strategy is configured.
+var MatrixAuthPlugin = Must(New(matrixAuthPlugin))
+
+// LDAPPlugin is the plugin added to the base plugins when the LDAP security realm is configured.
+var LDAPPlugin = Must(New(ldapPlugin))
+
+// GradlePlugin is the plugin added to the base plugins when the Gradle installations are configured.
+var GradlePlugin = Must(New(gradlePlugin))
+
 // BasePlugins returns list of plugins to install by operator.
 func BasePlugins() []Plugin {
 	return basePluginsList
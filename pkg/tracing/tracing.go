@@ -0,0 +1,87 @@
+package tracing
+
+import (
+	"context"
+
+	"github.com/jenkinsci/kubernetes-operator/pkg/constants"
+
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpgrpc"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/semconv"
+	"go.opentelemetry.io/otel/trace"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	// TracerName is the name of the tracer used by the operator
+	TracerName = "github.com/jenkinsci/kubernetes-operator"
+	// ReconcileSpanName is the name of the span created for every reconcile loop
+	ReconcileSpanName = "Reconcile"
+
+	// JenkinsNameKey is the span attribute key of the reconciled Jenkins name
+	JenkinsNameKey = attribute.Key("jenkins.name")
+	// JenkinsNamespaceKey is the span attribute key of the reconciled Jenkins namespace
+	JenkinsNamespaceKey = attribute.Key("jenkins.namespace")
+	// OutcomeKey is the span attribute key of the reconcile outcome
+	OutcomeKey = attribute.Key("reconcile.outcome")
+
+	// OutcomeSuccess means that reconcile loop finished without errors and without requeue
+	OutcomeSuccess = "success"
+	// OutcomeRequeue means that reconcile loop finished without errors and requested requeue
+	OutcomeRequeue = "requeue"
+	// OutcomeError means that reconcile loop failed
+	OutcomeError = "error"
+)
+
+// Setup configures global tracer provider which exports spans to the OTLP collector listening on given endpoint.
+// When endpoint is empty tracing is disabled and the no-op tracer provider is used.
+// The returned function flushes and stops the exporter.
+func Setup(ctx context.Context, endpoint string) (func(context.Context) error, error) {
+	if len(endpoint) == 0 {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	exporter, err := otlp.NewExporter(ctx, otlpgrpc.NewDriver(
+		otlpgrpc.WithEndpoint(endpoint),
+		otlpgrpc.WithInsecure(),
+	))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to create OTLP exporter for endpoint '%s'", endpoint)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(resource.NewWithAttributes(semconv.ServiceNameKey.String(constants.OperatorName))),
+	)
+	otel.SetTracerProvider(provider)
+
+	return provider.Shutdown, nil
+}
+
+// StartReconcileSpan starts the span which covers single reconcile loop of given Jenkins
+func StartReconcileSpan(ctx context.Context, request reconcile.Request) (context.Context, trace.Span) {
+	return otel.Tracer(TracerName).Start(ctx, ReconcileSpanName, trace.WithAttributes(
+		JenkinsNameKey.String(request.Name),
+		JenkinsNamespaceKey.String(request.Namespace),
+	))
+}
+
+// RecordOutcome sets the outcome attribute of the reconcile span, the error is recorded as well
+func RecordOutcome(span trace.Span, result reconcile.Result, err error) {
+	switch {
+	case err != nil:
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		span.SetAttributes(OutcomeKey.String(OutcomeError))
+	case result.Requeue || result.RequeueAfter > 0:
+		span.SetAttributes(OutcomeKey.String(OutcomeRequeue))
+	default:
+		span.SetAttributes(OutcomeKey.String(OutcomeSuccess))
+	}
+}
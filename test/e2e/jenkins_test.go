@@ -179,7 +179,7 @@ func createJenkinsAPIClientFromServiceAccount(jenkins *v1alpha2.Jenkins, jenkins
 		return nil, err
 	}
 
-	return jenkinsclient.NewBearerTokenAuthorization(jenkinsAPIURL, token.String())
+	return jenkinsclient.NewBearerTokenAuthorization(jenkinsAPIURL, token.String(), jenkinsclient.ClientOptions{})
 }
 
 func createJenkinsAPIClientFromSecret(jenkins *v1alpha2.Jenkins, jenkinsAPIURL string) (jenkinsclient.Jenkins, error) {
@@ -195,6 +195,7 @@ func createJenkinsAPIClientFromSecret(jenkins *v1alpha2.Jenkins, jenkinsAPIURL s
 		jenkinsAPIURL,
 		string(adminSecret.Data[resources.OperatorCredentialsSecretUserNameKey]),
 		string(adminSecret.Data[resources.OperatorCredentialsSecretTokenKey]),
+		jenkinsclient.ClientOptions{},
 	)
 }
 